@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"strings"
+)
+
+const redacted = "REDACTED"
+
+// AdminServer serves the operational endpoints (metrics, pprof, config dump, feature flags) on a separate listener.
+//
+// Every admin request must carry the token as a bearer token.
+type AdminServer struct {
+	Token    string
+	Config   *Config
+	Flags    map[string]string
+	Features *FeatureFlags
+}
+
+// loadAdminToken reads the admin bearer token from path, required when the admin listener is enabled at address.
+func loadAdminToken(address, path string) (string, error) {
+	if address == "" {
+		return "", nil
+	}
+	if path == "" {
+		return "", errors.New("--admin-token-file is required with --admin-address")
+	}
+
+	token, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if token = bytes.TrimSpace(token); len(token) == 0 {
+		return "", fmt.Errorf("admin token file %s is empty", path)
+	}
+	return string(token), nil
+}
+
+// commandLineFlags returns the value of every flag, redacting the ones pointing at secrets.
+func commandLineFlags(flags *flag.FlagSet) map[string]string {
+	values := map[string]string{}
+	flags.VisitAll(func(f *flag.Flag) {
+		value := f.Value.String()
+		if isSecretName(f.Name) && !strings.HasSuffix(f.Name, "-file") && value != "" {
+			value = redacted
+		}
+		values[f.Name] = value
+	})
+	return values
+}
+
+func isSecretName(name string) bool {
+	name = strings.ToLower(name)
+	for _, secret := range []string{"secret", "token", "password", "authorization", "key"} {
+		if strings.Contains(name, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// newAdminMux builds the handler of the admin listener.
+func newAdminMux(admin *AdminServer) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/healthz", healthHandler)
+	mux.Handle("GET /metrics", metricsHandler())
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("GET /admin/config", admin.configHandler)
+	mux.HandleFunc("GET /admin/features", admin.featuresHandler)
+	mux.HandleFunc("PUT /admin/features/{name}", admin.setFeatureHandler)
+
+	return admin.authenticate(mux)
+}
+
+func (a *AdminServer) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if a.Token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(a.Token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeErrorf(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Handler dumping the effective configuration with secrets redacted
+func (a *AdminServer) configHandler(w http.ResponseWriter, r *http.Request) {
+	var config map[string]interface{}
+	data, err := json.Marshal(a.Config)
+	if err == nil {
+		err = json.Unmarshal(data, &config)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	redactSecrets(config)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"flags":  a.Flags,
+		"config": config,
+	})
+}
+
+// redactSecrets replaces in place the values of keys that look like secrets, and every header value.
+func redactSecrets(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if _, ok := child.(string); ok && isSecretName(key) {
+				v[key] = redacted
+				continue
+			}
+			if headers, ok := child.(map[string]interface{}); ok && key == "headers" {
+				for name := range headers {
+					headers[name] = redacted
+				}
+				continue
+			}
+			redactSecrets(child)
+		}
+	case []interface{}:
+		for _, child := range v {
+			redactSecrets(child)
+		}
+	}
+}
+
+// Handler listing the feature flags
+func (a *AdminServer) featuresHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, a.Features.Snapshot())
+}
+
+// Handler toggling a feature flag
+func (a *AdminServer) setFeatureHandler(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Enabled *bool `json:"enabled"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Enabled == nil {
+		writeErrorf(w, http.StatusBadRequest, `Expected {"enabled": true|false}`)
+		return
+	}
+
+	name := r.PathValue("name")
+	if err := a.Features.Set(name, *request.Enabled); err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	recordAudit(AuditEvent{
+		Action:          "feature.set",
+		RequestMetadata: requestMetadata(r.Context()),
+		RemoteAddr:      r.RemoteAddr,
+		Target:          name,
+		Request:         request,
+		Result:          "ok",
+	})
+	writeJSON(w, http.StatusOK, a.Features.Snapshot())
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminMux(t *testing.T) {
+	features, err := newFeatureFlags(map[string]bool{featurePodExec: false})
+	assert.NoError(t, err)
+
+	admin := &AdminServer{
+		Token: "t0ken",
+		Config: &Config{Ticketing: &TicketingConfig{
+			Headers: map[string]string{"Authorization": "Basic c3JlOnRva2Vu"},
+			Open:    TicketRequest{URL: "https://jira.example.com/rest/api/2/issue"},
+		}},
+		Flags:    map[string]string{"address": ":8080"},
+		Features: features,
+	}
+	handler := newAdminMux(admin)
+
+	serve := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, "/admin/config", "", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, "/admin/config", "wrong", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, "/metrics", "", "").Code)
+	rec := serve(http.MethodGet, "/metrics", "t0ken", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "go_goroutines")
+
+	rec = serve(http.MethodGet, "/admin/config", "t0ken", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "c3JlOnRva2Vu")
+	assert.Contains(t, rec.Body.String(), "jira.example.com")
+
+	rec = serve(http.MethodPut, "/admin/features/"+featurePodExec, "t0ken", `{"enabled": true}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var snapshot map[string]bool
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&snapshot))
+	assert.True(t, snapshot[featurePodExec])
+	assert.True(t, features.Enabled(featurePodExec))
+
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodPut, "/admin/features/teleport", "t0ken", `{"enabled": true}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPut, "/admin/features/"+featurePodExec, "t0ken", `{}`).Code)
+}
+
+func TestLoadAdminToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(path, []byte("t0ken\n"), 0o600))
+
+	token, err := loadAdminToken(":9090", path)
+	assert.NoError(t, err)
+	assert.Equal(t, "t0ken", token)
+	token, err = loadAdminToken("", "")
+	assert.NoError(t, err)
+	assert.Empty(t, token)
+
+	// An enabled admin listener is never left unauthenticated
+	_, err = loadAdminToken(":9090", "")
+	assert.Error(t, err)
+	assert.NoError(t, os.WriteFile(path, []byte("\n"), 0o600))
+	_, err = loadAdminToken(":9090", path)
+	assert.Error(t, err)
+
+	rec := httptest.NewRecorder()
+	newAdminMux(&AdminServer{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/config", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestFeatureFlagsRequire(t *testing.T) {
+	_, err := newFeatureFlags(map[string]bool{"teleport": true})
+	assert.Error(t, err)
+
+	features, _ := newFeatureFlags(map[string]bool{featureBulkIsolation: false})
+	handler := features.require(featureBulkIsolation, func(w http.ResponseWriter, r *http.Request) {})
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, "/api/v1/isolations/bulk", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	var unset *FeatureFlags
+	assert.True(t, unset.Enabled(featureBulkIsolation))
+}
@@ -0,0 +1,356 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+const defaultAlertingInterval = time.Minute
+
+// Conditions an alerting rule fires on, for each object meeting it
+const (
+	conditionFailedDeployments = "failed_deployments"
+	conditionNotReadyNodes     = "not_ready_nodes"
+	conditionProblemPods       = "problem_pods"
+)
+
+var alertConditions = []string{conditionFailedDeployments, conditionNotReadyNodes, conditionProblemPods}
+
+// Statuses of an alert
+const (
+	alertFiring   = "firing"
+	alertResolved = "resolved"
+)
+
+// AlertingConfig maps conditions of the cluster to the targets notified when they hold for long enough.
+type AlertingConfig struct {
+	Interval metav1.Duration        `json:"interval,omitempty"`
+	Retries  *int                   `json:"retries,omitempty"`
+	Targets  map[string]AlertTarget `json:"targets"`
+	Rules    []AlertRule            `json:"rules"`
+}
+
+// AlertTarget is notified of alerts, with a webhook event or a Slack message.
+type AlertTarget struct {
+	Webhook *WebhookEndpoint `json:"webhook,omitempty"`
+	Slack   *SlackTarget     `json:"slack,omitempty"`
+}
+
+// AlertRule fires an alert for each object meeting Condition for For, about Namespaces only when set, shell patterns
+// such as payments-*, which nodes never match. The alert is resolved once the object stops meeting it.
+type AlertRule struct {
+	Name       string          `json:"name"`
+	Condition  string          `json:"condition"`
+	Namespaces []string        `json:"namespaces,omitempty"`
+	For        metav1.Duration `json:"for,omitempty"`
+	Targets    []string        `json:"targets"`
+}
+
+// Alert is the payload POSTed to webhook targets when an alert fires or resolves.
+type Alert struct {
+	Rule      string `json:"rule"`
+	Status    string `json:"status"`
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace,omitempty"`
+	// Object is the deployment, node or pod, namespace/name for namespaced ones
+	Object  string    `json:"object"`
+	Message string    `json:"message"`
+	Since   time.Time `json:"since"`
+	At      time.Time `json:"at"`
+}
+
+// alertObject is an object meeting the condition of a rule.
+type alertObject struct {
+	namespace string
+	key       string
+	message   string
+}
+
+// alertState is an object which met the condition of a rule at the last scan, since then, firing once notified.
+type alertState struct {
+	object alertObject
+	since  time.Time
+	firing bool
+}
+
+// AlertEngine scans the cluster every interval, firing the alerts of the rules whose condition held for long enough
+// and resolving them, unless a silence matches them.
+//
+// The alerts are kept in memory, so a restart fires those still holding again once their For is over.
+type AlertEngine struct {
+	webhookSender
+	interval  time.Duration
+	rules     []AlertRule
+	targets   map[string]AlertTarget
+	endpoints map[string]webhookEndpoint
+	cluster   string
+	clientset kubernetes.Interface
+	scope     NamespaceScope
+	excluded  []string
+	// deployments reads the health of the deployments, see Server.reportedDeploymentsHealth
+	deployments func(context.Context) (*ClusterDeploymentsInfo, error)
+	Silences    *SilenceStore
+	// states by rule, then by object
+	states map[string]map[string]*alertState
+}
+
+func newAlertEngine(config *AlertingConfig, cluster string, clientset kubernetes.Interface, scope NamespaceScope, excluded []string, deployments func(context.Context) (*ClusterDeploymentsInfo, error)) (*AlertEngine, error) {
+	sender, err := newWebhookSender(config.Retries, "alerting.retries")
+	if err != nil {
+		return nil, err
+	}
+	engine := &AlertEngine{
+		webhookSender: sender,
+		interval:      config.Interval.Duration,
+		rules:         config.Rules,
+		targets:       config.Targets,
+		endpoints:     map[string]webhookEndpoint{},
+		cluster:       cluster,
+		clientset:     clientset,
+		scope:         scope,
+		excluded:      excluded,
+		deployments:   deployments,
+		Silences:      newSilenceStore(),
+		states:        map[string]map[string]*alertState{},
+	}
+	if engine.interval == 0 {
+		engine.interval = defaultAlertingInterval
+	}
+
+	for name, target := range config.Targets {
+		field := "alerting.targets." + name
+		switch {
+		case (target.Webhook == nil) == (target.Slack == nil):
+			return nil, fmt.Errorf("%s needs either a webhook or slack", field)
+		case target.Webhook != nil:
+			if engine.endpoints[name], err = newWebhookEndpoint(field+".webhook", *target.Webhook); err != nil {
+				return nil, err
+			}
+		default:
+			if err := target.Slack.validate(field + ".slack"); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(config.Rules) == 0 {
+		return nil, errors.New("alerting.rules needs at least a rule")
+	}
+	for i, rule := range config.Rules {
+		field := fmt.Sprintf("alerting.rules[%d]", i)
+		switch {
+		case rule.Name == "":
+			return nil, fmt.Errorf("%s.name is required", field)
+		case slices.ContainsFunc(config.Rules[:i], func(other AlertRule) bool { return other.Name == rule.Name }):
+			return nil, fmt.Errorf("%s.name %q is taken by another rule", field, rule.Name)
+		case !slices.Contains(alertConditions, rule.Condition):
+			return nil, fmt.Errorf("%s.condition %q is unknown, expected one of %v", field, rule.Condition, alertConditions)
+		case len(rule.Targets) == 0:
+			return nil, fmt.Errorf("%s.targets needs at least a target", field)
+		}
+		for _, pattern := range rule.Namespaces {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("%s.namespaces: invalid pattern %q", field, pattern)
+			}
+		}
+		for _, target := range rule.Targets {
+			if _, ok := config.Targets[target]; !ok {
+				return nil, fmt.Errorf("%s.targets: unknown target %q", field, target)
+			}
+		}
+	}
+	return engine, nil
+}
+
+// rule returns the rule called name.
+func (e *AlertEngine) rule(name string) (AlertRule, bool) {
+	index := slices.IndexFunc(e.rules, func(rule AlertRule) bool { return rule.Name == name })
+	if index < 0 {
+		return AlertRule{}, false
+	}
+	return e.rules[index], true
+}
+
+// run evaluates the rules every interval until ctx is done.
+func (e *AlertEngine) run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		e.evaluate(ctx, e.scan(ctx, time.Now()), time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scan returns the objects meeting each condition the rules use, leaving out those which couldn't be scanned.
+func (e *AlertEngine) scan(ctx context.Context, now time.Time) map[string][]alertObject {
+	needed := map[string]bool{}
+	for _, rule := range e.rules {
+		needed[rule.Condition] = true
+	}
+	objects := map[string][]alertObject{}
+
+	if needed[conditionFailedDeployments] {
+		if deployments, err := e.deployments(ctx); err != nil {
+			slog.ErrorContext(ctx, "Failed scanning deployments for alerting", "error", err)
+		} else {
+			objects[conditionFailedDeployments] = []alertObject{}
+			for _, deployment := range append(append([]DeploymentInfo{}, deployments.FailedDeployments...), deployments.StuckRollouts...) {
+				objects[conditionFailedDeployments] = append(objects[conditionFailedDeployments], alertObject{
+					namespace: deployment.Namespace,
+					key:       deployment.Namespace + "/" + deployment.Name,
+					message:   fmt.Sprintf("%d/%d pods ready", deployment.ReadyPods, deployment.RequestedPods),
+				})
+			}
+		}
+	}
+	if needed[conditionNotReadyNodes] {
+		if nodes, err := getNodesHealth(ctx, e.clientset, labels.Everything()); err != nil {
+			slog.ErrorContext(ctx, "Failed scanning nodes for alerting", "error", err)
+		} else {
+			objects[conditionNotReadyNodes] = []alertObject{}
+			for _, node := range nodes.UnhealthyNodes {
+				if !node.Ready {
+					objects[conditionNotReadyNodes] = append(objects[conditionNotReadyNodes], alertObject{key: node.Name, message: "not ready"})
+				}
+			}
+		}
+	}
+	if needed[conditionProblemPods] {
+		options := problemPodsOptions{RestartThreshold: defaultRestartThreshold, OOMWindow: defaultOOMWindow}
+		if report, err := getProblemPods(ctx, e.clientset, e.scope, labels.Everything(), options, now); err != nil {
+			slog.ErrorContext(ctx, "Failed scanning pods for alerting", "error", err)
+		} else {
+			objects[conditionProblemPods] = []alertObject{}
+			for _, namespace := range report.Namespaces {
+				for _, owner := range namespace.Owners {
+					for _, pod := range owner.Pods {
+						problems := make([]string, 0, len(pod.Problems))
+						for _, problem := range pod.Problems {
+							problems = append(problems, problem.Type)
+						}
+						objects[conditionProblemPods] = append(objects[conditionProblemPods], alertObject{
+							namespace: namespace.Namespace,
+							key:       namespace.Namespace + "/" + pod.Name,
+							message:   strings.Join(problems, ", "),
+						})
+					}
+				}
+			}
+		}
+	}
+	return objects
+}
+
+// evaluate fires and resolves the alerts of every rule given the objects meeting each condition, a condition missing
+// from objects leaving the alerts of its rules as they were.
+func (e *AlertEngine) evaluate(ctx context.Context, objects map[string][]alertObject, now time.Time) {
+	for _, rule := range e.rules {
+		current, ok := objects[rule.Condition]
+		if !ok {
+			continue
+		}
+		states := e.states[rule.Name]
+		if states == nil {
+			states = map[string]*alertState{}
+			e.states[rule.Name] = states
+		}
+
+		seen := map[string]bool{}
+		for _, object := range current {
+			if slices.Contains(e.excluded, object.namespace) || !rule.matches(object.namespace) {
+				continue
+			}
+			seen[object.key] = true
+			state := states[object.key]
+			if state == nil {
+				state = &alertState{since: now}
+				states[object.key] = state
+			}
+			state.object = object
+			// A silenced alert fires once the silence ends, if it still holds
+			if !state.firing && now.Sub(state.since) >= rule.For.Duration && !e.Silences.silenced(rule.Name, object.namespace, now) {
+				state.firing = true
+				e.notify(ctx, rule, alertFiring, state, now)
+			}
+		}
+
+		for key, state := range states {
+			if seen[key] {
+				continue
+			}
+			// Resolving tells the targets which were notified, even when silenced since
+			if state.firing {
+				e.notify(ctx, rule, alertResolved, state, now)
+			}
+			delete(states, key)
+		}
+	}
+}
+
+// matches tells whether the rule alerts about the objects of namespace, empty for nodes.
+func (r AlertRule) matches(namespace string) bool {
+	if len(r.Namespaces) == 0 {
+		return true
+	}
+	for _, pattern := range r.Namespaces {
+		if matched, _ := path.Match(pattern, namespace); matched && namespace != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// notify sends an alert to every target of its rule.
+func (e *AlertEngine) notify(ctx context.Context, rule AlertRule, status string, state *alertState, now time.Time) {
+	alert := Alert{
+		Rule:      rule.Name,
+		Status:    status,
+		Cluster:   e.cluster,
+		Namespace: state.object.namespace,
+		Object:    state.object.key,
+		Message:   state.object.message,
+		Since:     state.since.UTC(),
+		At:        now.UTC(),
+	}
+	slog.InfoContext(ctx, "Alert "+status, "rule", rule.Name, "object", alert.Object)
+
+	body, err := json.Marshal(alert)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed encoding alert", "error", err)
+		return
+	}
+	for _, name := range rule.Targets {
+		if endpoint, ok := e.endpoints[name]; ok {
+			err = e.deliver(ctx, endpoint, body, now)
+		} else {
+			err = e.postSlack(ctx, *e.targets[name].Slack, alert.slackText())
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed notifying alert", "rule", rule.Name, "target", name, "error", err)
+		}
+	}
+}
+
+// slackText formats the alert as a Slack message.
+func (a Alert) slackText() string {
+	if a.Status == alertResolved {
+		return fmt.Sprintf(":large_green_circle: *[resolved] %s* `%s` in cluster %s, since %s", a.Rule, a.Object, a.Cluster, a.Since.Format(time.RFC3339))
+	}
+	return fmt.Sprintf(":rotating_light: *[firing] %s* `%s` in cluster %s: %s, since %s", a.Rule, a.Object, a.Cluster, a.Message, a.Since.Format(time.RFC3339))
+}
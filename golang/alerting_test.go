@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAlertEngineEvaluate(t *testing.T) {
+	var alerts []Alert
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert Alert
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&alert))
+		alerts = append(alerts, alert)
+	}))
+	defer receiver.Close()
+
+	engine, err := newAlertEngine(&AlertingConfig{
+		Targets: map[string]AlertTarget{"oncall": {Webhook: &WebhookEndpoint{URL: receiver.URL}}},
+		Rules: []AlertRule{
+			{Name: "payments-down", Condition: conditionFailedDeployments, Namespaces: []string{"payments-*"}, For: metav1.Duration{Duration: 10 * time.Minute}, Targets: []string{"oncall"}},
+			{Name: "node-down", Condition: conditionNotReadyNodes, Targets: []string{"oncall"}},
+		},
+	}, "prod", nil, NamespaceScope{}, []string{"payments-sandbox"}, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	now := time.Date(2024, time.January, 31, 12, 0, 0, 0, time.UTC)
+	ledger := alertObject{namespace: "payments-eu", key: "payments-eu/ledger", message: "0/2 pods ready"}
+	failed := func(objects ...alertObject) map[string][]alertObject {
+		return map[string][]alertObject{
+			conditionFailedDeployments: append(objects,
+				alertObject{namespace: "shop", key: "shop/cart"},
+				alertObject{namespace: "payments-sandbox", key: "payments-sandbox/ledger"}),
+			conditionNotReadyNodes: {},
+		}
+	}
+
+	engine.evaluate(context.TODO(), failed(ledger), now)
+	engine.evaluate(context.TODO(), failed(ledger), now.Add(9*time.Minute))
+	assert.Empty(t, alerts, "fires once failing for 10m, only in the payments namespaces which aren't excluded")
+	engine.evaluate(context.TODO(), failed(ledger), now.Add(10*time.Minute))
+	engine.evaluate(context.TODO(), failed(ledger), now.Add(11*time.Minute))
+	if assert.Len(t, alerts, 1) {
+		assert.Equal(t, Alert{
+			Rule: "payments-down", Status: alertFiring, Cluster: "prod", Namespace: "payments-eu", Object: "payments-eu/ledger",
+			Message: "0/2 pods ready", Since: now, At: now.Add(10 * time.Minute),
+		}, alerts[0])
+	}
+
+	// A failed scan keeps the alerts as they were
+	engine.evaluate(context.TODO(), map[string][]alertObject{}, now.Add(12*time.Minute))
+	engine.evaluate(context.TODO(), failed(), now.Add(13*time.Minute))
+	if assert.Len(t, alerts, 2) {
+		assert.Equal(t, alertResolved, alerts[1].Status)
+	}
+
+	// A silenced alert fires once the silence ends
+	engine.Silences.add(Silence{ID: "maintenance", Namespace: "payments-*", StartsAt: now, EndsAt: now.Add(time.Hour)})
+	engine.evaluate(context.TODO(), failed(ledger), now.Add(20*time.Minute))
+	engine.evaluate(context.TODO(), failed(ledger), now.Add(50*time.Minute))
+	assert.Len(t, alerts, 2)
+	engine.evaluate(context.TODO(), failed(ledger), now.Add(time.Hour))
+	if assert.Len(t, alerts, 3) {
+		assert.Equal(t, alertFiring, alerts[2].Status)
+		assert.Equal(t, now.Add(20*time.Minute), alerts[2].Since)
+	}
+	assert.Empty(t, engine.Silences.list(now.Add(time.Hour)), "ended silences are dropped")
+
+	// Nodes don't need to fail for long
+	engine.evaluate(context.TODO(), map[string][]alertObject{conditionNotReadyNodes: {{key: "worker", message: "not ready"}}}, now.Add(61*time.Minute))
+	if assert.Len(t, alerts, 4) {
+		assert.Equal(t, "node-down", alerts[3].Rule)
+		assert.Equal(t, "worker", alerts[3].Object)
+	}
+}
+
+func TestNewAlertEngineValidation(t *testing.T) {
+	targets := map[string]AlertTarget{"oncall": {Slack: &SlackTarget{WebhookURL: "https://hooks.slack.com/services/T/B/X"}}}
+	for name, config := range map[string]AlertingConfig{
+		"no rules":          {Targets: targets},
+		"unknown condition": {Targets: targets, Rules: []AlertRule{{Name: "a", Condition: "sad_pods", Targets: []string{"oncall"}}}},
+		"unknown target":    {Targets: targets, Rules: []AlertRule{{Name: "a", Condition: conditionProblemPods, Targets: []string{"pager"}}}},
+		"duplicate name": {Targets: targets, Rules: []AlertRule{
+			{Name: "a", Condition: conditionProblemPods, Targets: []string{"oncall"}},
+			{Name: "a", Condition: conditionNotReadyNodes, Targets: []string{"oncall"}},
+		}},
+		"target of both kinds": {
+			Targets: map[string]AlertTarget{"oncall": {Slack: targets["oncall"].Slack, Webhook: &WebhookEndpoint{URL: "https://example.com"}}},
+			Rules:   []AlertRule{{Name: "a", Condition: conditionProblemPods, Targets: []string{"oncall"}}},
+		},
+	} {
+		_, err := newAlertEngine(&config, "prod", nil, NamespaceScope{}, nil, nil)
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+var errUnknownAlias = errors.New("unknown workload alias")
+
+// WorkloadAliases maps friendly workload names to a namespace and label set, loaded from the aliases section of the config file.
+//
+// Names are case insensitive so runbooks don't have to match the config byte for byte.
+type WorkloadAliases map[string]DenyNetworkRequestWorkload
+
+// newWorkloadAliases normalizes the configured aliases, rejecting invalid namespaces and labels.
+func newWorkloadAliases(config map[string]DenyNetworkRequestWorkload) (WorkloadAliases, error) {
+	aliases := WorkloadAliases{}
+	for name, workload := range config {
+		key := normalizeAlias(name)
+		if _, ok := aliases[key]; ok {
+			return nil, fmt.Errorf("alias %q is defined more than once", name)
+		}
+		if workload.Alias != "" {
+			return nil, fmt.Errorf("alias %q can't reference another alias", name)
+		}
+
+		workload, err := normalizeWorkload(workload)
+		if err != nil {
+			return nil, fmt.Errorf("alias %q: %w", name, err)
+		}
+		aliases[key] = workload
+	}
+	return aliases, nil
+}
+
+func normalizeAlias(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// normalizeWorkload trims the namespace and labels and checks they're valid Kubernetes names, or checks the CIDR of a network range.
+func normalizeWorkload(workload DenyNetworkRequestWorkload) (DenyNetworkRequestWorkload, error) {
+	if workload.CIDR != "" {
+		err := normalizePeer(&workload)
+		return workload, err
+	}
+
+	normalized := DenyNetworkRequestWorkload{Namespace: strings.TrimSpace(workload.Namespace), Labels: map[string]string{}}
+
+	if errs := validation.IsDNS1123Label(normalized.Namespace); len(errs) > 0 {
+		return normalized, fmt.Errorf("invalid namespace %q: %s", normalized.Namespace, strings.Join(errs, ", "))
+	}
+	if len(workload.Labels) == 0 {
+		return normalized, errors.New("at least one label is required")
+	}
+	for key, value := range workload.Labels {
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if err := validateLabel(key, value); err != nil {
+			return normalized, err
+		}
+		normalized.Labels[key] = value
+	}
+	return normalized, nil
+}
+
+// resolve replaces a workload given by alias with the namespace and labels it stands for.
+//
+// A workload can't set both an alias and a namespace or labels.
+func (a WorkloadAliases) resolve(workload DenyNetworkRequestWorkload) (DenyNetworkRequestWorkload, error) {
+	if workload.Alias == "" {
+		return workload, nil
+	}
+	if workload.Namespace != "" || workload.selects() || workload.CIDR != "" {
+		return workload, fmt.Errorf("workload %q sets both an alias and a namespace, labels or CIDR", workload.Alias)
+	}
+
+	resolved, ok := a[normalizeAlias(workload.Alias)]
+	if !ok {
+		return workload, fmt.Errorf("%w %q", errUnknownAlias, workload.Alias)
+	}
+	return resolved, nil
+}
+
+// Handler listing the workload aliases
+func (s *Server) aliasesHandler(w http.ResponseWriter, r *http.Request) {
+	names := make([]string, 0, len(s.Aliases))
+	for name := range s.Aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	aliases := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		aliases = append(aliases, map[string]interface{}{
+			"alias":     name,
+			"namespace": s.Aliases[name].Namespace,
+			"labels":    s.Aliases[name].Labels,
+			"selector":  renderMap(s.Aliases[name].Labels),
+		})
+	}
+	writeJSON(w, http.StatusOK, aliases)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestWorkloadAliases(t *testing.T) {
+	aliases, err := newWorkloadAliases(map[string]DenyNetworkRequestWorkload{
+		" Payments-API ": {Namespace: "payments ", Labels: map[string]string{" app": "api "}},
+		"office":         {CIDR: "192.0.2.0/24"},
+	})
+	assert.NoError(t, err)
+
+	resolved, err := aliases.resolve(DenyNetworkRequestWorkload{Alias: "payments-api"})
+	assert.NoError(t, err)
+	assert.Equal(t, DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "api"}}, resolved)
+
+	explicit := DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}}
+	resolved, err = aliases.resolve(explicit)
+	assert.NoError(t, err)
+	assert.Equal(t, explicit, resolved)
+
+	resolved, err = aliases.resolve(DenyNetworkRequestWorkload{Alias: "office"})
+	assert.NoError(t, err)
+	assert.Equal(t, DenyNetworkRequestWorkload{CIDR: "192.0.2.0/24"}, resolved)
+
+	_, err = aliases.resolve(DenyNetworkRequestWorkload{Alias: "ledger"})
+	assert.True(t, errors.Is(err, errUnknownAlias))
+
+	_, err = aliases.resolve(DenyNetworkRequestWorkload{Alias: "payments-api", Namespace: "shop"})
+	assert.Error(t, err)
+}
+
+func TestWorkloadAliasesValidation(t *testing.T) {
+	for name, config := range map[string]map[string]DenyNetworkRequestWorkload{
+		"bad namespace": {"a": {Namespace: "Payments", Labels: map[string]string{"app": "api"}}},
+		"no labels":     {"a": {Namespace: "payments"}},
+		"bad label":     {"a": {Namespace: "payments", Labels: map[string]string{"app": "not valid"}}},
+		"duplicate":     {"a": {Namespace: "payments", Labels: map[string]string{"app": "api"}}, "A": {Namespace: "payments", Labels: map[string]string{"app": "api"}}},
+		"nested alias":  {"a": {Alias: "b"}},
+		"bad CIDR":      {"a": {CIDR: "192.0.2.0"}},
+		"CIDR and ns":   {"a": {Namespace: "payments", CIDR: "192.0.2.0/24"}},
+	} {
+		_, err := newWorkloadAliases(config)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestGetWorkloadsHealth(t *testing.T) {
+	replicas := int32(1)
+	deployment := func(name, app string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "payments"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": app}}},
+			},
+		}
+	}
+	clientset := fake.NewSimpleClientset(deployment("api", "api"), deployment("worker", "worker"))
+
+	health, err := getWorkloadsHealth(context.Background(), clientset, NamespaceScope{Names: []string{"payments"}}, nil, nil, labels.SelectorFromSet(labels.Set{"app": "api"}))
+	assert.NoError(t, err)
+	assert.Len(t, health.FailedDeployments, 1)
+	assert.Equal(t, "api", health.FailedDeployments[0].Name)
+}
+
+func TestListWorkloadsHealthSelectsDeployments(t *testing.T) {
+	deployment := func(namespace, name, tier string) *appsv1.Deployment {
+		replicas := int32(1)
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"tier": tier}},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		}
+	}
+	clientset := fake.NewSimpleClientset(deployment("payments", "api", "web"), deployment("payments", "ledger", "backend"), deployment("shop", "cart", "web"))
+	names := func(scope NamespaceScope, deploymentSelector string) []string {
+		parsed, err := labels.Parse(deploymentSelector)
+		assert.NoError(t, err)
+		health, err := listWorkloadsHealth(context.Background(), clientset, scope, nil, nil, labels.Everything(), parsed, deploymentsPage{})
+		assert.NoError(t, err)
+		var names []string
+		for _, deployment := range append(health.ReadyDeployments, health.FailedDeployments...) {
+			names = append(names, deployment.Name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	assert.Equal(t, []string{"api", "cart"}, names(NamespaceScope{}, "tier=web"))
+	assert.Equal(t, []string{"api", "ledger"}, names(NamespaceScope{Names: []string{"payments"}}, ""))
+	assert.Empty(t, names(NamespaceScope{Names: []string{"shop"}}, "tier in (backend)"))
+}
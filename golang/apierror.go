@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Codes of the error responses, which tell apart the errors sharing a status. The errors without a code of their own
+// get the code of their status.
+const (
+	errorCodeInvalidRequest      = "invalid_request"
+	errorCodeInvalidJSON         = "invalid_json"
+	errorCodeInvalidReference    = "invalid_reference"
+	errorCodeInvalidContinue     = "invalid_continue"
+	errorCodeUnknownAlias        = "unknown_alias"
+	errorCodeUnauthenticated     = "unauthenticated"
+	errorCodeForbidden           = "forbidden"
+	errorCodeNamespaceOutOfScope = "namespace_out_of_scope"
+	errorCodeKubernetesForbidden = "kubernetes_forbidden"
+	errorCodeNotFound            = "not_found"
+	errorCodeNamespaceNotFound   = "namespace_not_found"
+	errorCodeWorkloadNotFound    = "workload_not_found"
+	errorCodeMethodNotAllowed    = "method_not_allowed"
+	errorCodeConflict            = "conflict"
+	errorCodePolicyConflict      = "policy_conflict"
+	errorCodeGone                = "gone"
+	errorCodePayloadTooLarge     = "payload_too_large"
+	errorCodeUnprocessable       = "unprocessable"
+	errorCodeRejectedByAPIServer = "rejected_by_api_server"
+	errorCodeRateLimited         = "rate_limited"
+	errorCodeBudgetExceeded      = "budget_exceeded"
+	errorCodeInternal            = "internal_error"
+	errorCodeUnavailable         = "unavailable"
+	errorCodeCalicoUnavailable   = "calico_api_unavailable"
+	errorCodeCalicoNotInstalled  = "calico_not_installed"
+	errorCodeMetricsUnavailable  = "metrics_unavailable"
+	errorCodeAlertingDisabled    = "alerting_disabled"
+	errorCodeTimeout             = "timeout"
+)
+
+// statusErrorCodes are the codes of the errors without a code of their own, by status.
+var statusErrorCodes = map[int]string{
+	http.StatusBadRequest:            errorCodeInvalidRequest,
+	http.StatusUnauthorized:          errorCodeUnauthenticated,
+	http.StatusForbidden:             errorCodeForbidden,
+	http.StatusNotFound:              errorCodeNotFound,
+	http.StatusMethodNotAllowed:      errorCodeMethodNotAllowed,
+	http.StatusConflict:              errorCodeConflict,
+	http.StatusGone:                  errorCodeGone,
+	http.StatusRequestEntityTooLarge: errorCodePayloadTooLarge,
+	http.StatusUnprocessableEntity:   errorCodeUnprocessable,
+	http.StatusTooManyRequests:       errorCodeRateLimited,
+	http.StatusServiceUnavailable:    errorCodeUnavailable,
+	http.StatusGatewayTimeout:        errorCodeTimeout,
+}
+
+// errInvalidJSON is wrapped by the errors of request bodies which aren't the JSON of the request.
+var errInvalidJSON = errors.New("error parsing JSON")
+
+// ErrorResponse is the body of every error response of the API.
+type ErrorResponse struct {
+	Code    string        `json:"code"`
+	Message string        `json:"message"`
+	Details []ErrorDetail `json:"details,omitempty"`
+	// RequestID is the correlation ID of the request, also returned in X-Correlation-ID
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// ErrorDetail points at what was wrong with the request, the field being empty when the error isn't about one.
+type ErrorDetail struct {
+	Field  string `json:"field,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// FieldError is a request failing validation because of one of its fields, reported in the details of the response.
+// Its message is that of Err, which already names the field.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// invalidField reports err as a validation error of field.
+func invalidField(field string, err error) error {
+	return &FieldError{Field: field, Err: err}
+}
+
+// invalidJSON is the error of a request body json.Unmarshal failed to read.
+func invalidJSON(err error) error {
+	return fmt.Errorf("%w: %w", errInvalidJSON, err)
+}
+
+// writeError answers the request with the JSON error response of err.
+//
+// The request ID is read back from the response headers, which withRequestMetadata sets before any handler runs.
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Del("Content-Length")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	writeJSON(w, status, ErrorResponse{
+		Code:      errorCode(status, err),
+		Message:   err.Error(),
+		Details:   errorDetails(err),
+		RequestID: w.Header().Get(correlationIDHeader),
+	})
+}
+
+// writeErrorf is writeError with an error formatted like fmt.Errorf.
+func writeErrorf(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	writeError(w, status, fmt.Errorf(format, args...))
+}
+
+// errorCode returns the code of err, or of status when err has no code of its own.
+func errorCode(status int, err error) string {
+	var conflict *PolicyConflictError
+	var exceeded *BudgetExceededError
+	switch {
+	case errors.Is(err, errInvalidJSON):
+		return errorCodeInvalidJSON
+	case errors.Is(err, errWorkloadNotFound):
+		return errorCodeWorkloadNotFound
+	case errors.Is(err, errNamespaceOutOfScope):
+		return errorCodeNamespaceOutOfScope
+	case errors.Is(err, errInvalidReference):
+		return errorCodeInvalidReference
+	case errors.Is(err, errUnknownAlias):
+		return errorCodeUnknownAlias
+	case errors.Is(err, errInvalidContinue):
+		return errorCodeInvalidContinue
+	case errors.Is(err, errCalicoAPIUnavailable):
+		return errorCodeCalicoUnavailable
+	case errors.Is(err, errCalicoNotInstalled):
+		return errorCodeCalicoNotInstalled
+	case errors.Is(err, errMetricsUnavailable):
+		return errorCodeMetricsUnavailable
+	case errors.Is(err, errAlertingDisabled):
+		return errorCodeAlertingDisabled
+	case errors.As(err, &conflict):
+		return errorCodePolicyConflict
+	case errors.As(err, &exceeded):
+		return errorCodeBudgetExceeded
+	case errors.Is(err, context.DeadlineExceeded), apierrors.IsTimeout(err), apierrors.IsServerTimeout(err):
+		return errorCodeTimeout
+	case apierrors.IsNotFound(err):
+		if details := kubeErrorDetails(err); details != nil && details.Kind == "namespaces" {
+			return errorCodeNamespaceNotFound
+		}
+		return errorCodeNotFound
+	case apierrors.IsInvalid(err):
+		return errorCodeRejectedByAPIServer
+	case apierrors.IsForbidden(err):
+		// The service account of the service lacks the permission, not the caller
+		return errorCodeKubernetesForbidden
+	}
+	if code, ok := statusErrorCodes[status]; ok {
+		return code
+	}
+	if status >= http.StatusInternalServerError {
+		return errorCodeInternal
+	}
+	return errorCodeInvalidRequest
+}
+
+// errorDetails returns the field of a validation error, the position of a JSON error or the causes the API server
+// rejected an object for.
+func errorDetails(err error) []ErrorDetail {
+	var field *FieldError
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &field):
+		return []ErrorDetail{{Field: field.Field, Reason: field.Err.Error()}}
+	case errors.As(err, &typeErr):
+		return []ErrorDetail{{Field: typeErr.Field, Reason: fmt.Sprintf("expected %s, got a JSON %s", typeErr.Type, typeErr.Value)}}
+	case errors.As(err, &syntaxErr):
+		return []ErrorDetail{{Reason: fmt.Sprintf("%s at offset %d", syntaxErr, syntaxErr.Offset)}}
+	case apierrors.IsInvalid(err):
+		var details []ErrorDetail
+		if status := kubeErrorDetails(err); status != nil {
+			for _, cause := range status.Causes {
+				details = append(details, ErrorDetail{Field: cause.Field, Reason: cause.Message})
+			}
+		}
+		return details
+	}
+	return nil
+}
+
+// kubeErrorDetails returns the details of a Kubernetes API error, nil for other errors.
+func kubeErrorDetails(err error) *metav1.StatusDetails {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return nil
+	}
+	return status.Status().Details
+}
+
+// withRouteErrors answers the requests no route of mux matches, which next serves through mux, with JSON error
+// responses in place of the plain text 404 and 405 of http.ServeMux.
+func withRouteErrors(mux *http.ServeMux, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, route := mux.Handler(r); route != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(&routeErrorWriter{ResponseWriter: w, request: r}, r)
+	})
+}
+
+// routeErrorWriter replaces the error response of an unmatched route, redirects going through.
+type routeErrorWriter struct {
+	http.ResponseWriter
+	request  *http.Request
+	replaced bool
+}
+
+func (w *routeErrorWriter) WriteHeader(status int) {
+	switch {
+	case status < http.StatusBadRequest:
+		w.ResponseWriter.WriteHeader(status)
+	case status == http.StatusMethodNotAllowed:
+		w.replaced = true
+		writeErrorf(w.ResponseWriter, status, "Method %s isn't allowed on %s", w.request.Method, w.request.URL.Path)
+	default:
+		w.replaced = true
+		writeErrorf(w.ResponseWriter, status, "No route matches %s %s", w.request.Method, w.request.URL.Path)
+	}
+}
+
+func (w *routeErrorWriter) Write(data []byte) (int, error) {
+	if w.replaced {
+		return len(data), nil
+	}
+	return w.ResponseWriter.Write(data)
+}
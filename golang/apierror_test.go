@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestErrorCode(t *testing.T) {
+	for expected, test := range map[string]struct {
+		status int
+		err    error
+	}{
+		errorCodeNamespaceNotFound:   {http.StatusUnprocessableEntity, apierrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, "shop")},
+		errorCodeNotFound:            {http.StatusNotFound, apierrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, "cart")},
+		errorCodeCalicoUnavailable:   {http.StatusServiceUnavailable, errCalicoAPIUnavailable},
+		errorCodeWorkloadNotFound:    {http.StatusUnprocessableEntity, fmt.Errorf("%w: no pod matches", errWorkloadNotFound)},
+		errorCodeNamespaceOutOfScope: {http.StatusForbidden, fmt.Errorf("%w: payments", errNamespaceOutOfScope)},
+		errorCodeTimeout:             {http.StatusInternalServerError, fmt.Errorf("listing deployments: %w", context.DeadlineExceeded)},
+		errorCodeKubernetesForbidden: {http.StatusInternalServerError, apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("no RBAC"))},
+		errorCodeRateLimited:         {http.StatusTooManyRequests, errors.New("slow down")},
+		errorCodeInternal:            {http.StatusBadGateway, errors.New("upstream failed")},
+	} {
+		assert.Equal(t, expected, errorCode(test.status, test.err))
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set(correlationIDHeader, "run-42")
+	writeError(rec, http.StatusBadRequest, invalidField("scope", errors.New(`invalid scope "cluster", expected namespace or global`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"code": "invalid_request",
+		"message": "invalid scope \"cluster\", expected namespace or global",
+		"details": [{"field": "scope", "reason": "invalid scope \"cluster\", expected namespace or global"}],
+		"request_id": "run-42"
+	}`, rec.Body.String())
+
+	var request DenyNetworkRequest
+	rec = httptest.NewRecorder()
+	writeError(rec, http.StatusBadRequest, invalidJSON(json.Unmarshal([]byte(`{"workload_a": {"labels": ["app"]}}`), &request)))
+	var response ErrorResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, errorCodeInvalidJSON, response.Code)
+	assert.Equal(t, []ErrorDetail{{Field: "workload_a.labels", Reason: "expected map[string]string, got a JSON array"}}, response.Details)
+
+	// The causes the API server rejected an object for
+	invalid := apierrors.NewInvalid(schema.GroupKind{Group: "networking.k8s.io", Kind: "NetworkPolicy"}, "deny", field.ErrorList{
+		field.Invalid(field.NewPath("spec", "podSelector"), "app=", "invalid label value"),
+	})
+	rec = httptest.NewRecorder()
+	writeError(rec, http.StatusUnprocessableEntity, invalid)
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, errorCodeRejectedByAPIServer, response.Code)
+	if assert.Len(t, response.Details, 1) {
+		assert.Equal(t, "spec.podSelector", response.Details[0].Field)
+	}
+}
+
+func TestDenyNetworkPolicyValidationError(t *testing.T) {
+	listen := ListenConfig{MaxBodyBytes: defaultMaxBodyBytes}
+	handler := listen.newHTTPServer("127.0.0.1:0", newServerMux(Server{K8sClientSet: fake.NewSimpleClientset()}), nil).Handler
+
+	request := httptest.NewRequest(http.MethodPost, "/denyNetworkPolicy", strings.NewReader(`{
+		"workload_a": {"namespace": "shop", "labels": {"app": "cart"}},
+		"workload_b": {"namespace": "payments", "labels": {"app": "ledger"}},
+		"ttl": "-1h"
+	}`))
+	request.Header.Set(correlationIDHeader, "run-42")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, request)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response ErrorResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, ErrorResponse{
+		Code:      errorCodeInvalidRequest,
+		Message:   "invalid ttl -1h0m0s, it can't be negative",
+		Details:   []ErrorDetail{{Field: "ttl", Reason: "invalid ttl -1h0m0s, it can't be negative"}},
+		RequestID: "run-42",
+	}, response)
+}
+
+func TestWithRouteErrors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthscan", func(w http.ResponseWriter, r *http.Request) {
+		writeErrorf(w, http.StatusServiceUnavailable, "The health scanner is disabled, set --scan-interval")
+	})
+	handler := withRouteErrors(mux, mux)
+
+	for target, expected := range map[string]struct {
+		method string
+		status int
+		code   string
+	}{
+		"/healthscan":  {http.MethodPost, http.StatusMethodNotAllowed, errorCodeMethodNotAllowed},
+		"/healthscans": {http.MethodGet, http.StatusNotFound, errorCodeNotFound},
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(expected.method, target, nil))
+		assert.Equal(t, expected.status, rec.Code)
+		var response ErrorResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response), rec.Body.String())
+		assert.Equal(t, expected.code, response.Code)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/healthscan", nil))
+	assert.Equal(t, "GET, HEAD", rec.Header().Get("Allow"))
+
+	// Redirects to the clean path go through
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/./healthscan", nil))
+	assert.Equal(t, http.StatusTemporaryRedirect, rec.Code)
+	assert.Equal(t, "/healthscan", rec.Header().Get("Location"))
+}
+
+func TestRequestBodiesRejectUnknownFields(t *testing.T) {
+	server := Server{K8sClientSet: fake.NewSimpleClientset(), Alerts: &AlertEngine{Silences: newSilenceStore()}}
+	handler := newServerMux(server)
+
+	for target, body := range map[string]string{
+		"/isolateNamespace":         `{"namespace": "payments", "alow_dns": true}`,
+		"/api/v1/isolations/bulk":   `{"selector": "team=payments", "target": {"cidr": "10.0.0.0/8"}, "dry_run": true}`,
+		"/api/v1/debug/shop/cart-1": `{"image": "busybox", "targetContainer": "cart"}`,
+		"/api/v1/exec/shop/cart-1":  `{"command": "ls", "containr": "cart"}`,
+		"/networkpath/simulate":     `{"source": {"namespace": "shop"}, "destination": {"namespace": "payments"}, "prot": 80}`,
+		"/api/v1/silences":          `{"rule": "payments-down", "duration": "1h", "coment": "maintenance"}`,
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, target, strings.NewReader(body)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+		var response ErrorResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response), target)
+		assert.Equal(t, errorCodeInvalidJSON, response.Code, target)
+		assert.Len(t, response.Details, 1, target)
+		assert.Contains(t, response.Message, "unknown field", target)
+	}
+}
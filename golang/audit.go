@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultAuditRetention is how many of the latest audit events are kept in memory.
+	defaultAuditRetention = 1000
+	// defaultAuditLimit and maxAuditLimit bound the events GET /audit returns.
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+	// maxAuditLineBytes bounds a line of the audit log file read back, larger lines are skipped.
+	maxAuditLineBytes = 1 << 20
+)
+
+// AuditEvent records a privileged operation performed through the API.
+type AuditEvent struct {
+	Time   time.Time `json:"time"`
+	Action string    `json:"action"`
+	RequestMetadata
+	RemoteAddr string      `json:"remote_addr,omitempty"`
+	Namespace  string      `json:"namespace,omitempty"`
+	Target     string      `json:"target,omitempty"`
+	Request    interface{} `json:"request,omitempty"`
+	Result     string      `json:"result"`
+	Error      string      `json:"error,omitempty"`
+}
+
+// AuditLog keeps the audit events served by GET /audit: the latest ones in memory, and every one appended to a file as
+// JSON lines when --audit-log-file is set, so the trail survives restarts.
+type AuditLog struct {
+	mu     sync.Mutex
+	events []AuditEvent
+	next   int
+	full   bool
+	path   string
+	file   *os.File
+}
+
+// auditLog is the audit log of the process, shared by every cluster it manages.
+var auditLog = newAuditLog(defaultAuditRetention)
+
+func newAuditLog(retention int) *AuditLog {
+	return &AuditLog{events: make([]AuditEvent, retention)}
+}
+
+// openFile appends the events recorded from now on to the file at path, creating it when missing.
+func (l *AuditLog) openFile(path string) error {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening the audit log: %w", err)
+	}
+	// A line cut short by a crash is ended, rather than the next event being appended to it
+	if err := endAuditLine(file); err != nil {
+		file.Close()
+		return fmt.Errorf("opening the audit log: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.path, l.file = path, file
+	return nil
+}
+
+// endAuditLine ends the last line of file when it has no newline.
+func endAuditLine(file *os.File) error {
+	info, err := file.Stat()
+	if err != nil || info.Size() == 0 {
+		return err
+	}
+	last := make([]byte, 1)
+	if _, err := file.ReadAt(last, info.Size()-1); err != nil || last[0] == '\n' {
+		return err
+	}
+	_, err = file.Write([]byte{'\n'})
+	return err
+}
+
+// append keeps the event, of which line is the JSON encoding.
+func (l *AuditLog) append(event AuditEvent, line []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.events[l.next] = event
+	l.next = (l.next + 1) % len(l.events)
+	l.full = l.full || l.next == 0
+
+	if l.file == nil {
+		return
+	}
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		slog.Error("Failed writing audit event", "path", l.path, "action", event.Action, "error", err)
+	}
+}
+
+// AuditFilter selects audit events, the empty fields matching every event.
+type AuditFilter struct {
+	Action    string
+	Namespace string
+	Principal string
+	Since     time.Time
+	Limit     int
+}
+
+func (f AuditFilter) matches(event AuditEvent) bool {
+	return (f.Action == "" || event.Action == f.Action) &&
+		(f.Namespace == "" || event.Namespace == f.Namespace) &&
+		(f.Principal == "" || event.Principal == f.Principal) &&
+		!event.Time.Before(f.Since)
+}
+
+// parseAuditFilter reads ?action=, ?namespace=, ?principal=, ?since= and ?limit= of a request.
+func parseAuditFilter(query url.Values, now time.Time) (AuditFilter, error) {
+	filter := AuditFilter{
+		Action:    query.Get("action"),
+		Namespace: query.Get("namespace"),
+		Principal: query.Get("principal"),
+		Limit:     defaultAuditLimit,
+	}
+	if value := query.Get("since"); value != "" {
+		window, err := time.ParseDuration(value)
+		if err != nil || window <= 0 {
+			return filter, invalidField("since", fmt.Errorf("invalid since %q, expected a positive duration such as 6h", value))
+		}
+		filter.Since = now.Add(-window)
+	}
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 || limit > maxAuditLimit {
+			return filter, invalidField("limit", fmt.Errorf("invalid limit %q, expected an integer from 1 to %d", value, maxAuditLimit))
+		}
+		filter.Limit = limit
+	}
+	return filter, nil
+}
+
+// list returns the latest events matching filter, most recent first. They're read back from the file when there's
+// one, older than the events kept in memory.
+func (l *AuditLog) list(filter AuditFilter) ([]AuditEvent, error) {
+	l.mu.Lock()
+	path := l.path
+	var events []AuditEvent
+	if path == "" {
+		if l.full {
+			events = append(events, l.events[l.next:]...)
+		}
+		events = append(events, l.events[:l.next]...)
+	}
+	l.mu.Unlock()
+
+	if path != "" {
+		var err error
+		if events, err = readAuditFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	matching := []AuditEvent{}
+	for i := len(events) - 1; i >= 0 && len(matching) < filter.Limit; i-- {
+		if filter.matches(events[i]) {
+			matching = append(matching, events[i])
+		}
+	}
+	return matching, nil
+}
+
+// readAuditFile reads the events of an audit log file, skipping the lines which aren't one, such as a line cut short
+// by a crash.
+func readAuditFile(path string) ([]AuditEvent, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading the audit log: %w", err)
+	}
+	defer file.Close()
+
+	var events []AuditEvent
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadSlice('\n')
+		if err == bufio.ErrBufferFull {
+			// Too long for the buffer, the rest of the line is skipped with it
+			for err == bufio.ErrBufferFull {
+				_, err = reader.ReadSlice('\n')
+			}
+			continue
+		}
+		var event AuditEvent
+		if len(line) > 0 && json.Unmarshal(line, &event) == nil {
+			events = append(events, event)
+		}
+		if err != nil {
+			break
+		}
+	}
+	return events, nil
+}
+
+// recordAudit logs the event as JSON with the AUDIT message, so it can be filtered out of the logs, and keeps it in
+// the audit log.
+func recordAudit(event AuditEvent) {
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		slog.Error("Failed encoding audit event", "action", event.Action, "error", err)
+		return
+	}
+	slog.Info("AUDIT", "event", json.RawMessage(line))
+	auditLog.append(event, line)
+}
+
+// Lists the latest audit events of the mutating operations, most recent first, filtered by ?action=, ?namespace=,
+// ?principal= and ?since= ago, such as 6h, at most ?limit= of them
+//
+// Without --audit-log-file only the latest events are kept, in memory: a restart starts them over, and every replica
+// has its own.
+func (s *Server) auditHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.authorize(w, r, operationAuditRead, "", "", nil) {
+		return
+	}
+
+	filter, err := parseAuditFilter(r.URL.Query(), time.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	events, err := auditLog.list(filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, events)
+}
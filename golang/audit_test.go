@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLog(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	event := func(minutes int, action, namespace, principal string) AuditEvent {
+		return AuditEvent{
+			Time:            now.Add(time.Duration(minutes) * time.Minute),
+			Action:          action,
+			RequestMetadata: RequestMetadata{Principal: principal},
+			Namespace:       namespace,
+			Result:          "created",
+		}
+	}
+	events := []AuditEvent{
+		event(-90, operationDenyPolicy.Action, "shop", "alice"),
+		event(-30, operationDenyPolicy.Action, "payments", "bob"),
+		event(-20, operationNodeDrain.Action, "", "alice"),
+		event(-10, operationDeletePolicy.Action, "shop", "alice"),
+	}
+
+	// Only the latest events are kept in memory
+	log := newAuditLog(3)
+	for _, event := range events {
+		line, _ := json.Marshal(event)
+		log.append(event, line)
+	}
+	listed, err := log.list(AuditFilter{Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, []AuditEvent{events[3], events[2], events[1]}, listed)
+
+	listed, err = log.list(AuditFilter{Principal: "alice", Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []AuditEvent{events[3]}, listed)
+	listed, err = log.list(AuditFilter{Action: operationDenyPolicy.Action, Namespace: "payments", Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, []AuditEvent{events[1]}, listed)
+	listed, err = log.list(AuditFilter{Since: now.Add(-25 * time.Minute), Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, listed, 2)
+
+	// The file keeps every event, across restarts
+	path := filepath.Join(t.TempDir(), "audit.log")
+	log = newAuditLog(1)
+	assert.NoError(t, log.openFile(path))
+	for _, event := range events[:2] {
+		line, _ := json.Marshal(event)
+		log.append(event, line)
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	assert.NoError(t, err)
+	_, err = file.WriteString(`{"time": "2024-03-01T11:45:00Z", "act`)
+	assert.NoError(t, file.Close())
+	assert.NoError(t, err)
+
+	log = newAuditLog(1)
+	assert.NoError(t, log.openFile(path))
+	line, _ := json.Marshal(events[2])
+	log.append(events[2], line)
+	listed, err = log.list(AuditFilter{Principal: "alice", Limit: 10})
+	assert.NoError(t, err)
+	if assert.Len(t, listed, 2) {
+		assert.Equal(t, operationNodeDrain.Action, listed[0].Action)
+		assert.Equal(t, events[0].Time, listed[1].Time)
+		assert.Equal(t, "shop", listed[1].Namespace)
+	}
+}
+
+func TestParseAuditFilter(t *testing.T) {
+	now := time.Now()
+	filter, err := parseAuditFilter(url.Values{"action": {"node.drain"}, "since": {"6h"}, "limit": {"20"}}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, AuditFilter{Action: "node.drain", Since: now.Add(-6 * time.Hour), Limit: 20}, filter)
+
+	filter, err = parseAuditFilter(url.Values{}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultAuditLimit, filter.Limit)
+
+	for _, query := range []url.Values{{"since": {"-1h"}}, {"since": {"yesterday"}}, {"limit": {"0"}}, {"limit": {"5000"}}} {
+		_, err = parseAuditFilter(query, now)
+		assert.Error(t, err, query.Encode())
+	}
+
+	rec := httptest.NewRecorder()
+	(&Server{}).auditHandler(rec, httptest.NewRequest(http.MethodGet, "/audit?limit=all", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	defaultTokenReviewCacheTTL = time.Minute
+	authenticationResultDenied = "unauthenticated"
+)
+
+var errInvalidToken = errors.New("invalid bearer token")
+
+// AuthenticationConfig requires a bearer token on the mutating endpoints, checked against a static token file, an
+// OIDC provider and Kubernetes TokenReview, in that order.
+//
+// With TokenReview as well, only the tokens whose issuer is that of the OIDC provider are validated against it.
+type AuthenticationConfig struct {
+	TokenFile   string             `json:"token_file,omitempty"`
+	OIDC        *OIDCConfig        `json:"oidc,omitempty"`
+	TokenReview *TokenReviewConfig `json:"token_review,omitempty"`
+}
+
+// TokenReviewConfig validates tokens, e.g. service account tokens, with the Kubernetes API.
+type TokenReviewConfig struct {
+	Audiences []string        `json:"audiences,omitempty"`
+	CacheTTL  metav1.Duration `json:"cache_ttl,omitempty"`
+}
+
+// TokenAuthenticator resolves the bearer token of mutating requests into the principal handed to the authorizers.
+//
+// A nil TokenAuthenticator lets every request through, callers are then identified by the proxy headers.
+type TokenAuthenticator struct {
+	static      []staticToken
+	oidc        *oidcVerifier
+	tokenReview *tokenReviewer
+}
+
+type staticToken struct {
+	token     string
+	principal Principal
+}
+
+func newTokenAuthenticator(config *AuthenticationConfig, clientset kubernetes.Interface) (*TokenAuthenticator, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	authenticator := &TokenAuthenticator{}
+	if config.TokenFile != "" {
+		file, err := os.Open(config.TokenFile)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		if authenticator.static, err = parseTokenFile(file); err != nil {
+			return nil, fmt.Errorf("token file %s: %w", config.TokenFile, err)
+		}
+	}
+	if config.OIDC != nil {
+		var err error
+		if authenticator.oidc, err = newOIDCVerifier(config.OIDC); err != nil {
+			return nil, err
+		}
+	}
+	if config.TokenReview != nil {
+		ttl := config.TokenReview.CacheTTL.Duration
+		if ttl == 0 {
+			ttl = defaultTokenReviewCacheTTL
+		}
+		authenticator.tokenReview = &tokenReviewer{
+			clientset: clientset,
+			audiences: config.TokenReview.Audiences,
+			ttl:       ttl,
+			now:       time.Now,
+			cache:     map[[sha256.Size]byte]cachedReview{},
+		}
+	}
+	if authenticator.static == nil && authenticator.oidc == nil && authenticator.tokenReview == nil {
+		return nil, errors.New("authentication is configured but none of token_file, oidc and token_review is set")
+	}
+	return authenticator, nil
+}
+
+// parseTokenFile reads the CSV format of the kube-apiserver --token-auth-file: token,user,uid,"group1,group2".
+func parseTokenFile(r io.Reader) ([]staticToken, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+
+	var tokens []staticToken
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if len(record) < 2 || record[0] == "" || record[1] == "" {
+			return nil, fmt.Errorf("line %d: expected at least a token and a user", line)
+		}
+
+		token := staticToken{token: record[0], principal: Principal{User: record[1]}}
+		if len(record) > 2 {
+			token.principal.UID = record[2]
+		}
+		if len(record) > 3 {
+			for _, group := range strings.Split(record[3], ",") {
+				if group = strings.TrimSpace(group); group != "" {
+					token.principal.Groups = append(token.principal.Groups, group)
+				}
+			}
+		}
+		tokens = append(tokens, token)
+	}
+	if len(tokens) == 0 {
+		return nil, errors.New("no tokens")
+	}
+	return tokens, nil
+}
+
+// wrap authenticates the requests of a guarded route, whatever their method, before calling next.
+func (a *TokenAuthenticator) wrap(next http.HandlerFunc) http.HandlerFunc {
+	if a == nil {
+		return next
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, err := a.authenticate(r.Context(), r.Header.Get("Authorization"))
+		if err != nil {
+			recordAudit(AuditEvent{
+				Action:          "authenticate",
+				RequestMetadata: requestMetadata(r.Context()),
+				RemoteAddr:      r.RemoteAddr,
+				Target:          r.Method + " " + r.URL.Path,
+				Result:          authenticationResultDenied,
+				Error:           err.Error(),
+			})
+			if errors.Is(err, errInvalidToken) {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="tyk-sre-assignment"`)
+				writeError(w, http.StatusUnauthorized, err)
+			} else {
+				writeErrorf(w, http.StatusServiceUnavailable, "Authentication failed: %v", err)
+			}
+			return
+		}
+		next(w, r.WithContext(withPrincipal(r.Context(), *principal)))
+	}
+}
+
+// authenticate resolves the Authorization header, errInvalidToken means the caller has to fix its credentials.
+func (a *TokenAuthenticator) authenticate(ctx context.Context, header string) (*Principal, error) {
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if token = strings.TrimSpace(token); !ok || token == "" {
+		return nil, fmt.Errorf("%w: missing Authorization: Bearer header", errInvalidToken)
+	}
+
+	for _, static := range a.static {
+		if subtle.ConstantTimeCompare([]byte(static.token), []byte(token)) == 1 {
+			principal := static.principal
+			return &principal, nil
+		}
+	}
+	if a.oidc != nil && (a.tokenReview == nil || a.oidc.issues(token)) {
+		return a.oidc.verify(ctx, token)
+	}
+	if a.tokenReview != nil {
+		return a.tokenReview.review(ctx, token)
+	}
+	return nil, errInvalidToken
+}
+
+// tokenReviewer asks the Kubernetes API who a token belongs to, caching the answers for ttl.
+type tokenReviewer struct {
+	clientset kubernetes.Interface
+	audiences []string
+	ttl       time.Duration
+	now       func() time.Time
+
+	mu    sync.Mutex
+	cache map[[sha256.Size]byte]cachedReview
+}
+
+type cachedReview struct {
+	principal *Principal
+	expires   time.Time
+}
+
+func (t *tokenReviewer) review(ctx context.Context, token string) (*Principal, error) {
+	key := sha256.Sum256([]byte(token))
+	now := t.now()
+
+	t.mu.Lock()
+	cached, ok := t.cache[key]
+	t.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		if cached.principal == nil {
+			return nil, errInvalidToken
+		}
+		return cached.principal, nil
+	}
+
+	review, err := t.clientset.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token, Audiences: t.audiences},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var principal *Principal
+	if review.Status.Authenticated {
+		principal = &Principal{User: review.Status.User.Username, UID: review.Status.User.UID, Groups: review.Status.User.Groups}
+		for key, values := range review.Status.User.Extra {
+			if principal.Extra == nil {
+				principal.Extra = map[string][]string{}
+			}
+			principal.Extra[key] = values
+		}
+	}
+
+	t.mu.Lock()
+	for k, entry := range t.cache {
+		if !now.Before(entry.expires) {
+			delete(t.cache, k)
+		}
+	}
+	t.cache[key] = cachedReview{principal: principal, expires: now.Add(t.ttl)}
+	t.mu.Unlock()
+
+	if principal == nil {
+		if review.Status.Error != "" {
+			return nil, fmt.Errorf("%w: %s", errInvalidToken, review.Status.Error)
+		}
+		return nil, errInvalidToken
+	}
+	return principal, nil
+}
+
+type principalKey struct{}
+
+func withPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// authenticatedPrincipal returns the principal of a request authenticated with a bearer token.
+func authenticatedPrincipal(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
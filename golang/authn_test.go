@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestParseTokenFile(t *testing.T) {
+	tokens, err := parseTokenFile(strings.NewReader(`# token,user,uid,groups
+s3cret,deploy-bot,1001,"automation,deployers"
+t0ken,alice
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, []staticToken{
+		{token: "s3cret", principal: Principal{User: "deploy-bot", UID: "1001", Groups: []string{"automation", "deployers"}}},
+		{token: "t0ken", principal: Principal{User: "alice"}},
+	}, tokens)
+
+	_, err = parseTokenFile(strings.NewReader("lonely-token\n"))
+	assert.Error(t, err)
+	_, err = parseTokenFile(strings.NewReader("# nothing\n"))
+	assert.Error(t, err)
+}
+
+func TestTokenAuthenticatorWrap(t *testing.T) {
+	authenticator := &TokenAuthenticator{static: []staticToken{{token: "s3cret", principal: Principal{User: "deploy-bot"}}}}
+	handler := authenticator.wrap(func(w http.ResponseWriter, r *http.Request) {
+		principal, _ := authenticatedPrincipal(r.Context())
+		w.Write([]byte(principal.User))
+	})
+
+	tests := []struct {
+		method, authorization string
+		code                  int
+		body                  string
+	}{
+		{http.MethodGet, "", http.StatusUnauthorized, ""},
+		{http.MethodGet, "Bearer s3cret", http.StatusOK, "deploy-bot"},
+		{http.MethodPost, "", http.StatusUnauthorized, ""},
+		{http.MethodPost, "Bearer wrong", http.StatusUnauthorized, ""},
+		{http.MethodPost, "Basic s3cret", http.StatusUnauthorized, ""},
+		{http.MethodPost, "Bearer s3cret", http.StatusOK, "deploy-bot"},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, "/denyNetworkPolicy", nil)
+		if test.authorization != "" {
+			req.Header.Set("Authorization", test.authorization)
+		}
+		rr := httptest.NewRecorder()
+		handler(rr, req)
+
+		assert.Equal(t, test.code, rr.Code, "%s %q", test.method, test.authorization)
+		if test.code == http.StatusOK {
+			assert.Equal(t, test.body, rr.Body.String())
+		} else {
+			assert.NotEmpty(t, rr.Header().Get("WWW-Authenticate"))
+		}
+	}
+
+	var nilAuthenticator *TokenAuthenticator
+	rr := httptest.NewRecorder()
+	nilAuthenticator.wrap(func(w http.ResponseWriter, r *http.Request) {})(rr, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestSensitiveReadsAuthenticated(t *testing.T) {
+	server := Server{
+		K8sClientSet:  fake.NewSimpleClientset(),
+		Authenticator: &TokenAuthenticator{static: []staticToken{{token: "s3cret", principal: Principal{User: "deploy-bot"}}, {token: "t0ken", principal: Principal{User: "auditor"}}}},
+	}
+	var err error
+	server.Authorizer, err = newAuthorizerChain(&AuthorizationConfig{Static: &StaticAuthorizerConfig{
+		Roles:    map[string][]string{"auditor": {operationAuditRead.Action}},
+		Bindings: []StaticRoleBinding{{Role: "auditor", Users: []string{"auditor"}}},
+	}}, true, nil)
+	assert.NoError(t, err)
+	mux := newServerMux(server)
+
+	for _, path := range []string{"/logs/payments/ledger-1", "/audit"} {
+		for token, code := range map[string]int{"": http.StatusUnauthorized, "s3cret": http.StatusForbidden} {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+			assert.Equal(t, code, rr.Code, "%s %q", path, token)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/audit", nil)
+	req.Header.Set("Authorization", "Bearer t0ken")
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestTokenReview(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	reviews := 0
+	clientset.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		reviews++
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		assert.Equal(t, []string{"tyk-sre-assignment"}, review.Spec.Audiences)
+		switch review.Spec.Token {
+		case "sa-token":
+			review.Status.Authenticated = true
+			review.Status.User = authenticationv1.UserInfo{
+				Username: "system:serviceaccount:ci:deployer",
+				UID:      "5c2f",
+				Groups:   []string{"system:serviceaccounts"},
+				Extra:    map[string]authenticationv1.ExtraValue{"authentication.kubernetes.io/pod-name": {"deployer-7d9"}},
+			}
+		case "broken":
+			return true, nil, errors.New("apiserver unavailable")
+		}
+		return true, review, nil
+	})
+
+	authenticator, err := newTokenAuthenticator(&AuthenticationConfig{TokenReview: &TokenReviewConfig{Audiences: []string{"tyk-sre-assignment"}}}, clientset)
+	assert.NoError(t, err)
+	now := time.Unix(1720440000, 0)
+	authenticator.tokenReview.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		principal, err := authenticator.authenticate(context.Background(), "Bearer sa-token")
+		assert.NoError(t, err)
+		assert.Equal(t, &Principal{
+			User:   "system:serviceaccount:ci:deployer",
+			UID:    "5c2f",
+			Groups: []string{"system:serviceaccounts"},
+			Extra:  map[string][]string{"authentication.kubernetes.io/pod-name": {"deployer-7d9"}},
+		}, principal)
+
+		_, err = authenticator.authenticate(context.Background(), "Bearer expired")
+		assert.ErrorIs(t, err, errInvalidToken)
+	}
+	assert.Equal(t, 2, reviews)
+
+	now = now.Add(defaultTokenReviewCacheTTL)
+	_, err = authenticator.authenticate(context.Background(), "Bearer sa-token")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, reviews)
+
+	_, err = authenticator.authenticate(context.Background(), "Bearer broken")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, errInvalidToken)
+
+	_, err = newTokenAuthenticator(&AuthenticationConfig{}, clientset)
+	assert.Error(t, err)
+}
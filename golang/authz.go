@@ -0,0 +1,571 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	defaultUserHeader         = "X-Remote-User"
+	defaultGroupsHeader       = "X-Remote-Group"
+	defaultAuthorizerTimeout  = 5 * time.Second
+	anonymousUser             = "system:anonymous"
+	unauthenticatedGroup      = "system:unauthenticated"
+	serviceResourceGroup      = "tyk-sre-assignment"
+	authorizationResultDenied = "forbidden"
+
+	// subjectAccessReviewKubernetes checks the operations against the Kubernetes objects they change.
+	subjectAccessReviewKubernetes = "kubernetes"
+	// subjectAccessReviewVirtual checks the operations against the virtual resources of the service group.
+	subjectAccessReviewVirtual = "virtual"
+)
+
+// Operation is an action guarded by the authorizer, with the Kubernetes attributes used by SubjectAccessReview.
+//
+// The group and resource of the operations on deny policies are those of the Calico backend, replaced by the resources
+// of the active backend when authorized.
+type Operation struct {
+	Action      string `json:"action"`
+	Verb        string `json:"verb"`
+	Group       string `json:"group"`
+	Resource    string `json:"resource"`
+	Subresource string `json:"subresource,omitempty"`
+
+	policies policyResources
+}
+
+// policyResources tells which resources of the policy backend an operation changes.
+type policyResources int
+
+const (
+	policyResourcesNone policyResources = iota
+	// policyResourcesNamespaced changes the namespaced deny policies, and whatever else enforces them
+	policyResourcesNamespaced
+	// policyResourcesGlobal changes the cluster-wide deny policies
+	policyResourcesGlobal
+	// policyResourcesNetwork changes the namespaced network policies only, as namespace isolations do
+	policyResourcesNetwork
+)
+
+// onBackend returns the operation once per resource backend stores what it changes as, so SubjectAccessReview checks
+// each of them, or the operation itself when it doesn't change policies.
+func (o Operation) onBackend(backend PolicyBackend) []Operation {
+	if o.policies == policyResourcesNone || backend == nil {
+		return []Operation{o}
+	}
+
+	resources := backend.Resources(o.policies == policyResourcesGlobal)
+	if o.policies == policyResourcesNetwork && len(resources) > 1 {
+		resources = resources[:1]
+	}
+	operations := make([]Operation, 0, len(resources))
+	for _, resource := range resources {
+		operation := o
+		operation.Group, operation.Resource = resource.Group, resource.Resource
+		operations = append(operations, operation)
+	}
+	return operations
+}
+
+// Operations exposed by the API, the mutating ones and the reads of sensitive data.
+var (
+	operationDenyPolicy         = Operation{Action: "policy.deny", Verb: "create", Group: "projectcalico.org", Resource: "networkpolicies", policies: policyResourcesNamespaced}
+	operationDeletePolicy       = Operation{Action: "policy.delete", Verb: "delete", Group: "projectcalico.org", Resource: "networkpolicies", policies: policyResourcesNamespaced}
+	operationDenyGlobal         = Operation{Action: "policy.deny_global", Verb: "create", Group: "projectcalico.org", Resource: "globalnetworkpolicies", policies: policyResourcesGlobal}
+	operationDeleteGlobal       = Operation{Action: "policy.delete_global", Verb: "delete", Group: "projectcalico.org", Resource: "globalnetworkpolicies", policies: policyResourcesGlobal}
+	operationBulkIsolation      = Operation{Action: "isolation.bulk", Verb: "create", Group: "projectcalico.org", Resource: "networkpolicies", policies: policyResourcesNamespaced}
+	operationIsolateNamespace   = Operation{Action: "namespace.isolate", Verb: "create", Group: "projectcalico.org", Resource: "networkpolicies", policies: policyResourcesNetwork}
+	operationPodDebug           = Operation{Action: "pod.debug", Verb: "update", Resource: "pods", Subresource: "ephemeralcontainers"}
+	operationPodExec            = Operation{Action: "pod.exec", Verb: "create", Resource: "pods", Subresource: "exec"}
+	operationSnapshotCapture    = Operation{Action: "snapshot.capture", Verb: "create", Group: serviceResourceGroup, Resource: "snapshots"}
+	operationSelftest           = Operation{Action: "selftest.run", Verb: "create", Group: serviceResourceGroup, Resource: "selftests"}
+	operationDeploymentRestart  = Operation{Action: "deployment.restart", Verb: "patch", Group: "apps", Resource: "deployments"}
+	operationDeploymentRollback = Operation{Action: "deployment.rollback", Verb: "patch", Group: "apps", Resource: "deployments"}
+	operationNodeCordon         = Operation{Action: "node.cordon", Verb: "patch", Resource: "nodes"}
+	operationNodeUncordon       = Operation{Action: "node.uncordon", Verb: "patch", Resource: "nodes"}
+	operationNodeDrain          = Operation{Action: "node.drain", Verb: "create", Resource: "pods", Subresource: "eviction"}
+	operationPodEvict           = Operation{Action: "pod.evict", Verb: "create", Resource: "pods", Subresource: "eviction"}
+	operationPodForceDelete     = Operation{Action: "pod.force_delete", Verb: "delete", Resource: "pods"}
+	operationSilenceCreate      = Operation{Action: "silence.create", Verb: "create", Group: serviceResourceGroup, Resource: "silences"}
+	operationSilenceDelete      = Operation{Action: "silence.delete", Verb: "delete", Group: serviceResourceGroup, Resource: "silences"}
+	operationPodLogs            = Operation{Action: "pod.logs", Verb: "get", Resource: "pods", Subresource: "log"}
+	operationAuditRead          = Operation{Action: "audit.read", Verb: "list", Group: serviceResourceGroup, Resource: "auditevents"}
+)
+
+// VirtualResource is a resource of the service group, which no object backs, an operation is authorized on.
+type VirtualResource struct {
+	Verb        string `json:"verb"`
+	Resource    string `json:"resource"`
+	Subresource string `json:"subresource,omitempty"`
+}
+
+// virtualResources are the resources the operations are checked against with subject_access_review_resources: virtual,
+// so RBAC grants the actions of the API, e.g. create on networkisolations, rather than the permissions on the objects
+// they change, which the service account holds anyway.
+var virtualResources = map[string]VirtualResource{
+	operationDenyPolicy.Action:         {Verb: "create", Resource: "networkisolations"},
+	operationDeletePolicy.Action:       {Verb: "delete", Resource: "networkisolations"},
+	operationDenyGlobal.Action:         {Verb: "create", Resource: "globalnetworkisolations"},
+	operationDeleteGlobal.Action:       {Verb: "delete", Resource: "globalnetworkisolations"},
+	operationBulkIsolation.Action:      {Verb: "create", Resource: "networkisolations", Subresource: "bulk"},
+	operationIsolateNamespace.Action:   {Verb: "create", Resource: "namespaceisolations"},
+	operationPodDebug.Action:           {Verb: "create", Resource: "pods", Subresource: "debug"},
+	operationPodExec.Action:            {Verb: "create", Resource: "pods", Subresource: "exec"},
+	operationSnapshotCapture.Action:    {Verb: "create", Resource: "snapshots"},
+	operationSelftest.Action:           {Verb: "create", Resource: "selftests"},
+	operationDeploymentRestart.Action:  {Verb: "create", Resource: "deployments", Subresource: "restart"},
+	operationDeploymentRollback.Action: {Verb: "create", Resource: "deployments", Subresource: "rollback"},
+	operationNodeCordon.Action:         {Verb: "create", Resource: "nodes", Subresource: "cordon"},
+	operationNodeUncordon.Action:       {Verb: "create", Resource: "nodes", Subresource: "uncordon"},
+	operationNodeDrain.Action:          {Verb: "create", Resource: "nodes", Subresource: "drain"},
+	operationPodEvict.Action:           {Verb: "create", Resource: "pods", Subresource: "eviction"},
+	operationPodForceDelete.Action:     {Verb: "delete", Resource: "pods", Subresource: "force"},
+	operationSilenceCreate.Action:      {Verb: "create", Resource: "silences"},
+	operationSilenceDelete.Action:      {Verb: "delete", Resource: "silences"},
+	operationPodLogs.Action:            {Verb: "get", Resource: "pods", Subresource: "log"},
+	operationAuditRead.Action:          {Verb: "list", Resource: "auditevents"},
+}
+
+// Principal is the caller of the API, as asserted by the authenticating proxy in front of the service or by the
+// bearer token. UID and Extra only come with tokens, and are handed over to SubjectAccessReview.
+type Principal struct {
+	User   string              `json:"user"`
+	UID    string              `json:"uid,omitempty"`
+	Groups []string            `json:"groups,omitempty"`
+	Extra  map[string][]string `json:"extra,omitempty"`
+}
+
+// AuthorizationRequest is everything an authorizer knows about the operation being attempted.
+//
+// Namespace is empty for operations spanning several namespaces.
+type AuthorizationRequest struct {
+	Principal Principal `json:"principal"`
+	Operation
+	Namespace string `json:"namespace,omitempty"`
+	Target    string `json:"target,omitempty"`
+	Method    string `json:"method"`
+	Path      string `json:"path"`
+	RequestMetadata
+	RemoteAddr string      `json:"remote_addr,omitempty"`
+	Request    interface{} `json:"request,omitempty"`
+}
+
+// AuthorizationDecision is the answer of an authorizer.
+type AuthorizationDecision struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// Authorizer decides whether a principal may perform an operation.
+type Authorizer interface {
+	Name() string
+	Authorize(ctx context.Context, request AuthorizationRequest) (AuthorizationDecision, error)
+}
+
+// AuthorizationConfig enables the authorizers consulted before every mutating operation.
+//
+// Every configured authorizer must allow the operation, the first denial wins. The callers are identified by their
+// bearer token, or by the user headers of an authenticating proxy presenting a client certificate signed by
+// ProxyClientCA, optionally with one of ProxyAllowedNames as common name.
+type AuthorizationConfig struct {
+	UserHeader          string                  `json:"user_header,omitempty"`
+	GroupsHeader        string                  `json:"groups_header,omitempty"`
+	ProxyClientCA       string                  `json:"proxy_client_ca,omitempty"`
+	ProxyAllowedNames   []string                `json:"proxy_allowed_names,omitempty"`
+	Static              *StaticAuthorizerConfig `json:"static,omitempty"`
+	SubjectAccessReview bool                    `json:"subject_access_review,omitempty"`
+	// SubjectAccessReviewResources is kubernetes, the default, or virtual for the virtual resources of the service group
+	SubjectAccessReviewResources string                `json:"subject_access_review_resources,omitempty"`
+	HTTP                         *HTTPAuthorizerConfig `json:"http,omitempty"`
+}
+
+// StaticAuthorizerConfig grants actions to users and groups through named roles.
+//
+// Role actions are exact names, prefixes ending in ".*" or "*" for everything.
+type StaticAuthorizerConfig struct {
+	Roles    map[string][]string `json:"roles"`
+	Bindings []StaticRoleBinding `json:"bindings"`
+}
+
+type StaticRoleBinding struct {
+	Role   string   `json:"role"`
+	Users  []string `json:"users,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+// HTTPAuthorizerConfig posts every AuthorizationRequest to URL, which answers with an AuthorizationDecision.
+type HTTPAuthorizerConfig struct {
+	URL     string            `json:"url"`
+	Timeout metav1.Duration   `json:"timeout,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// AuthorizerChain consults its authorizers in order, a nil chain allows everything.
+type AuthorizerChain struct {
+	userHeader   string
+	groupsHeader string
+	// proxyCAs sign the client certificates of the proxies trusted with the user headers, none when nil
+	proxyCAs    *x509.CertPool
+	proxyNames  []string
+	authorizers []Authorizer
+}
+
+// newAuthorizerChain builds the authorizers of config. Unless authenticated, i.e. bearer tokens are authenticated, the
+// callers can only be identified by a trusted proxy, without which anyone could claim to be anyone.
+func newAuthorizerChain(config *AuthorizationConfig, authenticated bool, clientset kubernetes.Interface) (*AuthorizerChain, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	chain := &AuthorizerChain{userHeader: config.UserHeader, groupsHeader: config.GroupsHeader, proxyNames: config.ProxyAllowedNames}
+	if chain.userHeader == "" {
+		chain.userHeader = defaultUserHeader
+	}
+	if chain.groupsHeader == "" {
+		chain.groupsHeader = defaultGroupsHeader
+	}
+	if config.ProxyClientCA != "" {
+		bundle, err := os.ReadFile(config.ProxyClientCA)
+		if err != nil {
+			return nil, fmt.Errorf("reading proxy_client_ca: %w", err)
+		}
+		chain.proxyCAs = x509.NewCertPool()
+		if !chain.proxyCAs.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("no certificate found in proxy_client_ca %s", config.ProxyClientCA)
+		}
+	} else if config.UserHeader != "" || config.GroupsHeader != "" || len(config.ProxyAllowedNames) > 0 {
+		return nil, errors.New("user_header, groups_header and proxy_allowed_names require proxy_client_ca")
+	}
+	if !authenticated && chain.proxyCAs == nil {
+		return nil, errors.New("authorization requires authentication, or proxy_client_ca to trust the user headers of an authenticating proxy")
+	}
+
+	if config.Static != nil {
+		for _, binding := range config.Static.Bindings {
+			if _, ok := config.Static.Roles[binding.Role]; !ok {
+				return nil, fmt.Errorf("static authorizer binding references unknown role %q", binding.Role)
+			}
+		}
+		chain.authorizers = append(chain.authorizers, &staticAuthorizer{config: *config.Static})
+	}
+	switch config.SubjectAccessReviewResources {
+	case "", subjectAccessReviewKubernetes, subjectAccessReviewVirtual:
+	default:
+		return nil, fmt.Errorf("invalid subject_access_review_resources %q, expected %s or %s", config.SubjectAccessReviewResources, subjectAccessReviewKubernetes, subjectAccessReviewVirtual)
+	}
+	if config.SubjectAccessReviewResources != "" && !config.SubjectAccessReview {
+		return nil, errors.New("subject_access_review_resources is set but subject_access_review isn't enabled")
+	}
+	if config.SubjectAccessReview {
+		chain.authorizers = append(chain.authorizers, &subjectAccessReviewAuthorizer{
+			clientset: clientset,
+			virtual:   config.SubjectAccessReviewResources == subjectAccessReviewVirtual,
+		})
+	}
+	if config.HTTP != nil {
+		if config.HTTP.URL == "" {
+			return nil, errors.New("http authorizer requires a url")
+		}
+		timeout := config.HTTP.Timeout.Duration
+		if timeout == 0 {
+			timeout = defaultAuthorizerTimeout
+		}
+		chain.authorizers = append(chain.authorizers, &httpAuthorizer{
+			url:        config.HTTP.URL,
+			headers:    config.HTTP.Headers,
+			httpClient: &http.Client{Timeout: timeout},
+		})
+	}
+	if len(chain.authorizers) == 0 {
+		return nil, errors.New("authorization is configured but no authorizer is enabled")
+	}
+	return chain, nil
+}
+
+// principal returns the bearer token identity of the caller, or reads it from the proxy headers when the request
+// wasn't authenticated with a token but comes from a trusted proxy. Other callers are anonymous.
+func (c *AuthorizerChain) principal(r *http.Request) Principal {
+	if principal, ok := authenticatedPrincipal(r.Context()); ok {
+		return principal
+	}
+	if !c.proxied(r) {
+		return Principal{User: anonymousUser, Groups: []string{unauthenticatedGroup}}
+	}
+
+	principal := Principal{User: r.Header.Get(c.userHeader)}
+	for _, value := range r.Header.Values(c.groupsHeader) {
+		for _, group := range strings.Split(value, ",") {
+			if group = strings.TrimSpace(group); group != "" {
+				principal.Groups = append(principal.Groups, group)
+			}
+		}
+	}
+
+	if principal.User == "" {
+		principal = Principal{User: anonymousUser, Groups: []string{unauthenticatedGroup}}
+	}
+	return principal
+}
+
+// proxied reports whether r was sent by a trusted proxy, over a connection authenticated with a client certificate
+// signed by proxy_client_ca, with one of proxy_allowed_names as common name when set.
+func (c *AuthorizerChain) proxied(r *http.Request) bool {
+	if c.proxyCAs == nil || r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return false
+	}
+
+	certificate := r.TLS.PeerCertificates[0]
+	intermediates := x509.NewCertPool()
+	for _, intermediate := range r.TLS.PeerCertificates[1:] {
+		intermediates.AddCert(intermediate)
+	}
+	_, err := certificate.Verify(x509.VerifyOptions{
+		Roots:         c.proxyCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	return err == nil && (len(c.proxyNames) == 0 || slices.Contains(c.proxyNames, certificate.Subject.CommonName))
+}
+
+// clientCAs returns the CAs the listeners verify client certificates with, nil when no proxy is trusted.
+func (c *AuthorizerChain) clientCAs() *x509.CertPool {
+	if c == nil {
+		return nil
+	}
+	return c.proxyCAs
+}
+
+// principal identifies the caller of r like the authorizers do, even when none is configured.
+func (s *Server) principal(r *http.Request) Principal {
+	chain := s.Authorizer
+	if chain == nil {
+		chain = &AuthorizerChain{}
+	}
+	return chain.principal(r)
+}
+
+func (c *AuthorizerChain) authorize(ctx context.Context, request AuthorizationRequest) (AuthorizationDecision, error) {
+	for _, authorizer := range c.authorizers {
+		decision, err := authorizer.Authorize(ctx, request)
+		if err != nil {
+			return AuthorizationDecision{}, fmt.Errorf("%s authorizer: %w", authorizer.Name(), err)
+		}
+		if !decision.Allowed {
+			if decision.Reason == "" {
+				decision.Reason = "denied"
+			}
+			decision.Reason = fmt.Sprintf("%s authorizer: %s", authorizer.Name(), decision.Reason)
+			return decision, nil
+		}
+	}
+	return AuthorizationDecision{Allowed: true}, nil
+}
+
+// authorize checks the operation with the configured authorizers before a handler mutates anything.
+//
+// An operation on deny policies is checked against every resource the policy backend stores them as, e.g. Cilium
+// policies and Istio AuthorizationPolicies. It writes a 403 when the operation is denied, or a 503 when an authorizer
+// fails, and returns false in both cases.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request, operation Operation, namespace, target string, body interface{}) bool {
+	if s.Authorizer == nil {
+		return true
+	}
+
+	request := AuthorizationRequest{
+		Principal:       s.Authorizer.principal(r),
+		Namespace:       namespace,
+		Target:          target,
+		Method:          r.Method,
+		Path:            r.URL.Path,
+		RequestMetadata: requestMetadata(r.Context()),
+		RemoteAddr:      r.RemoteAddr,
+		Request:         body,
+	}
+
+	var decision AuthorizationDecision
+	var err error
+	for _, resolved := range operation.onBackend(s.Policies) {
+		request.Operation = resolved
+		if decision, err = s.Authorizer.authorize(r.Context(), request); err != nil || !decision.Allowed {
+			break
+		}
+	}
+	if err == nil && decision.Allowed {
+		return true
+	}
+
+	audit := AuditEvent{
+		Action:          operation.Action,
+		RequestMetadata: request.RequestMetadata,
+		RemoteAddr:      r.RemoteAddr,
+		Namespace:       namespace,
+		Target:          target,
+		Request:         body,
+		Result:          authorizationResultDenied,
+	}
+	if err != nil {
+		audit.Error = err.Error()
+		recordAudit(audit)
+		writeErrorf(w, http.StatusServiceUnavailable, "Authorization failed: %v", err)
+		return false
+	}
+
+	audit.Error = decision.Reason
+	recordAudit(audit)
+	writeErrorf(w, http.StatusForbidden, "%s may not perform %s: %s", request.Principal.User, operation.Action, decision.Reason)
+	return false
+}
+
+type staticAuthorizer struct {
+	config StaticAuthorizerConfig
+}
+
+func (a *staticAuthorizer) Name() string { return "static" }
+
+func (a *staticAuthorizer) Authorize(_ context.Context, request AuthorizationRequest) (AuthorizationDecision, error) {
+	for _, binding := range a.config.Bindings {
+		if !bindingMatches(binding, request.Principal) {
+			continue
+		}
+		for _, action := range a.config.Roles[binding.Role] {
+			if actionMatches(action, request.Action) {
+				return AuthorizationDecision{Allowed: true}, nil
+			}
+		}
+	}
+	return AuthorizationDecision{Reason: "no role grants " + request.Action}, nil
+}
+
+func bindingMatches(binding StaticRoleBinding, principal Principal) bool {
+	for _, user := range binding.Users {
+		if user == principal.User {
+			return true
+		}
+	}
+	for _, group := range binding.Groups {
+		for _, member := range principal.Groups {
+			if group == member {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func actionMatches(pattern, action string) bool {
+	if pattern == "*" || pattern == action {
+		return true
+	}
+	prefix, ok := strings.CutSuffix(pattern, "*")
+	return ok && strings.HasSuffix(prefix, ".") && strings.HasPrefix(action, prefix)
+}
+
+// subjectAccessReviewAuthorizer asks the Kubernetes API whether the principal could perform the operation itself, or
+// with virtual set whether RBAC grants it the virtual resource of the operation.
+type subjectAccessReviewAuthorizer struct {
+	clientset kubernetes.Interface
+	virtual   bool
+}
+
+func (a *subjectAccessReviewAuthorizer) Name() string { return "subjectaccessreview" }
+
+func (a *subjectAccessReviewAuthorizer) Authorize(ctx context.Context, request AuthorizationRequest) (AuthorizationDecision, error) {
+	attributes := &authorizationv1.ResourceAttributes{
+		Namespace:   request.Namespace,
+		Verb:        request.Verb,
+		Group:       request.Group,
+		Resource:    request.Resource,
+		Subresource: request.Subresource,
+		Name:        request.Target,
+	}
+	if a.virtual {
+		resource, ok := virtualResources[request.Action]
+		if !ok {
+			return AuthorizationDecision{Reason: "no virtual resource for " + request.Action}, nil
+		}
+		attributes.Group, attributes.Verb, attributes.Resource, attributes.Subresource = serviceResourceGroup, resource.Verb, resource.Resource, resource.Subresource
+	}
+
+	var extra map[string]authorizationv1.ExtraValue
+	for key, values := range request.Principal.Extra {
+		if extra == nil {
+			extra = map[string]authorizationv1.ExtraValue{}
+		}
+		extra[key] = values
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:               request.Principal.User,
+			UID:                request.Principal.UID,
+			Groups:             request.Principal.Groups,
+			Extra:              extra,
+			ResourceAttributes: attributes,
+		},
+	}
+
+	result, err := a.clientset.AuthorizationV1().SubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return AuthorizationDecision{}, err
+	}
+	return AuthorizationDecision{Allowed: result.Status.Allowed && !result.Status.Denied, Reason: result.Status.Reason}, nil
+}
+
+// httpAuthorizer delegates the decision to an external service.
+type httpAuthorizer struct {
+	url        string
+	headers    map[string]string
+	httpClient *http.Client
+}
+
+func (a *httpAuthorizer) Name() string { return "http" }
+
+func (a *httpAuthorizer) Authorize(ctx context.Context, request AuthorizationRequest) (AuthorizationDecision, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return AuthorizationDecision{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(body))
+	if err != nil {
+		return AuthorizationDecision{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range a.headers {
+		req.Header.Set(name, value)
+	}
+	request.RequestMetadata.setHeaders(req.Header)
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return AuthorizationDecision{}, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return AuthorizationDecision{}, err
+	}
+	if resp.StatusCode/100 != 2 {
+		return AuthorizationDecision{}, fmt.Errorf("%s returned %s", a.url, resp.Status)
+	}
+
+	var decision AuthorizationDecision
+	if err := json.Unmarshal(data, &decision); err != nil {
+		return AuthorizationDecision{}, fmt.Errorf("invalid response from %s: %w", a.url, err)
+	}
+	return decision, nil
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestStaticAuthorizer(t *testing.T) {
+	chain, err := newAuthorizerChain(&AuthorizationConfig{
+		Static: &StaticAuthorizerConfig{
+			Roles: map[string][]string{
+				"operator": {"policy.deny", "isolation.bulk"},
+				"debugger": {"pod.*"},
+			},
+			Bindings: []StaticRoleBinding{
+				{Role: "operator", Users: []string{"alice"}},
+				{Role: "debugger", Groups: []string{"sre"}},
+			},
+		},
+	}, true, nil)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		principal Principal
+		operation Operation
+		allowed   bool
+	}{
+		{Principal{User: "alice"}, operationDenyPolicy, true},
+		{Principal{User: "alice"}, operationPodExec, false},
+		{Principal{User: "bob", Groups: []string{"sre"}}, operationPodExec, true},
+		{Principal{User: "bob", Groups: []string{"sre"}}, operationBulkIsolation, false},
+		{Principal{User: anonymousUser}, operationSnapshotCapture, false},
+	}
+	for _, test := range tests {
+		decision, err := chain.authorize(context.Background(), AuthorizationRequest{Principal: test.principal, Operation: test.operation})
+		assert.NoError(t, err)
+		assert.Equal(t, test.allowed, decision.Allowed, "%s %s", test.principal.User, test.operation.Action)
+	}
+
+	_, err = newAuthorizerChain(&AuthorizationConfig{Static: &StaticAuthorizerConfig{Bindings: []StaticRoleBinding{{Role: "missing"}}}}, true, nil)
+	assert.Error(t, err)
+	_, err = newAuthorizerChain(&AuthorizationConfig{}, true, nil)
+	assert.Error(t, err)
+
+	// Nobody could be told apart without authentication or a trusted proxy
+	_, err = newAuthorizerChain(&AuthorizationConfig{Static: &StaticAuthorizerConfig{}}, false, nil)
+	assert.Error(t, err)
+	_, err = newAuthorizerChain(&AuthorizationConfig{Static: &StaticAuthorizerConfig{}, UserHeader: "X-Forwarded-User"}, true, nil)
+	assert.Error(t, err)
+	caFile, _ := newTestProxyCA(t)
+	_, err = newAuthorizerChain(&AuthorizationConfig{Static: &StaticAuthorizerConfig{}, ProxyClientCA: caFile}, false, nil)
+	assert.NoError(t, err)
+}
+
+func TestActionMatches(t *testing.T) {
+	assert.True(t, actionMatches("*", "pod.exec"))
+	assert.True(t, actionMatches("pod.*", "pod.exec"))
+	assert.True(t, actionMatches("pod.exec", "pod.exec"))
+	assert.False(t, actionMatches("pod*", "pods.exec"))
+	assert.False(t, actionMatches("policy.*", "pod.exec"))
+}
+
+func TestSubjectAccessReviewAuthorizer(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	var review *authorizationv1.SubjectAccessReview
+	clientset.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review = action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		review.Status.Allowed = review.Spec.User == "alice"
+		return true, review, nil
+	})
+
+	chain, err := newAuthorizerChain(&AuthorizationConfig{SubjectAccessReview: true}, true, clientset)
+	assert.NoError(t, err)
+
+	decision, err := chain.authorize(context.Background(), AuthorizationRequest{Principal: Principal{User: "alice"}, Operation: operationPodExec, Namespace: "payments", Target: "ledger-1"})
+	assert.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, &authorizationv1.ResourceAttributes{Namespace: "payments", Verb: "create", Resource: "pods", Subresource: "exec", Name: "ledger-1"}, review.Spec.ResourceAttributes)
+
+	decision, err = chain.authorize(context.Background(), AuthorizationRequest{Principal: Principal{User: "bob"}, Operation: operationPodExec})
+	assert.NoError(t, err)
+	assert.False(t, decision.Allowed)
+}
+
+func TestSubjectAccessReviewPolicyBackend(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	var reviewed []string
+	clientset.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		attributes := review.Spec.ResourceAttributes
+		reviewed = append(reviewed, attributes.Verb+" "+attributes.Resource+"."+attributes.Group)
+		review.Status.Allowed = attributes.Group != "security.istio.io"
+		return true, review, nil
+	})
+
+	server := &Server{Policies: newFakeCiliumBackend()}
+	var err error
+	server.Authorizer, err = newAuthorizerChain(&AuthorizationConfig{SubjectAccessReview: true}, true, clientset)
+	assert.NoError(t, err)
+	authorize := func(operation Operation) bool {
+		req := httptest.NewRequest(http.MethodPost, "/denyNetworkPolicy", nil)
+		req = req.WithContext(withPrincipal(req.Context(), Principal{User: "alice"}))
+		return server.authorize(httptest.NewRecorder(), req, operation, "payments", "", nil)
+	}
+
+	// The policies of the active backend are checked, not Calico's
+	assert.True(t, authorize(operationDenyPolicy))
+	assert.True(t, authorize(operationDeleteGlobal))
+	assert.True(t, authorize(operationPodExec))
+	assert.Equal(t, []string{"create ciliumnetworkpolicies.cilium.io", "delete ciliumclusterwidenetworkpolicies.cilium.io", "create pods."}, reviewed)
+
+	// With Istio, creating the AuthorizationPolicies must be allowed as well, except for namespace isolations
+	reviewed = nil
+	server.Policies = &istioMeshBackend{PolicyBackend: newFakeCiliumBackend()}
+	assert.False(t, authorize(operationDenyPolicy))
+	assert.True(t, authorize(operationIsolateNamespace))
+	assert.Equal(t, []string{"create ciliumnetworkpolicies.cilium.io", "create authorizationpolicies.security.istio.io", "create ciliumnetworkpolicies.cilium.io"}, reviewed)
+}
+
+func TestSubjectAccessReviewVirtualResources(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	var review *authorizationv1.SubjectAccessReview
+	clientset.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review = action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		review.Status.Allowed = true
+		return true, review, nil
+	})
+
+	chain, err := newAuthorizerChain(&AuthorizationConfig{SubjectAccessReview: true, SubjectAccessReviewResources: subjectAccessReviewVirtual}, true, clientset)
+	assert.NoError(t, err)
+
+	principal := Principal{User: "system:serviceaccount:ci:deployer", UID: "5c2f", Extra: map[string][]string{"scopes": {"deny"}}}
+	decision, err := chain.authorize(context.Background(), AuthorizationRequest{Principal: principal, Operation: operationDenyPolicy, Namespace: "shop"})
+	assert.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, &authorizationv1.ResourceAttributes{Namespace: "shop", Verb: "create", Group: serviceResourceGroup, Resource: "networkisolations"}, review.Spec.ResourceAttributes)
+	assert.Equal(t, "5c2f", review.Spec.UID)
+	assert.Equal(t, map[string]authorizationv1.ExtraValue{"scopes": {"deny"}}, review.Spec.Extra)
+
+	_, err = chain.authorize(context.Background(), AuthorizationRequest{Principal: principal, Operation: operationNodeDrain, Target: "worker-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, &authorizationv1.ResourceAttributes{Verb: "create", Group: serviceResourceGroup, Resource: "nodes", Subresource: "drain", Name: "worker-1"}, review.Spec.ResourceAttributes)
+
+	// Operations without a virtual resource aren't let through
+	review = nil
+	decision, err = chain.authorize(context.Background(), AuthorizationRequest{Principal: principal, Operation: Operation{Action: "policy.unknown"}})
+	assert.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Nil(t, review)
+
+	_, err = newAuthorizerChain(&AuthorizationConfig{SubjectAccessReview: true, SubjectAccessReviewResources: "rbac"}, true, clientset)
+	assert.Error(t, err)
+	_, err = newAuthorizerChain(&AuthorizationConfig{SubjectAccessReviewResources: subjectAccessReviewVirtual}, true, clientset)
+	assert.Error(t, err)
+}
+
+func TestHTTPAuthorizer(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request AuthorizationRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "run-42", r.Header.Get(correlationIDHeader))
+
+		allowed := request.Namespace != "payments"
+		json.NewEncoder(w).Encode(AuthorizationDecision{Allowed: allowed, Reason: "payments is frozen"})
+	}))
+	defer ts.Close()
+
+	server := &Server{}
+	server.Authorizer, _ = newAuthorizerChain(&AuthorizationConfig{HTTP: &HTTPAuthorizerConfig{URL: ts.URL, Headers: map[string]string{"Authorization": "secret"}}}, true, nil)
+
+	for namespace, code := range map[string]int{"payments": http.StatusForbidden, "shop": http.StatusOK} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/exec/"+namespace+"/pod", nil)
+		req = req.WithContext(context.WithValue(req.Context(), requestMetadataKey{}, RequestMetadata{CorrelationID: "run-42"}))
+		req = req.WithContext(withPrincipal(req.Context(), Principal{User: "alice"}))
+		rr := httptest.NewRecorder()
+
+		if server.authorize(rr, req, operationPodExec, namespace, "pod", nil) {
+			rr.WriteHeader(http.StatusOK)
+		}
+		assert.Equal(t, code, rr.Code, namespace)
+	}
+
+	ts.Close()
+	rr := httptest.NewRecorder()
+	assert.False(t, server.authorize(rr, httptest.NewRequest(http.MethodPost, "/", nil), operationPodExec, "shop", "pod", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+}
+
+// newTestProxyCA writes a CA to a file, returning it and a function issuing client certificates with it.
+func newTestProxyCA(t *testing.T) (string, func(commonName string, usage x509.ExtKeyUsage) *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "front-proxy-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	ca, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	caFile := filepath.Join(t.TempDir(), "front-proxy-ca.crt")
+	assert.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+
+	issue := func(commonName string, usage x509.ExtKeyUsage) *x509.Certificate {
+		clientKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+		der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+			SerialNumber: big.NewInt(2),
+			Subject:      pkix.Name{CommonName: commonName},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		}, ca, &clientKey.PublicKey, key)
+		assert.NoError(t, err)
+		certificate, err := x509.ParseCertificate(der)
+		assert.NoError(t, err)
+		return certificate
+	}
+	return caFile, issue
+}
+
+func TestPrincipalFromHeaders(t *testing.T) {
+	caFile, issue := newTestProxyCA(t)
+	_, issueUntrusted := newTestProxyCA(t)
+	chain, err := newAuthorizerChain(&AuthorizationConfig{
+		Static:            &StaticAuthorizerConfig{},
+		ProxyClientCA:     caFile,
+		ProxyAllowedNames: []string{"front-proxy"},
+	}, false, nil)
+	assert.NoError(t, err)
+
+	request := func(certificate *x509.Certificate) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set(defaultUserHeader, "alice")
+		req.Header.Add(defaultGroupsHeader, "sre, oncall")
+		req.Header.Add(defaultGroupsHeader, "payments")
+		if certificate != nil {
+			req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{certificate}}
+		}
+		return req
+	}
+	anonymous := Principal{User: anonymousUser, Groups: []string{unauthenticatedGroup}}
+
+	assert.Equal(t, Principal{User: "alice", Groups: []string{"sre", "oncall", "payments"}}, chain.principal(request(issue("front-proxy", x509.ExtKeyUsageClientAuth))))
+	noUser := request(issue("front-proxy", x509.ExtKeyUsageClientAuth))
+	noUser.Header.Del(defaultUserHeader)
+	assert.Equal(t, anonymous, chain.principal(noUser))
+
+	// Only the proxy is trusted with the headers
+	assert.Equal(t, anonymous, chain.principal(request(nil)))
+	assert.Equal(t, anonymous, chain.principal(request(issueUntrusted("front-proxy", x509.ExtKeyUsageClientAuth))))
+	assert.Equal(t, anonymous, chain.principal(request(issue("mallory", x509.ExtKeyUsageClientAuth))))
+	assert.Equal(t, anonymous, chain.principal(request(issue("front-proxy", x509.ExtKeyUsageServerAuth))))
+	assert.Equal(t, anonymous, (&Server{}).principal(request(issue("front-proxy", x509.ExtKeyUsageClientAuth))))
+}
+
+func TestPrincipalPrefersBearerToken(t *testing.T) {
+	chain := &AuthorizerChain{userHeader: defaultUserHeader, groupsHeader: defaultGroupsHeader}
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set(defaultUserHeader, "mallory")
+	req = req.WithContext(withPrincipal(req.Context(), Principal{User: "deploy-bot", Groups: []string{"automation"}}))
+	assert.Equal(t, Principal{User: "deploy-bot", Groups: []string{"automation"}}, chain.principal(req))
+}
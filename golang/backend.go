@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+const defaultPolicyBackend = "calico"
+
+// policyBackends are the backends selectable with --policy-backend, by name.
+var policyBackends = map[string]func(config *rest.Config, options policyBackendOptions) (PolicyBackend, error){
+	"calico": newCalicoBackend,
+	"cilium": newCiliumBackend,
+}
+
+// PolicyObject is a policy as stored by a backend, e.g. a Calico NetworkPolicy.
+type PolicyObject interface {
+	metav1.Object
+	runtime.Object
+}
+
+// PolicyBackend stores the policies denying traffic between workloads, the handlers don't know which network plugin enforces them.
+//
+// Objects returned by a backend carry their kind and API version, so they can be referenced by events and rendered as is.
+type PolicyBackend interface {
+	// Name identifies the backend on the command line.
+	Name() string
+	// Render builds the policy in the namespace of A denying traffic from and to B, peerNamespace being the namespace of B,
+	// nil when B is a CIDR or has no namespace. A global request renders a cluster-wide policy without namespace.
+	Render(request DenyNetworkRequest, peerNamespace *corev1.Namespace, meta RequestMetadata) PolicyObject
+	// Create stores the policy, or only has the API server validate it on a dry run. An existing policy is an AlreadyExists error.
+	Create(ctx context.Context, policy PolicyObject, dryRun bool) (PolicyObject, error)
+	// Get returns a policy, a global one when namespace is empty.
+	Get(ctx context.Context, namespace, name string) (PolicyObject, error)
+	// List returns the policies created by the service in namespace, and the global ones when namespace is metav1.NamespaceAll.
+	List(ctx context.Context, namespace string) ([]PolicyObject, error)
+	// Delete removes the policy, unless it was recreated under the same name since it was read.
+	Delete(ctx context.Context, policy PolicyObject) error
+	// Selectors describes the workload the policy applies to and its peer.
+	Selectors(policy PolicyObject) PolicySelectors
+	// Resources are the resources a deny policy is stored as, the network policy first, cluster-wide when global is set.
+	Resources(global bool) []schema.GroupResource
+}
+
+// policyBackendOptions are the command line settings of the backends, each one reading those it knows.
+type policyBackendOptions struct {
+	// createTiers has the policies of a missing tier create it first.
+	createTiers bool
+}
+
+// tieredBackend is implemented by the backends able to put a policy in a tier, evaluated before the policies of the applications.
+type tieredBackend interface {
+	tiered() bool
+}
+
+// supportsTiers tells whether the deny requests given to backend can ask for a tier.
+func supportsTiers(backend PolicyBackend) bool {
+	tiered, ok := backend.(tieredBackend)
+	return ok && tiered.tiered()
+}
+
+// stagingBackend is implemented by the backends able to stage a policy, which only reports the traffic it would deny until promoted.
+type stagingBackend interface {
+	canStage() bool
+	// getStaged returns a staged policy, a global one when namespace is empty.
+	getStaged(ctx context.Context, namespace, name string) (PolicyObject, error)
+	// listStaged returns the staged policies created by the service in namespace, and the global ones when namespace
+	// is metav1.NamespaceAll.
+	listStaged(ctx context.Context, namespace string) ([]PolicyObject, error)
+	// promote enforces a staged policy under the same name, and deletes the staged one.
+	promote(ctx context.Context, staged PolicyObject) (PolicyObject, error)
+}
+
+// stagingOf returns backend when it can stage policies.
+func stagingOf(backend PolicyBackend) (stagingBackend, bool) {
+	staging, ok := backend.(stagingBackend)
+	return staging, ok && staging.canStage()
+}
+
+// PolicySelectors describes a deny policy in the syntax of its backend, the peer being either selectors or a CIDR.
+type PolicySelectors struct {
+	Selector          string
+	PeerSelector      string
+	NamespaceSelector string
+	PeerCIDR          string
+}
+
+func newPolicyBackend(name string, config *rest.Config, options policyBackendOptions) (PolicyBackend, error) {
+	newBackend, ok := policyBackends[name]
+	if !ok {
+		names := make([]string, 0, len(policyBackends))
+		for name := range policyBackends {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown policy backend %q, expected one of %s", name, strings.Join(names, ", "))
+	}
+	return newBackend(config, options)
+}
+
+// policyReference points events at a policy returned by a backend.
+func policyReference(policy PolicyObject) corev1.ObjectReference {
+	gvk := policy.GetObjectKind().GroupVersionKind()
+	return corev1.ObjectReference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Namespace:  policy.GetNamespace(),
+		Name:       policy.GetName(),
+		UID:        policy.GetUID(),
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+func TestNewPolicyBackend(t *testing.T) {
+	backend, err := newPolicyBackend("calico", &rest.Config{Host: "https://kubernetes.default.svc"}, policyBackendOptions{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "calico", backend.Name())
+	}
+
+	_, err = newPolicyBackend("flannel", &rest.Config{}, policyBackendOptions{})
+	assert.EqualError(t, err, `unknown policy backend "flannel", expected one of calico, cilium`)
+}
+
+func TestPolicyReference(t *testing.T) {
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}},
+	}
+	policy := (&calicoBackend{}).Render(request, &corev1.Namespace{}, RequestMetadata{})
+
+	reference := policyReference(policy)
+	assert.Equal(t, "projectcalico.org/v3", reference.APIVersion)
+	assert.Equal(t, "NetworkPolicy", reference.Kind)
+	assert.Equal(t, "payments", reference.Namespace)
+	assert.Equal(t, policy.GetName(), reference.Name)
+}
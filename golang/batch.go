@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	// maxDenyBatchSize bounds the requests of a batch, which are applied one after the other.
+	maxDenyBatchSize = 100
+	// denyBatchRollBackTimeout bounds the rollback of a failed batch, which outlives the request.
+	denyBatchRollBackTimeout = 30 * time.Second
+)
+
+// DenyBatchResult reports each request of a batch, in order.
+type DenyBatchResult struct {
+	Created    int                `json:"created"`
+	RolledBack bool               `json:"rolled_back,omitempty"`
+	Items      []DenyBatchOutcome `json:"items"`
+}
+
+// DenyBatchOutcome is the result of a single request of a batch.
+type DenyBatchOutcome struct {
+	Policy     string `json:"policy,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	Existing   bool   `json:"existing,omitempty"`
+	RolledBack bool   `json:"rolled_back,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Handler creating the deny policies of several requests at once, all of them or none.
+//
+// Every request is resolved, authorized and checked before any policy is created. A policy failing to be created has
+// those created before it by the batch deleted again; policies which already existed are left alone.
+func (s *Server) denyNetworkPolicyBatchHandler(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		readBodyError(w, err)
+		return
+	}
+
+	defer r.Body.Close()
+
+	var requests []DenyNetworkRequest
+	if err := decodeStrictJSON(body, &requests); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if len(requests) == 0 || len(requests) > maxDenyBatchSize {
+		writeErrorf(w, http.StatusBadRequest, "A batch holds 1 to %d deny requests, got %d", maxDenyBatchSize, len(requests))
+		return
+	}
+
+	result := &DenyBatchResult{Items: make([]DenyBatchOutcome, len(requests))}
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+	ctx, cancel := s.kubeContext(r)
+	defer cancel()
+
+	// The first rejected request gives the status of the batch, the others are still reported
+	status := http.StatusOK
+	for i := range requests {
+		requests[i].DryRun = false
+		if code, err := s.resolveDenyRequest(ctx, &requests[i]); err != nil {
+			result.Items[i].Error = err.Error()
+			if status == http.StatusOK {
+				status = code
+			}
+		}
+	}
+	if status != http.StatusOK {
+		writeJSON(w, status, result)
+		return
+	}
+
+	for _, request := range requests {
+		if !s.authorize(w, r, request.operation(), request.A.Namespace, "", request) {
+			return
+		}
+	}
+
+	for i, request := range requests {
+		err := verifyWorkloads(ctx, s.K8sClientSet, request)
+		if err == nil {
+			err = s.checkPolicyConflicts(ctx, w, request)
+		}
+		if err != nil {
+			result.Items[i].Error = err.Error()
+			if status == http.StatusOK {
+				status = denyCheckErrorStatus(err)
+			}
+		}
+	}
+	if status != http.StatusOK {
+		writeJSON(w, status, result)
+		return
+	}
+
+	if dryRun {
+		for i, request := range requests {
+			policy, err := dryRunDenyNetworkPolicy(ctx, s.K8sClientSet, s.Policies, s.Namespaces, request, requestMetadata(r.Context()))
+			if err != nil {
+				result.Items[i].Error = err.Error()
+				if status == http.StatusOK {
+					status = denyPolicyErrorStatus(err)
+				}
+				continue
+			}
+			result.Items[i].Policy, result.Items[i].Namespace = policy.GetName(), policy.GetNamespace()
+		}
+		writeJSON(w, status, result)
+		return
+	}
+
+	// The whole batch is rejected up front rather than creating only part of it
+	perNamespace := map[string]int{}
+	for _, request := range requests {
+		perNamespace[request.A.Namespace]++
+	}
+	reservations := map[string]*BudgetReservation{}
+	for namespace, count := range perNamespace {
+		reservation, err := s.Guardrails.reserve(budgetPolicies, namespace, count, time.Now())
+		if err != nil {
+			for namespace, reservation := range reservations {
+				reservation.release(perNamespace[namespace])
+			}
+			writeGuardrailError(w, err)
+			return
+		}
+		reservations[namespace] = reservation
+	}
+
+	meta := requestMetadata(r.Context())
+	for i, request := range requests {
+		outcome := &result.Items[i]
+		outcome.Namespace = request.A.Namespace
+		name, created, err := createDenyNetworkPolicy(ctx, s.K8sClientSet, s.Policies, s.Namespaces, request, meta)
+		if !created {
+			reservations[request.A.Namespace].release(1)
+		}
+		audit := AuditEvent{
+			Action:          request.operation().Action,
+			RequestMetadata: meta,
+			RemoteAddr:      r.RemoteAddr,
+			Namespace:       request.A.Namespace,
+			Target:          name,
+			Request:         request,
+			Result:          "created",
+		}
+		if err != nil {
+			audit.Result, audit.Error = "failed", err.Error()
+		} else if !created {
+			audit.Result = "exists"
+		}
+		recordAudit(audit)
+		if err != nil {
+			outcome.Error = err.Error()
+			for j := i + 1; j < len(requests); j++ {
+				reservations[requests[j].A.Namespace].release(1)
+			}
+			s.rollBackDenyBatch(r.Context(), requests[:i], result)
+			writeJSON(w, denyPolicyErrorStatus(err), result)
+			return
+		}
+		outcome.Policy, outcome.Existing = name, !created
+		if created {
+			result.Created++
+		}
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// rollBackDenyBatch deletes the policies the batch created for requests, with their mirrors. Failures are reported on
+// the item, the policy being left in place.
+//
+// The rollback isn't cancelled with ctx, so a client hanging up doesn't leave half a batch behind.
+func (s *Server) rollBackDenyBatch(ctx context.Context, requests []DenyNetworkRequest, result *DenyBatchResult) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), denyBatchRollBackTimeout)
+	defer cancel()
+
+	result.RolledBack = true
+	for i := len(requests) - 1; i >= 0; i-- {
+		outcome := &result.Items[i]
+		if outcome.Existing {
+			continue
+		}
+
+		get := func(ctx context.Context, namespace, name string) (PolicyObject, error) {
+			return getDenyPolicy(ctx, s.Policies, requests[i], namespace, name)
+		}
+		policy, err := get(ctx, outcome.Namespace, outcome.Policy)
+		if err == nil {
+			err = s.Policies.Delete(ctx, policy)
+		}
+		if err == nil {
+			if mirrorNamespace, mirrorName, ok := mirrorOf(policy); ok {
+				err = s.deleteMirrorPolicy(ctx, get, mirrorNamespace, mirrorName)
+			}
+		}
+		audit := AuditEvent{
+			Action:          operationDeletePolicy.Action,
+			RequestMetadata: requestMetadata(ctx),
+			Namespace:       outcome.Namespace,
+			Target:          outcome.Policy,
+			Result:          "rolled_back",
+		}
+		if err != nil {
+			audit.Result, audit.Error = "failed", err.Error()
+			recordAudit(audit)
+			slog.ErrorContext(ctx, "Failed rolling back network policy", "namespace", outcome.Namespace, "name", outcome.Policy, "error", err)
+			outcome.Error = fmt.Sprintf("rolling back: %v", err)
+			continue
+		}
+		recordAudit(audit)
+		outcome.RolledBack = true
+		result.Created--
+		slog.InfoContext(ctx, "Network policy rolled back", "namespace", outcome.Namespace, "name", outcome.Policy)
+	}
+}
+
+// denyCheckErrorStatus is the HTTP status of a deny request failing the checks made before creating its policy.
+func denyCheckErrorStatus(err error) int {
+	var conflictErr *PolicyConflictError
+	switch {
+	case errors.Is(err, errWorkloadNotFound):
+		return http.StatusUnprocessableEntity
+	case errors.As(err, &conflictErr):
+		return http.StatusConflict
+	}
+	return kubeErrorStatus(err)
+}
+
+// denyPolicyErrorStatus is the HTTP status of a deny policy failing to be rendered or created.
+func denyPolicyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, errNamespaceOutOfScope):
+		return http.StatusForbidden
+	case apierrors.IsInvalid(err), apierrors.IsNotFound(err):
+		return http.StatusUnprocessableEntity
+	}
+	return kubeErrorStatus(err)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDenyNetworkPolicyBatchHandlerRejects(t *testing.T) {
+	server := &Server{Policies: &ciliumBackend{}}
+
+	for _, body := range []string{`[]`, `{"workload_a": {}}`} {
+		w := httptest.NewRecorder()
+		server.denyNetworkPolicyBatchHandler(w, httptest.NewRequest(http.MethodPost, "/denyNetworkPolicy/batch", strings.NewReader(body)))
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+
+	w := httptest.NewRecorder()
+	server.denyNetworkPolicyBatchHandler(w, httptest.NewRequest(http.MethodPost, "/denyNetworkPolicy/batch", strings.NewReader(`[
+		{"workload_a": {"namespace": "payments", "labels": {"app": "ledger"}}, "workload_b": {"namespace": "shop", "labels": {"app": "cart"}}},
+		{"workload_a": {"namespace": "payments", "labels": {"app": "ledger"}}, "workload_b": {"cidr": "10.12.0.0"}}
+	]`)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var result DenyBatchResult
+	if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result)) && assert.Len(t, result.Items, 2) {
+		assert.Empty(t, result.Items[0].Error)
+		assert.Equal(t, `invalid CIDR "10.12.0.0"`, result.Items[1].Error)
+		assert.Zero(t, result.Created)
+	}
+}
+
+func TestRollBackDenyBatch(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+	)
+	backend := newFakeCiliumBackend()
+	requests := []DenyNetworkRequest{
+		{
+			A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+			B: DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}},
+		},
+		{
+			A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+			B: DenyNetworkRequestWorkload{CIDR: "10.12.0.0/16"},
+		},
+	}
+
+	result := &DenyBatchResult{Items: make([]DenyBatchOutcome, len(requests))}
+	for i, request := range requests {
+		name, created, err := createDenyNetworkPolicy(context.Background(), clientset, backend, NamespaceScope{}, request, RequestMetadata{})
+		if !assert.NoError(t, err) {
+			return
+		}
+		result.Items[i] = DenyBatchOutcome{Policy: name, Namespace: "payments", Existing: !created}
+		result.Created++
+	}
+	// Existing policies aren't the batch's to delete
+	result.Items[1].Existing = true
+	result.Created--
+
+	(&Server{Policies: backend}).rollBackDenyBatch(ctx, requests, result)
+	assert.True(t, result.RolledBack)
+	assert.Zero(t, result.Created)
+	assert.True(t, result.Items[0].RolledBack)
+	assert.False(t, result.Items[1].RolledBack)
+
+	_, err := backend.Get(ctx, "payments", result.Items[0].Policy)
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = backend.Get(ctx, "payments", result.Items[1].Policy)
+	assert.NoError(t, err)
+	mirrors, err := backend.List(ctx, "shop")
+	assert.NoError(t, err)
+	assert.Empty(t, mirrors)
+}
+
+// cancellableBackend fails the deletes of a done context, like the API server client does.
+type cancellableBackend struct {
+	PolicyBackend
+}
+
+func (b cancellableBackend) Delete(ctx context.Context, policy PolicyObject) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return b.PolicyBackend.Delete(ctx, policy)
+}
+
+func TestRollBackDenyBatchCancelledRequest(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}})
+	backend := newFakeCiliumBackend()
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{CIDR: "10.12.0.0/16"},
+	}
+	name, _, err := createDenyNetworkPolicy(context.Background(), clientset, backend, NamespaceScope{}, request, RequestMetadata{})
+	assert.NoError(t, err)
+	result := &DenyBatchResult{Created: 1, Items: []DenyBatchOutcome{{Policy: name, Namespace: "payments"}}}
+
+	// The client hanging up doesn't stop the rollback
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	(&Server{Policies: cancellableBackend{backend}}).rollBackDenyBatch(ctx, []DenyNetworkRequest{request}, result)
+	assert.True(t, result.Items[0].RolledBack, result.Items[0].Error)
+	assert.Zero(t, result.Created)
+	_, err = backend.Get(context.Background(), "payments", name)
+	assert.True(t, apierrors.IsNotFound(err))
+}
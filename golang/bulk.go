@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// BulkIsolationRequest isolates every deployment matching Selector, in any namespace in scope, from Target.
+type BulkIsolationRequest struct {
+	Selector string                     `json:"selector"`
+	Target   DenyNetworkRequestWorkload `json:"target"`
+}
+
+type BulkIsolationResult struct {
+	Matched    int                               `json:"matched"`
+	Failed     int                               `json:"failed"`
+	Namespaces map[string][]BulkIsolationOutcome `json:"namespaces"`
+}
+
+// BulkIsolationOutcome is the result of isolating a single deployment.
+type BulkIsolationOutcome struct {
+	Deployment string `json:"deployment"`
+	Policy     string `json:"policy,omitempty"`
+	Existing   bool   `json:"existing,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Handler creating deny policies for every workload matching a selector
+func (s *Server) bulkIsolationHandler(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		readBodyError(w, err)
+		return
+	}
+
+	defer r.Body.Close()
+
+	var request BulkIsolationRequest
+	if err := decodeStrictJSON(body, &request); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	selector, err := labels.Parse(request.Selector)
+	if err != nil || selector.Empty() {
+		writeErrorf(w, http.StatusBadRequest, "Invalid selector %q", request.Selector)
+		return
+	}
+
+	request.Target, err = s.Aliases.resolve(request.Target)
+	if err == nil {
+		err = normalizePeer(&request.Target)
+	}
+	if err == nil {
+		err = validateLabels(request.Target.Labels)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if request.Target.referenced() {
+		if err := s.Namespaces.check(r.Context(), s.K8sClientSet, request.Target.Namespace); err != nil {
+			writeError(w, http.StatusForbidden, err)
+			return
+		}
+		if request.Target, err = resolveReference(r.Context(), s.K8sClientSet, request.Target); err != nil {
+			writeError(w, referenceErrorStatus(err), err)
+			return
+		}
+	}
+
+	if !s.authorize(w, r, operationBulkIsolation, "", "", request) {
+		return
+	}
+
+	result, err := s.bulkIsolate(r.Context(), selector, request.Target)
+	if writeGuardrailError(w, err) {
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// bulkIsolate fans out one deny policy per matching deployment, one goroutine per namespace.
+//
+// Failures are reported per deployment and don't stop the other namespaces.
+func (s *Server) bulkIsolate(ctx context.Context, selector labels.Selector, target DenyNetworkRequestWorkload) (*BulkIsolationResult, error) {
+	namespaces, err := s.Namespaces.resolve(ctx, s.K8sClientSet)
+	if err != nil {
+		return nil, err
+	}
+
+	byNamespace := map[string][]appsv1.Deployment{}
+	for _, namespace := range namespaces {
+		deployments, err := s.K8sClientSet.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, err
+		}
+		for _, deployment := range deployments.Items {
+			byNamespace[deployment.Namespace] = append(byNamespace[deployment.Namespace], deployment)
+		}
+	}
+
+	// The whole change is rejected up front rather than isolating only part of the matching deployments
+	reservations := map[string]*BudgetReservation{}
+	for namespace, deployments := range byNamespace {
+		reservation, err := s.Guardrails.reserve(budgetPolicies, namespace, len(deployments), time.Now())
+		if err != nil {
+			for namespace, reservation := range reservations {
+				reservation.release(len(byNamespace[namespace]))
+			}
+			return nil, err
+		}
+		reservations[namespace] = reservation
+	}
+
+	result := &BulkIsolationResult{Namespaces: map[string][]BulkIsolationOutcome{}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for namespace, deployments := range byNamespace {
+		wg.Add(1)
+		go func(namespace string, deployments []appsv1.Deployment) {
+			defer wg.Done()
+
+			outcomes := make([]BulkIsolationOutcome, 0, len(deployments))
+			failed := 0
+			for _, deployment := range deployments {
+				outcome := s.isolateDeployment(ctx, deployment, target, requestMetadata(ctx))
+				if outcome.Error != "" || outcome.Existing {
+					reservations[namespace].release(1)
+				}
+				if outcome.Error != "" {
+					failed++
+				}
+				outcomes = append(outcomes, outcome)
+			}
+			sort.Slice(outcomes, func(i, j int) bool { return outcomes[i].Deployment < outcomes[j].Deployment })
+
+			mu.Lock()
+			defer mu.Unlock()
+			result.Namespaces[namespace] = outcomes
+			result.Matched += len(deployments)
+			result.Failed += failed
+		}(namespace, deployments)
+	}
+	wg.Wait()
+
+	return result, nil
+}
+
+func (s *Server) isolateDeployment(ctx context.Context, deployment appsv1.Deployment, target DenyNetworkRequestWorkload, meta RequestMetadata) BulkIsolationOutcome {
+	outcome := BulkIsolationOutcome{Deployment: deployment.Name}
+
+	if deployment.Spec.Selector == nil || len(deployment.Spec.Selector.MatchExpressions) > 0 || len(deployment.Spec.Selector.MatchLabels) == 0 {
+		outcome.Error = "deployment selector can't be expressed as exact-match labels"
+		return outcome
+	}
+
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: deployment.Namespace, Labels: deployment.Spec.Selector.MatchLabels},
+		B: target,
+	}
+	name, created, err := createDenyNetworkPolicy(ctx, s.K8sClientSet, s.Policies, s.Namespaces, request, meta)
+	audit := AuditEvent{
+		Action:          operationBulkIsolation.Action,
+		RequestMetadata: meta,
+		Namespace:       deployment.Namespace,
+		Target:          name,
+		Request:         request,
+		Result:          "created",
+	}
+	if err != nil {
+		audit.Result, audit.Error = "failed", err.Error()
+		recordAudit(audit)
+		outcome.Error = err.Error()
+		return outcome
+	}
+	if !created {
+		audit.Result = "exists"
+	}
+	recordAudit(audit)
+	outcome.Policy, outcome.Existing = name, !created
+	return outcome
+}
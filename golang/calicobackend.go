@@ -0,0 +1,462 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	clientset "github.com/projectcalico/api/pkg/client/clientset_generated/clientset"
+	"github.com/projectcalico/api/pkg/lib/numorstring"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+var (
+	calicoPolicyTypeMeta = metav1.TypeMeta{
+		APIVersion: v3.SchemeGroupVersion.String(),
+		Kind:       "NetworkPolicy",
+	}
+	calicoGlobalPolicyTypeMeta = metav1.TypeMeta{
+		APIVersion: v3.SchemeGroupVersion.String(),
+		Kind:       "GlobalNetworkPolicy",
+	}
+
+	calicoPolicyResource       = v3.SchemeGroupVersion.WithResource("networkpolicies")
+	calicoGlobalPolicyResource = v3.SchemeGroupVersion.WithResource("globalnetworkpolicies")
+	calicoTierResource         = v3.SchemeGroupVersion.WithResource("tiers")
+
+	calicoStagedPolicyResource       = v3.SchemeGroupVersion.WithResource("stagednetworkpolicies")
+	calicoStagedGlobalPolicyResource = v3.SchemeGroupVersion.WithResource("stagedglobalnetworkpolicies")
+)
+
+const (
+	// calicoDefaultTier holds the policies created without a tier.
+	calicoDefaultTier = "default"
+	// calicoTierOrder places the tiers created by the service before the default one, ordered 1000000.
+	calicoTierOrder = 100
+	// calicoStagedPrefix turns the kind of a policy into the kind of its staged version.
+	calicoStagedPrefix = "Staged"
+)
+
+// calicoBackend denies traffic with namespaced Calico NetworkPolicies, B's namespace being selected by its labels.
+//
+// Global requests get a GlobalNetworkPolicy, selecting A and a peer without namespace in every namespace.
+// Workloads of kind HostEndpoint select the Calico HostEndpoints of the nodes instead of pods.
+//
+// Policies in a tier are named after it, as Calico requires. Staged policies only report the traffic they would deny in the flow
+// logs, until promoted. Both are created with the dynamic client: the Calico API this service is built with predates them.
+type calicoBackend struct {
+	clientset   clientset.Interface
+	client      dynamic.Interface
+	createTiers bool
+}
+
+func newCalicoBackend(config *rest.Config, options policyBackendOptions) (PolicyBackend, error) {
+	calicoClientset, err := clientset.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &calicoBackend{clientset: calicoClientset, client: client, createTiers: options.createTiers}, nil
+}
+
+func (b *calicoBackend) Name() string {
+	return "calico"
+}
+
+func (b *calicoBackend) tiered() bool {
+	return true
+}
+
+func (b *calicoBackend) selectsNetworkSets() bool {
+	return true
+}
+
+func (b *calicoBackend) selectsHostEndpoints() bool {
+	return true
+}
+
+func (b *calicoBackend) canStage() bool {
+	return true
+}
+
+func (b *calicoBackend) Render(request DenyNetworkRequest, peerNamespace *corev1.Namespace, meta RequestMetadata) PolicyObject {
+	peer := v3.EntityRule{Nets: []string{request.B.CIDR}}
+	if request.B.CIDR == "" {
+		peer = v3.EntityRule{Selector: renderSelector(request.B)}
+		switch {
+		case request.B.Kind == workloadKindHostEndpoint:
+			// global() selects the endpoints without namespace, the host endpoints among them
+			peer.NamespaceSelector = "global()"
+		case peerNamespace != nil:
+			peer.NamespaceSelector = renderMap(peerNamespace.Labels)
+		}
+	}
+	ingress := []v3.Rule{{Action: v3.Deny, Source: peer}}
+	egress := []v3.Rule{{Action: v3.Deny, Destination: peer}}
+	var types []v3.PolicyType
+	switch {
+	case request.A.Kind == workloadKindHostEndpoint:
+		// Calico drops the traffic of a host endpoint no rule matches once a policy selects it: the rest goes on to the
+		// next tier, then to the profile of the endpoint, which allows everything for the automatic host endpoints
+		ingress = append(ingress, v3.Rule{Action: v3.Pass})
+		egress = append(egress, v3.Rule{Action: v3.Pass})
+	case request.B.Kind == workloadKindHostEndpoint:
+		// The kubelet probes the pods from their node, only the traffic of the pods to the hosts is denied
+		ingress, types = nil, []v3.PolicyType{v3.PolicyTypeEgress}
+	}
+
+	labels, annotations := managedObjectMeta(actionDeny, request.B.Namespace, meta, request)
+	objectMeta := metav1.ObjectMeta{
+		Name:        denyPolicyName(request),
+		Namespace:   request.A.Namespace,
+		Labels:      labels,
+		Annotations: annotations,
+	}
+	if request.Tier != "" {
+		objectMeta.Name = request.Tier + "." + objectMeta.Name
+		objectMeta.Annotations[tierAnnotation] = request.Tier
+	}
+	if request.Staged {
+		objectMeta.Annotations[stagedAnnotation] = "true"
+	}
+
+	if request.global() {
+		globalPolicy := &v3.GlobalNetworkPolicy{
+			TypeMeta:   calicoGlobalPolicyTypeMeta,
+			ObjectMeta: objectMeta,
+			Spec: v3.GlobalNetworkPolicySpec{
+				Selector: renderSelector(request.A),
+				Types:    types,
+				Ingress:  ingress,
+				Egress:   egress,
+			},
+		}
+		globalPolicy.Annotations[specHashAnnotation] = specHash(globalPolicy.Spec)
+		return globalPolicy
+	}
+
+	networkPolicy := &v3.NetworkPolicy{
+		TypeMeta:   calicoPolicyTypeMeta,
+		ObjectMeta: objectMeta,
+		Spec: v3.NetworkPolicySpec{
+			Selector: renderSelector(request.A),
+			Types:    types,
+			Ingress:  ingress,
+			Egress:   egress,
+		},
+	}
+	networkPolicy.Annotations[specHashAnnotation] = specHash(networkPolicy.Spec)
+	return networkPolicy
+}
+
+// renderQuarantine selects every pod of the namespace, allows the exceptions then denies the rest, so the traffic isn't
+// left to the policies evaluated after it.
+func (b *calicoBackend) renderQuarantine(request NamespaceIsolationRequest, services []DenyNetworkRequestWorkload, meta RequestMetadata) PolicyObject {
+	var ingress, egress []v3.Rule
+	if request.AllowDNS {
+		dns := v3.EntityRule{
+			Selector:          renderMap(map[string]string{kubeDNSLabel: kubeDNSValue}),
+			NamespaceSelector: calicoNamespaceSelector(kubeSystemNamespace),
+			Ports:             []numorstring.Port{numorstring.SinglePort(dnsPort)},
+		}
+		for _, protocol := range []string{"UDP", "TCP"} {
+			protocol := numorstring.ProtocolFromString(protocol)
+			egress = append(egress, v3.Rule{Action: v3.Allow, Protocol: &protocol, Destination: dns})
+		}
+	}
+	peers := []v3.EntityRule{}
+	if request.AllowKubeSystem {
+		peers = append(peers, v3.EntityRule{NamespaceSelector: calicoNamespaceSelector(kubeSystemNamespace)})
+	}
+	for _, service := range services {
+		peers = append(peers, v3.EntityRule{Selector: renderSelector(service), NamespaceSelector: calicoNamespaceSelector(service.Namespace)})
+	}
+	for _, peer := range peers {
+		ingress = append(ingress, v3.Rule{Action: v3.Allow, Source: peer})
+		egress = append(egress, v3.Rule{Action: v3.Allow, Destination: peer})
+	}
+	ingress = append(ingress, v3.Rule{Action: v3.Deny})
+	egress = append(egress, v3.Rule{Action: v3.Deny})
+
+	labels, annotations := managedObjectMeta(actionQuarantine, "", meta, request)
+	policy := &v3.NetworkPolicy{
+		TypeMeta: calicoPolicyTypeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        quarantinePolicyName,
+			Namespace:   request.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: v3.NetworkPolicySpec{
+			Selector: "all()",
+			Types:    []v3.PolicyType{v3.PolicyTypeIngress, v3.PolicyTypeEgress},
+			Ingress:  ingress,
+			Egress:   egress,
+		},
+	}
+	policy.Annotations[specHashAnnotation] = specHash(policy.Spec)
+	return policy
+}
+
+// calicoNamespaceSelector selects a namespace by the label Calico gives every namespace with its name.
+func calicoNamespaceSelector(namespace string) string {
+	return renderMap(map[string]string{"projectcalico.org/name": namespace})
+}
+
+func (b *calicoBackend) Create(ctx context.Context, policy PolicyObject, dryRun bool) (PolicyObject, error) {
+	options := metav1.CreateOptions{}
+	if dryRun {
+		options.DryRun = []string{metav1.DryRunAll}
+	}
+	if tier, staged := policy.GetAnnotations()[tierAnnotation], policy.GetAnnotations()[stagedAnnotation] == "true"; tier != "" || staged {
+		return b.createUnstructured(ctx, policy, tier, staged, options)
+	}
+
+	switch policy := policy.(type) {
+	case *v3.NetworkPolicy:
+		created, err := b.clientset.ProjectcalicoV3().NetworkPolicies(policy.Namespace).Create(ctx, policy, options)
+		if err != nil {
+			return nil, err
+		}
+		created.TypeMeta = calicoPolicyTypeMeta
+		return created, nil
+	case *v3.GlobalNetworkPolicy:
+		created, err := b.clientset.ProjectcalicoV3().GlobalNetworkPolicies().Create(ctx, policy, options)
+		if err != nil {
+			return nil, err
+		}
+		created.TypeMeta = calicoGlobalPolicyTypeMeta
+		return created, nil
+	}
+	return nil, fmt.Errorf("expected a Calico NetworkPolicy or GlobalNetworkPolicy, got %T", policy)
+}
+
+// createUnstructured creates the policy in its tier, creating the tier first when it's missing and the backend may, or staged.
+func (b *calicoBackend) createUnstructured(ctx context.Context, policy PolicyObject, tier string, staged bool, options metav1.CreateOptions) (PolicyObject, error) {
+	if tier != "" && b.createTiers {
+		if err := b.ensureTier(ctx, tier, options); err != nil {
+			return nil, fmt.Errorf("creating Calico tier %s: %w", tier, err)
+		}
+	}
+
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(policy)
+	if err != nil {
+		return nil, err
+	}
+	unstructuredPolicy := &unstructured.Unstructured{Object: object}
+	if tier != "" {
+		if err := unstructured.SetNestedField(object, tier, "spec", "tier"); err != nil {
+			return nil, err
+		}
+	}
+	if staged {
+		unstructuredPolicy.SetKind(calicoStagedPrefix + unstructuredPolicy.GetKind())
+		if err := unstructured.SetNestedField(object, "Set", "spec", "stagedAction"); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.client.Resource(calicoResource(unstructuredPolicy)).Namespace(policy.GetNamespace()).Create(ctx, unstructuredPolicy, options)
+}
+
+// calicoResource returns the resource of a Calico policy from its kind, staged or not.
+func calicoResource(policy PolicyObject) schema.GroupVersionResource {
+	kind := policy.GetObjectKind().GroupVersionKind().Kind
+	staged := strings.HasPrefix(kind, calicoStagedPrefix)
+	switch {
+	case policy.GetNamespace() == "" && staged:
+		return calicoStagedGlobalPolicyResource
+	case policy.GetNamespace() == "":
+		return calicoGlobalPolicyResource
+	case staged:
+		return calicoStagedPolicyResource
+	}
+	return calicoPolicyResource
+}
+
+// ensureTier creates the tier ordered before the default one, unless it exists.
+func (b *calicoBackend) ensureTier(ctx context.Context, name string, options metav1.CreateOptions) error {
+	_, err := b.client.Resource(calicoTierResource).Get(ctx, name, metav1.GetOptions{})
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	tier := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"order": int64(calicoTierOrder)},
+	}}
+	tier.SetGroupVersionKind(v3.SchemeGroupVersion.WithKind("Tier"))
+	tier.SetName(name)
+	tier.SetLabels(map[string]string{managedByLabel: managedByValue})
+	_, err = b.client.Resource(calicoTierResource).Create(ctx, tier, options)
+	if apierrors.IsAlreadyExists(err) {
+		return nil
+	}
+	if err == nil && len(options.DryRun) == 0 {
+		slog.Info("Created Calico tier", "tier", name, "order", calicoTierOrder)
+	}
+	return err
+}
+
+func (b *calicoBackend) Get(ctx context.Context, namespace, name string) (PolicyObject, error) {
+	if namespace == "" {
+		policy, err := b.clientset.ProjectcalicoV3().GlobalNetworkPolicies().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		policy.TypeMeta = calicoGlobalPolicyTypeMeta
+		return policy, nil
+	}
+
+	policy, err := b.clientset.ProjectcalicoV3().NetworkPolicies(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	policy.TypeMeta = calicoPolicyTypeMeta
+	return policy, nil
+}
+
+func (b *calicoBackend) List(ctx context.Context, namespace string) ([]PolicyObject, error) {
+	list, err := b.clientset.ProjectcalicoV3().NetworkPolicies(namespace).List(ctx, metav1.ListOptions{LabelSelector: managedObjectSelector})
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make([]PolicyObject, 0, len(list.Items))
+	for i := range list.Items {
+		list.Items[i].TypeMeta = calicoPolicyTypeMeta
+		policies = append(policies, &list.Items[i])
+	}
+	if namespace != metav1.NamespaceAll {
+		return policies, nil
+	}
+
+	globalList, err := b.clientset.ProjectcalicoV3().GlobalNetworkPolicies().List(ctx, metav1.ListOptions{LabelSelector: managedObjectSelector})
+	if err != nil {
+		return nil, err
+	}
+	for i := range globalList.Items {
+		globalList.Items[i].TypeMeta = calicoGlobalPolicyTypeMeta
+		policies = append(policies, &globalList.Items[i])
+	}
+	return policies, nil
+}
+
+func (b *calicoBackend) Delete(ctx context.Context, policy PolicyObject) error {
+	uid := policy.GetUID()
+	options := metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}}
+	if strings.HasPrefix(policy.GetObjectKind().GroupVersionKind().Kind, calicoStagedPrefix) {
+		return b.client.Resource(calicoResource(policy)).Namespace(policy.GetNamespace()).Delete(ctx, policy.GetName(), options)
+	}
+	if policy.GetNamespace() == "" {
+		return b.clientset.ProjectcalicoV3().GlobalNetworkPolicies().Delete(ctx, policy.GetName(), options)
+	}
+	return b.clientset.ProjectcalicoV3().NetworkPolicies(policy.GetNamespace()).Delete(ctx, policy.GetName(), options)
+}
+
+func (b *calicoBackend) listStaged(ctx context.Context, namespace string) ([]PolicyObject, error) {
+	resources := []dynamic.ResourceInterface{b.client.Resource(calicoStagedPolicyResource).Namespace(namespace)}
+	if namespace == metav1.NamespaceAll {
+		resources = append(resources, b.client.Resource(calicoStagedGlobalPolicyResource))
+	}
+
+	var policies []PolicyObject
+	for _, resource := range resources {
+		list, err := resource.List(ctx, metav1.ListOptions{LabelSelector: managedObjectSelector})
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			policies = append(policies, &list.Items[i])
+		}
+	}
+	return policies, nil
+}
+
+// getStaged returns a staged policy, a global one when namespace is empty.
+func (b *calicoBackend) getStaged(ctx context.Context, namespace, name string) (PolicyObject, error) {
+	resource := calicoStagedPolicyResource
+	if namespace == "" {
+		resource = calicoStagedGlobalPolicyResource
+	}
+	return b.client.Resource(resource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// promote creates the policy a staged one would be, under the same name, then deletes the staged policy.
+func (b *calicoBackend) promote(ctx context.Context, staged PolicyObject) (PolicyObject, error) {
+	stagedPolicy, ok := staged.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("expected a staged Calico policy, got %T", staged)
+	}
+
+	spec, _, err := unstructured.NestedMap(stagedPolicy.Object, "spec")
+	if err != nil {
+		return nil, err
+	}
+	delete(spec, "stagedAction")
+	policy := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	policy.SetGroupVersionKind(v3.SchemeGroupVersion.WithKind(strings.TrimPrefix(stagedPolicy.GetKind(), calicoStagedPrefix)))
+	policy.SetNamespace(stagedPolicy.GetNamespace())
+	policy.SetName(stagedPolicy.GetName())
+	policy.SetLabels(stagedPolicy.GetLabels())
+	annotations := stagedPolicy.GetAnnotations()
+	delete(annotations, stagedAnnotation)
+	policy.SetAnnotations(annotations)
+
+	created, err := b.client.Resource(calicoResource(policy)).Namespace(policy.GetNamespace()).Create(ctx, policy, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if err := b.Delete(ctx, staged); err != nil && !apierrors.IsNotFound(err) {
+		return created, fmt.Errorf("deleting the staged policy: %w", err)
+	}
+	return created, nil
+}
+
+func (b *calicoBackend) Resources(global bool) []schema.GroupResource {
+	if global {
+		return []schema.GroupResource{calicoGlobalPolicyResource.GroupResource()}
+	}
+	return []schema.GroupResource{calicoPolicyResource.GroupResource()}
+}
+
+func (b *calicoBackend) Selectors(policy PolicyObject) PolicySelectors {
+	var selector string
+	var ingress []v3.Rule
+	switch policy := policy.(type) {
+	case *v3.NetworkPolicy:
+		selector, ingress = policy.Spec.Selector, policy.Spec.Ingress
+	case *v3.GlobalNetworkPolicy:
+		selector, ingress = policy.Spec.Selector, policy.Spec.Ingress
+	case *unstructured.Unstructured:
+		// Policies in a tier or staged, every kind sharing the selector and rules of a NetworkPolicy
+		var networkPolicy v3.NetworkPolicy
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(policy.Object, &networkPolicy); err != nil {
+			return PolicySelectors{}
+		}
+		selector, ingress = networkPolicy.Spec.Selector, networkPolicy.Spec.Ingress
+	default:
+		return PolicySelectors{}
+	}
+
+	selectors := PolicySelectors{Selector: selector}
+	if len(ingress) > 0 {
+		source := ingress[0].Source
+		selectors.PeerSelector, selectors.NamespaceSelector = source.Selector, source.NamespaceSelector
+		if len(source.Nets) > 0 {
+			selectors.PeerCIDR = source.Nets[0]
+		}
+	}
+	return selectors
+}
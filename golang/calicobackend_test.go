@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestCalicoBackendRender(t *testing.T) {
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}},
+	}
+	rendered := (&calicoBackend{}).Render(request, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop", Labels: map[string]string{"team": "shop"}}}, RequestMetadata{CorrelationID: "run-42", Principal: "alice"})
+	policy := rendered.(*v3.NetworkPolicy)
+
+	assert.Equal(t, "NetworkPolicy", policy.Kind)
+	assert.Equal(t, denyPolicyName(request), policy.Name)
+	assert.Equal(t, "payments", policy.Namespace)
+	assert.Equal(t, managedByValue, policy.Labels[managedByLabel])
+	assert.Equal(t, "alice", policy.Annotations[createdByAnnotation])
+	assert.Equal(t, "app == 'ledger'", policy.Spec.Selector)
+	assert.Equal(t, "app == 'cart'", policy.Spec.Ingress[0].Source.Selector)
+	assert.Equal(t, "team == 'shop'", policy.Spec.Egress[0].Destination.NamespaceSelector)
+	assert.Equal(t, specHash(policy.Spec), policy.Annotations[specHashAnnotation])
+}
+
+func TestCalicoBackendSelectors(t *testing.T) {
+	backend := &calicoBackend{}
+	policy := &v3.NetworkPolicy{Spec: v3.NetworkPolicySpec{
+		Selector: "app == 'ledger'",
+		Ingress:  []v3.Rule{{Action: v3.Deny, Source: v3.EntityRule{Selector: "app == 'cart'", NamespaceSelector: "team == 'shop'"}}},
+	}}
+
+	assert.Equal(t, PolicySelectors{Selector: "app == 'ledger'", PeerSelector: "app == 'cart'", NamespaceSelector: "team == 'shop'"}, backend.Selectors(policy))
+
+	policy.Spec.Ingress = []v3.Rule{{Action: v3.Deny, Source: v3.EntityRule{Nets: []string{"10.12.0.0/16"}}}}
+	assert.Equal(t, PolicySelectors{Selector: "app == 'ledger'", PeerCIDR: "10.12.0.0/16"}, backend.Selectors(policy))
+
+	policy.Spec.Ingress = nil
+	assert.Equal(t, PolicySelectors{Selector: "app == 'ledger'"}, backend.Selectors(policy))
+}
+
+func TestCalicoBackendRenderCIDR(t *testing.T) {
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{CIDR: "10.12.0.0/16"},
+	}
+	policy := (&calicoBackend{}).Render(request, nil, RequestMetadata{}).(*v3.NetworkPolicy)
+
+	assert.Equal(t, v3.EntityRule{Nets: []string{"10.12.0.0/16"}}, policy.Spec.Ingress[0].Source)
+	assert.Equal(t, v3.EntityRule{Nets: []string{"10.12.0.0/16"}}, policy.Spec.Egress[0].Destination)
+	assert.NotContains(t, policy.Annotations, peerNamespaceAnnotation)
+}
+
+func TestCalicoBackendTier(t *testing.T) {
+	ctx := context.Background()
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		calicoPolicyResource: "NetworkPolicyList",
+		calicoTierResource:   "TierList",
+	})
+	backend := &calicoBackend{client: client, createTiers: true}
+	request := DenyNetworkRequest{
+		A:    DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B:    DenyNetworkRequestWorkload{CIDR: "10.12.0.0/16"},
+		Tier: "security",
+	}
+
+	created, err := backend.Create(ctx, backend.Render(request, nil, RequestMetadata{}), false)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "security."+denyPolicyName(request), created.GetName())
+	assert.Equal(t, "security", created.GetAnnotations()[tierAnnotation])
+
+	stored, err := client.Resource(calicoPolicyResource).Namespace("payments").Get(ctx, created.GetName(), metav1.GetOptions{})
+	if assert.NoError(t, err) {
+		tier, _, _ := unstructured.NestedString(stored.Object, "spec", "tier")
+		assert.Equal(t, "security", tier)
+	}
+	tier, err := client.Resource(calicoTierResource).Get(ctx, "security", metav1.GetOptions{})
+	if assert.NoError(t, err) {
+		order, _, _ := unstructured.NestedInt64(tier.Object, "spec", "order")
+		assert.Equal(t, int64(calicoTierOrder), order)
+	}
+
+	request.B.CIDR = "10.13.0.0/16"
+	backend.createTiers = false
+	_, err = backend.Create(ctx, backend.Render(request, nil, RequestMetadata{}), false)
+	assert.NoError(t, err)
+}
+
+func TestCalicoBackendStagedPromote(t *testing.T) {
+	ctx := context.Background()
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		calicoPolicyResource:       "NetworkPolicyList",
+		calicoStagedPolicyResource: "StagedNetworkPolicyList",
+	})
+	backend := &calicoBackend{client: client}
+	request := DenyNetworkRequest{
+		A:      DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B:      DenyNetworkRequestWorkload{CIDR: "10.12.0.0/16"},
+		Staged: true,
+	}
+
+	created, err := backend.Create(ctx, backend.Render(request, nil, RequestMetadata{}), false)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "StagedNetworkPolicy", created.GetObjectKind().GroupVersionKind().Kind)
+	staged, err := backend.getStaged(ctx, "payments", created.GetName())
+	if !assert.NoError(t, err) {
+		return
+	}
+	action, _, _ := unstructured.NestedString(staged.(*unstructured.Unstructured).Object, "spec", "stagedAction")
+	assert.Equal(t, "Set", action)
+
+	policy, err := backend.promote(ctx, staged)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "NetworkPolicy", policy.GetObjectKind().GroupVersionKind().Kind)
+	assert.Equal(t, created.GetName(), policy.GetName())
+	assert.NotContains(t, policy.GetAnnotations(), stagedAnnotation)
+	_, found, _ := unstructured.NestedString(policy.(*unstructured.Unstructured).Object, "spec", "stagedAction")
+	assert.False(t, found)
+
+	_, err = backend.getStaged(ctx, "payments", created.GetName())
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestCalicoBackendRenderGlobal(t *testing.T) {
+	request := DenyNetworkRequest{
+		A:     DenyNetworkRequestWorkload{Labels: map[string]string{"app": "ledger"}},
+		B:     DenyNetworkRequestWorkload{Labels: map[string]string{"app": "cart"}},
+		Scope: policyScopeGlobal,
+	}
+	backend := &calicoBackend{}
+	policy, ok := backend.Render(request, nil, RequestMetadata{}).(*v3.GlobalNetworkPolicy)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	assert.Equal(t, "GlobalNetworkPolicy", policy.Kind)
+	assert.Empty(t, policy.Namespace)
+	assert.Equal(t, "app == 'ledger'", policy.Spec.Selector)
+	assert.Equal(t, v3.EntityRule{Selector: "app == 'cart'"}, policy.Spec.Egress[0].Destination)
+	assert.Equal(t, PolicySelectors{Selector: "app == 'ledger'", PeerSelector: "app == 'cart'"}, backend.Selectors(policy))
+	assert.NotEqual(t, denyPolicyName(DenyNetworkRequest{A: request.A, B: request.B}), policy.Name)
+}
+
+func TestCalicoBackendRenderHostEndpoints(t *testing.T) {
+	backend := &calicoBackend{}
+	nodes := DenyNetworkRequestWorkload{Kind: workloadKindHostEndpoint, Labels: map[string]string{"role": "worker"}}
+
+	// Pods denied their node: only their egress, the kubelet still probing them
+	request := DenyNetworkRequest{A: DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}}, B: nodes}
+	policy := backend.Render(request, nil, RequestMetadata{}).(*v3.NetworkPolicy)
+	assert.Equal(t, []v3.PolicyType{v3.PolicyTypeEgress}, policy.Spec.Types)
+	assert.Empty(t, policy.Spec.Ingress)
+	assert.Equal(t, v3.EntityRule{Selector: "role == 'worker'", NamespaceSelector: "global()"}, policy.Spec.Egress[0].Destination)
+	pods := request
+	pods.B.Kind = ""
+	assert.NotEqual(t, denyPolicyName(pods), policy.Name)
+
+	// Nodes denied each other pass the rest of their traffic on
+	request = DenyNetworkRequest{A: nodes, B: nodes, Scope: policyScopeGlobal}
+	global := backend.Render(request, nil, RequestMetadata{}).(*v3.GlobalNetworkPolicy)
+	assert.Equal(t, "role == 'worker'", global.Spec.Selector)
+	assert.Empty(t, global.Spec.Types)
+	assert.Equal(t, []v3.Rule{{Action: v3.Deny, Source: v3.EntityRule{Selector: "role == 'worker'", NamespaceSelector: "global()"}}, {Action: v3.Pass}}, global.Spec.Ingress)
+	assert.Equal(t, v3.Pass, global.Spec.Egress[1].Action)
+}
+
+func TestCalicoBackendRenderQuarantine(t *testing.T) {
+	request := NamespaceIsolationRequest{Namespace: "payments", AllowDNS: true, AllowServices: []string{"monitoring/prometheus"}}
+	services := []DenyNetworkRequestWorkload{{Kind: workloadKindService, Name: "prometheus", Namespace: "monitoring", Labels: map[string]string{"app": "prometheus"}}}
+	policy := (&calicoBackend{}).renderQuarantine(request, services, RequestMetadata{Principal: "alice"}).(*v3.NetworkPolicy)
+
+	assert.Equal(t, quarantinePolicyName, policy.Name)
+	assert.Equal(t, "payments", policy.Namespace)
+	assert.Equal(t, actionQuarantine, policy.Labels[actionLabel])
+	assert.Equal(t, "all()", policy.Spec.Selector)
+	assert.Equal(t, []v3.PolicyType{v3.PolicyTypeIngress, v3.PolicyTypeEgress}, policy.Spec.Types)
+
+	// DNS over UDP and TCP, the service, then the rest denied
+	if assert.Len(t, policy.Spec.Egress, 4) {
+		assert.Equal(t, "k8s-app == 'kube-dns'", policy.Spec.Egress[0].Destination.Selector)
+		assert.Equal(t, "projectcalico.org/name == 'kube-system'", policy.Spec.Egress[0].Destination.NamespaceSelector)
+		assert.Equal(t, "app == 'prometheus'", policy.Spec.Egress[2].Destination.Selector)
+		assert.Equal(t, "projectcalico.org/name == 'monitoring'", policy.Spec.Egress[2].Destination.NamespaceSelector)
+		assert.Equal(t, v3.Rule{Action: v3.Deny}, policy.Spec.Egress[3])
+	}
+	if assert.Len(t, policy.Spec.Ingress, 2) {
+		assert.Equal(t, v3.Allow, policy.Spec.Ingress[0].Action)
+		assert.Equal(t, "app == 'prometheus'", policy.Spec.Ingress[0].Source.Selector)
+		assert.Equal(t, v3.Rule{Action: v3.Deny}, policy.Spec.Ingress[1])
+	}
+}
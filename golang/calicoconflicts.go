@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"net"
+	"sort"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// calicoPolicyRules is the part of the spec of a NetworkPolicy or GlobalNetworkPolicy conflicts are looked for in.
+type calicoPolicyRules struct {
+	Tier     string    `json:"tier,omitempty"`
+	Order    *float64  `json:"order,omitempty"`
+	Selector string    `json:"selector,omitempty"`
+	Ingress  []v3.Rule `json:"ingress,omitempty"`
+	Egress   []v3.Rule `json:"egress,omitempty"`
+}
+
+// conflicts returns the policies of the tier of the request selecting A and allowing traffic with B, the global ones for a global request.
+//
+// The policies are read with the dynamic client for their tier, which the Calico API this service is built with doesn't have.
+// Workloads are matched as a pod with their labels, plus one value for each In and Exists expression, would be.
+func (b *calicoBackend) conflicts(ctx context.Context, request DenyNetworkRequest, peerNamespace *corev1.Namespace) ([]PolicyConflict, error) {
+	resource, kind := b.client.Resource(calicoPolicyResource).Namespace(request.A.Namespace), calicoPolicyTypeMeta.Kind
+	if request.global() {
+		resource, kind = b.client.Resource(calicoGlobalPolicyResource), calicoGlobalPolicyTypeMeta.Kind
+	}
+	list, err := resource.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	tier := request.Tier
+	if tier == "" {
+		tier = calicoDefaultTier
+	}
+	name := b.Render(request, peerNamespace, RequestMetadata{}).GetName()
+
+	var conflicts []PolicyConflict
+	for _, item := range list.Items {
+		if item.GetName() == name || item.GetAnnotations()[actionAnnotation] == actionDeny {
+			continue
+		}
+		var rules calicoPolicyRules
+		spec, _, _ := unstructured.NestedMap(item.Object, "spec")
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(spec, &rules); err != nil {
+			continue
+		}
+		if rules.Tier == "" {
+			rules.Tier = calicoDefaultTier
+		}
+		if rules.Tier != tier {
+			continue
+		}
+		if conflict, ok := calicoPolicyConflict(item.GetNamespace(), rules, request, peerNamespace); ok {
+			conflict.Kind, conflict.Name = kind, item.GetName()
+			// Policies are evaluated by order then name, and the deny policy has no order
+			conflict.Precedes = rules.Order != nil || item.GetName() < name
+			conflicts = append(conflicts, conflict)
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Name < conflicts[j].Name })
+	return conflicts, nil
+}
+
+// calicoPolicyConflict tells whether the policy of namespace with rules selects A and allows traffic from or to B.
+func calicoPolicyConflict(namespace string, rules calicoPolicyRules, request DenyNetworkRequest, peerNamespace *corev1.Namespace) (PolicyConflict, bool) {
+	conflict := PolicyConflict{Namespace: namespace, Tier: rules.Tier, Order: rules.Order}
+
+	selector, err := parseCalicoSelector(rules.Selector)
+	if err != nil || !selector.Matches(calicoEndpointLabels(request.A)) {
+		return conflict, false
+	}
+	for _, direction := range []struct {
+		name  string
+		rules []v3.Rule
+		peer  func(v3.Rule) v3.EntityRule
+	}{
+		{"ingress", rules.Ingress, func(rule v3.Rule) v3.EntityRule { return rule.Source }},
+		{"egress", rules.Egress, func(rule v3.Rule) v3.EntityRule { return rule.Destination }},
+	} {
+		for _, rule := range direction.rules {
+			if rule.Action == v3.Allow && calicoEntityMatches(direction.peer(rule), namespace, request.B, peerNamespace) {
+				conflict.Directions = append(conflict.Directions, direction.name)
+				break
+			}
+		}
+	}
+	return conflict, len(conflict.Directions) > 0
+}
+
+// calicoEntityMatches tells whether a rule of a policy of namespace, empty for a global one, matches the peer.
+//
+// Selectors only match endpoints and nets only match a CIDR peer: whether the pods of a workload are in a range isn't known.
+func calicoEntityMatches(entity v3.EntityRule, namespace string, peer DenyNetworkRequestWorkload, peerNamespace *corev1.Namespace) bool {
+	if peer.CIDR != "" {
+		if len(entity.Nets) == 0 {
+			return entity.Selector == "" && entity.NamespaceSelector == ""
+		}
+		_, peerNet, err := net.ParseCIDR(peer.CIDR)
+		if err != nil {
+			return false
+		}
+		for _, cidr := range entity.Nets {
+			if _, ruleNet, err := net.ParseCIDR(cidr); err == nil && (ruleNet.Contains(peerNet.IP) || peerNet.Contains(ruleNet.IP)) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(entity.Nets) > 0 {
+		return false
+	}
+
+	if entity.NamespaceSelector != "" {
+		if peerNamespace == nil {
+			return false
+		}
+		labels := map[string]string{"projectcalico.org/name": peerNamespace.Name}
+		for key, value := range peerNamespace.Labels {
+			labels[key] = value
+		}
+		namespaceSelector, err := parseCalicoSelector(entity.NamespaceSelector)
+		if err != nil || !namespaceSelector.Matches(labels) {
+			return false
+		}
+	} else if entity.Selector != "" && namespace != "" && peer.Namespace != namespace {
+		// Without a namespace selector, the selector of a namespaced policy stays in its namespace
+		return false
+	}
+
+	labels := calicoEndpointLabels(peer)
+	if entity.Selector != "" {
+		selector, err := parseCalicoSelector(entity.Selector)
+		if err != nil || !selector.Matches(labels) {
+			return false
+		}
+	}
+	if entity.NotSelector != "" {
+		notSelector, err := parseCalicoSelector(entity.NotSelector)
+		if err != nil || notSelector.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// calicoEndpointLabels returns the labels Calico would see on a pod of the workload, like endpointLabels does for an actual pod.
+func calicoEndpointLabels(workload DenyNetworkRequestWorkload) map[string]string {
+	labels := map[string]string{"projectcalico.org/orchestrator": "k8s"}
+	if workload.Namespace != "" {
+		labels["projectcalico.org/namespace"] = workload.Namespace
+	}
+	for key, value := range workload.Labels {
+		labels[key] = value
+	}
+	for _, expression := range workload.MatchExpressions {
+		switch expression.Operator {
+		case metav1.LabelSelectorOpIn:
+			labels[expression.Key] = expression.Values[0]
+		case metav1.LabelSelectorOpExists:
+			labels[expression.Key] = ""
+		}
+	}
+	return labels
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func calicoTestPolicy(name string, spec map[string]interface{}) *unstructured.Unstructured {
+	policy := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	policy.SetAPIVersion(calicoPolicyTypeMeta.APIVersion)
+	policy.SetKind(calicoPolicyTypeMeta.Kind)
+	policy.SetNamespace("payments")
+	policy.SetName(name)
+	return policy
+}
+
+func newConflictingCalicoBackend() *calicoBackend {
+	allowFrom := func(source map[string]interface{}) []interface{} {
+		return []interface{}{map[string]interface{}{"action": "Allow", "source": source}}
+	}
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		calicoPolicyResource:       "NetworkPolicyList",
+		calicoGlobalPolicyResource: "GlobalNetworkPolicyList",
+	},
+		calicoTestPolicy("allow-shop", map[string]interface{}{
+			"order":    float64(100),
+			"selector": "app == 'ledger'",
+			"ingress":  allowFrom(map[string]interface{}{"selector": "app == 'cart'", "namespaceSelector": "team == 'shop'"}),
+		}),
+		calicoTestPolicy("allow-local", map[string]interface{}{
+			"selector": "has(app)",
+			"ingress":  allowFrom(map[string]interface{}{"selector": "app == 'cart'"}),
+		}),
+		calicoTestPolicy("security.allow-shop", map[string]interface{}{
+			"tier":     "security",
+			"selector": "app == 'ledger'",
+			"ingress":  allowFrom(map[string]interface{}{}),
+		}),
+		calicoTestPolicy("allow-batch", map[string]interface{}{
+			"selector": "app == 'batch'",
+			"ingress":  allowFrom(map[string]interface{}{}),
+		}),
+		calicoTestPolicy("allow-office", map[string]interface{}{
+			"selector": "all()",
+			"egress":   []interface{}{map[string]interface{}{"action": "Allow", "destination": map[string]interface{}{"nets": []interface{}{"10.12.3.0/24"}}}},
+		}),
+	)
+	return &calicoBackend{client: client}
+}
+
+func TestCalicoBackendConflicts(t *testing.T) {
+	ctx := context.Background()
+	backend := newConflictingCalicoBackend()
+	shop := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop", Labels: map[string]string{"team": "shop"}}}
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}},
+	}
+
+	// allow-local only allows cart pods of its own namespace
+	conflicts, err := backend.conflicts(ctx, request, shop)
+	if assert.NoError(t, err) && assert.Len(t, conflicts, 1) {
+		order := float64(100)
+		assert.Equal(t, PolicyConflict{
+			Kind: "NetworkPolicy", Namespace: "payments", Name: "allow-shop", Tier: calicoDefaultTier, Order: &order,
+			Directions: []string{"ingress"}, Precedes: true,
+		}, conflicts[0])
+		assert.Equal(t, "NetworkPolicy payments/allow-shop in tier default allows ingress traffic with the peer, it's evaluated first and keeps the traffic allowed", conflicts[0].String())
+	}
+
+	request.Tier = "security"
+	conflicts, err = backend.conflicts(ctx, request, shop)
+	if assert.NoError(t, err) && assert.Len(t, conflicts, 1) {
+		assert.Equal(t, "security.allow-shop", conflicts[0].Name)
+		assert.True(t, conflicts[0].Precedes, "security.allow-shop sorts before security.deny-network-policy-...")
+	}
+
+	request = DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{CIDR: "10.12.0.0/16"},
+	}
+	conflicts, err = backend.conflicts(ctx, request, nil)
+	if assert.NoError(t, err) && assert.Len(t, conflicts, 1) {
+		assert.Equal(t, "allow-office", conflicts[0].Name)
+		assert.Equal(t, []string{"egress"}, conflicts[0].Directions)
+		assert.True(t, conflicts[0].Precedes)
+	}
+
+	request.B.CIDR = "192.168.0.0/16"
+	conflicts, err = backend.conflicts(ctx, request, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, conflicts)
+}
+
+func TestCheckPolicyConflicts(t *testing.T) {
+	ctx := context.Background()
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{CIDR: "10.12.0.0/16"},
+	}
+
+	server := &Server{Policies: newConflictingCalicoBackend(), PolicyConflicts: policyConflictsWarn}
+	w := httptest.NewRecorder()
+	assert.NoError(t, server.checkPolicyConflicts(ctx, w, request))
+	assert.Equal(t, []string{`299 - "NetworkPolicy payments/allow-office in tier default allows egress traffic with the peer, it's evaluated first and keeps the traffic allowed"`}, w.Header().Values("Warning"))
+
+	server.PolicyConflicts = policyConflictsReject
+	w = httptest.NewRecorder()
+	err := server.checkPolicyConflicts(ctx, w, request)
+	var conflictErr *PolicyConflictError
+	if assert.ErrorAs(t, err, &conflictErr) {
+		assert.Len(t, conflictErr.Conflicts, 1)
+		assert.Equal(t, 409, denyCheckErrorStatus(err))
+	}
+	assert.Empty(t, w.Header().Values("Warning"))
+
+	server.PolicyConflicts = policyConflictsOff
+	assert.NoError(t, server.checkPolicyConflicts(ctx, httptest.NewRecorder(), request))
+	assert.Error(t, validatePolicyConflictsMode("ignore"))
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	calicoclient "github.com/projectcalico/api/pkg/client/clientset_generated/clientset"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// calicoStatusStalePeriods is how many update periods a CalicoNodeStatus may go without an update before it's stale,
+// calico-node having stopped reporting.
+const calicoStatusStalePeriods = 3
+
+// errCalicoNotInstalled is returned when the cluster runs neither calico-node nor the Calico API.
+var errCalicoNotInstalled = errors.New("calico-node doesn't run on any node and the Calico API isn't served, is Calico installed?")
+
+type BGPPeer struct {
+	PeerIP string `json:"peer_ip"`
+	// Type is NodeMesh, NodePeer or GlobalPeer
+	Type  string `json:"type"`
+	State string `json:"state"`
+	Since string `json:"since,omitempty"`
+}
+
+// CalicoBGPStatus is the state of the BGP daemons and sessions of a node, as reported in its CalicoNodeStatus.
+type CalicoBGPStatus struct {
+	// BIRDV4 and BIRDV6 are the states of the BGP daemons, Ready or NotReady, empty when not reported
+	BIRDV4         string    `json:"bird_v4,omitempty"`
+	BIRDV6         string    `json:"bird_v6,omitempty"`
+	Established    int       `json:"established"`
+	NotEstablished int       `json:"not_established"`
+	Peers          []BGPPeer `json:"peers,omitempty"`
+	LastUpdated    time.Time `json:"last_updated"`
+	// Stale is set when calico-node stopped updating the status
+	Stale bool `json:"stale"`
+}
+
+type CalicoNodeHealth struct {
+	Name      string `json:"node_name"`
+	NodeReady bool   `json:"node_ready"`
+	// Pod is the calico-node pod of the node, empty when none runs there
+	Pod string `json:"calico_node_pod,omitempty"`
+	// AgentReady is the readiness of calico-node, whose probe checks Felix and BIRD
+	AgentReady bool  `json:"agent_ready"`
+	Restarts   int32 `json:"restarts"`
+	// BGP is unset when no CalicoNodeStatus reports on the node
+	BGP *CalicoBGPStatus `json:"bgp,omitempty"`
+	// EnforcementAtRisk is set when Felix may not be programming the policies on the node
+	EnforcementAtRisk bool     `json:"enforcement_at_risk"`
+	Issues            []string `json:"issues,omitempty"`
+}
+
+type CalicoNodeStatusReport struct {
+	Nodes []CalicoNodeHealth `json:"nodes"`
+	// AtRisk counts the nodes whose policy enforcement may not be active
+	AtRisk int `json:"at_risk"`
+}
+
+// Reports the health of the Calico dataplane of the nodes whose labels match ?labelSelector=: whether calico-node, and
+// with it Felix, is ready, and the BGP state of the CalicoNodeStatus of the node, when one was created for it
+//
+// A deny policy silently does nothing on a node whose Felix is broken, so those nodes are flagged. A 503 tells Calico
+// isn't installed.
+func (s *Server) calicoNodeStatusHandler(w http.ResponseWriter, r *http.Request) {
+	selector := labels.Everything()
+	if value := r.URL.Query().Get("labelSelector"); value != "" {
+		parsed, err := labels.Parse(value)
+		if err != nil {
+			writeErrorf(w, http.StatusBadRequest, "invalid labelSelector: %v", err)
+			return
+		}
+		selector = parsed
+	}
+
+	report, err := getCalicoNodeStatus(r.Context(), s.K8sClientSet, s.CalicoClientSet, selector, time.Now())
+	switch {
+	case errors.Is(err, errCalicoNotInstalled):
+		writeError(w, http.StatusServiceUnavailable, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// getCalicoNodeStatus matches the nodes whose labels match selector with their calico-node pod and CalicoNodeStatus.
+// Calico versions without the CalicoNodeStatus API only report the readiness of calico-node.
+func getCalicoNodeStatus(ctx context.Context, clientset kubernetes.Interface, calico calicoclient.Interface, selector labels.Selector, now time.Time) (*CalicoNodeStatusReport, error) {
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: calicoNodeSelector})
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := calico.ProjectcalicoV3().CalicoNodeStatuses().List(ctx, metav1.ListOptions{})
+	if apierrors.IsNotFound(err) {
+		if len(pods.Items) == 0 {
+			return nil, errCalicoNotInstalled
+		}
+		statuses = &v3.CalicoNodeStatusList{}
+	} else if err != nil {
+		return nil, err
+	}
+
+	calicoPods := map[string]corev1.Pod{}
+	for _, pod := range pods.Items {
+		// A node may briefly run two calico-node pods during an upgrade, the ready one counts
+		if current, ok := calicoPods[pod.Spec.NodeName]; !ok || !podReady(current) && podReady(pod) {
+			calicoPods[pod.Spec.NodeName] = pod
+		}
+	}
+	nodeStatuses := map[string]v3.CalicoNodeStatus{}
+	for _, status := range statuses.Items {
+		if current, ok := nodeStatuses[status.Spec.Node]; !ok || status.Status.LastUpdated.After(current.Status.LastUpdated.Time) {
+			nodeStatuses[status.Spec.Node] = status
+		}
+	}
+
+	report := &CalicoNodeStatusReport{Nodes: []CalicoNodeHealth{}}
+	for _, node := range nodes.Items {
+		health := calicoNodeHealth(node, calicoPods, nodeStatuses, now)
+		if health.EnforcementAtRisk {
+			report.AtRisk++
+		}
+		report.Nodes = append(report.Nodes, health)
+	}
+	sort.Slice(report.Nodes, func(i, j int) bool { return report.Nodes[i].Name < report.Nodes[j].Name })
+	slog.DebugContext(ctx, "Calico node status", "nodes", len(report.Nodes), "at_risk", report.AtRisk)
+	return report, nil
+}
+
+// calicoNodeHealth flags a node without a ready calico-node, or whose CalicoNodeStatus stopped being updated, as
+// not enforcing policies. BGP issues are reported without flagging the node: they break routing, not policies.
+func calicoNodeHealth(node corev1.Node, pods map[string]corev1.Pod, statuses map[string]v3.CalicoNodeStatus, now time.Time) CalicoNodeHealth {
+	health := CalicoNodeHealth{Name: node.Name}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			health.NodeReady = condition.Status == corev1.ConditionTrue
+		}
+	}
+	if !health.NodeReady {
+		health.Issues = append(health.Issues, "the node isn't ready")
+	}
+
+	pod, ok := pods[node.Name]
+	switch {
+	case !ok:
+		health.EnforcementAtRisk = true
+		health.Issues = append(health.Issues, "no calico-node pod runs on the node")
+	case !podReady(pod):
+		health.EnforcementAtRisk = true
+		health.Issues = append(health.Issues, fmt.Sprintf("calico-node pod %s isn't ready, Felix may not be programming policies", pod.Name))
+	}
+	if ok {
+		health.Pod, health.AgentReady = pod.Name, podReady(pod)
+		for _, status := range pod.Status.ContainerStatuses {
+			health.Restarts += status.RestartCount
+		}
+	}
+
+	status, ok := statuses[node.Name]
+	if !ok {
+		return health
+	}
+	bgp := &CalicoBGPStatus{
+		BIRDV4:         string(status.Status.Agent.BIRDV4.State),
+		BIRDV6:         string(status.Status.Agent.BIRDV6.State),
+		Established:    status.Status.BGP.NumberEstablishedV4 + status.Status.BGP.NumberEstablishedV6,
+		NotEstablished: status.Status.BGP.NumberNotEstablishedV4 + status.Status.BGP.NumberNotEstablishedV6,
+		LastUpdated:    status.Status.LastUpdated.Time,
+	}
+	for _, peer := range append(append([]v3.CalicoNodePeer{}, status.Status.BGP.PeersV4...), status.Status.BGP.PeersV6...) {
+		bgp.Peers = append(bgp.Peers, BGPPeer{PeerIP: peer.PeerIP, Type: string(peer.Type), State: string(peer.State), Since: peer.Since})
+	}
+	// A period of 0 stops the updates
+	if period := status.Spec.UpdatePeriodSeconds; period != nil && *period > 0 {
+		bgp.Stale = now.Sub(bgp.LastUpdated) > calicoStatusStalePeriods*time.Duration(*period)*time.Second
+	}
+	health.BGP = bgp
+
+	if bgp.Stale {
+		health.EnforcementAtRisk = true
+		health.Issues = append(health.Issues, fmt.Sprintf("CalicoNodeStatus %s wasn't updated since %s, calico-node stopped reporting", status.Name, bgp.LastUpdated.UTC().Format(time.RFC3339)))
+	}
+	for _, daemon := range []struct{ family, state string }{{"IPv4", bgp.BIRDV4}, {"IPv6", bgp.BIRDV6}} {
+		if daemon.state == string(v3.BGPDaemonStateNotReady) {
+			health.Issues = append(health.Issues, fmt.Sprintf("BIRD isn't ready for %s, routes to the pods of the node may be missing", daemon.family))
+		}
+	}
+	if bgp.NotEstablished > 0 {
+		health.Issues = append(health.Issues, fmt.Sprintf("%d of %d BGP sessions aren't established", bgp.NotEstablished, bgp.NotEstablished+bgp.Established))
+	}
+	return health
+}
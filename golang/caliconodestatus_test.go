@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	calicofake "github.com/projectcalico/api/pkg/client/clientset_generated/clientset/fake"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestGetCalicoNodeStatus(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	node := func(name string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}},
+		}
+	}
+	calicoNode := func(name, node string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "calico-system", Labels: map[string]string{"k8s-app": "calico-node"}},
+			Spec:       corev1.PodSpec{NodeName: node},
+			Status: corev1.PodStatus{
+				Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "calico-node", RestartCount: 2}},
+			},
+		}
+	}
+	period := uint32(60)
+	nodeStatus := func(node string, updated time.Time, bird v3.BGPDaemonState, notEstablished int) *v3.CalicoNodeStatus {
+		return &v3.CalicoNodeStatus{
+			ObjectMeta: metav1.ObjectMeta{Name: node + "-status"},
+			Spec:       v3.CalicoNodeStatusSpec{Node: node, UpdatePeriodSeconds: &period},
+			Status: v3.CalicoNodeStatusStatus{
+				LastUpdated: metav1.NewTime(updated),
+				Agent:       v3.CalicoNodeAgentStatus{BIRDV4: v3.BGPDaemonStatus{State: bird}},
+				BGP: v3.CalicoNodeBGPStatus{
+					NumberEstablishedV4:    1,
+					NumberNotEstablishedV4: notEstablished,
+					PeersV4:                []v3.CalicoNodePeer{{PeerIP: "10.0.0.1", Type: v3.BGPPeerTypeNodeMesh, State: v3.BGPSessionStateEstablished}},
+				},
+			},
+		}
+	}
+
+	clientset := fake.NewSimpleClientset(
+		node("healthy"), node("broken"), node("missing"), node("stale"), node("unpeered"),
+		calicoNode("calico-node-a", "healthy", corev1.ConditionTrue),
+		calicoNode("calico-node-b", "broken", corev1.ConditionFalse),
+		calicoNode("calico-node-c", "stale", corev1.ConditionTrue),
+		calicoNode("calico-node-d", "unpeered", corev1.ConditionTrue),
+	)
+	calico := calicofake.NewSimpleClientset(
+		nodeStatus("healthy", now.Add(-time.Minute), v3.BGPDaemonStateReady, 0),
+		nodeStatus("stale", now.Add(-time.Hour), v3.BGPDaemonStateReady, 0),
+		nodeStatus("unpeered", now, v3.BGPDaemonStateNotReady, 2),
+	)
+
+	report, err := getCalicoNodeStatus(context.Background(), clientset, calico, labels.Everything(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.AtRisk)
+	nodes := map[string]CalicoNodeHealth{}
+	for _, health := range report.Nodes {
+		nodes[health.Name] = health
+	}
+
+	assert.False(t, nodes["healthy"].EnforcementAtRisk)
+	assert.Empty(t, nodes["healthy"].Issues)
+	assert.Equal(t, "calico-node-a", nodes["healthy"].Pod)
+	assert.Equal(t, int32(2), nodes["healthy"].Restarts)
+	if assert.NotNil(t, nodes["healthy"].BGP) {
+		assert.Equal(t, "Ready", nodes["healthy"].BGP.BIRDV4)
+		assert.Len(t, nodes["healthy"].BGP.Peers, 1)
+	}
+
+	assert.True(t, nodes["broken"].EnforcementAtRisk)
+	assert.False(t, nodes["broken"].AgentReady)
+	assert.Nil(t, nodes["broken"].BGP)
+	assert.True(t, nodes["missing"].EnforcementAtRisk)
+	assert.Empty(t, nodes["missing"].Pod)
+	assert.True(t, nodes["stale"].EnforcementAtRisk)
+	assert.True(t, nodes["stale"].BGP.Stale)
+
+	// BGP issues break routing, not policies
+	assert.False(t, nodes["unpeered"].EnforcementAtRisk)
+	assert.Len(t, nodes["unpeered"].Issues, 2)
+	assert.Equal(t, 2, nodes["unpeered"].BGP.NotEstablished)
+}
+
+func TestGetCalicoNodeStatusNotInstalled(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}})
+	calico := calicofake.NewSimpleClientset()
+	calico.PrependReactor("list", "caliconodestatuses", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewNotFound(schema.GroupResource{Group: "projectcalico.org", Resource: "caliconodestatuses"}, "")
+	})
+
+	_, err := getCalicoNodeStatus(context.Background(), clientset, calico, labels.Everything(), time.Now())
+	assert.ErrorIs(t, err, errCalicoNotInstalled)
+
+	// Calico versions without the CalicoNodeStatus API still report calico-node
+	_, err = clientset.CoreV1().Pods("kube-system").Create(context.Background(), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "calico-node-a", Namespace: "kube-system", Labels: map[string]string{"k8s-app": "calico-node"}},
+		Spec:       corev1.PodSpec{NodeName: "node"},
+	}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	report, err := getCalicoNodeStatus(context.Background(), clientset, calico, labels.Everything(), time.Now())
+	assert.NoError(t, err)
+	if assert.Len(t, report.Nodes, 1) {
+		assert.Equal(t, "calico-node-a", report.Nodes[0].Pod)
+		assert.True(t, report.Nodes[0].EnforcementAtRisk)
+	}
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	calicoclient "github.com/projectcalico/api/pkg/client/clientset_generated/clientset"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// workloadKindNetworkSet references a Calico NetworkSet as workload B, the policy then selecting the set by its labels.
+	workloadKindNetworkSet = "NetworkSet"
+	// workloadKindHostEndpoint selects the Calico HostEndpoints of the nodes, by name or labels, instead of pods.
+	workloadKindHostEndpoint = "HostEndpoint"
+)
+
+// errCalicoAPIUnavailable is returned when the cluster doesn't serve the Calico API, without Calico or its API server.
+var errCalicoAPIUnavailable = errors.New("the Calico API isn't served, is the Calico API server installed?")
+
+type IPPoolInfo struct {
+	Name        string `json:"name"`
+	CIDR        string `json:"cidr"`
+	BlockSize   int    `json:"block_size,omitempty"`
+	VXLANMode   string `json:"vxlan_mode,omitempty"`
+	IPIPMode    string `json:"ipip_mode,omitempty"`
+	NATOutgoing bool   `json:"nat_outgoing"`
+	// Disabled pools don't allocate new addresses
+	Disabled     bool     `json:"disabled"`
+	NodeSelector string   `json:"node_selector,omitempty"`
+	AllowedUses  []string `json:"allowed_uses,omitempty"`
+}
+
+type NetworkSetInfo struct {
+	Namespace string            `json:"namespace"`
+	Name      string            `json:"name"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Nets      []string          `json:"nets"`
+}
+
+// Lists the Calico IP pools the pod addresses are allocated from
+//
+// IP pools aren't namespaced, so --namespaces doesn't restrict the list. A 503 tells the Calico API isn't served.
+func (s *Server) listIPPoolsHandler(w http.ResponseWriter, r *http.Request) {
+	pools, err := listIPPools(r.Context(), s.CalicoClientSet)
+	switch {
+	case errors.Is(err, errCalicoAPIUnavailable):
+		writeError(w, http.StatusServiceUnavailable, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, pools)
+}
+
+func listIPPools(ctx context.Context, calico calicoclient.Interface) ([]IPPoolInfo, error) {
+	list, err := calico.ProjectcalicoV3().IPPools().List(ctx, metav1.ListOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, errCalicoAPIUnavailable
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pools := make([]IPPoolInfo, 0, len(list.Items))
+	for _, pool := range list.Items {
+		info := IPPoolInfo{
+			Name:         pool.Name,
+			CIDR:         pool.Spec.CIDR,
+			BlockSize:    pool.Spec.BlockSize,
+			VXLANMode:    string(pool.Spec.VXLANMode),
+			IPIPMode:     string(pool.Spec.IPIPMode),
+			NATOutgoing:  pool.Spec.NATOutgoing,
+			Disabled:     pool.Spec.Disabled,
+			NodeSelector: pool.Spec.NodeSelector,
+		}
+		for _, use := range pool.Spec.AllowedUses {
+			info.AllowedUses = append(info.AllowedUses, string(use))
+		}
+		pools = append(pools, info)
+	}
+	sort.Slice(pools, func(i, j int) bool { return pools[i].Name < pools[j].Name })
+	return pools, nil
+}
+
+// Lists the Calico NetworkSets of every namespace in scope or of ?namespace=, which deny requests can reference as
+// workload B with {"kind": "NetworkSet", "namespace": ..., "name": ...}
+func (s *Server) listNetworkSetsHandler(w http.ResponseWriter, r *http.Request) {
+	scope := s.Namespaces
+	if namespace := r.URL.Query().Get("namespace"); namespace != "" {
+		if err := s.Namespaces.check(r.Context(), s.K8sClientSet, namespace); err != nil {
+			writeError(w, http.StatusForbidden, err)
+			return
+		}
+		scope = NamespaceScope{Names: []string{namespace}}
+	}
+	namespaces, err := scope.resolve(r.Context(), s.K8sClientSet)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sets, err := listNetworkSets(r.Context(), s.CalicoClientSet, namespaces)
+	switch {
+	case errors.Is(err, errCalicoAPIUnavailable):
+		writeError(w, http.StatusServiceUnavailable, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, sets)
+}
+
+// listNetworkSets lists the NetworkSets of namespaces, by namespace then name.
+func listNetworkSets(ctx context.Context, calico calicoclient.Interface, namespaces []string) ([]NetworkSetInfo, error) {
+	sets := []NetworkSetInfo{}
+	for _, namespace := range namespaces {
+		list, err := calico.ProjectcalicoV3().NetworkSets(namespace).List(ctx, metav1.ListOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil, errCalicoAPIUnavailable
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, set := range list.Items {
+			nets := set.Spec.Nets
+			if nets == nil {
+				nets = []string{}
+			}
+			sets = append(sets, NetworkSetInfo{Namespace: set.Namespace, Name: set.Name, Labels: set.Labels, Nets: nets})
+		}
+	}
+	sort.Slice(sets, func(i, j int) bool {
+		if sets[i].Namespace != sets[j].Namespace {
+			return sets[i].Namespace < sets[j].Namespace
+		}
+		return sets[i].Name < sets[j].Name
+	})
+	return sets, nil
+}
+
+// networkSetBackend is implemented by the backends whose policies can select a Calico NetworkSet as their peer.
+type networkSetBackend interface {
+	selectsNetworkSets() bool
+}
+
+// supportsNetworkSets tells whether the deny requests given to backend can reference a NetworkSet.
+func supportsNetworkSets(backend PolicyBackend) bool {
+	sets, ok := backend.(networkSetBackend)
+	return ok && sets.selectsNetworkSets()
+}
+
+// resolveNetworkSet replaces a workload referencing a NetworkSet with its labels, which Calico policy rules select
+// network sets by like they select pods, in its namespace.
+func resolveNetworkSet(ctx context.Context, calico calicoclient.Interface, workload DenyNetworkRequestWorkload) (DenyNetworkRequestWorkload, error) {
+	if workload.Name == "" || workload.Namespace == "" {
+		return workload, fmt.Errorf("%w: a name and a namespace are required", errInvalidReference)
+	}
+	if workload.selects() || workload.CIDR != "" {
+		return workload, fmt.Errorf("%w: NetworkSet %s sets labels or a CIDR too", errInvalidReference, workload.Name)
+	}
+
+	set, err := calico.ProjectcalicoV3().NetworkSets(workload.Namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return workload, fmt.Errorf("%w: NetworkSet %s/%s doesn't exist", errWorkloadNotFound, workload.Namespace, workload.Name)
+	}
+	if err != nil {
+		return workload, err
+	}
+	// Without labels the selector would match every pod of the namespace too
+	if len(set.Labels) == 0 {
+		return workload, fmt.Errorf("%w: NetworkSet %s/%s has no labels to select it by", errInvalidReference, workload.Namespace, workload.Name)
+	}
+	workload.Labels = set.Labels
+	return workload, nil
+}
+
+// hostEndpointBackend is implemented by the backends whose policies can select Calico HostEndpoints, as A or as the peer.
+type hostEndpointBackend interface {
+	selectsHostEndpoints() bool
+}
+
+// supportsHostEndpoints tells whether the deny requests given to backend can select host endpoints.
+func supportsHostEndpoints(backend PolicyBackend) bool {
+	endpoints, ok := backend.(hostEndpointBackend)
+	return ok && endpoints.selectsHostEndpoints()
+}
+
+// resolveHostEndpoint checks a workload selecting host endpoints matches at least one, replacing the name of a host
+// endpoint with its labels. Host endpoints aren't namespaced.
+func resolveHostEndpoint(ctx context.Context, calico calicoclient.Interface, workload DenyNetworkRequestWorkload) (DenyNetworkRequestWorkload, error) {
+	if workload.Namespace != "" || workload.CIDR != "" {
+		return workload, fmt.Errorf("%w: host endpoints have no namespace nor CIDR", errInvalidReference)
+	}
+	if workload.Name != "" {
+		if workload.selects() {
+			return workload, fmt.Errorf("%w: HostEndpoint %s sets labels too", errInvalidReference, workload.Name)
+		}
+		endpoint, err := calico.ProjectcalicoV3().HostEndpoints().Get(ctx, workload.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return workload, fmt.Errorf("%w: HostEndpoint %s doesn't exist", errWorkloadNotFound, workload.Name)
+		}
+		if err != nil {
+			return workload, err
+		}
+		if len(endpoint.Labels) == 0 {
+			return workload, fmt.Errorf("%w: HostEndpoint %s has no labels to select it by", errInvalidReference, workload.Name)
+		}
+		workload.Labels = endpoint.Labels
+		return workload, nil
+	}
+
+	// Without labels the policy would select every host endpoint, and every pod
+	if !workload.selects() {
+		return workload, fmt.Errorf("%w: host endpoints are selected by a name or labels", errInvalidReference)
+	}
+	if err := validateWorkloadSelector(workload); err != nil {
+		return workload, fmt.Errorf("%w: %v", errInvalidReference, err)
+	}
+	endpoints, err := calico.ProjectcalicoV3().HostEndpoints().List(ctx, metav1.ListOptions{LabelSelector: workload.selector().String(), Limit: 1})
+	if apierrors.IsNotFound(err) {
+		return workload, errCalicoAPIUnavailable
+	}
+	if err != nil {
+		return workload, err
+	}
+	if len(endpoints.Items) == 0 {
+		return workload, fmt.Errorf("%w: %s matches no HostEndpoint", errWorkloadNotFound, workload.describe())
+	}
+	return workload, nil
+}
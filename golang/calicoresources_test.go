@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	calicofake "github.com/projectcalico/api/pkg/client/clientset_generated/clientset/fake"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestListCalicoResources(t *testing.T) {
+	ctx := context.Background()
+	calico := calicofake.NewSimpleClientset(
+		&v3.IPPool{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-ipv4-ippool"},
+			Spec:       v3.IPPoolSpec{CIDR: "10.244.0.0/16", BlockSize: 26, VXLANMode: v3.VXLANModeAlways, NATOutgoing: true, AllowedUses: []v3.IPPoolAllowedUse{v3.IPPoolAllowedUseWorkload}},
+		},
+		&v3.IPPool{ObjectMeta: metav1.ObjectMeta{Name: "backup"}, Spec: v3.IPPoolSpec{CIDR: "10.245.0.0/16", Disabled: true}},
+		&v3.NetworkSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "known-bad", Namespace: "security", Labels: map[string]string{"feed": "known-bad"}},
+			Spec:       v3.NetworkSetSpec{Nets: []string{"198.51.100.0/24", "203.0.113.7/32"}},
+		},
+		&v3.NetworkSet{ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: "security"}},
+		&v3.NetworkSet{ObjectMeta: metav1.ObjectMeta{Name: "partners", Namespace: "shop"}},
+	)
+
+	pools, err := listIPPools(ctx, calico)
+	assert.NoError(t, err)
+	if assert.Len(t, pools, 2) {
+		assert.Equal(t, "backup", pools[0].Name)
+		assert.True(t, pools[0].Disabled)
+		assert.Equal(t, IPPoolInfo{
+			Name:        "default-ipv4-ippool",
+			CIDR:        "10.244.0.0/16",
+			BlockSize:   26,
+			VXLANMode:   "Always",
+			NATOutgoing: true,
+			AllowedUses: []string{"Workload"},
+		}, pools[1])
+	}
+
+	sets, err := listNetworkSets(ctx, calico, []string{"security"})
+	assert.NoError(t, err)
+	if assert.Len(t, sets, 2) {
+		assert.Equal(t, NetworkSetInfo{Namespace: "security", Name: "empty", Nets: []string{}}, sets[0])
+		assert.Equal(t, []string{"198.51.100.0/24", "203.0.113.7/32"}, sets[1].Nets)
+	}
+}
+
+func TestResolveNetworkSet(t *testing.T) {
+	ctx := context.Background()
+	calico := calicofake.NewSimpleClientset(
+		&v3.NetworkSet{ObjectMeta: metav1.ObjectMeta{Name: "known-bad", Namespace: "security", Labels: map[string]string{"feed": "known-bad"}}},
+		&v3.NetworkSet{ObjectMeta: metav1.ObjectMeta{Name: "unlabelled", Namespace: "security"}},
+	)
+
+	workload, err := resolveNetworkSet(ctx, calico, DenyNetworkRequestWorkload{Kind: workloadKindNetworkSet, Name: "known-bad", Namespace: "security"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"feed": "known-bad"}, workload.Labels)
+	assert.Equal(t, workloadKindNetworkSet, workload.Kind)
+
+	_, err = resolveNetworkSet(ctx, calico, DenyNetworkRequestWorkload{Kind: workloadKindNetworkSet, Name: "missing", Namespace: "security"})
+	assert.ErrorIs(t, err, errWorkloadNotFound)
+	_, err = resolveNetworkSet(ctx, calico, DenyNetworkRequestWorkload{Kind: workloadKindNetworkSet, Name: "unlabelled", Namespace: "security"})
+	assert.ErrorIs(t, err, errInvalidReference)
+	_, err = resolveNetworkSet(ctx, calico, DenyNetworkRequestWorkload{Kind: workloadKindNetworkSet, Name: "known-bad"})
+	assert.ErrorIs(t, err, errInvalidReference)
+
+	assert.True(t, supportsNetworkSets(&calicoBackend{}))
+	assert.False(t, supportsNetworkSets(&ciliumBackend{}))
+
+	// NetworkSets have no pods for a mirrored policy to select
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}},
+		B: workload,
+	}
+	_, ok := request.mirror()
+	assert.False(t, ok)
+}
+
+func TestResolveHostEndpoint(t *testing.T) {
+	ctx := context.Background()
+	calico := calicofake.NewSimpleClientset(
+		&v3.HostEndpoint{ObjectMeta: metav1.ObjectMeta{Name: "node-1-eth0", Labels: map[string]string{"kubernetes.io/hostname": "node-1", "role": "worker"}}},
+		&v3.HostEndpoint{ObjectMeta: metav1.ObjectMeta{Name: "unlabelled"}},
+	)
+
+	workload, err := resolveHostEndpoint(ctx, calico, DenyNetworkRequestWorkload{Kind: workloadKindHostEndpoint, Name: "node-1-eth0"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"kubernetes.io/hostname": "node-1", "role": "worker"}, workload.Labels)
+
+	_, err = resolveHostEndpoint(ctx, calico, DenyNetworkRequestWorkload{Kind: workloadKindHostEndpoint, Labels: map[string]string{"role": "worker"}})
+	assert.NoError(t, err)
+	_, err = resolveHostEndpoint(ctx, calico, DenyNetworkRequestWorkload{Kind: workloadKindHostEndpoint, Labels: map[string]string{"role": "control-plane"}})
+	assert.ErrorIs(t, err, errWorkloadNotFound)
+	_, err = resolveHostEndpoint(ctx, calico, DenyNetworkRequestWorkload{Kind: workloadKindHostEndpoint, Name: "missing"})
+	assert.ErrorIs(t, err, errWorkloadNotFound)
+
+	for _, workload := range []DenyNetworkRequestWorkload{
+		{Kind: workloadKindHostEndpoint},
+		{Kind: workloadKindHostEndpoint, Name: "unlabelled"},
+		{Kind: workloadKindHostEndpoint, Namespace: "kube-system", Labels: map[string]string{"role": "worker"}},
+		{Kind: workloadKindHostEndpoint, Name: "node-1-eth0", Labels: map[string]string{"role": "worker"}},
+	} {
+		_, err = resolveHostEndpoint(ctx, calico, workload)
+		assert.ErrorIs(t, err, errInvalidReference)
+	}
+
+	assert.True(t, supportsHostEndpoints(&calicoBackend{}))
+	assert.False(t, supportsHostEndpoints(&ciliumBackend{}))
+}
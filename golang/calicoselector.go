@@ -0,0 +1,326 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// calicoSelector is a parsed Calico selector expression that can be evaluated against endpoint labels.
+//
+// It supports the subset used by policies in practice: all(), has(k), ==, !=, in, not in, contains, starts with, ends with, !, && and ||.
+type calicoSelector interface {
+	Matches(labels map[string]string) bool
+}
+
+type selectorAll struct{}
+
+type selectorHas struct{ key string }
+
+type selectorNot struct{ inner calicoSelector }
+
+type selectorAnd struct{ left, right calicoSelector }
+
+type selectorOr struct{ left, right calicoSelector }
+
+type selectorCompare struct {
+	key   string
+	op    string
+	value string
+}
+
+type selectorIn struct {
+	key    string
+	values map[string]bool
+	negate bool
+}
+
+func (selectorAll) Matches(map[string]string) bool { return true }
+
+func (s selectorHas) Matches(labels map[string]string) bool {
+	_, ok := labels[s.key]
+	return ok
+}
+
+func (s selectorNot) Matches(labels map[string]string) bool { return !s.inner.Matches(labels) }
+
+func (s selectorAnd) Matches(labels map[string]string) bool {
+	return s.left.Matches(labels) && s.right.Matches(labels)
+}
+
+func (s selectorOr) Matches(labels map[string]string) bool {
+	return s.left.Matches(labels) || s.right.Matches(labels)
+}
+
+func (s selectorCompare) Matches(labels map[string]string) bool {
+	value, ok := labels[s.key]
+	switch s.op {
+	case "==":
+		return ok && value == s.value
+	case "!=":
+		return !ok || value != s.value
+	case "contains":
+		return ok && strings.Contains(value, s.value)
+	case "starts with":
+		return ok && strings.HasPrefix(value, s.value)
+	case "ends with":
+		return ok && strings.HasSuffix(value, s.value)
+	}
+	return false
+}
+
+func (s selectorIn) Matches(labels map[string]string) bool {
+	value, ok := labels[s.key]
+	if s.negate {
+		return !ok || !s.values[value]
+	}
+	return ok && s.values[value]
+}
+
+// parseCalicoSelector parses a Calico selector, an empty selector matches every endpoint.
+func parseCalicoSelector(selector string) (calicoSelector, error) {
+	tokens, err := tokenizeCalicoSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return selectorAll{}, nil
+	}
+
+	p := &selectorParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q in selector %q", p.peek().value, selector)
+	}
+	return expr, nil
+}
+
+type selectorTokenKind int
+
+const (
+	tokenIdent selectorTokenKind = iota
+	tokenString
+	tokenOperator
+)
+
+type selectorToken struct {
+	kind  selectorTokenKind
+	value string
+}
+
+func tokenizeCalicoSelector(selector string) ([]selectorToken, error) {
+	var tokens []selectorToken
+	runes := []rune(selector)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'' || r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated string in selector %q", selector)
+			}
+			tokens = append(tokens, selectorToken{kind: tokenString, value: string(runes[i+1 : end])})
+			i = end + 1
+		case strings.ContainsRune("(){},", r):
+			tokens = append(tokens, selectorToken{kind: tokenOperator, value: string(r)})
+			i++
+		case i+1 < len(runes) && isTwoCharSelectorOperator(string(runes[i:i+2])):
+			tokens = append(tokens, selectorToken{kind: tokenOperator, value: string(runes[i : i+2])})
+			i += 2
+		case r == '!':
+			tokens = append(tokens, selectorToken{kind: tokenOperator, value: "!"})
+			i++
+		case isSelectorIdentRune(r):
+			end := i
+			for end < len(runes) && isSelectorIdentRune(runes[end]) {
+				end++
+			}
+			tokens = append(tokens, selectorToken{kind: tokenIdent, value: string(runes[i:end])})
+			i = end
+		default:
+			return nil, fmt.Errorf("unexpected character %q in selector %q", r, selector)
+		}
+	}
+	return tokens, nil
+}
+
+func isTwoCharSelectorOperator(op string) bool {
+	return op == "&&" || op == "||" || op == "==" || op == "!="
+}
+
+func isSelectorIdentRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_./-", r)
+}
+
+type selectorParser struct {
+	tokens []selectorToken
+	pos    int
+}
+
+func (p *selectorParser) done() bool { return p.pos >= len(p.tokens) }
+
+func (p *selectorParser) peek() selectorToken {
+	if p.done() {
+		return selectorToken{kind: tokenOperator, value: "<end>"}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *selectorParser) next() selectorToken {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *selectorParser) accept(kind selectorTokenKind, value string) bool {
+	if token := p.peek(); !p.done() && token.kind == kind && token.value == value {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *selectorParser) expect(kind selectorTokenKind, value string) error {
+	if !p.accept(kind, value) {
+		return fmt.Errorf("expected %q, got %q", value, p.peek().value)
+	}
+	return nil
+}
+
+func (p *selectorParser) parseOr() (calicoSelector, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept(tokenOperator, "||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = selectorOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *selectorParser) parseAnd() (calicoSelector, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept(tokenOperator, "&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = selectorAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *selectorParser) parseUnary() (calicoSelector, error) {
+	if p.accept(tokenOperator, "!") {
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return selectorNot{inner: inner}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *selectorParser) parsePrimary() (calicoSelector, error) {
+	if p.accept(tokenOperator, "(") {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return expr, p.expect(tokenOperator, ")")
+	}
+
+	token := p.next()
+	if token.kind != tokenIdent {
+		return nil, fmt.Errorf("expected a label or function, got %q", token.value)
+	}
+
+	switch token.value {
+	case "all", "global":
+		if err := p.expect(tokenOperator, "("); err != nil {
+			return nil, err
+		}
+		return selectorAll{}, p.expect(tokenOperator, ")")
+	case "has":
+		if err := p.expect(tokenOperator, "("); err != nil {
+			return nil, err
+		}
+		key := p.next()
+		if key.kind != tokenIdent {
+			return nil, fmt.Errorf("expected a label in has(), got %q", key.value)
+		}
+		return selectorHas{key: key.value}, p.expect(tokenOperator, ")")
+	}
+
+	key := token.value
+	switch {
+	case p.accept(tokenOperator, "=="):
+		return p.parseCompare(key, "==")
+	case p.accept(tokenOperator, "!="):
+		return p.parseCompare(key, "!=")
+	case p.accept(tokenIdent, "contains"):
+		return p.parseCompare(key, "contains")
+	case p.accept(tokenIdent, "starts"):
+		if err := p.expect(tokenIdent, "with"); err != nil {
+			return nil, err
+		}
+		return p.parseCompare(key, "starts with")
+	case p.accept(tokenIdent, "ends"):
+		if err := p.expect(tokenIdent, "with"); err != nil {
+			return nil, err
+		}
+		return p.parseCompare(key, "ends with")
+	case p.accept(tokenIdent, "in"):
+		return p.parseIn(key, false)
+	case p.accept(tokenIdent, "not"):
+		if err := p.expect(tokenIdent, "in"); err != nil {
+			return nil, err
+		}
+		return p.parseIn(key, true)
+	}
+	return nil, fmt.Errorf("expected an operator after %q, got %q", key, p.peek().value)
+}
+
+func (p *selectorParser) parseCompare(key, op string) (calicoSelector, error) {
+	value := p.next()
+	if value.kind != tokenString {
+		return nil, fmt.Errorf("expected a quoted value after %s %s, got %q", key, op, value.value)
+	}
+	return selectorCompare{key: key, op: op, value: value.value}, nil
+}
+
+func (p *selectorParser) parseIn(key string, negate bool) (calicoSelector, error) {
+	if err := p.expect(tokenOperator, "{"); err != nil {
+		return nil, err
+	}
+
+	values := map[string]bool{}
+	for !p.accept(tokenOperator, "}") {
+		value := p.next()
+		if value.kind != tokenString {
+			return nil, fmt.Errorf("expected a quoted value in set, got %q", value.value)
+		}
+		values[value.value] = true
+
+		if !p.accept(tokenOperator, ",") && p.peek().value != "}" {
+			return nil, fmt.Errorf("expected ',' or '}' in set, got %q", p.peek().value)
+		}
+	}
+	return selectorIn{key: key, values: values, negate: negate}, nil
+}
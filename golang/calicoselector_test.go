@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCalicoSelector(t *testing.T) {
+	labels := map[string]string{"app": "ledger", "tier": "backend", "projectcalico.org/namespace": "payments"}
+
+	tests := []struct {
+		selector string
+		matches  bool
+	}{
+		{"", true},
+		{"all()", true},
+		{"app == 'ledger'", true},
+		{`app == "ledger" && tier == 'backend'`, true},
+		{"app == 'ledger' && tier == 'frontend'", false},
+		{"app == 'shop' || tier == 'backend'", true},
+		{"app != 'ledger'", false},
+		{"missing != 'x'", true},
+		{"has(tier)", true},
+		{"!has(tier)", false},
+		{"app in {'ledger', 'shop'}", true},
+		{"app not in {'ledger'}", false},
+		{"app starts with 'led' && app ends with 'ger' && app contains 'dg'", true},
+		{"projectcalico.org/namespace == 'payments'", true},
+		{"!(app == 'ledger' || app == 'shop')", false},
+	}
+
+	for _, test := range tests {
+		selector, err := parseCalicoSelector(test.selector)
+		if assert.NoError(t, err, test.selector) {
+			assert.Equal(t, test.matches, selector.Matches(labels), test.selector)
+		}
+	}
+
+	for _, invalid := range []string{"app ==", "app == ledger", "app == 'ledger", "has(app", "app in {'a' 'b'}", "app == 'a' &&", "(app == 'a'"} {
+		_, err := parseCalicoSelector(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// defaultCapacityThreshold is the percentage of its allocatable CPU or memory requested beyond which a node is flagged.
+const defaultCapacityThreshold = 85
+
+// ResourceCapacity is the allocatable amount of a resource and the requests and limits of the pods on it, in
+// millicores for CPU and bytes for memory.
+type ResourceCapacity struct {
+	Allocatable     int64   `json:"allocatable"`
+	Requests        int64   `json:"requests"`
+	Limits          int64   `json:"limits"`
+	RequestsPercent float64 `json:"requests_percent"`
+	LimitsPercent   float64 `json:"limits_percent"`
+	// Headroom is what's left to request, the allocatable amount minus the requests
+	Headroom int64 `json:"headroom"`
+}
+
+func (c *ResourceCapacity) add(other ResourceCapacity) {
+	c.Allocatable += other.Allocatable
+	c.Requests += other.Requests
+	c.Limits += other.Limits
+}
+
+// complete computes the percentages and headroom of the sums.
+func (c *ResourceCapacity) complete() {
+	c.Headroom = c.Allocatable - c.Requests
+	if c.Allocatable > 0 {
+		c.RequestsPercent = float64(c.Requests) * 100 / float64(c.Allocatable)
+		c.LimitsPercent = float64(c.Limits) * 100 / float64(c.Allocatable)
+	}
+}
+
+type NodeCapacity struct {
+	Name          string           `json:"node_name"`
+	Unschedulable bool             `json:"unschedulable"`
+	CPU           ResourceCapacity `json:"cpu"`
+	Memory        ResourceCapacity `json:"memory"`
+	Pods          int              `json:"pods"`
+	// OverThreshold is set when the requests of CPU or memory exceed the threshold percentage of the allocatable amount
+	OverThreshold bool `json:"over_threshold"`
+}
+
+type CapacityReport struct {
+	ThresholdPercent float64 `json:"threshold_percent"`
+	// CPU and Memory sum those of the schedulable nodes, cordoned ones not taking new pods
+	CPU    ResourceCapacity `json:"cpu"`
+	Memory ResourceCapacity `json:"memory"`
+	Nodes  []NodeCapacity   `json:"nodes"`
+}
+
+// Reports the allocatable CPU and memory of the nodes whose labels match ?labelSelector=, and the requests and limits of
+// their pods, flagging the nodes requested beyond ?threshold= percent, --capacity-threshold by default
+func (s *Server) capacityHandler(w http.ResponseWriter, r *http.Request) {
+	selector := labels.Everything()
+	if value := r.URL.Query().Get("labelSelector"); value != "" {
+		parsed, err := labels.Parse(value)
+		if err != nil {
+			writeErrorf(w, http.StatusBadRequest, "invalid labelSelector: %v", err)
+			return
+		}
+		selector = parsed
+	}
+	threshold := s.CapacityThreshold
+	if value := r.URL.Query().Get("threshold"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed <= 0 || parsed > 100 {
+			writeErrorf(w, http.StatusBadRequest, "invalid threshold %q, expected a percentage", value)
+			return
+		}
+		threshold = parsed
+	}
+
+	report, err := getCapacity(r.Context(), s.K8sClientSet, selector, threshold)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// getCapacity sums the requests and limits of the pods which haven't terminated on each node whose labels match
+// selector, and cluster-wide.
+func getCapacity(ctx context.Context, clientset kubernetes.Interface, selector labels.Selector, threshold float64) (*CapacityReport, error) {
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{FieldSelector: "status.phase!=Succeeded,status.phase!=Failed"})
+	if err != nil {
+		return nil, err
+	}
+
+	capacities := make(map[string]*NodeCapacity, len(nodes.Items))
+	for _, node := range nodes.Items {
+		capacities[node.Name] = &NodeCapacity{
+			Name:          node.Name,
+			Unschedulable: node.Spec.Unschedulable,
+			CPU:           ResourceCapacity{Allocatable: node.Status.Allocatable.Cpu().MilliValue()},
+			Memory:        ResourceCapacity{Allocatable: node.Status.Allocatable.Memory().Value()},
+		}
+	}
+	for _, pod := range pods.Items {
+		capacity, ok := capacities[pod.Spec.NodeName]
+		if !ok || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		requests, limits := podResources(pod)
+		capacity.CPU.Requests += requests.Cpu().MilliValue()
+		capacity.CPU.Limits += limits.Cpu().MilliValue()
+		capacity.Memory.Requests += requests.Memory().Value()
+		capacity.Memory.Limits += limits.Memory().Value()
+		capacity.Pods++
+	}
+
+	report := &CapacityReport{ThresholdPercent: threshold, Nodes: make([]NodeCapacity, 0, len(capacities))}
+	for _, capacity := range capacities {
+		capacity.CPU.complete()
+		capacity.Memory.complete()
+		capacity.OverThreshold = capacity.CPU.RequestsPercent > threshold || capacity.Memory.RequestsPercent > threshold
+		if !capacity.Unschedulable {
+			report.CPU.add(capacity.CPU)
+			report.Memory.add(capacity.Memory)
+		}
+		report.Nodes = append(report.Nodes, *capacity)
+	}
+	report.CPU.complete()
+	report.Memory.complete()
+	sort.Slice(report.Nodes, func(i, j int) bool { return report.Nodes[i].Name < report.Nodes[j].Name })
+	return report, nil
+}
+
+// podResources returns the requests and limits the scheduler accounts a pod for: those of its containers, or of its
+// largest init container when that's more, plus its overhead.
+func podResources(pod corev1.Pod) (requests, limits corev1.ResourceList) {
+	requests, limits = corev1.ResourceList{}, corev1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		addResources(requests, container.Resources.Requests)
+		addResources(limits, container.Resources.Limits)
+	}
+	for _, container := range pod.Spec.InitContainers {
+		maxResources(requests, container.Resources.Requests)
+		maxResources(limits, container.Resources.Limits)
+	}
+	addResources(requests, pod.Spec.Overhead)
+	addResources(limits, pod.Spec.Overhead)
+	return requests, limits
+}
+
+func addResources(total, list corev1.ResourceList) {
+	for name, quantity := range list {
+		sum := total[name]
+		sum.Add(quantity)
+		total[name] = sum
+	}
+}
+
+func maxResources(total, list corev1.ResourceList) {
+	for name, quantity := range list {
+		if current, ok := total[name]; !ok || quantity.Cmp(current) > 0 {
+			total[name] = quantity.DeepCopy()
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetCapacity(t *testing.T) {
+	resources := func(cpu, memory string) corev1.ResourceList {
+		return corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu), corev1.ResourceMemory: resource.MustParse(memory)}
+	}
+	node := func(name string, cordoned bool) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       corev1.NodeSpec{Unschedulable: cordoned},
+			Status:     corev1.NodeStatus{Allocatable: resources("4", "8Gi")},
+		}
+	}
+	pod := func(name, node string, phase corev1.PodPhase, requests, limits corev1.ResourceList) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "payments"},
+			Spec: corev1.PodSpec{NodeName: node, Containers: []corev1.Container{
+				{Name: "app", Resources: corev1.ResourceRequirements{Requests: requests, Limits: limits}},
+				{Name: "sidecar", Resources: corev1.ResourceRequirements{Requests: resources("100m", "128Mi")}},
+			}},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+	withInit := pod("migrate", "node-a", corev1.PodRunning, resources("400m", "1Gi"), nil)
+	withInit.Spec.InitContainers = []corev1.Container{{Name: "init", Resources: corev1.ResourceRequirements{Requests: resources("2", "256Mi")}}}
+	clientset := fake.NewSimpleClientset(
+		node("node-a", false),
+		node("node-b", true),
+		pod("api-1", "node-a", corev1.PodRunning, resources("1400m", "5Gi"), resources("2", "6Gi")),
+		withInit,
+		pod("api-2", "node-b", corev1.PodPending, resources("1", "1Gi"), nil),
+		pod("report", "node-b", corev1.PodSucceeded, resources("3", "6Gi"), nil),
+	)
+
+	report, err := getCapacity(context.Background(), clientset, labels.Everything(), 85)
+	assert.NoError(t, err)
+	if !assert.Len(t, report.Nodes, 2) {
+		return
+	}
+
+	a := report.Nodes[0]
+	assert.Equal(t, "node-a", a.Name)
+	assert.Equal(t, 2, a.Pods)
+	// 1400m + 100m for api-1, the 2 cores of the init container of migrate being more than its 500m
+	assert.Equal(t, int64(3500), a.CPU.Requests)
+	assert.Equal(t, int64(2000), a.CPU.Limits)
+	assert.InDelta(t, 87.5, a.CPU.RequestsPercent, 0.001)
+	assert.Equal(t, int64(500), a.CPU.Headroom)
+	assert.Equal(t, int64(5*1024+128+1024+128)<<20, a.Memory.Requests)
+	assert.True(t, a.OverThreshold)
+
+	b := report.Nodes[1]
+	assert.True(t, b.Unschedulable)
+	assert.Equal(t, 1, b.Pods)
+	assert.Equal(t, int64(1100), b.CPU.Requests)
+	assert.False(t, b.OverThreshold)
+
+	// The cordoned node is left out of the cluster-wide sums
+	assert.Equal(t, a.CPU.Allocatable, report.CPU.Allocatable)
+	assert.Equal(t, a.CPU.Requests, report.CPU.Requests)
+	assert.Equal(t, float64(85), report.ThresholdPercent)
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+// ciliumNamespaceLabel is the label Cilium gives every endpoint with the name of its namespace.
+const ciliumNamespaceLabel = "k8s:io.kubernetes.pod.namespace"
+
+var (
+	ciliumPolicyResource            = schema.GroupVersionResource{Group: "cilium.io", Version: "v2", Resource: "ciliumnetworkpolicies"}
+	ciliumClusterwidePolicyResource = schema.GroupVersionResource{Group: "cilium.io", Version: "v2", Resource: "ciliumclusterwidenetworkpolicies"}
+)
+
+// ciliumBackend denies traffic with CiliumNetworkPolicies, through the dynamic client as Cilium's own is a heavy dependency.
+//
+// Global requests get a CiliumClusterwideNetworkPolicy, which has the same spec.
+type ciliumBackend struct {
+	client dynamic.Interface
+}
+
+func newCiliumBackend(config *rest.Config, _ policyBackendOptions) (PolicyBackend, error) {
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &ciliumBackend{client: client}, nil
+}
+
+func (b *ciliumBackend) Name() string {
+	return "cilium"
+}
+
+// Render selects B by its CIDR, or by its pod labels and the namespace label Cilium adds to every endpoint rather than by namespace labels.
+func (b *ciliumBackend) Render(request DenyNetworkRequest, peerNamespace *corev1.Namespace, meta RequestMetadata) PolicyObject {
+	from := map[string]interface{}{"fromCIDR": []interface{}{request.B.CIDR}}
+	to := map[string]interface{}{"toCIDR": []interface{}{request.B.CIDR}}
+	if request.B.CIDR == "" {
+		peer := map[string]interface{}{}
+		if request.B.Namespace != "" {
+			peer[ciliumNamespaceLabel] = request.B.Namespace
+		}
+		for key, value := range request.B.Labels {
+			peer[key] = value
+		}
+		endpoints := ciliumEndpointSelector(peer, request.B.MatchExpressions)
+		from = map[string]interface{}{"fromEndpoints": []interface{}{endpoints}}
+		to = map[string]interface{}{"toEndpoints": []interface{}{runtime.DeepCopyJSONValue(endpoints)}}
+	}
+	spec := map[string]interface{}{
+		"endpointSelector": ciliumEndpointSelector(stringMap(request.A.Labels), request.A.MatchExpressions),
+		"ingressDeny":      []interface{}{from},
+		"egressDeny":       []interface{}{to},
+		// Without it, a policy selecting a workload drops all the traffic not explicitly allowed
+		"enableDefaultDeny": map[string]interface{}{"ingress": false, "egress": false},
+	}
+
+	managedLabels, annotations := managedObjectMeta(actionDeny, request.B.Namespace, meta, request)
+	annotations[specHashAnnotation] = specHash(spec)
+
+	kind := "CiliumNetworkPolicy"
+	if request.global() {
+		kind = "CiliumClusterwideNetworkPolicy"
+	}
+	policy := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	policy.SetGroupVersionKind(ciliumPolicyResource.GroupVersion().WithKind(kind))
+	policy.SetName(denyPolicyName(request))
+	policy.SetNamespace(request.A.Namespace)
+	policy.SetLabels(managedLabels)
+	policy.SetAnnotations(annotations)
+	return policy
+}
+
+// renderQuarantine selects every endpoint of the namespace and only allows the exceptions, Cilium denying the rest by default.
+//
+// Cilium allows the traffic any policy allows: the other policies selecting the pods of the namespace still apply.
+func (b *ciliumBackend) renderQuarantine(request NamespaceIsolationRequest, services []DenyNetworkRequestWorkload, meta RequestMetadata) PolicyObject {
+	ingress, egress := []interface{}{}, []interface{}{}
+	if request.AllowDNS {
+		egress = append(egress, map[string]interface{}{
+			"toEndpoints": []interface{}{ciliumEndpointSelector(map[string]interface{}{ciliumNamespaceLabel: kubeSystemNamespace, kubeDNSLabel: kubeDNSValue}, nil)},
+			"toPorts": []interface{}{map[string]interface{}{"ports": []interface{}{
+				map[string]interface{}{"port": fmt.Sprint(dnsPort), "protocol": "ANY"},
+			}}},
+		})
+	}
+	var peers []map[string]interface{}
+	if request.AllowKubeSystem {
+		peers = append(peers, ciliumEndpointSelector(map[string]interface{}{ciliumNamespaceLabel: kubeSystemNamespace}, nil))
+	}
+	for _, service := range services {
+		labels := stringMap(service.Labels)
+		labels[ciliumNamespaceLabel] = service.Namespace
+		peers = append(peers, ciliumEndpointSelector(labels, service.MatchExpressions))
+	}
+	for _, peer := range peers {
+		ingress = append(ingress, map[string]interface{}{"fromEndpoints": []interface{}{peer}})
+		egress = append(egress, map[string]interface{}{"toEndpoints": []interface{}{runtime.DeepCopyJSONValue(peer)}})
+	}
+	// An empty rule allows nothing, but still has the direction denied by default
+	for _, rules := range []*[]interface{}{&ingress, &egress} {
+		if len(*rules) == 0 {
+			*rules = append(*rules, map[string]interface{}{})
+		}
+	}
+	spec := map[string]interface{}{
+		"endpointSelector": map[string]interface{}{},
+		"ingress":          ingress,
+		"egress":           egress,
+	}
+
+	managedLabels, annotations := managedObjectMeta(actionQuarantine, "", meta, request)
+	annotations[specHashAnnotation] = specHash(spec)
+
+	policy := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	policy.SetGroupVersionKind(ciliumPolicyResource.GroupVersion().WithKind("CiliumNetworkPolicy"))
+	policy.SetName(quarantinePolicyName)
+	policy.SetNamespace(request.Namespace)
+	policy.SetLabels(managedLabels)
+	policy.SetAnnotations(annotations)
+	return policy
+}
+
+func (b *ciliumBackend) Create(ctx context.Context, policy PolicyObject, dryRun bool) (PolicyObject, error) {
+	object, err := ciliumNetworkPolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	options := metav1.CreateOptions{}
+	if dryRun {
+		options.DryRun = []string{metav1.DryRunAll}
+	}
+	return b.resource(object.GetNamespace()).Create(ctx, object, options)
+}
+
+func (b *ciliumBackend) Get(ctx context.Context, namespace, name string) (PolicyObject, error) {
+	return b.resource(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (b *ciliumBackend) List(ctx context.Context, namespace string) ([]PolicyObject, error) {
+	resources := []dynamic.ResourceInterface{b.client.Resource(ciliumPolicyResource).Namespace(namespace)}
+	if namespace == metav1.NamespaceAll {
+		resources = append(resources, b.client.Resource(ciliumClusterwidePolicyResource))
+	}
+
+	var policies []PolicyObject
+	for _, resource := range resources {
+		list, err := resource.List(ctx, metav1.ListOptions{LabelSelector: managedObjectSelector})
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			policies = append(policies, &list.Items[i])
+		}
+	}
+	return policies, nil
+}
+
+func (b *ciliumBackend) Delete(ctx context.Context, policy PolicyObject) error {
+	uid := policy.GetUID()
+	return b.resource(policy.GetNamespace()).Delete(ctx, policy.GetName(), metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &uid},
+	})
+}
+
+// resource returns the namespaced policies of namespace, or the clusterwide ones when it's empty.
+func (b *ciliumBackend) resource(namespace string) dynamic.ResourceInterface {
+	if namespace == "" {
+		return b.client.Resource(ciliumClusterwidePolicyResource)
+	}
+	return b.client.Resource(ciliumPolicyResource).Namespace(namespace)
+}
+
+func (b *ciliumBackend) Resources(global bool) []schema.GroupResource {
+	if global {
+		return []schema.GroupResource{ciliumClusterwidePolicyResource.GroupResource()}
+	}
+	return []schema.GroupResource{ciliumPolicyResource.GroupResource()}
+}
+
+// Selectors renders the label selectors in the Kubernetes syntax, the peer namespace being split out of the peer selector.
+func (b *ciliumBackend) Selectors(policy PolicyObject) PolicySelectors {
+	object, err := ciliumNetworkPolicy(policy)
+	if err != nil {
+		return PolicySelectors{}
+	}
+
+	selector, _, _ := unstructured.NestedMap(object.Object, "spec", "endpointSelector")
+	selectors := PolicySelectors{Selector: ciliumSelectorString(selector)}
+
+	rules, _, _ := unstructured.NestedSlice(object.Object, "spec", "ingressDeny")
+	if len(rules) == 0 {
+		return selectors
+	}
+	rule, _ := rules[0].(map[string]interface{})
+	if cidrs, _, _ := unstructured.NestedStringSlice(rule, "fromCIDR"); len(cidrs) > 0 {
+		selectors.PeerCIDR = cidrs[0]
+		return selectors
+	}
+
+	if endpoints, _, _ := unstructured.NestedSlice(rule, "fromEndpoints"); len(endpoints) > 0 {
+		if endpoint, ok := endpoints[0].(map[string]interface{}); ok {
+			if namespace, ok, _ := unstructured.NestedString(endpoint, "matchLabels", ciliumNamespaceLabel); ok {
+				selectors.NamespaceSelector = labels.Set{ciliumNamespaceLabel: namespace}.String()
+			}
+			selectors.PeerSelector = ciliumSelectorString(endpoint)
+		}
+	}
+	return selectors
+}
+
+// ciliumEndpointSelector builds an endpoint selector, Cilium taking the matchLabels and matchExpressions of a Kubernetes selector.
+func ciliumEndpointSelector(matchLabels map[string]interface{}, expressions []metav1.LabelSelectorRequirement) map[string]interface{} {
+	selector := map[string]interface{}{"matchLabels": matchLabels}
+	if len(expressions) == 0 {
+		return selector
+	}
+
+	matchExpressions := make([]interface{}, 0, len(expressions))
+	for _, expression := range expressions {
+		requirement := map[string]interface{}{"key": expression.Key, "operator": string(expression.Operator)}
+		if len(expression.Values) > 0 {
+			values := make([]interface{}, 0, len(expression.Values))
+			for _, value := range expression.Values {
+				values = append(values, value)
+			}
+			requirement["values"] = values
+		}
+		matchExpressions = append(matchExpressions, requirement)
+	}
+	selector["matchExpressions"] = matchExpressions
+	return selector
+}
+
+// ciliumSelectorString renders an endpoint selector in the Kubernetes syntax, without the namespace label of the peer.
+func ciliumSelectorString(endpoints map[string]interface{}) string {
+	var selector metav1.LabelSelector
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(endpoints, &selector); err != nil {
+		return ""
+	}
+	delete(selector.MatchLabels, ciliumNamespaceLabel)
+	if len(selector.MatchExpressions) == 0 {
+		return labels.Set(selector.MatchLabels).String()
+	}
+	parsed, err := metav1.LabelSelectorAsSelector(&selector)
+	if err != nil {
+		return ""
+	}
+	return parsed.String()
+}
+
+func ciliumNetworkPolicy(policy PolicyObject) (*unstructured.Unstructured, error) {
+	object, ok := policy.(*unstructured.Unstructured)
+	if !ok || object.GetKind() != "CiliumNetworkPolicy" && object.GetKind() != "CiliumClusterwideNetworkPolicy" {
+		return nil, fmt.Errorf("expected a CiliumNetworkPolicy or CiliumClusterwideNetworkPolicy, got %T %s", policy, policy.GetObjectKind().GroupVersionKind().Kind)
+	}
+	return object, nil
+}
+
+func stringMap(m map[string]string) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		result[key] = value
+	}
+	return result
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func newFakeCiliumBackend() *ciliumBackend {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		ciliumPolicyResource:            "CiliumNetworkPolicyList",
+		ciliumClusterwidePolicyResource: "CiliumClusterwideNetworkPolicyList",
+	})
+	return &ciliumBackend{client: client}
+}
+
+func TestCiliumBackendRender(t *testing.T) {
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}},
+	}
+	policy := (&ciliumBackend{}).Render(request, &corev1.Namespace{}, RequestMetadata{Principal: "alice"})
+	object := policy.(*unstructured.Unstructured)
+
+	assert.Equal(t, "cilium.io/v2", object.GetAPIVersion())
+	assert.Equal(t, "CiliumNetworkPolicy", object.GetKind())
+	assert.Equal(t, denyPolicyName(request), object.GetName())
+	assert.Equal(t, "payments", object.GetNamespace())
+	assert.Equal(t, managedByValue, object.GetLabels()[managedByLabel])
+	assert.Equal(t, "alice", object.GetAnnotations()[createdByAnnotation])
+
+	selector, _, _ := unstructured.NestedStringMap(object.Object, "spec", "endpointSelector", "matchLabels")
+	assert.Equal(t, map[string]string{"app": "ledger"}, selector)
+	egress, _, _ := unstructured.NestedSlice(object.Object, "spec", "egressDeny")
+	assert.Equal(t, []interface{}{map[string]interface{}{"toEndpoints": []interface{}{map[string]interface{}{
+		"matchLabels": map[string]interface{}{"app": "cart", ciliumNamespaceLabel: "shop"},
+	}}}}, egress)
+}
+
+func TestCiliumBackendLifecycle(t *testing.T) {
+	ctx := context.Background()
+	backend := newFakeCiliumBackend()
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger", "tier": "backend"}},
+		B: DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}},
+	}
+
+	created, err := backend.Create(ctx, backend.Render(request, &corev1.Namespace{}, RequestMetadata{}), false)
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = backend.Create(ctx, backend.Render(request, &corev1.Namespace{}, RequestMetadata{}), false)
+	assert.True(t, apierrors.IsAlreadyExists(err))
+
+	policies, err := backend.List(ctx, "payments")
+	assert.NoError(t, err)
+	if assert.Len(t, policies, 1) {
+		assert.Equal(t, created.GetName(), policies[0].GetName())
+
+		assert.Equal(t, PolicySelectors{
+			Selector:          "app=ledger,tier=backend",
+			PeerSelector:      "app=cart",
+			NamespaceSelector: ciliumNamespaceLabel + "=shop",
+		}, backend.Selectors(policies[0]))
+	}
+
+	assert.NoError(t, backend.Delete(ctx, created))
+	_, err = backend.Get(ctx, "payments", created.GetName())
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestCiliumBackendMatchExpressions(t *testing.T) {
+	backend := &ciliumBackend{}
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{Namespace: "shop", MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"frontend", "edge"}},
+		}},
+	}
+	policy := backend.Render(request, &corev1.Namespace{}, RequestMetadata{})
+
+	egress, _, _ := unstructured.NestedSlice(policy.(*unstructured.Unstructured).Object, "spec", "egressDeny")
+	assert.Equal(t, []interface{}{map[string]interface{}{"toEndpoints": []interface{}{map[string]interface{}{
+		"matchLabels":      map[string]interface{}{ciliumNamespaceLabel: "shop"},
+		"matchExpressions": []interface{}{map[string]interface{}{"key": "tier", "operator": "In", "values": []interface{}{"frontend", "edge"}}},
+	}}}}, egress)
+	assert.Equal(t, PolicySelectors{
+		Selector:          "app=ledger",
+		PeerSelector:      "tier in (edge,frontend)",
+		NamespaceSelector: ciliumNamespaceLabel + "=shop",
+	}, backend.Selectors(policy))
+}
+
+func TestCiliumBackendCIDR(t *testing.T) {
+	backend := &ciliumBackend{}
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{CIDR: "10.12.0.0/16"},
+	}
+	policy := backend.Render(request, nil, RequestMetadata{})
+
+	egress, _, _ := unstructured.NestedSlice(policy.(*unstructured.Unstructured).Object, "spec", "egressDeny")
+	assert.Equal(t, []interface{}{map[string]interface{}{"toCIDR": []interface{}{"10.12.0.0/16"}}}, egress)
+	assert.Equal(t, PolicySelectors{Selector: "app=ledger", PeerCIDR: "10.12.0.0/16"}, backend.Selectors(policy))
+}
+
+func TestCiliumBackendGlobal(t *testing.T) {
+	ctx := context.Background()
+	backend := newFakeCiliumBackend()
+	request := DenyNetworkRequest{
+		A:     DenyNetworkRequestWorkload{Labels: map[string]string{"app": "ledger"}},
+		B:     DenyNetworkRequestWorkload{Labels: map[string]string{"app": "cart"}},
+		Scope: policyScopeGlobal,
+	}
+
+	created, err := backend.Create(ctx, backend.Render(request, nil, RequestMetadata{}), false)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "CiliumClusterwideNetworkPolicy", created.GetObjectKind().GroupVersionKind().Kind)
+	assert.Equal(t, PolicySelectors{Selector: "app=ledger", PeerSelector: "app=cart"}, backend.Selectors(created))
+
+	policies, err := backend.List(ctx, metav1.NamespaceAll)
+	assert.NoError(t, err)
+	assert.Len(t, policies, 1)
+	policies, err = backend.List(ctx, "payments")
+	assert.NoError(t, err)
+	assert.Empty(t, policies)
+
+	assert.NoError(t, backend.Delete(ctx, created))
+	_, err = backend.Get(ctx, "", created.GetName())
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestCiliumBackendRenderQuarantine(t *testing.T) {
+	policy := (&ciliumBackend{}).renderQuarantine(NamespaceIsolationRequest{Namespace: "payments", AllowKubeSystem: true}, nil, RequestMetadata{}).(*unstructured.Unstructured)
+
+	assert.Equal(t, quarantinePolicyName, policy.GetName())
+	assert.Equal(t, "payments", policy.GetNamespace())
+	assert.Equal(t, actionQuarantine, policy.GetAnnotations()[actionAnnotation])
+
+	selector, _, _ := unstructured.NestedMap(policy.Object, "spec", "endpointSelector")
+	assert.Empty(t, selector)
+	kubeSystem := map[string]interface{}{"matchLabels": map[string]interface{}{ciliumNamespaceLabel: "kube-system"}}
+	ingress, _, _ := unstructured.NestedSlice(policy.Object, "spec", "ingress")
+	assert.Equal(t, []interface{}{map[string]interface{}{"fromEndpoints": []interface{}{kubeSystem}}}, ingress)
+	egress, _, _ := unstructured.NestedSlice(policy.Object, "spec", "egress")
+	assert.Equal(t, []interface{}{map[string]interface{}{"toEndpoints": []interface{}{kubeSystem}}}, egress)
+
+	// Without exceptions, the empty rules still deny both directions
+	policy = (&ciliumBackend{}).renderQuarantine(NamespaceIsolationRequest{Namespace: "payments"}, nil, RequestMetadata{}).(*unstructured.Unstructured)
+	ingress, _, _ = unstructured.NestedSlice(policy.Object, "spec", "ingress")
+	assert.Equal(t, []interface{}{map[string]interface{}{}}, ingress)
+}
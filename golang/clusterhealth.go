@@ -0,0 +1,225 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Statuses of the cluster and of its health checks, from the best to the worst
+const (
+	healthHealthy  = "healthy"
+	healthDegraded = "degraded"
+	healthCritical = "critical"
+)
+
+const (
+	// maxHealthIssues is how many issues a health check lists, the others only being counted.
+	maxHealthIssues = 10
+	// healthEventsWindow is how far back the events check looks for warning events.
+	healthEventsWindow = 15 * time.Minute
+	// Score lost for each degraded and critical check
+	degradedPenalty = 10
+	criticalPenalty = 30
+)
+
+// HealthIssue is a problem a health check found, with the object it's about.
+type HealthIssue struct {
+	Object  string `json:"object"`
+	Problem string `json:"problem"`
+}
+
+type HealthCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	// IssueCount counts every issue, Issues listing the first maxHealthIssues
+	IssueCount int           `json:"issue_count"`
+	Issues     []HealthIssue `json:"issues,omitempty"`
+	// Error is why the check couldn't run, which makes it degraded
+	Error string `json:"error,omitempty"`
+}
+
+type ClusterHealthSummary struct {
+	// Status is the worst status of the checks
+	Status string `json:"status"`
+	// Score is 100 minus 10 for each degraded check and 30 for each critical one, down to 0
+	Score     int           `json:"score"`
+	Checks    []HealthCheck `json:"checks"`
+	CheckedAt time.Time     `json:"checked_at"`
+}
+
+// Reports the overall health of the cluster, healthy, degraded or critical, from the checks of the deployments, nodes,
+// pods and recent warning events, with the issues each found
+//
+// ?namespace= and ?excludeNamespaces= restrict the checks of namespaced objects like the deployments of
+// clusterDeploymentsInfoHandler, the nodes being checked whatever they are.
+func (s *Server) clusterHealthHandler(w http.ResponseWriter, r *http.Request) {
+	filter, status, err := s.parseReportFilter(r)
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+	ctx, now := r.Context(), time.Now()
+	excluded := filter.excludedNamespaces(s.ExcludedNamespaces)
+	prometheus := s.Prometheus
+	if !s.Features.Enabled(featurePrometheusChecks) {
+		prometheus = nil
+	}
+
+	deployments, err := s.workloadsHealth(ctx, filter.Scope, prometheus, labels.Everything(), filter.Selector, deploymentsPage{})
+	if err == nil {
+		deployments.excludeNamespaces(excluded)
+	}
+	checks := []HealthCheck{deploymentsCheck(deployments, err)}
+
+	nodes, err := getNodesHealth(ctx, s.K8sClientSet, labels.Everything())
+	checks = append(checks, nodesCheck(nodes, err))
+
+	pods, err := getProblemPods(ctx, s.K8sClientSet, filter.Scope, filter.Selector, problemPodsOptions{RestartThreshold: defaultRestartThreshold, OOMWindow: defaultOOMWindow}, now)
+	if err == nil {
+		pods.excludeNamespaces(excluded)
+	}
+	checks = append(checks, podsCheck(pods, err))
+
+	events, err := getWarningEvents(ctx, s.K8sClientSet, filter.Scope, nil, now.Add(-healthEventsWindow))
+	if err == nil {
+		events.Events = slices.DeleteFunc(events.Events, func(event NamespacedWarningEvent) bool { return slices.Contains(excluded, event.Namespace) })
+	}
+	checks = append(checks, eventsCheck(events, err))
+
+	writeJSON(w, http.StatusOK, newClusterHealth(checks, now))
+}
+
+// newClusterHealth sums the checks up into the status and score of the cluster.
+func newClusterHealth(checks []HealthCheck, now time.Time) *ClusterHealthSummary {
+	health := &ClusterHealthSummary{Status: healthHealthy, Score: 100, Checks: checks, CheckedAt: now}
+	for _, check := range checks {
+		switch check.Status {
+		case healthCritical:
+			health.Score -= criticalPenalty
+		case healthDegraded:
+			health.Score -= degradedPenalty
+		}
+		if healthRank(check.Status) > healthRank(health.Status) {
+			health.Status = check.Status
+		}
+	}
+	health.Score = max(health.Score, 0)
+	return health
+}
+
+func healthRank(status string) int {
+	return slices.Index([]string{healthHealthy, healthDegraded, healthCritical}, status)
+}
+
+// newHealthCheck returns the check of name, degraded when it has issues or err isn't nil.
+func newHealthCheck(name string, issues []HealthIssue, err error) HealthCheck {
+	check := HealthCheck{Name: name, Status: healthHealthy, IssueCount: len(issues), Issues: issues[:min(len(issues), maxHealthIssues)]}
+	if len(issues) > 0 || err != nil {
+		check.Status = healthDegraded
+	}
+	if err != nil {
+		check.Error = err.Error()
+	}
+	return check
+}
+
+// deploymentsCheck is degraded by failed deployments and stuck rollouts, and critical when a deployment has no
+// available replica left.
+func deploymentsCheck(info *ClusterDeploymentsInfo, err error) HealthCheck {
+	if err != nil {
+		return newHealthCheck("deployments", nil, err)
+	}
+	var issues []HealthIssue
+	outage := false
+	add := func(deployments []DeploymentInfo, problem string) {
+		for _, deployment := range deployments {
+			current := HealthIssue{Object: "deployment/" + deployment.Namespace + "/" + deployment.Name, Problem: problem}
+			if deployment.RequestedPods > 0 && deployment.AvailableReplicas == 0 {
+				current.Problem, outage = "no available replica", true
+			}
+			issues = append(issues, current)
+		}
+	}
+	add(info.StuckRollouts, "stuck rollout")
+	add(info.FailedDeployments, "failed")
+	// The outages first, so they're listed whatever the number of issues
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Problem == "no available replica" && issues[j].Problem != "no available replica"
+	})
+
+	check := newHealthCheck("deployments", issues, nil)
+	if outage {
+		check.Status = healthCritical
+	}
+	return check
+}
+
+// nodesCheck is degraded by unhealthy nodes, and critical when at least half of them are unhealthy.
+func nodesCheck(info *ClusterNodesInfo, err error) HealthCheck {
+	if err != nil {
+		return newHealthCheck("nodes", nil, err)
+	}
+	var issues []HealthIssue
+	for _, node := range info.UnhealthyNodes {
+		problem := "not ready"
+		if node.Ready {
+			problem = "under " + strings.Join(node.Pressure, ", ")
+		}
+		issues = append(issues, HealthIssue{Object: "node/" + node.Name, Problem: problem})
+	}
+
+	check := newHealthCheck("nodes", issues, nil)
+	if len(info.UnhealthyNodes) > 0 && len(info.UnhealthyNodes) >= len(info.ReadyNodes) {
+		check.Status = healthCritical
+	}
+	return check
+}
+
+// podsCheck is degraded by problem pods.
+func podsCheck(report *ProblemPodsReport, err error) HealthCheck {
+	if err != nil {
+		return newHealthCheck("pods", nil, err)
+	}
+	var issues []HealthIssue
+	for _, namespace := range report.Namespaces {
+		for _, owner := range namespace.Owners {
+			for _, pod := range owner.Pods {
+				issues = append(issues, HealthIssue{Object: "pod/" + namespace.Namespace + "/" + pod.Name, Problem: pod.Problems[0].Type})
+			}
+		}
+	}
+	return newHealthCheck("pods", issues, nil)
+}
+
+// eventsCheck is degraded by warning events within healthEventsWindow, an issue per reason.
+func eventsCheck(report *EventsReport, err error) HealthCheck {
+	if err != nil {
+		return newHealthCheck("events", nil, err)
+	}
+	counts := map[string]int32{}
+	for _, event := range report.Events {
+		counts[event.Reason] += max(event.Count, 1)
+	}
+	reasons := make([]string, 0, len(counts))
+	for reason := range counts {
+		reasons = append(reasons, reason)
+	}
+	// The most frequent reasons first
+	sort.Slice(reasons, func(i, j int) bool {
+		if counts[reasons[i]] != counts[reasons[j]] {
+			return counts[reasons[i]] > counts[reasons[j]]
+		}
+		return reasons[i] < reasons[j]
+	})
+	var issues []HealthIssue
+	for _, reason := range reasons {
+		issues = append(issues, HealthIssue{Object: "events/" + reason, Problem: fmt.Sprintf("%d warnings in the last %s", counts[reason], healthEventsWindow)})
+	}
+	return newHealthCheck("events", issues, nil)
+}
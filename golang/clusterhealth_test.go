@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClusterHealth(t *testing.T) {
+	now := time.Date(2024, time.January, 31, 12, 0, 0, 0, time.UTC)
+	deployments := &ClusterDeploymentsInfo{
+		FailedDeployments: []DeploymentInfo{{Name: "web", Namespace: "default", RequestedPods: 3, AvailableReplicas: 2}},
+	}
+	nodes := &ClusterNodesInfo{
+		ReadyNodes:     []NodeInfo{{Name: "node-1", Ready: true}, {Name: "node-2", Ready: true}},
+		UnhealthyNodes: []NodeInfo{{Name: "node-3", Ready: true, Pressure: []string{"DiskPressure"}}},
+	}
+	events := &EventsReport{Events: []NamespacedWarningEvent{
+		{Namespace: "default", WarningEvent: WarningEvent{Reason: "BackOff", Count: 4}},
+		{Namespace: "default", WarningEvent: WarningEvent{Reason: "FailedMount", Count: 1}},
+		{Namespace: "web", WarningEvent: WarningEvent{Reason: "FailedMount", Count: 1}},
+	}}
+
+	health := newClusterHealth([]HealthCheck{
+		deploymentsCheck(deployments, nil),
+		nodesCheck(nodes, nil),
+		podsCheck(&ProblemPodsReport{}, nil),
+		eventsCheck(events, nil),
+	}, now)
+	assert.Equal(t, healthDegraded, health.Status)
+	assert.Equal(t, 70, health.Score)
+	assert.Equal(t, []HealthIssue{{Object: "deployment/default/web", Problem: "failed"}}, health.Checks[0].Issues)
+	assert.Equal(t, []HealthIssue{{Object: "node/node-3", Problem: "under DiskPressure"}}, health.Checks[1].Issues)
+	assert.Equal(t, healthHealthy, health.Checks[2].Status)
+	assert.Equal(t, "events/BackOff", health.Checks[3].Issues[0].Object)
+	assert.Equal(t, 2, health.Checks[3].IssueCount)
+
+	// An outage is critical, and a check which couldn't run degraded
+	deployments.StuckRollouts = []DeploymentInfo{{Name: "ledger", Namespace: "payments", RequestedPods: 2}}
+	health = newClusterHealth([]HealthCheck{
+		deploymentsCheck(deployments, nil),
+		nodesCheck(nil, errors.New("forbidden")),
+	}, now)
+	assert.Equal(t, healthCritical, health.Status)
+	assert.Equal(t, 60, health.Score)
+	assert.Equal(t, HealthIssue{Object: "deployment/payments/ledger", Problem: "no available replica"}, health.Checks[0].Issues[0])
+	assert.Equal(t, "forbidden", health.Checks[1].Error)
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/projectcalico/api/pkg/client/clientset_generated/clientset"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// clusterParameter selects the cluster a request applies to, the cluster of --kubeconfig without it.
+const clusterParameter = "cluster"
+
+const defaultClusterName = "local"
+
+// ClusterConfig is a further cluster the service manages, selected with ?cluster=<name>.
+//
+// Its kubeconfig is read like --kubeconfig: $KUBECONFIG or ~/.kube/config when empty, in its current context unless
+// Context is set.
+type ClusterConfig struct {
+	Name       string `json:"name"`
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+	Context    string `json:"context,omitempty"`
+}
+
+// parseClusterContexts returns the clusters of a comma-separated list of kubeconfig contexts, each named after its context.
+func parseClusterContexts(value string) []ClusterConfig {
+	var clusters []ClusterConfig
+	for _, context := range strings.Split(value, ",") {
+		if context = strings.TrimSpace(context); context != "" {
+			clusters = append(clusters, ClusterConfig{Name: context, Context: context})
+		}
+	}
+	return clusters
+}
+
+// validateClusters checks the names of the clusters, home being that of the cluster of --kubeconfig, are unique and
+// usable in a query string, and each cluster names its kubeconfig or context.
+func validateClusters(home string, clusters []ClusterConfig) error {
+	if errs := validation.IsDNS1123Subdomain(home); len(errs) > 0 {
+		return fmt.Errorf("invalid cluster name %q: %s", home, strings.Join(errs, ", "))
+	}
+	seen := map[string]bool{home: true}
+	for _, cluster := range clusters {
+		if errs := validation.IsDNS1123Subdomain(cluster.Name); len(errs) > 0 {
+			return fmt.Errorf("invalid cluster name %q: %s", cluster.Name, strings.Join(errs, ", "))
+		}
+		if cluster.Kubeconfig == "" && cluster.Context == "" {
+			return fmt.Errorf("cluster %q needs a kubeconfig or a context", cluster.Name)
+		}
+		if seen[cluster.Name] {
+			return fmt.Errorf("cluster %q is configured twice", cluster.Name)
+		}
+		seen[cluster.Name] = true
+	}
+	return nil
+}
+
+// clusterOptions are the flags every cluster connects with.
+type clusterOptions struct {
+	policyBackend    string
+	backendOptions   policyBackendOptions
+	istioPolicies    string
+	isolationIntents bool
+}
+
+// clusterConnection holds the clients of a cluster and the policy backend creating its deny policies.
+type clusterConnection struct {
+	config    *rest.Config
+	clientset kubernetes.Interface
+	calico    clientset.Interface
+	policies  PolicyBackend
+	// intents is the backend recording the IsolationIntents, nil without them
+	intents *intentBackend
+	// deployments caches the deployments of the cluster, nil with --deployment-cache=false
+	deployments *DeploymentCache
+}
+
+func connectCluster(config *rest.Config, options clusterOptions) (*clusterConnection, error) {
+	clientsetVanilla, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	clientsetCalico, err := clientset.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	policies, err := newPolicyBackend(options.policyBackend, config, options.backendOptions)
+	if err != nil {
+		return nil, err
+	}
+	policies, err = withIstioPolicies(policies, options.istioPolicies, config, clientsetVanilla)
+	if err != nil {
+		return nil, err
+	}
+	connection := &clusterConnection{config: config, clientset: clientsetVanilla, calico: clientsetCalico, policies: policies}
+	if options.isolationIntents {
+		if connection.intents, err = withIsolationIntents(policies, config); err != nil {
+			return nil, err
+		}
+		connection.policies = connection.intents
+	}
+	return connection, nil
+}
+
+// forCluster returns a copy of the server acting on the cluster name. Guardrail budgets and snapshots are kept per
+// cluster, since the same namespaces exist in every cluster of a fleet; callers are authenticated and authorized
+// against the cluster of --kubeconfig. The PromQL checks query the Prometheus of that cluster, so they're left out.
+func (s Server) forCluster(name string, connection *clusterConnection, guardrails *RateGuard) Server {
+	s.Cluster = name
+	s.K8sConfig = connection.config
+	s.K8sClientSet = connection.clientset
+	s.CalicoClientSet = connection.calico
+	s.Policies = connection.policies
+	s.Guardrails = guardrails
+	s.Snapshots = newSnapshotStore()
+	s.Deployments = connection.deployments
+	s.Scanner = nil
+	s.Alerts = nil
+	s.ResourceMetrics = newMetricsClient(connection.clientset.CoreV1().RESTClient())
+	s.Prometheus = nil
+	return s
+}
+
+// clusterMux serves a request with the routes of the cluster of its ?cluster=, those of the home cluster, the cluster
+// of --kubeconfig, without it. The cluster is recorded in the request metadata, so audit records name it.
+type clusterMux struct {
+	home     string
+	clusters map[string]http.Handler
+}
+
+func (m *clusterMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get(clusterParameter)
+	if name == "" {
+		name = m.home
+	}
+
+	handler, ok := m.clusters[name]
+	if !ok {
+		names := make([]string, 0, len(m.clusters))
+		for name := range m.clusters {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		writeErrorf(w, http.StatusNotFound, "Unknown cluster %q, expected one of: %s", name, strings.Join(names, ", "))
+		return
+	}
+	handler.ServeHTTP(w, r.WithContext(withCluster(r.Context(), name)))
+}
+
+// withCluster names the cluster in the request metadata of ctx, and so in the audit records made with it.
+func withCluster(ctx context.Context, name string) context.Context {
+	meta := requestMetadata(ctx)
+	meta.Cluster = name
+	return context.WithValue(ctx, requestMetadataKey{}, meta)
+}
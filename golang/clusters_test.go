@@ -0,0 +1,58 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseClusterContexts(t *testing.T) {
+	assert.Nil(t, parseClusterContexts(""))
+	assert.Equal(t, []ClusterConfig{
+		{Name: "eu-west", Context: "eu-west"},
+		{Name: "us-east", Context: "us-east"},
+	}, parseClusterContexts("eu-west, us-east,"))
+}
+
+func TestValidateClusters(t *testing.T) {
+	assert.NoError(t, validateClusters(defaultClusterName, []ClusterConfig{
+		{Name: "eu-west", Context: "eu-west"},
+		{Name: "us-east.prod", Kubeconfig: "/etc/clusters/us-east"},
+	}))
+	assert.ErrorContains(t, validateClusters(defaultClusterName, []ClusterConfig{{Name: "EU West", Context: "eu-west"}}), "invalid cluster name")
+	assert.ErrorContains(t, validateClusters("", nil), "invalid cluster name")
+	assert.ErrorContains(t, validateClusters(defaultClusterName, []ClusterConfig{{Name: "eu-west"}}), "needs a kubeconfig or a context")
+	assert.ErrorContains(t, validateClusters(defaultClusterName, []ClusterConfig{
+		{Name: "eu-west", Context: "eu-west"},
+		{Name: "eu-west", Kubeconfig: "/etc/clusters/eu-west"},
+	}), "configured twice")
+	assert.ErrorContains(t, validateClusters("eu-west", []ClusterConfig{{Name: "eu-west", Context: "eu-west"}}), "configured twice")
+}
+
+func TestClusterMux(t *testing.T) {
+	served := func(name string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, name, requestMetadata(r.Context()).Cluster)
+			w.Write([]byte(name))
+		})
+	}
+	mux := &clusterMux{home: "eu-west", clusters: map[string]http.Handler{"eu-west": served("eu-west"), "us-east": served("us-east")}}
+
+	for target, expected := range map[string]string{
+		"/denyNetworkPolicy":                 "eu-west",
+		"/denyNetworkPolicy?cluster=us-east": "us-east",
+		"/denyNetworkPolicy?cluster=eu-west": "eu-west",
+	} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, expected, rec.Body.String())
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/denyNetworkPolicy?cluster=ap-south", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "expected one of: eu-west, us-east")
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Config holds the settings that don't fit on the command line, loaded from the YAML or JSON file passed with --config.
+type Config struct {
+	Prometheus   *PrometheusConfig   `json:"prometheus,omitempty"`
+	Dependencies *DependenciesConfig `json:"dependencies,omitempty"`
+	Export       *ExportConfig       `json:"export,omitempty"`
+	Ticketing    *TicketingConfig    `json:"ticketing,omitempty"`
+	Webhooks     *WebhooksConfig     `json:"webhooks,omitempty"`
+	Slack        *SlackConfig        `json:"slack,omitempty"`
+	Alerting     *AlertingConfig     `json:"alerting,omitempty"`
+	Features     map[string]bool     `json:"features,omitempty"`
+	Health       *HealthConfig       `json:"health,omitempty"`
+
+	Aliases    map[string]DenyNetworkRequestWorkload `json:"aliases,omitempty"`
+	Guardrails *GuardrailsConfig                     `json:"guardrails,omitempty"`
+
+	Authentication *AuthenticationConfig `json:"authentication,omitempty"`
+	Authorization  *AuthorizationConfig  `json:"authorization,omitempty"`
+
+	Clusters []ClusterConfig `json:"clusters,omitempty"`
+}
+
+// loadConfig reads the configuration file at path, an empty path returns an empty configuration.
+//
+// Unknown fields are rejected so typos don't silently disable a setting.
+func loadConfig(path string) (*Config, error) {
+	config := new(Config)
+	if path == "" {
+		return config, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return config, nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Values of --policy-conflicts.
+const (
+	policyConflictsWarn   = "warn"
+	policyConflictsReject = "reject"
+	policyConflictsOff    = "off"
+)
+
+// PolicyConflict is an existing policy of the tier of a deny policy, selecting its workload and allowing the traffic it denies.
+//
+// Precedes tells the policy is evaluated first, so the traffic stays allowed.
+type PolicyConflict struct {
+	Kind       string   `json:"kind"`
+	Namespace  string   `json:"namespace,omitempty"`
+	Name       string   `json:"name"`
+	Tier       string   `json:"tier"`
+	Order      *float64 `json:"order,omitempty"`
+	Directions []string `json:"directions"`
+	Precedes   bool     `json:"precedes"`
+}
+
+func (c PolicyConflict) String() string {
+	name := c.Name
+	if c.Namespace != "" {
+		name = c.Namespace + "/" + name
+	}
+	outcome := "the deny policy is evaluated first"
+	if c.Precedes {
+		outcome = "it's evaluated first and keeps the traffic allowed"
+	}
+	return fmt.Sprintf("%s %s in tier %s allows %s traffic with the peer, %s", c.Kind, name, c.Tier, strings.Join(c.Directions, " and "), outcome)
+}
+
+// PolicyConflictError rejects a deny policy conflicting with existing policies.
+type PolicyConflictError struct {
+	Conflicts []PolicyConflict
+}
+
+func (e *PolicyConflictError) Error() string {
+	conflicts := make([]string, 0, len(e.Conflicts))
+	for _, conflict := range e.Conflicts {
+		conflicts = append(conflicts, conflict.String())
+	}
+	return "conflicting policies: " + strings.Join(conflicts, "; ")
+}
+
+// conflictDetector is implemented by the backends able to find the policies conflicting with a deny request.
+type conflictDetector interface {
+	// conflicts returns the policies allowing traffic the policy of request denies, peerNamespace being the namespace of B as for Render.
+	conflicts(ctx context.Context, request DenyNetworkRequest, peerNamespace *corev1.Namespace) ([]PolicyConflict, error)
+}
+
+func validatePolicyConflictsMode(mode string) error {
+	switch mode {
+	case policyConflictsWarn, policyConflictsReject, policyConflictsOff:
+		return nil
+	}
+	return fmt.Errorf("invalid policy conflicts mode %q, expected warn, reject or off", mode)
+}
+
+// checkPolicyConflicts looks for the policies conflicting with the policy of request and its mirror.
+//
+// In warn mode, the conflicts are returned as Warning headers like the API server does, and a failed check only gets logged.
+// In reject mode, they are a *PolicyConflictError.
+func (s *Server) checkPolicyConflicts(ctx context.Context, w http.ResponseWriter, request DenyNetworkRequest) error {
+	detector, ok := s.Policies.(conflictDetector)
+	if !ok || s.PolicyConflicts == "" || s.PolicyConflicts == policyConflictsOff {
+		return nil
+	}
+
+	requests := []DenyNetworkRequest{request}
+	if mirror, ok := request.mirror(); ok {
+		requests = append(requests, mirror)
+	}
+	var conflicts []PolicyConflict
+	for _, request := range requests {
+		found, err := s.findPolicyConflicts(ctx, detector, request)
+		if err != nil {
+			if s.PolicyConflicts == policyConflictsReject {
+				return fmt.Errorf("checking for conflicting policies: %w", err)
+			}
+			slog.WarnContext(ctx, "Failed checking for conflicting policies", "namespace", request.A.Namespace, "error", err)
+			return nil
+		}
+		conflicts = append(conflicts, found...)
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	if s.PolicyConflicts == policyConflictsReject {
+		return &PolicyConflictError{Conflicts: conflicts}
+	}
+	for _, conflict := range conflicts {
+		w.Header().Add("Warning", fmt.Sprintf("299 - %q", conflict.String()))
+		slog.WarnContext(ctx, "Deny policy conflicts with an existing policy", "conflict", conflict.String())
+	}
+	return nil
+}
+
+func (s *Server) findPolicyConflicts(ctx context.Context, detector conflictDetector, request DenyNetworkRequest) ([]PolicyConflict, error) {
+	var peerNamespace *corev1.Namespace
+	if request.B.CIDR == "" && request.B.Namespace != "" {
+		var err error
+		if peerNamespace, err = s.K8sClientSet.CoreV1().Namespaces().Get(ctx, request.B.Namespace, metav1.GetOptions{}); err != nil {
+			return nil, err
+		}
+	}
+	return detector.conflicts(ctx, request, peerNamespace)
+}
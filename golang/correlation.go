@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+
+	"github.com/google/uuid"
+)
+
+const (
+	correlationIDHeader = "X-Correlation-ID"
+	operationIDHeader   = "X-Operation-ID"
+	traceParentHeader   = "traceparent"
+
+	correlationIDAnnotation = annotationPrefix + "correlation-id"
+	traceParentAnnotation   = annotationPrefix + "traceparent"
+)
+
+var (
+	traceParentPattern   = regexp.MustCompile(`^[0-9a-f]{2}-[0-9a-f]{32}-[0-9a-f]{16}-[0-9a-f]{2}$`)
+	correlationIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+)
+
+// RequestMetadata ties the cluster mutations of a request back to the automation run that originated it.
+//
+// Principal is only known once the caller has been identified on the mutating routes, which get an OperationID
+// too. Unlike the correlation ID, which the caller may share between requests, it names the objects of a single
+// request so they can be undone together. Cluster names the cluster selected with ?cluster=, empty for the default one.
+type RequestMetadata struct {
+	CorrelationID string `json:"correlation_id,omitempty"`
+	OperationID   string `json:"operation_id,omitempty"`
+	TraceParent   string `json:"traceparent,omitempty"`
+	Principal     string `json:"principal,omitempty"`
+	Cluster       string `json:"cluster,omitempty"`
+}
+
+type requestMetadataKey struct{}
+
+// withRequestMetadata reads the caller correlation ID and W3C traceparent, generating a correlation ID when missing.
+//
+// The correlation ID is echoed back so callers can find the audit records of their request.
+func withRequestMetadata(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		meta := RequestMetadata{
+			CorrelationID: r.Header.Get(correlationIDHeader),
+			TraceParent:   r.Header.Get(traceParentHeader),
+		}
+		if !correlationIDPattern.MatchString(meta.CorrelationID) {
+			meta.CorrelationID = uuid.New().String()
+		}
+		if !traceParentPattern.MatchString(meta.TraceParent) {
+			meta.TraceParent = ""
+		}
+
+		w.Header().Set(correlationIDHeader, meta.CorrelationID)
+		if meta.TraceParent != "" {
+			w.Header().Set(traceParentHeader, meta.TraceParent)
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestMetadataKey{}, meta)))
+	})
+}
+
+// requestMetadata returns the metadata of the request, empty outside of withRequestMetadata.
+func requestMetadata(ctx context.Context) RequestMetadata {
+	meta, _ := ctx.Value(requestMetadataKey{}).(RequestMetadata)
+	return meta
+}
+
+// identify records the caller in the request metadata, so the objects, events and audit records of the request name it.
+//
+// The request gets an operation ID, returned to the caller to undo it.
+func (s *Server) identify(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		meta := requestMetadata(r.Context())
+		meta.Principal = s.principal(r).User
+		meta.OperationID = uuid.New().String()
+		w.Header().Set(operationIDHeader, meta.OperationID)
+		next(w, r.WithContext(context.WithValue(r.Context(), requestMetadataKey{}, meta)))
+	}
+}
+
+// annotate adds the metadata to annotations of an object created for the request.
+func (m RequestMetadata) annotate(annotations map[string]string) {
+	if m.CorrelationID != "" {
+		annotations[correlationIDAnnotation] = m.CorrelationID
+	}
+	if m.TraceParent != "" {
+		annotations[traceParentAnnotation] = m.TraceParent
+	}
+}
+
+// setHeaders propagates the metadata on an outgoing request made on behalf of the request.
+func (m RequestMetadata) setHeaders(header http.Header) {
+	if m.CorrelationID != "" {
+		header.Set(correlationIDHeader, m.CorrelationID)
+	}
+	if m.TraceParent != "" {
+		header.Set(traceParentHeader, m.TraceParent)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRequestMetadata(t *testing.T) {
+	var meta RequestMetadata
+	handler := withRequestMetadata(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		meta = requestMetadata(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/denyNetworkPolicy", nil)
+	req.Header.Set(correlationIDHeader, "run-42")
+	req.Header.Set(traceParentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, "run-42", meta.CorrelationID)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", meta.TraceParent)
+	assert.Equal(t, "run-42", rec.Header().Get(correlationIDHeader))
+
+	req = httptest.NewRequest(http.MethodPost, "/denyNetworkPolicy", nil)
+	req.Header.Set(traceParentHeader, "garbage")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.NotEmpty(t, meta.CorrelationID)
+	assert.Empty(t, meta.TraceParent)
+}
+
+func TestIdentify(t *testing.T) {
+	var meta RequestMetadata
+	server := &Server{}
+	handler := withRequestMetadata(server.identify(func(w http.ResponseWriter, r *http.Request) {
+		meta = requestMetadata(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/denyNetworkPolicy", nil)
+	req.Header.Set(correlationIDHeader, "run-42")
+	req = req.WithContext(withPrincipal(req.Context(), Principal{User: "alice"}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.NotEmpty(t, meta.OperationID)
+	assert.Equal(t, meta.OperationID, rec.Header().Get(operationIDHeader))
+	assert.Equal(t, RequestMetadata{CorrelationID: "run-42", OperationID: meta.OperationID, Principal: "alice"}, meta)
+
+	req = httptest.NewRequest(http.MethodPost, "/denyNetworkPolicy", nil)
+	req = req.WithContext(withPrincipal(req.Context(), Principal{User: "deploy-bot"}))
+	req.Header.Set(defaultUserHeader, "mallory")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "deploy-bot", meta.Principal)
+
+	// The user headers of untrusted callers are ignored
+	req = httptest.NewRequest(http.MethodPost, "/denyNetworkPolicy", nil)
+	req.Header.Set(defaultUserHeader, "admin")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, anonymousUser, meta.Principal)
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PolicyCoverageReport lists, per namespace in scope, the workloads no network policy selects.
+type PolicyCoverageReport struct {
+	Namespaces []NamespaceCoverage `json:"namespaces"`
+}
+
+// NamespaceCoverage counts the workloads of a namespace and lists those no policy selects, which any traffic reaches.
+type NamespaceCoverage struct {
+	Namespace string              `json:"namespace"`
+	Workloads int                 `json:"workloads"`
+	Uncovered []UncoveredWorkload `json:"uncovered"`
+}
+
+// UncoveredWorkload is a Deployment, StatefulSet or DaemonSet whose pods no policy selects.
+type UncoveredWorkload struct {
+	Kind   string            `json:"kind"`
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// coverageWorkload is a controller of pods, checked by the labels of its pod template.
+type coverageWorkload struct {
+	kind     string
+	name     string
+	template corev1.PodTemplateSpec
+}
+
+// coveragePolicies are the policies which may select the pods of a namespace.
+type coveragePolicies struct {
+	native []networkingv1.NetworkPolicy
+	calico []v3.NetworkPolicy
+	global []v3.GlobalNetworkPolicy
+}
+
+// Handler reporting the workloads no Kubernetes or Calico network policy selects, in every namespace in scope or in ?namespace=
+//
+// A workload is covered as soon as a policy selects it, whatever its rules: the report finds the pods left wide open,
+// not whether the rules are right.
+func (s *Server) policyCoverageHandler(w http.ResponseWriter, r *http.Request) {
+	scope := s.Namespaces
+	if namespace := r.URL.Query().Get("namespace"); namespace != "" {
+		if err := s.Namespaces.check(r.Context(), s.K8sClientSet, namespace); err != nil {
+			writeError(w, http.StatusForbidden, err)
+			return
+		}
+		scope = NamespaceScope{Names: []string{namespace}}
+	}
+
+	report, err := s.policyCoverage(r.Context(), scope)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+func (s *Server) policyCoverage(ctx context.Context, scope NamespaceScope) (*PolicyCoverageReport, error) {
+	namespaces, err := scope.resolve(ctx, s.K8sClientSet)
+	if err != nil {
+		return nil, err
+	}
+
+	// Clusters without Calico only have Kubernetes policies
+	var global []v3.GlobalNetworkPolicy
+	globalList, err := s.CalicoClientSet.ProjectcalicoV3().GlobalNetworkPolicies().List(ctx, metav1.ListOptions{})
+	calicoInstalled := !apierrors.IsNotFound(err)
+	if err != nil && calicoInstalled {
+		return nil, err
+	}
+	if calicoInstalled {
+		global = globalList.Items
+	}
+
+	report := &PolicyCoverageReport{Namespaces: []NamespaceCoverage{}}
+	for _, name := range namespaces {
+		namespace, err := s.K8sClientSet.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		workloads, err := listCoverageWorkloads(ctx, s.K8sClientSet, name)
+		if err != nil {
+			return nil, err
+		}
+
+		policies := coveragePolicies{global: global}
+		native, err := s.K8sClientSet.NetworkingV1().NetworkPolicies(name).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		policies.native = native.Items
+		if calicoInstalled {
+			calico, err := s.CalicoClientSet.ProjectcalicoV3().NetworkPolicies(name).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			policies.calico = calico.Items
+		}
+
+		report.Namespaces = append(report.Namespaces, namespaceCoverage(namespace, workloads, policies))
+	}
+	return report, nil
+}
+
+// listCoverageWorkloads returns the Deployments, StatefulSets and DaemonSets of namespace.
+func listCoverageWorkloads(ctx context.Context, clientset kubernetes.Interface, namespace string) ([]coverageWorkload, error) {
+	var workloads []coverageWorkload
+	deployments, err := clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, deployment := range deployments.Items {
+		workloads = append(workloads, coverageWorkload{workloadKindDeployment, deployment.Name, deployment.Spec.Template})
+	}
+	statefulSets, err := clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, statefulSet := range statefulSets.Items {
+		workloads = append(workloads, coverageWorkload{"StatefulSet", statefulSet.Name, statefulSet.Spec.Template})
+	}
+	daemonSets, err := clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, daemonSet := range daemonSets.Items {
+		workloads = append(workloads, coverageWorkload{"DaemonSet", daemonSet.Name, daemonSet.Spec.Template})
+	}
+	return workloads, nil
+}
+
+// namespaceCoverage lists the workloads of namespace none of the policies selects, by kind then name.
+//
+// Calico selectors are evaluated against the labels of the pod template plus those Calico adds to every endpoint, the
+// namespace selectors of global policies against the namespace labels plus its name.
+func namespaceCoverage(namespace *corev1.Namespace, workloads []coverageWorkload, policies coveragePolicies) NamespaceCoverage {
+	coverage := NamespaceCoverage{Namespace: namespace.Name, Workloads: len(workloads), Uncovered: []UncoveredWorkload{}}
+
+	namespaceLabels := map[string]string{"projectcalico.org/name": namespace.Name}
+	for key, value := range namespace.Labels {
+		namespaceLabels[key] = value
+	}
+	var globalPolicies []v3.GlobalNetworkPolicy
+	for _, policy := range policies.global {
+		if namespaceSelector, err := parseCalicoSelector(policy.Spec.NamespaceSelector); err == nil && namespaceSelector.Matches(namespaceLabels) {
+			globalPolicies = append(globalPolicies, policy)
+		}
+	}
+
+	for _, workload := range workloads {
+		if !workloadCovered(namespace.Name, workload, policies.native, policies.calico, globalPolicies) {
+			coverage.Uncovered = append(coverage.Uncovered, UncoveredWorkload{Kind: workload.kind, Name: workload.name, Labels: workload.template.Labels})
+		}
+	}
+	sort.Slice(coverage.Uncovered, func(i, j int) bool {
+		a, b := coverage.Uncovered[i], coverage.Uncovered[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return coverage
+}
+
+// workloadCovered tells whether a policy selects the pods of workload, the global policies already matching its namespace.
+func workloadCovered(namespace string, workload coverageWorkload, native []networkingv1.NetworkPolicy, calico []v3.NetworkPolicy, global []v3.GlobalNetworkPolicy) bool {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Labels: workload.template.Labels},
+		Spec:       corev1.PodSpec{ServiceAccountName: workload.template.Spec.ServiceAccountName},
+	}
+	for _, policy := range native {
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+		if err == nil && selector.Matches(labels.Set(pod.Labels)) {
+			return true
+		}
+	}
+
+	endpoint := endpointLabels(pod)
+	selectors := make([]string, 0, len(calico)+len(global))
+	for _, policy := range calico {
+		selectors = append(selectors, policy.Spec.Selector)
+	}
+	for _, policy := range global {
+		selectors = append(selectors, policy.Spec.Selector)
+	}
+	for _, selector := range selectors {
+		if parsed, err := parseCalicoSelector(selector); err == nil && parsed.Matches(endpoint) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func coverageTestWorkload(kind, name string, labels map[string]string) coverageWorkload {
+	return coverageWorkload{kind: kind, name: name, template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: labels}}}
+}
+
+func TestNamespaceCoverage(t *testing.T) {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Labels: map[string]string{"team": "payments"}}}
+	workloads := []coverageWorkload{
+		coverageTestWorkload(workloadKindDeployment, "ledger", map[string]string{"app": "ledger"}),
+		coverageTestWorkload(workloadKindDeployment, "api", map[string]string{"app": "api"}),
+		coverageTestWorkload("StatefulSet", "db", map[string]string{"app": "db"}),
+		coverageTestWorkload("DaemonSet", "agent", map[string]string{"app": "agent"}),
+		coverageTestWorkload(workloadKindDeployment, "cron", map[string]string{"app": "cron"}),
+	}
+	policies := coveragePolicies{
+		native: []networkingv1.NetworkPolicy{{Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "ledger"}},
+		}}},
+		calico: []v3.NetworkPolicy{{Spec: v3.NetworkPolicySpec{Selector: "app in {'api', 'db'}"}}},
+		global: []v3.GlobalNetworkPolicy{
+			{Spec: v3.GlobalNetworkPolicySpec{Selector: "app == 'agent'", NamespaceSelector: "team == 'payments'"}},
+			// Its namespace selector doesn't match the namespace
+			{Spec: v3.GlobalNetworkPolicySpec{Selector: "app == 'cron'", NamespaceSelector: "projectcalico.org/name == 'shop'"}},
+		},
+	}
+
+	coverage := namespaceCoverage(namespace, workloads, policies)
+	assert.Equal(t, "payments", coverage.Namespace)
+	assert.Equal(t, 5, coverage.Workloads)
+	assert.Equal(t, []UncoveredWorkload{{Kind: workloadKindDeployment, Name: "cron", Labels: map[string]string{"app": "cron"}}}, coverage.Uncovered)
+
+	coverage = namespaceCoverage(namespace, workloads, coveragePolicies{})
+	assert.Len(t, coverage.Uncovered, 5)
+	assert.Equal(t, "DaemonSet", coverage.Uncovered[0].Kind)
+}
+
+func TestListCoverageWorkloads(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "ledger", Namespace: "payments"}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "payments"}},
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "kube-system"}},
+	)
+
+	workloads, err := listCoverageWorkloads(context.Background(), clientset, "payments")
+	assert.NoError(t, err)
+	if assert.Len(t, workloads, 2) {
+		assert.Equal(t, workloadKindDeployment, workloads[0].kind)
+		assert.Equal(t, "db", workloads[1].name)
+	}
+}
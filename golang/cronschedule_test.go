@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	from := time.Date(2024, time.January, 31, 10, 7, 30, 0, time.UTC) // a Wednesday
+	for source, want := range map[string]time.Time{
+		"*/15 * * * *":      time.Date(2024, time.January, 31, 10, 15, 0, 0, time.UTC),
+		"@hourly":           time.Date(2024, time.January, 31, 11, 0, 0, 0, time.UTC),
+		"30 2 * * *":        time.Date(2024, time.February, 1, 2, 30, 0, 0, time.UTC),
+		"0 9 * * mon-fri":   time.Date(2024, time.February, 1, 9, 0, 0, 0, time.UTC),
+		"0 0 * * 7":         time.Date(2024, time.February, 4, 0, 0, 0, 0, time.UTC),
+		"0 0 29 feb *":      time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		"0 0 1 * 5":         time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+		"0 0 13 * 5":        time.Date(2024, time.February, 2, 0, 0, 0, 0, time.UTC),
+		"5,10 8-12/2 * * *": time.Date(2024, time.January, 31, 10, 10, 0, 0, time.UTC),
+		"@every 90m":        time.Date(2024, time.January, 31, 11, 37, 30, 0, time.UTC),
+	} {
+		schedule, err := parseCronSchedule(source, "")
+		if assert.NoError(t, err, source) {
+			assert.Equal(t, want, schedule.next(from).UTC(), source)
+		}
+	}
+
+	schedule, err := parseCronSchedule("CRON_TZ=Asia/Kolkata 0 9 * * *", "")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, time.February, 1, 3, 30, 0, 0, time.UTC), schedule.next(from).UTC())
+
+	schedule, err = parseCronSchedule("0 0 30 2 *", "")
+	assert.NoError(t, err)
+	assert.True(t, schedule.next(from).IsZero())
+
+	for _, invalid := range []string{"* * * *", "60 * * * *", "* * * foo *", "*/0 * * * *", "5-1 * * * *", "@every soon"} {
+		_, err := parseCronSchedule(invalid, "")
+		assert.Error(t, err, invalid)
+	}
+	_, err = parseCronSchedule("0 0 * * *", "Mars/Olympus")
+	assert.ErrorContains(t, err, "invalid time zone")
+}
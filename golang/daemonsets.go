@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+type DaemonSetInfo struct {
+	Name      string `json:"daemonset_name"`
+	Namespace string `json:"namespace"`
+	// DesiredPods is the number of nodes which should run a pod of the DaemonSet
+	DesiredPods     int32 `json:"desired_pods"`
+	CurrentPods     int32 `json:"current_pods"`
+	ReadyPods       int32 `json:"ready_pods"`
+	AvailablePods   int32 `json:"available_pods"`
+	UnavailablePods int32 `json:"unavailable_pods"`
+	UpdatedPods     int32 `json:"updated_pods"`
+	// MisscheduledPods run on nodes which shouldn't run the DaemonSet, e.g. after their labels or taints changed
+	MisscheduledPods int32     `json:"misscheduled_pods"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type ClusterDaemonSetsInfo struct {
+	ReadyDaemonSets []DaemonSetInfo `json:"ready_daemonsets"`
+	// FailedDaemonSets have nodes without an available pod, or misscheduled pods
+	FailedDaemonSets []DaemonSetInfo `json:"failed_daemonsets"`
+}
+
+// Reports the health of the DaemonSets in scope, filtered like the deployments of clusterDeploymentsInfoHandler
+func (s *Server) clusterDaemonSetsInfoHandler(w http.ResponseWriter, r *http.Request) {
+	filter, status, err := s.parseReportFilter(r)
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+
+	info, err := getDaemonSetsHealth(r.Context(), s.K8sClientSet, filter.Scope, filter.Selector)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	info.excludeNamespaces(filter.excludedNamespaces(s.ExcludedNamespaces))
+	writeJSON(w, http.StatusOK, info)
+}
+
+// getDaemonSetsHealth sorts the DaemonSets of every namespace in scope whose own labels match selector into ready and
+// failed ones. A DaemonSet is ready once every node which should run it has an available pod and none runs a pod it
+// shouldn't.
+func getDaemonSetsHealth(ctx context.Context, clientset kubernetes.Interface, scope NamespaceScope, selector labels.Selector) (*ClusterDaemonSetsInfo, error) {
+	namespaces, err := scope.resolve(ctx, clientset)
+	if err != nil {
+		return nil, err
+	}
+
+	info := new(ClusterDaemonSetsInfo)
+	for _, namespace := range namespaces {
+		list, err := clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, err
+		}
+		for _, daemonSet := range list.Items {
+			status := daemonSet.Status
+			current := DaemonSetInfo{
+				Name:             daemonSet.Name,
+				Namespace:        daemonSet.Namespace,
+				DesiredPods:      status.DesiredNumberScheduled,
+				CurrentPods:      status.CurrentNumberScheduled,
+				ReadyPods:        status.NumberReady,
+				AvailablePods:    status.NumberAvailable,
+				UnavailablePods:  status.NumberUnavailable,
+				UpdatedPods:      status.UpdatedNumberScheduled,
+				MisscheduledPods: status.NumberMisscheduled,
+				CreatedAt:        daemonSet.CreationTimestamp.Time,
+			}
+
+			if current.DesiredPods <= current.AvailablePods && current.MisscheduledPods == 0 {
+				info.ReadyDaemonSets = append(info.ReadyDaemonSets, current)
+			} else {
+				info.FailedDaemonSets = append(info.FailedDaemonSets, current)
+			}
+		}
+	}
+	slog.DebugContext(ctx, "DaemonSets health", "ready", len(info.ReadyDaemonSets), "failed", len(info.FailedDaemonSets))
+	return info, nil
+}
+
+// excludeNamespaces removes the DaemonSets of namespaces from every category of the report.
+func (info *ClusterDaemonSetsInfo) excludeNamespaces(namespaces []string) {
+	if len(namespaces) == 0 {
+		return
+	}
+	keep := func(daemonSets []DaemonSetInfo) []DaemonSetInfo {
+		kept := daemonSets[:0]
+		for _, daemonSet := range daemonSets {
+			if !slices.Contains(namespaces, daemonSet.Namespace) {
+				kept = append(kept, daemonSet)
+			}
+		}
+		return kept
+	}
+	info.ReadyDaemonSets = keep(info.ReadyDaemonSets)
+	info.FailedDaemonSets = keep(info.FailedDaemonSets)
+}
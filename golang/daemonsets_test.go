@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetDaemonSetsHealth(t *testing.T) {
+	daemonSet := func(namespace, name string, desired, available, misscheduled int32) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Status: appsv1.DaemonSetStatus{
+				DesiredNumberScheduled: desired,
+				CurrentNumberScheduled: desired,
+				NumberReady:            available,
+				NumberAvailable:        available,
+				NumberUnavailable:      desired - available,
+				NumberMisscheduled:     misscheduled,
+			},
+		}
+	}
+	clientset := fake.NewSimpleClientset(
+		daemonSet("kube-system", "calico-node", 5, 5, 0),
+		daemonSet("kube-system", "kube-proxy", 5, 4, 0),
+		daemonSet("logging", "fluent-bit", 5, 5, 1),
+		daemonSet("monitoring", "node-exporter", 0, 0, 0),
+	)
+
+	info, err := getDaemonSetsHealth(context.Background(), clientset, NamespaceScope{}, labels.Everything())
+	assert.NoError(t, err)
+	names := func(daemonSets []DaemonSetInfo) []string {
+		var result []string
+		for _, daemonSet := range daemonSets {
+			result = append(result, daemonSet.Name)
+		}
+		return result
+	}
+	assert.ElementsMatch(t, []string{"calico-node", "node-exporter"}, names(info.ReadyDaemonSets))
+	assert.ElementsMatch(t, []string{"kube-proxy", "fluent-bit"}, names(info.FailedDaemonSets))
+
+	info.excludeNamespaces([]string{"kube-system"})
+	assert.Equal(t, []string{"node-exporter"}, names(info.ReadyDaemonSets))
+	assert.Equal(t, []string{"fluent-bit"}, names(info.FailedDaemonSets))
+}
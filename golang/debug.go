@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+)
+
+const defaultDebugImage = "busybox:1.36"
+
+// DebugRequest optionally overrides the debug image and the container whose process namespace is shared.
+type DebugRequest struct {
+	Image           string   `json:"image,omitempty"`
+	TargetContainer string   `json:"target_container,omitempty"`
+	Command         []string `json:"command,omitempty"`
+}
+
+// DebugSession tells the caller how to connect to the ephemeral debug container.
+type DebugSession struct {
+	Namespace     string `json:"namespace"`
+	Pod           string `json:"pod"`
+	Container     string `json:"container"`
+	Image         string `json:"image"`
+	AttachURL     string `json:"attach_url"`
+	AttachCommand string `json:"attach_command"`
+	ExecCommand   string `json:"exec_command"`
+}
+
+// Handler attaching an ephemeral debug container to a pod
+func (s *Server) debugPodHandler(w http.ResponseWriter, r *http.Request) {
+	namespace, podName := r.PathValue("namespace"), r.PathValue("pod")
+
+	if err := s.Namespaces.check(r.Context(), s.K8sClientSet, namespace); err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		readBodyError(w, err)
+		return
+	}
+
+	defer r.Body.Close()
+
+	var request DebugRequest
+	if len(body) > 0 {
+		if err := decodeStrictJSON(body, &request); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	if request.Image == "" {
+		request.Image = s.DebugImage
+	}
+
+	if !s.authorize(w, r, operationPodDebug, namespace, podName, request) {
+		return
+	}
+
+	pod, err := s.K8sClientSet.CoreV1().Pods(namespace).Get(r.Context(), podName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if request.TargetContainer == "" && len(pod.Spec.Containers) > 0 {
+		request.TargetContainer = pod.Spec.Containers[0].Name
+	}
+
+	container := corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:                     "debugger-" + utilrand.String(5),
+			Image:                    request.Image,
+			Command:                  request.Command,
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		},
+		TargetContainerName: request.TargetContainer,
+	}
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, container)
+
+	reservation, err := s.Guardrails.reserve(budgetPodOperations, namespace, 1, time.Now())
+	if writeGuardrailError(w, err) {
+		return
+	}
+
+	_, err = s.K8sClientSet.CoreV1().Pods(namespace).UpdateEphemeralContainers(r.Context(), podName, pod, metav1.UpdateOptions{})
+	if err != nil {
+		reservation.release(1)
+	}
+	if apierrors.IsInvalid(err) {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	slog.InfoContext(r.Context(), "Ephemeral container added", "container", container.Name, "namespace", namespace, "pod", podName)
+	meta := requestMetadata(r.Context())
+	recordAudit(AuditEvent{
+		Action:          "pod.debug",
+		RequestMetadata: meta,
+		RemoteAddr:      r.RemoteAddr,
+		Namespace:       namespace,
+		Target:          podName,
+		Request:         request,
+		Result:          "created " + container.Name,
+	})
+	recordEvent(r.Context(), s.K8sClientSet, corev1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Namespace:  namespace,
+		Name:       podName,
+		UID:        pod.UID,
+	}, "EphemeralContainerAdded", fmt.Sprintf("Debug container %s started with image %s", container.Name, request.Image), meta)
+	writeJSON(w, http.StatusCreated, newDebugSession(namespace, podName, container.Name, request.Image))
+}
+
+func newDebugSession(namespace, pod, container, image string) DebugSession {
+	query := url.Values{
+		"container": {container},
+		"stdin":     {"true"},
+		"stdout":    {"true"},
+		"stderr":    {"true"},
+		"tty":       {"true"},
+	}
+
+	return DebugSession{
+		Namespace:     namespace,
+		Pod:           pod,
+		Container:     container,
+		Image:         image,
+		AttachURL:     fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/attach?%s", namespace, pod, query.Encode()),
+		AttachCommand: fmt.Sprintf("kubectl attach -it -n %s %s -c %s", namespace, pod, container),
+		ExecCommand:   fmt.Sprintf("kubectl exec -it -n %s %s -c %s -- sh", namespace, pod, container),
+	}
+}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDebugSession(t *testing.T) {
+	session := newDebugSession("payments", "api-0", "debugger-x7k2p", defaultDebugImage)
+
+	assert.Equal(t, "/api/v1/namespaces/payments/pods/api-0/attach?container=debugger-x7k2p&stderr=true&stdin=true&stdout=true&tty=true", session.AttachURL)
+	assert.Equal(t, "kubectl attach -it -n payments api-0 -c debugger-x7k2p", session.AttachCommand)
+	assert.Equal(t, defaultDebugImage, session.Image)
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DependenciesConfig configures the optional sources used to build the dependency map.
+//
+// FlowLogsURL must return a JSON array of Calico flow log records, e.g. a proxy in front of the flow log index.
+type DependenciesConfig struct {
+	FlowLogsURL string `json:"flow_logs_url,omitempty"`
+}
+
+// FlowLogRecord is the subset of a Calico flow log record used to find callers of a workload.
+type FlowLogRecord struct {
+	SourceNamespace      string `json:"source_namespace"`
+	SourceNameAggr       string `json:"source_name_aggr"`
+	DestNamespace        string `json:"dest_namespace"`
+	DestNameAggr         string `json:"dest_name_aggr"`
+	DestServiceNamespace string `json:"dest_service_namespace"`
+	DestServiceName      string `json:"dest_service_name"`
+}
+
+type WorkloadReference struct {
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// Dependent is a workload that calls the deployment, with the evidence the dependency was derived from.
+type Dependent struct {
+	WorkloadReference
+	Evidence []string `json:"evidence"`
+}
+
+type DeploymentDependents struct {
+	Deployment WorkloadReference   `json:"deployment"`
+	Services   []WorkloadReference `json:"services"`
+	Dependents []Dependent         `json:"dependents"`
+}
+
+// Handler listing the workloads depending on a deployment
+func (s *Server) deploymentDependentsHandler(w http.ResponseWriter, r *http.Request) {
+	namespace, name := r.PathValue("namespace"), r.PathValue("name")
+
+	if err := s.Namespaces.check(r.Context(), s.K8sClientSet, namespace); err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	var flowLogsURL string
+	if s.Dependencies != nil && s.Features.Enabled(featureFlowLogs) {
+		flowLogsURL = s.Dependencies.FlowLogsURL
+	}
+
+	dependents, err := getDeploymentDependents(r.Context(), s.K8sClientSet, s.Namespaces, flowLogsURL, namespace, name)
+	if apierrors.IsNotFound(err) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dependents)
+}
+
+// getDeploymentDependents maps the Services fronting a deployment and finds the workloads calling them
+//
+// Callers are found by references to the service DNS names in other deployments' env and args, and in flow logs when flowLogsURL is set.
+func getDeploymentDependents(ctx context.Context, clientset kubernetes.Interface, scope NamespaceScope, flowLogsURL, namespace, name string) (*DeploymentDependents, error) {
+	deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := servicesForDeployment(ctx, clientset, deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DeploymentDependents{
+		Deployment: WorkloadReference{Kind: "Deployment", Namespace: namespace, Name: name},
+		Services:   []WorkloadReference{},
+		Dependents: []Dependent{},
+	}
+	for _, service := range services {
+		result.Services = append(result.Services, WorkloadReference{Kind: "Service", Namespace: service.Namespace, Name: service.Name})
+	}
+
+	namespaces, err := scope.resolve(ctx, clientset)
+	if err != nil {
+		return nil, err
+	}
+
+	var deployments []appsv1.Deployment
+	for _, ns := range namespaces {
+		list, err := clientset.AppsV1().Deployments(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		deployments = append(deployments, list.Items...)
+	}
+
+	evidence := map[WorkloadReference][]string{}
+	for _, caller := range deployments {
+		if caller.Namespace == namespace && caller.Name == name {
+			continue
+		}
+		ref := WorkloadReference{Kind: "Deployment", Namespace: caller.Namespace, Name: caller.Name}
+		for _, service := range services {
+			if match := findServiceReference(caller, service); match != "" {
+				evidence[ref] = append(evidence[ref], match)
+			}
+		}
+	}
+
+	if flowLogsURL != "" {
+		records, err := fetchFlowLogs(ctx, flowLogsURL)
+		if err != nil {
+			return nil, fmt.Errorf("fetching flow logs: %w", err)
+		}
+		for _, record := range records {
+			if !flowTargetsDeployment(record, deployment, services) {
+				continue
+			}
+			if caller := deploymentForAggregatedName(deployments, record.SourceNamespace, record.SourceNameAggr); caller != nil {
+				if caller.Namespace == namespace && caller.Name == name {
+					continue
+				}
+				ref := WorkloadReference{Kind: "Deployment", Namespace: caller.Namespace, Name: caller.Name}
+				evidence[ref] = appendUnique(evidence[ref], "flow logs")
+			}
+		}
+	}
+
+	for ref, reasons := range evidence {
+		result.Dependents = append(result.Dependents, Dependent{WorkloadReference: ref, Evidence: reasons})
+	}
+	sort.Slice(result.Dependents, func(i, j int) bool {
+		a, b := result.Dependents[i], result.Dependents[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return result, nil
+}
+
+// servicesForDeployment returns the Services of the deployment namespace routing to its pods.
+//
+// Endpoints are preferred as they reflect the actual routing, the selector is used for services without endpoints.
+func servicesForDeployment(ctx context.Context, clientset kubernetes.Interface, deployment *appsv1.Deployment) ([]corev1.Service, error) {
+	podLabels := labels.Set(deployment.Spec.Template.Labels)
+
+	pods, err := clientset.CoreV1().Pods(deployment.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: metav1.FormatLabelSelector(deployment.Spec.Selector),
+	})
+	if err != nil {
+		return nil, err
+	}
+	podNames := map[string]bool{}
+	for _, pod := range pods.Items {
+		podNames[pod.Name] = true
+	}
+
+	services, err := clientset.CoreV1().Services(deployment.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []corev1.Service
+	for _, service := range services.Items {
+		if len(service.Spec.Selector) == 0 {
+			continue
+		}
+
+		endpoints, err := clientset.CoreV1().Endpoints(service.Namespace).Get(ctx, service.Name, metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if endpoints != nil && endpointsReferencePods(endpoints, podNames) {
+			matched = append(matched, service)
+			continue
+		}
+		if labels.SelectorFromSet(service.Spec.Selector).Matches(podLabels) {
+			matched = append(matched, service)
+		}
+	}
+	return matched, nil
+}
+
+func endpointsReferencePods(endpoints *corev1.Endpoints, podNames map[string]bool) bool {
+	for _, subset := range endpoints.Subsets {
+		for _, addresses := range [][]corev1.EndpointAddress{subset.Addresses, subset.NotReadyAddresses} {
+			for _, address := range addresses {
+				if address.TargetRef != nil && address.TargetRef.Kind == "Pod" && podNames[address.TargetRef.Name] {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// findServiceReference returns a description of where the caller references the service DNS name, or an empty string
+func findServiceReference(caller appsv1.Deployment, service corev1.Service) string {
+	pattern := regexp.QuoteMeta(service.Name) + `\.` + regexp.QuoteMeta(service.Namespace) + `(\.svc(\.cluster\.local)?)?`
+	if caller.Namespace == service.Namespace {
+		pattern = regexp.QuoteMeta(service.Name) + `(\.` + regexp.QuoteMeta(service.Namespace) + `(\.svc(\.cluster\.local)?)?)?`
+	}
+	host := regexp.MustCompile(`(^|[/@=\s,;"'])` + pattern + `($|[:/\s,;"'])`)
+
+	podSpec := caller.Spec.Template.Spec
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for _, container := range containers {
+			for _, env := range container.Env {
+				if host.MatchString(env.Value) {
+					return fmt.Sprintf("env %s of container %s references service %s", env.Name, container.Name, service.Name)
+				}
+			}
+			for _, args := range [][]string{container.Command, container.Args} {
+				for _, arg := range args {
+					if host.MatchString(arg) {
+						return fmt.Sprintf("args of container %s reference service %s", container.Name, service.Name)
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func flowTargetsDeployment(record FlowLogRecord, deployment *appsv1.Deployment, services []corev1.Service) bool {
+	for _, service := range services {
+		if record.DestServiceNamespace == service.Namespace && record.DestServiceName == service.Name {
+			return true
+		}
+	}
+	return record.DestNamespace == deployment.Namespace && strings.HasPrefix(record.DestNameAggr, deployment.Name+"-")
+}
+
+// deploymentForAggregatedName maps a flow log aggregated pod name (e.g. web-5c8f8bd7d4-*) back to its deployment
+func deploymentForAggregatedName(deployments []appsv1.Deployment, namespace, nameAggr string) *appsv1.Deployment {
+	var best *appsv1.Deployment
+	for i := range deployments {
+		deployment := &deployments[i]
+		if deployment.Namespace != namespace || !strings.HasPrefix(nameAggr, deployment.Name+"-") {
+			continue
+		}
+		if best == nil || len(deployment.Name) > len(best.Name) {
+			best = deployment
+		}
+	}
+	return best
+}
+
+func fetchFlowLogs(ctx context.Context, url string) ([]FlowLogRecord, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var records []FlowLogRecord
+	if err := json.NewDecoder(resp.Body).Decode(&records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testDeployment(namespace, name string, env ...corev1.EnvVar) *appsv1.Deployment {
+	labels := map[string]string{"app": name}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: name, Env: env}}},
+			},
+		},
+	}
+}
+
+func TestGetDeploymentDependents(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		testDeployment("payments", "ledger"),
+		testDeployment("payments", "api", corev1.EnvVar{Name: "LEDGER_URL", Value: "http://ledger:8080"}),
+		testDeployment("shop", "checkout", corev1.EnvVar{Name: "LEDGER_ADDR", Value: "ledger.payments.svc.cluster.local:8080"}),
+		testDeployment("shop", "cart", corev1.EnvVar{Name: "LEDGER_URL", Value: "http://ledger:8080"}),
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "ledger", Namespace: "payments"},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "ledger"}},
+		},
+	)
+
+	dependents, err := getDeploymentDependents(context.TODO(), clientset, NamespaceScope{}, "", "payments", "ledger")
+	assert.NoError(t, err)
+	assert.Equal(t, []WorkloadReference{{Kind: "Service", Namespace: "payments", Name: "ledger"}}, dependents.Services)
+
+	// cart references a "ledger" service in its own namespace, which doesn't exist
+	var names []string
+	for _, dependent := range dependents.Dependents {
+		names = append(names, dependent.Namespace+"/"+dependent.Name)
+	}
+	assert.Equal(t, []string{"payments/api", "shop/checkout"}, names)
+}
+
+func TestDeploymentForAggregatedName(t *testing.T) {
+	deployments := []appsv1.Deployment{
+		*testDeployment("shop", "web"),
+		*testDeployment("shop", "web-admin"),
+	}
+
+	assert.Equal(t, "web-admin", deploymentForAggregatedName(deployments, "shop", "web-admin-5c8f8bd7d4-*").Name)
+	assert.Equal(t, "web", deploymentForAggregatedName(deployments, "shop", "web-7d9f7c9b5-*").Name)
+	assert.Nil(t, deploymentForAggregatedName(deployments, "payments", "web-7d9f7c9b5-*"))
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// DeploymentCache keeps the deployments in scope in shared informers, so polling their health doesn't list every
+// deployment from the API server on each request.
+//
+// A scope of namespace names gets an informer per namespace, as the service may only be granted namespaced Roles
+// there, other scopes a single informer watching the whole cluster.
+type DeploymentCache struct {
+	factories []informers.SharedInformerFactory
+	// informers by namespace, metav1.NamespaceAll for the informer watching the whole cluster
+	informers map[string]cache.SharedIndexInformer
+
+	mu sync.Mutex
+	// subscribers get the updates of the deployments, until the cache stops
+	subscribers map[chan deploymentUpdate]struct{}
+	stopped     bool
+}
+
+// deploymentUpdate is a deployment as it was before and after an update the informers saw.
+type deploymentUpdate struct {
+	old, new *appsv1.Deployment
+}
+
+// subscriberBuffer is how many updates a subscriber may lag behind before the next ones are dropped.
+const subscriberBuffer = 64
+
+func newDeploymentCache(clientset kubernetes.Interface, scope NamespaceScope) *DeploymentCache {
+	namespaces := scope.Names
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	c := &DeploymentCache{informers: make(map[string]cache.SharedIndexInformer, len(namespaces)), subscribers: map[chan deploymentUpdate]struct{}{}}
+	for _, namespace := range namespaces {
+		factory := informers.NewSharedInformerFactoryWithOptions(clientset, 0, informers.WithNamespace(namespace))
+		informer := factory.Apps().V1().Deployments().Informer()
+		if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{UpdateFunc: c.publish}); err != nil {
+			slog.Warn("Failed watching deployment updates", "namespace", namespace, "error", err)
+		}
+		c.informers[namespace] = informer
+		c.factories = append(c.factories, factory)
+	}
+	return c
+}
+
+// subscribe returns the updates of the deployments from now on, until unsubscribe is called or the cache stops, which
+// closes the channel.
+func (c *DeploymentCache) subscribe() (updates <-chan deploymentUpdate, unsubscribe func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan deploymentUpdate, subscriberBuffer)
+	if c.stopped {
+		close(ch)
+		return ch, func() {}
+	}
+	c.subscribers[ch] = struct{}{}
+	return ch, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if _, ok := c.subscribers[ch]; ok {
+			delete(c.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// publish hands an update over to the subscribers, dropping it for those lagging behind rather than blocking the
+// informer.
+func (c *DeploymentCache) publish(oldObj, newObj interface{}) {
+	old, okOld := oldObj.(*appsv1.Deployment)
+	updated, okNew := newObj.(*appsv1.Deployment)
+	if !okOld || !okNew {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for ch := range c.subscribers {
+		select {
+		case ch <- deploymentUpdate{old: old, new: updated}:
+		default:
+			slog.Warn("Dropped a deployment update for a slow subscriber", "namespace", updated.Namespace, "deployment", updated.Name)
+		}
+	}
+}
+
+// run starts the informers and stops them once ctx is done.
+func (c *DeploymentCache) run(ctx context.Context) {
+	for _, factory := range c.factories {
+		factory.Start(ctx.Done())
+	}
+	for _, factory := range c.factories {
+		factory.WaitForCacheSync(ctx.Done())
+	}
+	if ctx.Err() == nil {
+		slog.InfoContext(ctx, "Deployment cache synced", "informers", len(c.informers))
+	}
+
+	<-ctx.Done()
+	for _, factory := range c.factories {
+		factory.Shutdown()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopped = true
+	for ch := range c.subscribers {
+		delete(c.subscribers, ch)
+		close(ch)
+	}
+}
+
+// synced reports whether every informer has listed its deployments once, the cache is empty until then.
+func (c *DeploymentCache) synced() bool {
+	for _, informer := range c.informers {
+		if !informer.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+// list returns the deployments of namespaces, metav1.NamespaceAll for every namespace, whose own labels match selector,
+// sorted by namespace and name like a list from the API server.
+//
+// The resource version is the one the informer is at, empty when several informers were read. ok is false when a
+// namespace isn't watched by the cache.
+func (c *DeploymentCache) list(namespaces []string, selector labels.Selector) (deployments []appsv1.Deployment, resourceVersion string, ok bool) {
+	read := map[cache.SharedIndexInformer]bool{}
+	for _, namespace := range namespaces {
+		informer, watched := c.informers[namespace]
+		if !watched {
+			if informer, watched = c.informers[metav1.NamespaceAll]; !watched {
+				return nil, "", false
+			}
+		}
+		read[informer] = true
+
+		err := cache.ListAllByNamespace(informer.GetIndexer(), namespace, selector, func(obj interface{}) {
+			deployments = append(deployments, *obj.(*appsv1.Deployment))
+		})
+		if err != nil {
+			return nil, "", false
+		}
+	}
+
+	sort.Slice(deployments, func(i, j int) bool {
+		if deployments[i].Namespace != deployments[j].Namespace {
+			return deployments[i].Namespace < deployments[j].Namespace
+		}
+		return deployments[i].Name < deployments[j].Name
+	})
+	if len(read) == 1 {
+		for informer := range read {
+			resourceVersion = informer.LastSyncResourceVersion()
+		}
+	}
+	return deployments, resourceVersion, true
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDeploymentCache(t *testing.T) {
+	replicas := int32(1)
+	deployment := func(namespace, name, tier string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"tier": tier}},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		}
+	}
+	clientset := fake.NewSimpleClientset(deployment("shop", "cart", "web"), deployment("payments", "ledger", "backend"), deployment("payments", "api", "web"), deployment("kube-system", "coredns", "dns"))
+	scope := NamespaceScope{Names: []string{"payments", "shop"}}
+	deployments := newDeploymentCache(clientset, scope)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go deployments.run(ctx)
+	assert.Eventually(t, deployments.synced, 5*time.Second, 10*time.Millisecond)
+
+	names := func(namespaces []string, selector labels.Selector) []string {
+		list, _, ok := deployments.list(namespaces, selector)
+		assert.True(t, ok)
+		var names []string
+		for _, deployment := range list {
+			names = append(names, deployment.Namespace+"/"+deployment.Name)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"payments/api", "payments/ledger", "shop/cart"}, names(scope.Names, labels.Everything()))
+	assert.Equal(t, []string{"payments/api", "shop/cart"}, names(scope.Names, labels.SelectorFromSet(labels.Set{"tier": "web"})))
+
+	_, _, ok := deployments.list([]string{"kube-system"}, labels.Everything())
+	assert.False(t, ok)
+
+	// The server reads its deployment health from the cache, which follows the changes to the deployments
+	server := Server{Namespaces: scope, Deployments: deployments}
+	_, err := clientset.AppsV1().Deployments("shop").Create(ctx, deployment("shop", "checkout", "web"), metav1.CreateOptions{})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		health, err := server.workloadsHealth(context.Background(), NamespaceScope{Names: []string{"shop"}}, nil, labels.Everything(), labels.Everything(), deploymentsPage{})
+		return err == nil && health.Cached && len(health.FailedDeployments) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestDeploymentCacheWatchesTheCluster(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"}})
+	deployments := newDeploymentCache(clientset, NamespaceScope{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go deployments.run(ctx)
+	assert.Eventually(t, deployments.synced, 5*time.Second, 10*time.Millisecond)
+
+	list, _, ok := deployments.list([]string{metav1.NamespaceAll}, labels.Everything())
+	assert.True(t, ok)
+	assert.Len(t, list, 1)
+	list, _, ok = deployments.list([]string{"default"}, labels.Everything())
+	assert.True(t, ok)
+	assert.Empty(t, list)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// streamKeepAlive is how often an idle stream gets a comment, so proxies don't close it.
+const streamKeepAlive = 30 * time.Second
+
+// States of a deployment in the stream, those of the categories of ClusterDeploymentsInfo
+const (
+	deploymentReady       = "ready"
+	deploymentFailed      = "failed"
+	deploymentStuck       = "stuck"
+	deploymentProgressing = "progressing"
+)
+
+// DeploymentTransition is a deployment changing state, e.g. from ready to failed.
+type DeploymentTransition struct {
+	Namespace  string         `json:"namespace"`
+	Name       string         `json:"deployment_name"`
+	From       string         `json:"from"`
+	To         string         `json:"to"`
+	Deployment DeploymentInfo `json:"deployment"`
+	At         time.Time      `json:"at"`
+}
+
+// Streams the deployments in scope changing state between ready, failed, stuck and progressing as Server-Sent Events,
+// filtered like the deployments of clusterDeploymentsInfoHandler
+//
+// The transitions are those the deployment cache sees, so it needs --deployment-cache, and the health criteria apply
+// but not the PromQL checks. The stream ends when the client goes away or the server shuts down.
+func (s *Server) streamDeploymentsHandler(w http.ResponseWriter, r *http.Request) {
+	if s.Deployments == nil {
+		writeErrorf(w, http.StatusServiceUnavailable, "Streaming the deployments needs the deployment cache, enable --deployment-cache")
+		return
+	}
+	filter, status, err := s.parseReportFilter(r)
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+	excluded := filter.excludedNamespaces(s.ExcludedNamespaces)
+	namespaces, err := filter.Scope.resolve(r.Context(), s.K8sClientSet)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	updates, unsubscribe := s.Deployments.subscribe()
+	defer unsubscribe()
+
+	controller := http.NewResponseController(w)
+	// The stream outlives any write deadline of the server
+	_ = controller.SetWriteDeadline(time.Time{})
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if err := controller.Flush(); err != nil {
+		slog.WarnContext(r.Context(), "Failed flushing the deployment stream", "error", err)
+		return
+	}
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		var message string
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			message = ": keep-alive\n\n"
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+			transition, ok := s.deploymentTransition(r.Context(), update, namespaces, excluded, filter.Selector)
+			if !ok {
+				continue
+			}
+			data, err := json.Marshal(transition)
+			if err != nil {
+				slog.ErrorContext(r.Context(), "Failed encoding a deployment transition", "error", err)
+				continue
+			}
+			message = fmt.Sprintf("event: transition\ndata: %s\n\n", data)
+		}
+
+		if _, err := fmt.Fprint(w, message); err != nil {
+			return
+		}
+		if err := controller.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// deploymentTransition returns the transition of an updated deployment of namespaces, metav1.NamespaceAll for every
+// namespace, out of the excluded ones, whose own labels match selector, false when it's out of scope or kept its state.
+func (s *Server) deploymentTransition(ctx context.Context, update deploymentUpdate, namespaces, excluded []string, selector labels.Selector) (DeploymentTransition, bool) {
+	deployment := update.new
+	inScope := (slices.Contains(namespaces, metav1.NamespaceAll) || slices.Contains(namespaces, deployment.Namespace)) &&
+		!slices.Contains(excluded, deployment.Namespace) && selector.Matches(labels.Set(deployment.Labels))
+	if !inScope {
+		return DeploymentTransition{}, false
+	}
+	from, _ := s.deploymentState(ctx, update.old)
+	to, info := s.deploymentState(ctx, deployment)
+	if from == to {
+		return DeploymentTransition{}, false
+	}
+	return DeploymentTransition{Namespace: deployment.Namespace, Name: deployment.Name, From: from, To: to, Deployment: info, At: time.Now()}, true
+}
+
+// deploymentState returns the state of a deployment and its DeploymentInfo, as the health reports would have it.
+func (s *Server) deploymentState(ctx context.Context, deployment *appsv1.Deployment) (string, DeploymentInfo) {
+	health := deploymentsHealth(ctx, []appsv1.Deployment{*deployment}, nil, s.Health, labels.Everything())
+	health.applyGrace(s.DeploymentGrace, time.Now())
+	switch {
+	case len(health.ReadyDeployments) > 0:
+		return deploymentReady, health.ReadyDeployments[0]
+	case len(health.StuckRollouts) > 0:
+		return deploymentStuck, health.StuckRollouts[0]
+	case len(health.Progressing) > 0:
+		return deploymentProgressing, health.Progressing[0]
+	default:
+		return deploymentFailed, health.FailedDeployments[0]
+	}
+}
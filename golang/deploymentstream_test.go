@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestStreamDeployments(t *testing.T) {
+	replicas := int32(2)
+	deployment := func(namespace, name string, ready int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: ready, AvailableReplicas: ready},
+		}
+	}
+	clientset := fake.NewSimpleClientset(deployment("shop", "cart", 2), deployment("kube-system", "coredns", 2))
+	deployments := newDeploymentCache(clientset, NamespaceScope{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go deployments.run(ctx)
+	assert.Eventually(t, deployments.synced, 5*time.Second, 10*time.Millisecond)
+
+	server := &Server{Deployments: deployments, ExcludedNamespaces: []string{"kube-system"}}
+	ts := httptest.NewServer(http.HandlerFunc(server.streamDeploymentsHandler))
+	defer ts.Close()
+	resp, err := http.Get(ts.URL)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// The excluded namespace and an update keeping the deployment ready aren't streamed
+	update := func(namespace, name string, ready int32) {
+		updated := deployment(namespace, name, ready)
+		updated.Generation = int64(time.Now().UnixNano())
+		_, err := clientset.AppsV1().Deployments(namespace).Update(ctx, updated, metav1.UpdateOptions{})
+		assert.NoError(t, err)
+	}
+	update("kube-system", "coredns", 0)
+	update("shop", "cart", 2)
+	update("shop", "cart", 1)
+
+	reader := bufio.NewReader(resp.Body)
+	var lines []string
+	for len(lines) < 2 {
+		line, err := reader.ReadString('\n')
+		if !assert.NoError(t, err) {
+			return
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	assert.Equal(t, "event: transition", lines[0])
+	var transition DeploymentTransition
+	assert.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(lines[1], "data: ")), &transition))
+	assert.Equal(t, "cart", transition.Name)
+	assert.Equal(t, deploymentReady, transition.From)
+	assert.Equal(t, deploymentFailed, transition.To)
+	assert.Equal(t, int32(1), transition.Deployment.ReadyPods)
+
+	// Stopping the cache ends the stream
+	cancel()
+	rest, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Empty(t, strings.TrimSpace(string(rest)))
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxDiagnosisEvents is how many of the most recent warning events a failure diagnosis keeps.
+const maxDiagnosisEvents = 5
+
+// Failure reasons not taken from a container or a deployment condition
+const (
+	failureUnschedulable  = "Unschedulable"
+	failureReadinessProbe = "ReadinessProbeFailing"
+	failurePending        = "Pending"
+	failureNoFailingPods  = "NoFailingPods"
+)
+
+// progressDeadlineExceeded is the reason of the Progressing condition of a deployment whose rollout is stuck.
+const progressDeadlineExceeded = "ProgressDeadlineExceeded"
+
+// FailureDiagnosis tells why a deployment is failing: the reason most of its failing pods share, or that of its
+// ReplicaFailure condition when it couldn't create them, and its most recent warning events.
+type FailureDiagnosis struct {
+	Reason string `json:"reason"`
+	// Message is that of a pod failing with Reason, or of the deployment condition
+	Message     string         `json:"message,omitempty"`
+	FailingPods int            `json:"failing_pods"`
+	Events      []WarningEvent `json:"events,omitempty"`
+	// Usage is the current usage of its pods against their limits, when metrics-server is available
+	Usage []PodUsage `json:"usage,omitempty"`
+}
+
+// WarningEvent is a warning event about a deployment, one of its ReplicaSets or one of its pods.
+type WarningEvent struct {
+	Object   string    `json:"object"`
+	Reason   string    `json:"reason"`
+	Message  string    `json:"message"`
+	Count    int32     `json:"count"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// diagnoseFailures attaches a FailureDiagnosis to each failed deployment and stuck rollout of info, with the usage of
+// its pods when metrics isn't nil. A deployment which can't be diagnosed is left without, the report is still worth
+// returning.
+func diagnoseFailures(ctx context.Context, clientset kubernetes.Interface, metrics *MetricsClient, info *ClusterDeploymentsInfo) {
+	// The warning events are listed once per namespace rather than once per deployment
+	events := map[string][]corev1.Event{}
+	unhealthy := append(append([]*DeploymentInfo{}, pointers(info.FailedDeployments)...), pointers(info.StuckRollouts)...)
+	for _, failed := range unhealthy {
+		if _, ok := events[failed.Namespace]; !ok {
+			list, err := clientset.CoreV1().Events(failed.Namespace).List(ctx, metav1.ListOptions{FieldSelector: "type=" + corev1.EventTypeWarning})
+			if err != nil {
+				slog.WarnContext(ctx, "Failed listing warning events", "namespace", failed.Namespace, "error", err)
+			} else {
+				events[failed.Namespace] = list.Items
+			}
+		}
+
+		diagnosis, err := diagnoseDeployment(ctx, clientset, metrics, failed.Namespace, failed.Name, events[failed.Namespace])
+		if err != nil {
+			slog.WarnContext(ctx, "Failed diagnosing deployment", "namespace", failed.Namespace, "deployment", failed.Name, "error", err)
+			continue
+		}
+		failed.Failure = diagnosis
+	}
+}
+
+// pointers returns pointers to the deployments, to be updated in place.
+func pointers(deployments []DeploymentInfo) []*DeploymentInfo {
+	result := make([]*DeploymentInfo, len(deployments))
+	for i := range deployments {
+		result[i] = &deployments[i]
+	}
+	return result
+}
+
+// diagnoseDeployment finds the dominant failure reason of the pods of a deployment and picks its warnings out of events.
+func diagnoseDeployment(ctx context.Context, clientset kubernetes.Interface, metrics *MetricsClient, namespace, name string, events []corev1.Event) (*FailureDiagnosis, error) {
+	deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	diagnosis := &FailureDiagnosis{}
+	counts, messages := map[string]int{}, map[string]string{}
+	podNames := map[string]bool{}
+	for _, pod := range pods.Items {
+		podNames[pod.Name] = true
+		reason, message := podFailure(pod)
+		if reason == "" {
+			continue
+		}
+		diagnosis.FailingPods++
+		counts[reason]++
+		if messages[reason] == "" {
+			messages[reason] = message
+		}
+	}
+
+	switch {
+	case len(counts) > 0:
+		diagnosis.Reason = dominantReason(counts)
+		diagnosis.Message = messages[diagnosis.Reason]
+	default:
+		// Without failing pods, the deployment itself tells why it can't create or roll them out
+		diagnosis.Reason = failureNoFailingPods
+		for _, condition := range deployment.Status.Conditions {
+			switch {
+			case condition.Type == appsv1.DeploymentReplicaFailure && condition.Status == corev1.ConditionTrue:
+				diagnosis.Reason, diagnosis.Message = condition.Reason, condition.Message
+			case condition.Type == appsv1.DeploymentProgressing && condition.Reason == progressDeadlineExceeded && diagnosis.Reason == failureNoFailingPods:
+				diagnosis.Reason, diagnosis.Message = condition.Reason, condition.Message
+			}
+		}
+	}
+
+	diagnosis.Events = deploymentWarnings(events, name, podNames)
+	diagnoseUsage(ctx, metrics, diagnosis, namespace, selector, pods.Items)
+	return diagnosis, nil
+}
+
+// podFailure returns why a pod isn't ready, or an empty reason when it is.
+func podFailure(pod corev1.Pod) (reason, message string) {
+	if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded {
+		return "", ""
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse {
+			return failureUnschedulable, condition.Message
+		}
+	}
+
+	// A waiting container, an init container first, tells the most: ImagePullBackOff, CrashLoopBackOff...
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		waiting := status.State.Waiting
+		if waiting == nil || waiting.Reason == "" || waiting.Reason == "ContainerCreating" || waiting.Reason == "PodInitializing" {
+			continue
+		}
+		message = waiting.Message
+		if terminated := status.LastTerminationState.Terminated; terminated != nil && terminated.Reason != "" {
+			message = strings.TrimSpace(fmt.Sprintf("%s (last terminated: %s, exit code %d)", message, terminated.Reason, terminated.ExitCode))
+		}
+		return waiting.Reason, message
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Running != nil && !status.Ready {
+			return failureReadinessProbe, fmt.Sprintf("container %s is running but not ready", status.Name)
+		}
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+			return "", ""
+		}
+	}
+	return failurePending, pod.Status.Message
+}
+
+// dominantReason returns the reason of the most pods, the first by name on a tie.
+func dominantReason(counts map[string]int) string {
+	var dominant string
+	for reason, count := range counts {
+		if count > counts[dominant] || count == counts[dominant] && reason < dominant {
+			dominant = reason
+		}
+	}
+	return dominant
+}
+
+// deploymentWarnings returns the most recent warnings about the deployment, its ReplicaSets, named after it, or its pods.
+func deploymentWarnings(events []corev1.Event, name string, pods map[string]bool) []WarningEvent {
+	var warnings []WarningEvent
+	for _, event := range events {
+		object := event.InvolvedObject
+		if event.Type != corev1.EventTypeWarning {
+			continue
+		}
+		relevant := object.Kind == "Deployment" && object.Name == name ||
+			object.Kind == "ReplicaSet" && strings.HasPrefix(object.Name, name+"-") ||
+			object.Kind == "Pod" && pods[object.Name]
+		if !relevant {
+			continue
+		}
+
+		warnings = append(warnings, newWarningEvent(event))
+	}
+
+	sort.SliceStable(warnings, func(i, j int) bool { return warnings[i].LastSeen.After(warnings[j].LastSeen) })
+	if len(warnings) > maxDiagnosisEvents {
+		warnings = warnings[:maxDiagnosisEvents]
+	}
+	return warnings
+}
+
+// newWarningEvent returns the WarningEvent of an event, whether it was recorded by the core/v1 or the events.k8s.io
+// API, the latter setting EventTime and Series rather than LastTimestamp and Count.
+func newWarningEvent(event corev1.Event) WarningEvent {
+	warning := WarningEvent{
+		Object:   strings.ToLower(event.InvolvedObject.Kind) + "/" + event.InvolvedObject.Name,
+		Reason:   event.Reason,
+		Message:  event.Message,
+		Count:    event.Count,
+		LastSeen: event.LastTimestamp.Time,
+	}
+	if event.Series != nil {
+		warning.Count = event.Series.Count
+		warning.LastSeen = event.Series.LastObservedTime.Time
+	}
+	if warning.LastSeen.IsZero() {
+		warning.LastSeen = event.EventTime.Time
+	}
+	return warning
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDiagnoseFailures(t *testing.T) {
+	deployment := func(name string, conditions ...appsv1.DeploymentCondition) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "payments"},
+			Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}}},
+			Status:     appsv1.DeploymentStatus{Conditions: conditions},
+		}
+	}
+	pod := func(name, app string, status corev1.PodStatus) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "payments", Labels: map[string]string{"app": app}}, Status: status}
+	}
+	waiting := func(reason string) corev1.PodStatus {
+		return corev1.PodStatus{Phase: corev1.PodPending, ContainerStatuses: []corev1.ContainerStatus{{Name: "app", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason, Message: "back-off"}}}}}
+	}
+	warning := func(kind, name, reason string, seen time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name + "." + reason, Namespace: "payments"},
+			InvolvedObject: corev1.ObjectReference{Kind: kind, Name: name},
+			Type:           corev1.EventTypeWarning, Reason: reason, Count: 3, LastTimestamp: metav1.NewTime(seen),
+		}
+	}
+	now := time.Date(2024, 7, 8, 12, 0, 0, 0, time.UTC)
+
+	clientset := fake.NewSimpleClientset(
+		deployment("ledger"),
+		pod("ledger-1", "ledger", waiting("CrashLoopBackOff")),
+		pod("ledger-2", "ledger", waiting("CrashLoopBackOff")),
+		pod("ledger-3", "ledger", waiting("ImagePullBackOff")),
+		pod("ledger-4", "ledger", corev1.PodStatus{Phase: corev1.PodRunning, Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}}),
+		warning("Pod", "ledger-1", "BackOff", now),
+		warning("ReplicaSet", "ledger-7d9f", "FailedCreate", now.Add(-time.Minute)),
+		warning("Pod", "cart-1", "BackOff", now),
+		deployment("cart", appsv1.DeploymentCondition{Type: appsv1.DeploymentReplicaFailure, Status: corev1.ConditionTrue, Reason: "FailedCreate", Message: `pods "cart-x" is forbidden: exceeded quota`}),
+		deployment("api"),
+		pod("api-1", "api", corev1.PodStatus{Phase: corev1.PodPending, Conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Message: "0/3 nodes are available"}}}),
+		pod("api-2", "api", corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{{Name: "api", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}}}}),
+	)
+	info := &ClusterDeploymentsInfo{
+		FailedDeployments: []DeploymentInfo{{Name: "ledger", Namespace: "payments"}, {Name: "api", Namespace: "payments"}, {Name: "gone", Namespace: "payments"}},
+		StuckRollouts:     []DeploymentInfo{{Name: "cart", Namespace: "payments"}},
+	}
+	diagnoseFailures(context.Background(), clientset, nil, info)
+
+	ledger := info.FailedDeployments[0].Failure
+	if assert.NotNil(t, ledger) {
+		assert.Equal(t, "CrashLoopBackOff", ledger.Reason)
+		assert.Equal(t, 3, ledger.FailingPods)
+		assert.Equal(t, []WarningEvent{
+			{Object: "pod/ledger-1", Reason: "BackOff", Count: 3, LastSeen: now},
+			{Object: "replicaset/ledger-7d9f", Reason: "FailedCreate", Count: 3, LastSeen: now.Add(-time.Minute)},
+		}, ledger.Events)
+	}
+	assert.Equal(t, &FailureDiagnosis{Reason: "FailedCreate", Message: `pods "cart-x" is forbidden: exceeded quota`}, info.StuckRollouts[0].Failure)
+	// A tie goes to the first reason by name
+	api := info.FailedDeployments[1].Failure
+	if assert.NotNil(t, api) {
+		assert.Equal(t, failureReadinessProbe, api.Reason)
+		assert.Equal(t, 2, api.FailingPods)
+	}
+	assert.Nil(t, info.FailedDeployments[2].Failure)
+}
+
+func TestPodFailure(t *testing.T) {
+	reason, message := podFailure(corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+		State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off 5m0s"}},
+		LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+	}}}})
+	assert.Equal(t, "CrashLoopBackOff", reason)
+	assert.Equal(t, "back-off 5m0s (last terminated: OOMKilled, exit code 137)", message)
+
+	reason, _ = podFailure(corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodSucceeded}})
+	assert.Empty(t, reason)
+}
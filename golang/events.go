@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const eventSourceComponent = "tyk-sre-assignment"
+
+// recordEvent emits a Normal Kubernetes Event on the object, annotated with the request metadata.
+//
+// Events are best effort, a failure is logged and never fails the operation.
+func recordEvent(ctx context.Context, clientset kubernetes.Interface, involved corev1.ObjectReference, reason, message string, meta RequestMetadata) {
+	now := metav1.NewTime(time.Now())
+	annotations := map[string]string{}
+	meta.annotate(annotations)
+
+	if meta.CorrelationID != "" {
+		message = fmt.Sprintf("%s (correlation id %s)", message, meta.CorrelationID)
+	}
+
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s.%x", involved.Name, now.UnixNano()),
+			Namespace:   involved.Namespace,
+			Annotations: annotations,
+		},
+		InvolvedObject: involved,
+		Reason:         reason,
+		Message:        message,
+		Type:           corev1.EventTypeNormal,
+		Source:         corev1.EventSource{Component: eventSourceComponent},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+
+	// Like kubectl does for nodes, the events of cluster-scoped objects go to the default namespace
+	if event.Namespace == "" {
+		event.Namespace = metav1.NamespaceDefault
+	}
+	_, err := clientset.CoreV1().Events(event.Namespace).Create(ctx, event, metav1.CreateOptions{})
+	if err != nil {
+		slog.WarnContext(ctx, "Failed recording event", "reason", reason, "namespace", involved.Namespace, "name", involved.Name, "error", err)
+	}
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+const (
+	execTimeout     = 30 * time.Second
+	execOutputLimit = 64 * 1024
+)
+
+// ExecRequest runs one of the allowlisted diagnostic commands inside a pod.
+type ExecRequest struct {
+	Container string   `json:"container,omitempty"`
+	Command   string   `json:"command"`
+	Args      []string `json:"args"`
+}
+
+type ExecResult struct {
+	Command  []string `json:"command"`
+	ExitCode int      `json:"exit_code"`
+	Stdout   string   `json:"stdout"`
+	Stderr   string   `json:"stderr"`
+}
+
+// diagnosticCommands maps the allowed command names to builders validating the arguments.
+//
+// Commands are never run through a shell, every argument is validated before it reaches the pod.
+var diagnosticCommands = map[string]func(args []string) ([]string, error){
+	"nslookup": func(args []string) ([]string, error) {
+		if len(args) != 1 || !isHostname(args[0]) {
+			return nil, errors.New("nslookup expects a single hostname or IP")
+		}
+		return []string{"nslookup", args[0]}, nil
+	},
+	"getent-hosts": func(args []string) ([]string, error) {
+		if len(args) != 1 || !isHostname(args[0]) {
+			return nil, errors.New("getent-hosts expects a single hostname or IP")
+		}
+		return []string{"getent", "hosts", args[0]}, nil
+	},
+	"curl": func(args []string) ([]string, error) {
+		if len(args) != 1 || !isHTTPURL(args[0]) {
+			return nil, errors.New("curl expects a single http or https URL")
+		}
+		return []string{"curl", "-sS", "-o", "/dev/null", "-w", "%{http_code} %{time_total}s\n", "--max-time", "10", args[0]}, nil
+	},
+	"resolv-conf": func(args []string) ([]string, error) {
+		if len(args) != 0 {
+			return nil, errors.New("resolv-conf takes no arguments")
+		}
+		return []string{"cat", "/etc/resolv.conf"}, nil
+	},
+}
+
+// Handler running an allowlisted diagnostic command in a pod through the exec subresource
+//
+// Every attempt the authorizers allowed is audited, whether the command ran or not. Denied ones are audited by them.
+func (s *Server) execPodHandler(w http.ResponseWriter, r *http.Request) {
+	namespace, podName := r.PathValue("namespace"), r.PathValue("pod")
+
+	if err := s.Namespaces.check(r.Context(), s.K8sClientSet, namespace); err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		readBodyError(w, err)
+		return
+	}
+
+	defer r.Body.Close()
+
+	var request ExecRequest
+	var command []string
+	if err := decodeStrictJSON(body, &request); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if !s.authorize(w, r, operationPodExec, namespace, podName, request) {
+		return
+	}
+
+	audit := AuditEvent{
+		Action:          operationPodExec.Action,
+		RequestMetadata: requestMetadata(r.Context()),
+		RemoteAddr:      r.RemoteAddr,
+		Namespace:       namespace,
+		Target:          podName,
+		Request:         request,
+	}
+
+	build, ok := diagnosticCommands[request.Command]
+	if !ok {
+		err = fmt.Errorf("command %q is not allowed", request.Command)
+	} else {
+		command, err = build(request.Args)
+	}
+	if err != nil {
+		audit.Result = "rejected"
+		audit.Error = err.Error()
+		recordAudit(audit)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	pod, err := s.K8sClientSet.CoreV1().Pods(namespace).Get(r.Context(), podName, metav1.GetOptions{})
+	if err != nil {
+		audit.Result, audit.Error = "failed", err.Error()
+		status := kubeErrorStatus(err)
+		if apierrors.IsNotFound(err) {
+			audit.Result, status = "not found", http.StatusNotFound
+		}
+		recordAudit(audit)
+		writeError(w, status, err)
+		return
+	}
+	if request.Container == "" && len(pod.Spec.Containers) > 0 {
+		request.Container = pod.Spec.Containers[0].Name
+	}
+
+	reservation, err := s.Guardrails.reserve(budgetPodOperations, namespace, 1, time.Now())
+	if writeGuardrailError(w, err) {
+		audit.Result, audit.Error = "rejected", err.Error()
+		recordAudit(audit)
+		return
+	}
+
+	result, err := s.execInPod(r.Context(), namespace, podName, request.Container, command)
+	if err != nil {
+		reservation.release(1)
+		audit.Result, audit.Error = "failed", err.Error()
+		recordAudit(audit)
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	audit.Result = "exit " + strconv.Itoa(result.ExitCode)
+	recordAudit(audit)
+	recordEvent(r.Context(), s.K8sClientSet, corev1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Namespace:  namespace,
+		Name:       podName,
+		UID:        pod.UID,
+		FieldPath:  fmt.Sprintf("spec.containers{%s}", request.Container),
+	}, "DiagnosticCommand", fmt.Sprintf("Ran %s, exit code %d", request.Command, result.ExitCode), audit.RequestMetadata)
+	writeJSON(w, http.StatusOK, result)
+}
+
+// execInPod runs command in the container and collects its output, a non-zero exit code isn't an error.
+func (s *Server) execInPod(ctx context.Context, namespace, pod, container string, command []string) (*ExecResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, execTimeout)
+	defer cancel()
+
+	req := s.K8sClientSet.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(s.K8sConfig, http.MethodPost, req.URL())
+	if err != nil {
+		return nil, err
+	}
+
+	stdout := &limitedBuffer{limit: execOutputLimit}
+	stderr := &limitedBuffer{limit: execOutputLimit}
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: stdout, Stderr: stderr})
+
+	result := &ExecResult{Command: command, Stdout: stdout.String(), Stderr: stderr.String()}
+	var exitErr utilexec.ExitError
+	if errors.As(err, &exitErr) {
+		result.ExitCode = exitErr.ExitStatus()
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// limitedBuffer keeps the first limit bytes written to it and discards the rest.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - b.Len(); remaining > 0 {
+		if len(p) > remaining {
+			b.Buffer.Write(p[:remaining])
+		} else {
+			b.Buffer.Write(p)
+		}
+	}
+	return len(p), nil
+}
+
+func isHostname(value string) bool {
+	if net.ParseIP(value) != nil {
+		return true
+	}
+	return len(validation.IsDNS1123Subdomain(value)) == 0
+}
+
+func isHTTPURL(value string) bool {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.User != nil {
+		return false
+	}
+	return isHostname(u.Hostname())
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const defaultExportInterval = time.Minute
+
+// ExportConfig configures the periodic export of health snapshots to external storage.
+type ExportConfig struct {
+	Interval    metav1.Duration    `json:"interval,omitempty"`
+	RemoteWrite *RemoteWriteConfig `json:"remote_write,omitempty"`
+	S3          *S3ExportConfig    `json:"s3,omitempty"`
+}
+
+// HealthSnapshot is the deployments health of the cluster at a point in time.
+type HealthSnapshot struct {
+	Time        time.Time
+	Deployments *ClusterDeploymentsInfo
+}
+
+// snapshotSink writes health snapshots to an external system.
+type snapshotSink interface {
+	Name() string
+	Export(ctx context.Context, snapshot HealthSnapshot) error
+}
+
+// SnapshotExporter periodically scans the deployments health and sends it to every configured sink.
+type SnapshotExporter struct {
+	interval time.Duration
+	sinks    []snapshotSink
+	// deployments reads the health of the deployments, see Server.reportedDeploymentsHealth
+	deployments func(context.Context) (*ClusterDeploymentsInfo, error)
+}
+
+func newSnapshotExporter(config *ExportConfig, deployments func(context.Context) (*ClusterDeploymentsInfo, error)) (*SnapshotExporter, error) {
+	exporter := &SnapshotExporter{
+		interval:    config.Interval.Duration,
+		deployments: deployments,
+	}
+	if exporter.interval == 0 {
+		exporter.interval = defaultExportInterval
+	}
+
+	if config.RemoteWrite != nil {
+		sink, err := newRemoteWriteSink(config.RemoteWrite)
+		if err != nil {
+			return nil, err
+		}
+		exporter.sinks = append(exporter.sinks, sink)
+	}
+	if config.S3 != nil {
+		sink, err := newS3Sink(config.S3)
+		if err != nil {
+			return nil, err
+		}
+		exporter.sinks = append(exporter.sinks, sink)
+	}
+
+	if len(exporter.sinks) == 0 {
+		return nil, errors.New("export needs at least one of remote_write or s3")
+	}
+	return exporter, nil
+}
+
+// run exports a snapshot every interval until ctx is done.
+func (e *SnapshotExporter) run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		e.exportOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// exportOnce takes a snapshot and sends it to every sink, a failing sink doesn't stop the others.
+func (e *SnapshotExporter) exportOnce(ctx context.Context) {
+	deployments, err := e.deployments(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed taking health snapshot", "error", err)
+		return
+	}
+
+	snapshot := HealthSnapshot{Time: time.Now().UTC(), Deployments: deployments}
+	for _, sink := range e.sinks {
+		sinkCtx, cancel := context.WithTimeout(ctx, e.interval)
+		if err := sink.Export(sinkCtx, snapshot); err != nil {
+			slog.ErrorContext(ctx, "Failed exporting health snapshot", "sink", sink.Name(), "error", err)
+		}
+		cancel()
+	}
+}
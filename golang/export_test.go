@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testSnapshot() HealthSnapshot {
+	return HealthSnapshot{
+		Time: time.Date(2024, 7, 8, 20, 21, 4, 0, time.UTC),
+		Deployments: &ClusterDeploymentsInfo{
+			ReadyDeployments:  []DeploymentInfo{{Namespace: "default", Name: "api", RequestedPods: 2, ReadyPods: 2}},
+			FailedDeployments: []DeploymentInfo{{Namespace: "payments", Name: "ledger", RequestedPods: 3, ReadyPods: 1}},
+		},
+	}
+}
+
+func TestSnappyEncode(t *testing.T) {
+	assert.Equal(t, []byte{3, 2 << 2, 'a', 'b', 'c'}, snappyEncode([]byte("abc")))
+
+	long := snappyEncode([]byte(strings.Repeat("x", 100)))
+	assert.Equal(t, []byte{100, 60 << 2, 99}, long[:3])
+	assert.Len(t, long, 103)
+}
+
+func TestRemoteWriteSinkExport(t *testing.T) {
+	var headers http.Header
+	var body []byte
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer endpoint.Close()
+
+	sink, err := newRemoteWriteSink(&RemoteWriteConfig{URL: endpoint.URL, Headers: map[string]string{"X-Scope-OrgID": "sre"}})
+	assert.NoError(t, err)
+	assert.NoError(t, sink.Export(context.TODO(), testSnapshot()))
+
+	assert.Equal(t, "snappy", headers.Get("Content-Encoding"))
+	assert.Equal(t, "sre", headers.Get("X-Scope-OrgID"))
+	assert.Contains(t, string(body), "tyk_sre_deployment_ready_pods")
+	assert.Contains(t, string(body), "ledger")
+	assert.Contains(t, string(body), "\x09namespace\x12\x08payments")
+}
+
+func TestS3SinkExport(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	var req *http.Request
+	var body []byte
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer endpoint.Close()
+
+	sink, err := newS3Sink(&S3ExportConfig{Bucket: "health", Region: "eu-west-1", Prefix: "/snapshots/", Endpoint: endpoint.URL})
+	assert.NoError(t, err)
+	assert.NoError(t, sink.Export(context.TODO(), testSnapshot()))
+
+	assert.Equal(t, http.MethodPut, req.Method)
+	assert.Equal(t, "/health/snapshots/2024/07/08/20240708T202104Z.jsonl", req.URL.Path)
+	assert.True(t, strings.HasPrefix(req.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240708/eu-west-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature="))
+	assert.Equal(t, 2, strings.Count(string(body), "\n"))
+}
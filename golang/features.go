@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// Runtime feature flags, each one a kill switch for a risky or expensive feature.
+const (
+	featureBulkIsolation    = "bulk_isolation"
+	featureDebugContainers  = "debug_containers"
+	featurePodExec          = "pod_exec"
+	featurePrometheusChecks = "prometheus_checks"
+	featureFlowLogs         = "flow_logs"
+	featureSelftest         = "selftest"
+)
+
+var defaultFeatures = map[string]bool{
+	featureBulkIsolation:    true,
+	featureDebugContainers:  true,
+	featurePodExec:          true,
+	featurePrometheusChecks: true,
+	featureFlowLogs:         true,
+	featureSelftest:         true,
+}
+
+// FeatureFlags holds the feature switches, set from the config file and toggled at runtime through the admin listener.
+//
+// A nil FeatureFlags has every feature at its default.
+type FeatureFlags struct {
+	mu    sync.RWMutex
+	flags map[string]bool
+}
+
+func newFeatureFlags(overrides map[string]bool) (*FeatureFlags, error) {
+	features := &FeatureFlags{flags: map[string]bool{}}
+	for name, enabled := range defaultFeatures {
+		features.flags[name] = enabled
+	}
+	for name, enabled := range overrides {
+		if err := features.Set(name, enabled); err != nil {
+			return nil, err
+		}
+	}
+	return features, nil
+}
+
+// Enabled reports whether the feature is switched on.
+func (f *FeatureFlags) Enabled(name string) bool {
+	if f == nil {
+		return defaultFeatures[name]
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.flags[name]
+}
+
+// Set switches a known feature on or off.
+func (f *FeatureFlags) Set(name string, enabled bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.flags[name]; !ok {
+		return fmt.Errorf("unknown feature %q", name)
+	}
+	f.flags[name] = enabled
+	return nil
+}
+
+// Snapshot returns a copy of every feature and its state.
+func (f *FeatureFlags) Snapshot() map[string]bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	snapshot := make(map[string]bool, len(f.flags))
+	for name, enabled := range f.flags {
+		snapshot[name] = enabled
+	}
+	return snapshot
+}
+
+// require responds with 503 instead of calling next while the feature is disabled.
+func (f *FeatureFlags) require(name string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !f.Enabled(name) {
+			writeErrorf(w, http.StatusServiceUnavailable, "Feature %s is disabled", name)
+			return
+		}
+		next(w, r)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// fleetClusterTimeout bounds the health scan of each cluster of the fleet, so an unreachable one doesn't hold up the report.
+const fleetClusterTimeout = 20 * time.Second
+
+// FleetDeploymentsInfo is the deployment health of every cluster the service manages, by cluster name.
+type FleetDeploymentsInfo struct {
+	Clusters []ClusterHealth `json:"clusters"`
+}
+
+// ClusterHealth is the deployment health of a cluster, or the error which kept it from being scanned.
+type ClusterHealth struct {
+	Cluster string `json:"cluster"`
+	*ClusterDeploymentsInfo
+	Error string `json:"error,omitempty"`
+}
+
+// fleet is every cluster the service manages, the cluster of --kubeconfig included, by name.
+type fleet map[string]Server
+
+// Handler reporting the deployment health of every cluster, scanned concurrently
+//
+// A cluster failing to be scanned is reported with its error rather than failing the whole report.
+func (f fleet) deploymentsInfoHandler(w http.ResponseWriter, r *http.Request) {
+	scans := make(map[string]func(context.Context) (*ClusterDeploymentsInfo, error), len(f))
+	for name, server := range f {
+		prometheus := server.Prometheus
+		if !server.Features.Enabled(featurePrometheusChecks) {
+			prometheus = nil
+		}
+		scans[name] = func(ctx context.Context) (*ClusterDeploymentsInfo, error) {
+			info, err := server.workloadsHealth(ctx, server.Namespaces, prometheus, labels.Everything(), labels.Everything(), deploymentsPage{})
+			if err == nil {
+				info.excludeNamespaces(server.ExcludedNamespaces)
+			}
+			return info, err
+		}
+	}
+	writeJSON(w, http.StatusOK, fleetDeploymentsInfo(r.Context(), scans, fleetClusterTimeout))
+}
+
+// fleetDeploymentsInfo runs the scans of every cluster concurrently, each one for up to timeout.
+func fleetDeploymentsInfo(ctx context.Context, scans map[string]func(context.Context) (*ClusterDeploymentsInfo, error), timeout time.Duration) *FleetDeploymentsInfo {
+	results := make(chan ClusterHealth, len(scans))
+	for name, scan := range scans {
+		go func(name string, scan func(context.Context) (*ClusterDeploymentsInfo, error)) {
+			// The context of a scan timing out is cancelled, a scan still running is left to finish and its result dropped
+			scanCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			done := make(chan ClusterHealth, 1)
+			go func() {
+				info, err := scan(scanCtx)
+				if err != nil {
+					done <- ClusterHealth{Cluster: name, Error: err.Error()}
+					return
+				}
+				done <- ClusterHealth{Cluster: name, ClusterDeploymentsInfo: info}
+			}()
+
+			timer := time.NewTimer(timeout)
+			defer timer.Stop()
+			select {
+			case health := <-done:
+				results <- health
+			case <-timer.C:
+				results <- ClusterHealth{Cluster: name, Error: fmt.Sprintf("timed out after %s", timeout)}
+			case <-ctx.Done():
+				results <- ClusterHealth{Cluster: name, Error: ctx.Err().Error()}
+			}
+		}(name, scan)
+	}
+
+	report := &FleetDeploymentsInfo{Clusters: make([]ClusterHealth, 0, len(scans))}
+	for range scans {
+		report.Clusters = append(report.Clusters, <-results)
+	}
+	sort.Slice(report.Clusters, func(i, j int) bool { return report.Clusters[i].Cluster < report.Clusters[j].Cluster })
+	return report
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFleetDeploymentsInfo(t *testing.T) {
+	hung := make(chan struct{})
+	defer close(hung)
+
+	report := fleetDeploymentsInfo(context.Background(), map[string]func(context.Context) (*ClusterDeploymentsInfo, error){
+		"us-east": func(context.Context) (*ClusterDeploymentsInfo, error) {
+			return &ClusterDeploymentsInfo{ReadyDeployments: []DeploymentInfo{{Name: "ledger", RequestedPods: 2, ReadyPods: 2}}}, nil
+		},
+		"eu-west": func(context.Context) (*ClusterDeploymentsInfo, error) {
+			return nil, errors.New("connection refused")
+		},
+		"ap-south": func(context.Context) (*ClusterDeploymentsInfo, error) {
+			<-hung
+			return &ClusterDeploymentsInfo{}, nil
+		},
+	}, 50*time.Millisecond)
+
+	if !assert.Len(t, report.Clusters, 3) {
+		return
+	}
+	assert.Equal(t, ClusterHealth{Cluster: "ap-south", Error: "timed out after 50ms"}, report.Clusters[0])
+	assert.Equal(t, ClusterHealth{Cluster: "eu-west", Error: "connection refused"}, report.Clusters[1])
+	assert.Equal(t, "us-east", report.Clusters[2].Cluster)
+	if assert.NotNil(t, report.Clusters[2].ClusterDeploymentsInfo) {
+		assert.Equal(t, "ledger", report.Clusters[2].ReadyDeployments[0].Name)
+	}
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const defaultGuardrailWindow = 10 * time.Minute
+
+// Kinds of change limited by the guardrails.
+const (
+	budgetPolicies       = "policies"
+	budgetRestarts       = "restarts"
+	budgetPodOperations  = "pod_operations"
+	budgetNodeOperations = "node_operations"
+	budgetSilences       = "silences"
+)
+
+var budgetKinds = []string{budgetPolicies, budgetRestarts, budgetPodOperations, budgetNodeOperations, budgetSilences}
+
+// GuardrailsConfig caps how many changes of each kind can be made within Window, cluster-wide and per namespace.
+//
+// Namespaces overrides PerNamespace for specific namespaces, a missing or zero budget is unlimited. Changes outside any
+// namespace, such as global policies, node operations and silences, only count against Global.
+type GuardrailsConfig struct {
+	Window       metav1.Duration           `json:"window,omitempty"`
+	Global       map[string]int            `json:"global,omitempty"`
+	PerNamespace map[string]int            `json:"per_namespace,omitempty"`
+	Namespaces   map[string]map[string]int `json:"namespaces,omitempty"`
+}
+
+// BudgetExceededError is returned when a change would go over one of the budgets.
+type BudgetExceededError struct {
+	Kind       string
+	Namespace  string
+	Limit      int
+	Window     time.Duration
+	RetryAfter time.Duration
+}
+
+func (e *BudgetExceededError) Error() string {
+	scope := "cluster-wide"
+	if e.Namespace != "" {
+		scope = "in namespace " + e.Namespace
+	}
+	return fmt.Sprintf("budget of %d %s per %s %s exhausted, retry in %s", e.Limit, e.Kind, e.Window, scope, e.RetryAfter.Round(time.Second))
+}
+
+// RateGuard enforces the change budgets with a sliding window, a nil RateGuard allows everything.
+type RateGuard struct {
+	mu     sync.Mutex
+	config GuardrailsConfig
+	window time.Duration
+	global map[string][]time.Time
+	byNs   map[string]map[string][]time.Time
+}
+
+func newRateGuard(config *GuardrailsConfig) (*RateGuard, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	budgets := []map[string]int{config.Global, config.PerNamespace}
+	for _, namespaceBudgets := range config.Namespaces {
+		budgets = append(budgets, namespaceBudgets)
+	}
+	for _, budget := range budgets {
+		for kind, limit := range budget {
+			if !isBudgetKind(kind) {
+				return nil, fmt.Errorf("unknown guardrail budget %q", kind)
+			}
+			if limit < 0 {
+				return nil, fmt.Errorf("guardrail budget %q can't be negative", kind)
+			}
+		}
+	}
+
+	guard := &RateGuard{
+		config: *config,
+		window: config.Window.Duration,
+		global: map[string][]time.Time{},
+		byNs:   map[string]map[string][]time.Time{},
+	}
+	if guard.window == 0 {
+		guard.window = defaultGuardrailWindow
+	}
+	return guard, nil
+}
+
+func isBudgetKind(kind string) bool {
+	for _, known := range budgetKinds {
+		if kind == known {
+			return true
+		}
+	}
+	return false
+}
+
+// BudgetReservation is a change counted against the budgets, released if the change didn't happen.
+type BudgetReservation struct {
+	guard     *RateGuard
+	kind      string
+	namespace string
+	at        time.Time
+}
+
+// reserve counts count changes of kind in namespace, empty for cluster-wide changes, or returns a BudgetExceededError
+// without counting anything.
+func (g *RateGuard) reserve(kind, namespace string, count int, now time.Time) (*BudgetReservation, error) {
+	if g == nil {
+		return nil, nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.byNs[kind] == nil {
+		g.byNs[kind] = map[string][]time.Time{}
+	}
+	g.global[kind] = g.prune(g.global[kind], now)
+	g.byNs[kind][namespace] = g.prune(g.byNs[kind][namespace], now)
+
+	if err := g.check(g.global[kind], g.config.Global[kind], kind, "", count, now); err != nil {
+		return nil, err
+	}
+	if namespace != "" {
+		if err := g.check(g.byNs[kind][namespace], g.namespaceLimit(kind, namespace), kind, namespace, count, now); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := 0; i < count; i++ {
+		g.global[kind] = append(g.global[kind], now)
+		g.byNs[kind][namespace] = append(g.byNs[kind][namespace], now)
+	}
+	return &BudgetReservation{guard: g, kind: kind, namespace: namespace, at: now}, nil
+}
+
+func (g *RateGuard) namespaceLimit(kind, namespace string) int {
+	if limit, ok := g.config.Namespaces[namespace][kind]; ok {
+		return limit
+	}
+	return g.config.PerNamespace[kind]
+}
+
+func (g *RateGuard) check(changes []time.Time, limit int, kind, namespace string, count int, now time.Time) error {
+	if limit == 0 || len(changes)+count <= limit {
+		return nil
+	}
+
+	retryAfter := g.window
+	if count <= limit {
+		// The oldest changes have to leave the window before count more fit
+		retryAfter = changes[len(changes)+count-limit-1].Add(g.window).Sub(now)
+	}
+	return &BudgetExceededError{Kind: kind, Namespace: namespace, Limit: limit, Window: g.window, RetryAfter: retryAfter}
+}
+
+func (g *RateGuard) prune(changes []time.Time, now time.Time) []time.Time {
+	cutoff := now.Add(-g.window)
+	i := 0
+	for i < len(changes) && !changes[i].After(cutoff) {
+		i++
+	}
+	return changes[i:]
+}
+
+// release gives back count changes of the reservation that didn't happen.
+func (r *BudgetReservation) release(count int) {
+	if r == nil {
+		return
+	}
+
+	g := r.guard
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.global[r.kind] = removeChanges(g.global[r.kind], r.at, count)
+	g.byNs[r.kind][r.namespace] = removeChanges(g.byNs[r.kind][r.namespace], r.at, count)
+}
+
+func removeChanges(changes []time.Time, at time.Time, count int) []time.Time {
+	kept := changes[:0]
+	for _, change := range changes {
+		if count > 0 && change.Equal(at) {
+			count--
+			continue
+		}
+		kept = append(kept, change)
+	}
+	return kept
+}
+
+// writeGuardrailError responds with a 429 and a Retry-After header when err is a BudgetExceededError.
+func writeGuardrailError(w http.ResponseWriter, err error) bool {
+	var exceeded *BudgetExceededError
+	if !errors.As(err, &exceeded) {
+		return false
+	}
+
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(exceeded.RetryAfter.Seconds()))))
+	writeError(w, http.StatusTooManyRequests, exceeded)
+	return true
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRateGuard(t *testing.T) {
+	guard, err := newRateGuard(&GuardrailsConfig{
+		Window:       metav1.Duration{Duration: time.Minute},
+		Global:       map[string]int{budgetPolicies: 4},
+		PerNamespace: map[string]int{budgetPolicies: 2},
+		Namespaces:   map[string]map[string]int{"payments": {budgetPolicies: 3}},
+	})
+	assert.NoError(t, err)
+
+	now := time.Date(2024, 7, 8, 12, 0, 0, 0, time.UTC)
+	_, err = guard.reserve(budgetPolicies, "shop", 2, now)
+	assert.NoError(t, err)
+
+	_, err = guard.reserve(budgetPolicies, "shop", 1, now.Add(10*time.Second))
+	var exceeded *BudgetExceededError
+	if assert.True(t, errors.As(err, &exceeded)) {
+		assert.Equal(t, "shop", exceeded.Namespace)
+		assert.Equal(t, 50*time.Second, exceeded.RetryAfter)
+	}
+
+	reservation, err := guard.reserve(budgetPolicies, "payments", 2, now.Add(20*time.Second))
+	assert.NoError(t, err)
+
+	_, err = guard.reserve(budgetPolicies, "search", 1, now.Add(30*time.Second))
+	if assert.True(t, errors.As(err, &exceeded)) {
+		assert.Empty(t, exceeded.Namespace)
+		assert.Equal(t, 30*time.Second, exceeded.RetryAfter)
+	}
+
+	reservation.release(1)
+	_, err = guard.reserve(budgetPolicies, "search", 1, now.Add(30*time.Second))
+	assert.NoError(t, err)
+
+	_, err = guard.reserve(budgetPolicies, "shop", 1, now.Add(time.Minute))
+	assert.NoError(t, err)
+
+	_, err = guard.reserve(budgetRestarts, "shop", 100, now)
+	assert.NoError(t, err)
+
+	// Cluster-wide changes only count against the global budget
+	_, err = guard.reserve(budgetPolicies, "", 3, now.Add(2*time.Minute))
+	assert.NoError(t, err)
+	_, err = guard.reserve(budgetPolicies, "", 2, now.Add(2*time.Minute))
+	if assert.True(t, errors.As(err, &exceeded)) {
+		assert.Empty(t, exceeded.Namespace)
+	}
+}
+
+func TestNilRateGuard(t *testing.T) {
+	var guard *RateGuard
+	reservation, err := guard.reserve(budgetPolicies, "shop", 1000, time.Now())
+	assert.NoError(t, err)
+	reservation.release(1000)
+
+	guard, err = newRateGuard(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, guard)
+
+	_, err = newRateGuard(&GuardrailsConfig{Global: map[string]int{"deletions": 1}})
+	assert.Error(t, err)
+
+	// Nothing scales workloads, so a scale budget would never be enforced
+	_, err = newRateGuard(&GuardrailsConfig{PerNamespace: map[string]int{"scale_changes": 1}})
+	assert.Error(t, err)
+}
+
+func TestWriteGuardrailError(t *testing.T) {
+	rr := httptest.NewRecorder()
+	assert.True(t, writeGuardrailError(rr, &BudgetExceededError{Kind: budgetPolicies, Limit: 1, Window: time.Minute, RetryAfter: 1500 * time.Millisecond}))
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "2", rr.Header().Get("Retry-After"))
+
+	assert.False(t, writeGuardrailError(httptest.NewRecorder(), errors.New("boom")))
+	assert.False(t, writeGuardrailError(httptest.NewRecorder(), nil))
+}
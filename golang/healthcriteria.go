@@ -0,0 +1,411 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// HealthConfig customises when a deployment counts as ready, by default once every requested pod is ready.
+//
+// ReadyWhen is an expression over the replica counts of a deployment, e.g. "readyReplicas >= ceil(replicas * 0.8)",
+// and Namespaces overrides it in some namespaces.
+type HealthConfig struct {
+	ReadyWhen  string            `json:"ready_when,omitempty"`
+	Namespaces map[string]string `json:"namespaces,omitempty"`
+}
+
+// healthVariables are the names an expression can use, by the field of the deployment they read. The spec. and
+// status. prefixes are optional.
+var healthVariables = map[string]func(appsv1.Deployment) float64{
+	"replicas":            func(d appsv1.Deployment) float64 { return float64(specReplicas(d.Spec.Replicas)) },
+	"readyReplicas":       func(d appsv1.Deployment) float64 { return float64(d.Status.ReadyReplicas) },
+	"availableReplicas":   func(d appsv1.Deployment) float64 { return float64(d.Status.AvailableReplicas) },
+	"updatedReplicas":     func(d appsv1.Deployment) float64 { return float64(d.Status.UpdatedReplicas) },
+	"unavailableReplicas": func(d appsv1.Deployment) float64 { return float64(d.Status.UnavailableReplicas) },
+}
+
+// healthFunctions are the functions an expression can call, by name and number of arguments.
+var healthFunctions = map[string]struct {
+	args int
+	call func(args []float64) float64
+}{
+	"ceil":  {1, func(args []float64) float64 { return math.Ceil(args[0]) }},
+	"floor": {1, func(args []float64) float64 { return math.Floor(args[0]) }},
+	"round": {1, func(args []float64) float64 { return math.Round(args[0]) }},
+	"min":   {2, func(args []float64) float64 { return math.Min(args[0], args[1]) }},
+	"max":   {2, func(args []float64) float64 { return math.Max(args[0], args[1]) }},
+}
+
+// HealthCriteria decides whether a deployment is ready, a nil one once every requested pod is.
+type HealthCriteria struct {
+	readyWhen  *healthExpression
+	namespaces map[string]*healthExpression
+}
+
+func newHealthCriteria(config *HealthConfig) (*HealthCriteria, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	criteria := &HealthCriteria{namespaces: make(map[string]*healthExpression, len(config.Namespaces))}
+	if config.ReadyWhen != "" {
+		expression, err := parseHealthExpression(config.ReadyWhen)
+		if err != nil {
+			return nil, fmt.Errorf("health ready_when: %w", err)
+		}
+		criteria.readyWhen = expression
+	}
+	for namespace, source := range config.Namespaces {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return nil, fmt.Errorf("health namespaces: invalid namespace %q: %s", namespace, strings.Join(errs, ", "))
+		}
+		expression, err := parseHealthExpression(source)
+		if err != nil {
+			return nil, fmt.Errorf("health namespaces %s: %w", namespace, err)
+		}
+		criteria.namespaces[namespace] = expression
+	}
+	return criteria, nil
+}
+
+// ready reports whether the deployment is ready by the expression of its namespace, the default one or, without
+// either, whether every requested pod is ready.
+func (c *HealthCriteria) ready(deployment appsv1.Deployment) bool {
+	var expression *healthExpression
+	if c != nil {
+		expression = c.readyWhen
+		if override, ok := c.namespaces[deployment.Namespace]; ok {
+			expression = override
+		}
+	}
+	if expression == nil {
+		return specReplicas(deployment.Spec.Replicas) <= deployment.Status.ReadyReplicas
+	}
+	return expression.eval(deployment)
+}
+
+// specReplicas returns the replicas a deployment or StatefulSet asks for, 1 when unset like the API server defaults it.
+func specReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+// healthExpression is a parsed ready_when expression.
+type healthExpression struct {
+	eval func(appsv1.Deployment) bool
+}
+
+// healthOperand is a parsed subexpression, a number or a boolean.
+type healthOperand struct {
+	number  func(appsv1.Deployment) float64
+	boolean func(appsv1.Deployment) bool
+}
+
+// parseHealthExpression parses an expression of numbers, the variables of healthVariables, the functions of
+// healthFunctions, + - * /, comparisons, && || ! and parentheses, which must evaluate to a boolean.
+func parseHealthExpression(source string) (*healthExpression, error) {
+	p := &healthParser{source: source}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+	operand, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in %q", p.tokens[p.pos], source)
+	}
+	if operand.boolean == nil {
+		return nil, fmt.Errorf("%q is a number, expected a condition such as readyReplicas >= 1", source)
+	}
+	return &healthExpression{eval: operand.boolean}, nil
+}
+
+type healthParser struct {
+	source string
+	tokens []string
+	pos    int
+}
+
+func (p *healthParser) tokenize() error {
+	runes := []rune(p.source)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || r == '.':
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			p.tokens = append(p.tokens, string(runes[start:i]))
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '.') {
+				i++
+			}
+			p.tokens = append(p.tokens, string(runes[start:i]))
+		default:
+			if i+1 < len(runes) {
+				if two := string(runes[i : i+2]); two == "&&" || two == "||" || two == "==" || two == "!=" || two == "<=" || two == ">=" {
+					p.tokens = append(p.tokens, two)
+					i += 2
+					continue
+				}
+			}
+			if !strings.ContainsRune("+-*/()<>!,", r) {
+				return fmt.Errorf("unexpected %q in %q", r, p.source)
+			}
+			p.tokens = append(p.tokens, string(r))
+			i++
+		}
+	}
+	return nil
+}
+
+func (p *healthParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *healthParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *healthParser) expect(token string) error {
+	if got := p.next(); got != token {
+		if got == "" {
+			got = "end of expression"
+		}
+		return fmt.Errorf("expected %q, got %q in %q", token, got, p.source)
+	}
+	return nil
+}
+
+func (p *healthParser) parseOr() (healthOperand, error) {
+	left, err := p.parseAnd()
+	for err == nil && p.peek() == "||" {
+		p.next()
+		var right healthOperand
+		if right, err = p.parseAnd(); err == nil {
+			left, err = p.logical("||", left, right)
+		}
+	}
+	return left, err
+}
+
+func (p *healthParser) parseAnd() (healthOperand, error) {
+	left, err := p.parseNot()
+	for err == nil && p.peek() == "&&" {
+		p.next()
+		var right healthOperand
+		if right, err = p.parseNot(); err == nil {
+			left, err = p.logical("&&", left, right)
+		}
+	}
+	return left, err
+}
+
+func (p *healthParser) logical(operator string, left, right healthOperand) (healthOperand, error) {
+	if left.boolean == nil || right.boolean == nil {
+		return healthOperand{}, fmt.Errorf("%s needs conditions on both sides in %q", operator, p.source)
+	}
+	a, b := left.boolean, right.boolean
+	if operator == "&&" {
+		return healthOperand{boolean: func(d appsv1.Deployment) bool { return a(d) && b(d) }}, nil
+	}
+	return healthOperand{boolean: func(d appsv1.Deployment) bool { return a(d) || b(d) }}, nil
+}
+
+func (p *healthParser) parseNot() (healthOperand, error) {
+	if p.peek() != "!" {
+		return p.parseComparison()
+	}
+	p.next()
+	operand, err := p.parseNot()
+	if err != nil {
+		return operand, err
+	}
+	if operand.boolean == nil {
+		return healthOperand{}, fmt.Errorf("! needs a condition in %q", p.source)
+	}
+	return healthOperand{boolean: func(d appsv1.Deployment) bool { return !operand.boolean(d) }}, nil
+}
+
+func (p *healthParser) parseComparison() (healthOperand, error) {
+	left, err := p.parseSum()
+	if err != nil {
+		return left, err
+	}
+	operator := p.peek()
+	compare, ok := map[string]func(a, b float64) bool{
+		"==": func(a, b float64) bool { return a == b },
+		"!=": func(a, b float64) bool { return a != b },
+		"<":  func(a, b float64) bool { return a < b },
+		"<=": func(a, b float64) bool { return a <= b },
+		">":  func(a, b float64) bool { return a > b },
+		">=": func(a, b float64) bool { return a >= b },
+	}[operator]
+	if !ok {
+		return left, nil
+	}
+	p.next()
+	right, err := p.parseSum()
+	if err != nil {
+		return right, err
+	}
+	if left.number == nil || right.number == nil {
+		return healthOperand{}, fmt.Errorf("%s compares numbers in %q", operator, p.source)
+	}
+	a, b := left.number, right.number
+	return healthOperand{boolean: func(d appsv1.Deployment) bool { return compare(a(d), b(d)) }}, nil
+}
+
+func (p *healthParser) parseSum() (healthOperand, error) {
+	left, err := p.parseProduct()
+	for err == nil && (p.peek() == "+" || p.peek() == "-") {
+		operator := p.next()
+		var right healthOperand
+		if right, err = p.parseProduct(); err == nil {
+			left, err = p.arithmetic(operator, left, right)
+		}
+	}
+	return left, err
+}
+
+func (p *healthParser) parseProduct() (healthOperand, error) {
+	left, err := p.parseUnary()
+	for err == nil && (p.peek() == "*" || p.peek() == "/") {
+		operator := p.next()
+		var right healthOperand
+		if right, err = p.parseUnary(); err == nil {
+			left, err = p.arithmetic(operator, left, right)
+		}
+	}
+	return left, err
+}
+
+func (p *healthParser) arithmetic(operator string, left, right healthOperand) (healthOperand, error) {
+	if left.number == nil || right.number == nil {
+		return healthOperand{}, fmt.Errorf("%s needs numbers on both sides in %q", operator, p.source)
+	}
+	a, b := left.number, right.number
+	switch operator {
+	case "+":
+		return healthOperand{number: func(d appsv1.Deployment) float64 { return a(d) + b(d) }}, nil
+	case "-":
+		return healthOperand{number: func(d appsv1.Deployment) float64 { return a(d) - b(d) }}, nil
+	case "*":
+		return healthOperand{number: func(d appsv1.Deployment) float64 { return a(d) * b(d) }}, nil
+	default:
+		return healthOperand{number: func(d appsv1.Deployment) float64 { return a(d) / b(d) }}, nil
+	}
+}
+
+func (p *healthParser) parseUnary() (healthOperand, error) {
+	if p.peek() != "-" {
+		return p.parsePrimary()
+	}
+	p.next()
+	operand, err := p.parseUnary()
+	if err != nil {
+		return operand, err
+	}
+	if operand.number == nil {
+		return healthOperand{}, fmt.Errorf("- needs a number in %q", p.source)
+	}
+	return healthOperand{number: func(d appsv1.Deployment) float64 { return -operand.number(d) }}, nil
+}
+
+func (p *healthParser) parsePrimary() (healthOperand, error) {
+	token := p.next()
+	switch {
+	case token == "":
+		return healthOperand{}, fmt.Errorf("unexpected end of %q", p.source)
+	case token == "(":
+		operand, err := p.parseOr()
+		if err != nil {
+			return operand, err
+		}
+		return operand, p.expect(")")
+	case unicode.IsDigit(rune(token[0])) || token[0] == '.':
+		value, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return healthOperand{}, fmt.Errorf("invalid number %q in %q", token, p.source)
+		}
+		return healthOperand{number: func(appsv1.Deployment) float64 { return value }}, nil
+	case p.peek() == "(":
+		return p.parseCall(token)
+	}
+
+	variable, ok := healthVariables[strings.TrimPrefix(strings.TrimPrefix(token, "spec."), "status.")]
+	if !ok {
+		return healthOperand{}, fmt.Errorf("unknown variable %q in %q, expected one of: %s", token, p.source, strings.Join(healthVariableNames(), ", "))
+	}
+	return healthOperand{number: variable}, nil
+}
+
+func (p *healthParser) parseCall(name string) (healthOperand, error) {
+	function, ok := healthFunctions[name]
+	if !ok {
+		return healthOperand{}, fmt.Errorf("unknown function %q in %q", name, p.source)
+	}
+	p.next()
+
+	var args []func(appsv1.Deployment) float64
+	for p.peek() != ")" {
+		if len(args) > 0 {
+			if err := p.expect(","); err != nil {
+				return healthOperand{}, err
+			}
+		}
+		arg, err := p.parseSum()
+		if err != nil {
+			return arg, err
+		}
+		if arg.number == nil {
+			return healthOperand{}, fmt.Errorf("%s takes numbers in %q", name, p.source)
+		}
+		args = append(args, arg.number)
+		if p.peek() == "" {
+			break
+		}
+	}
+	if err := p.expect(")"); err != nil {
+		return healthOperand{}, err
+	}
+	if len(args) != function.args {
+		return healthOperand{}, fmt.Errorf("%s takes %d arguments, got %d in %q", name, function.args, len(args), p.source)
+	}
+
+	return healthOperand{number: func(d appsv1.Deployment) float64 {
+		values := make([]float64, len(args))
+		for i, arg := range args {
+			values[i] = arg(d)
+		}
+		return function.call(values)
+	}}, nil
+}
+
+func healthVariableNames() []string {
+	names := make([]string, 0, len(healthVariables))
+	for name := range healthVariables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
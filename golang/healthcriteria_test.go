@@ -0,0 +1,85 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHealthCriteria(t *testing.T) {
+	deployment := func(namespace string, replicas, ready, available int32) appsv1.Deployment {
+		return appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: namespace},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: ready, AvailableReplicas: available},
+		}
+	}
+
+	var none *HealthCriteria
+	assert.True(t, none.ready(deployment("payments", 3, 3, 3)))
+	assert.False(t, none.ready(deployment("payments", 3, 2, 2)))
+	// Unset replicas default to 1
+	unset := appsv1.Deployment{Status: appsv1.DeploymentStatus{ReadyReplicas: 1}}
+	assert.True(t, none.ready(unset))
+	unset.Status.ReadyReplicas = 0
+	assert.False(t, none.ready(unset))
+
+	criteria, err := newHealthCriteria(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, criteria)
+
+	criteria, err = newHealthCriteria(&HealthConfig{
+		ReadyWhen:  "readyReplicas >= ceil(spec.replicas * 0.8)",
+		Namespaces: map[string]string{"batch": "status.readyReplicas >= 1 || replicas == 0"},
+	})
+	assert.NoError(t, err)
+	assert.True(t, criteria.ready(deployment("payments", 5, 4, 4)))
+	assert.False(t, criteria.ready(deployment("payments", 5, 3, 3)))
+	assert.True(t, criteria.ready(deployment("batch", 5, 1, 1)))
+	assert.True(t, criteria.ready(deployment("batch", 0, 0, 0)))
+	assert.False(t, criteria.ready(deployment("batch", 5, 0, 0)))
+
+	// Without ready_when, namespaces without an override keep the default
+	criteria, err = newHealthCriteria(&HealthConfig{Namespaces: map[string]string{"batch": "readyReplicas >= 1"}})
+	assert.NoError(t, err)
+	assert.False(t, criteria.ready(deployment("payments", 3, 1, 1)))
+	assert.True(t, criteria.ready(deployment("batch", 3, 1, 1)))
+
+	for expression, want := range map[string]bool{
+		"readyReplicas >= min(replicas, 2)":                              true,
+		"readyReplicas >= max(replicas - 1, 1) && availableReplicas > 1": true,
+		"!(readyReplicas < replicas)":                                    false,
+		"readyReplicas * 2 > replicas + -1":                              true,
+		"floor((readyReplicas + 1) / 2) == round(1.4)":                   true,
+	} {
+		parsed, err := parseHealthExpression(expression)
+		if assert.NoError(t, err, expression) {
+			assert.Equal(t, want, parsed.eval(deployment("payments", 3, 2, 2)), expression)
+		}
+	}
+}
+
+func TestParseHealthExpressionErrors(t *testing.T) {
+	for expression, message := range map[string]string{
+		"ready >= 1":                      `unknown variable "ready"`,
+		"readyReplicas":                   "is a number",
+		"min(readyReplicas) > 1":          "min takes 2 arguments, got 1",
+		"sqrt(readyReplicas) > 1":         `unknown function "sqrt"`,
+		"readyReplicas >= 1 &&":           "unexpected end",
+		"(readyReplicas >= 1":             `expected ")"`,
+		"readyReplicas >= 1 && replicas":  "&& needs conditions on both sides",
+		"readyReplicas >= (replicas > 1)": ">= compares numbers",
+		"readyReplicas >= 1 replicas":     `unexpected "replicas"`,
+		"readyReplicas % 2 == 0":          `unexpected '%'`,
+	} {
+		_, err := parseHealthExpression(expression)
+		if assert.Error(t, err, expression) {
+			assert.Contains(t, err.Error(), message, expression)
+		}
+	}
+
+	_, err := newHealthCriteria(&HealthConfig{Namespaces: map[string]string{"Batch": "readyReplicas >= 1"}})
+	assert.ErrorContains(t, err, `invalid namespace "Batch"`)
+}
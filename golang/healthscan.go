@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Checks of a health scan
+const (
+	scanCheckDeployments = "deployments"
+	scanCheckNodes       = "nodes"
+	scanCheckProblemPods = "problem_pods"
+)
+
+// HealthScan is the latest result of the deployment, node and problem pod checks of the background scanner. A check
+// failing keeps its previous result, its error telling the result is stale.
+type HealthScan struct {
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+	// AgeSeconds is how long ago the scan completed when it was served
+	AgeSeconds  float64                 `json:"age_seconds"`
+	Deployments *ClusterDeploymentsInfo `json:"deployments,omitempty"`
+	Nodes       *ClusterNodesInfo       `json:"nodes,omitempty"`
+	ProblemPods *ProblemPodsReport      `json:"problem_pods,omitempty"`
+	// Errors are those of the checks which failed during the scan, by check
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// HealthScanner runs the health checks every interval and keeps their latest results, which are served from memory
+// however slow the API server is.
+type HealthScanner struct {
+	interval  time.Duration
+	cluster   string
+	clientset kubernetes.Interface
+	scope     NamespaceScope
+	excluded  []string
+	// deployments scans the deployments health, from the deployment cache when the server has one
+	deployments func(context.Context) (*ClusterDeploymentsInfo, error)
+	// history keeps the changes of the deployments seen by the scans
+	history *HealthHistory
+
+	mu     sync.RWMutex
+	latest *HealthScan
+}
+
+// newHealthScanner scans the cluster of server every interval, its deployments as unfiltered requests of
+// clusterDeploymentsInfoHandler would, keeping their history for retention.
+func newHealthScanner(server Server, interval, retention time.Duration) *HealthScanner {
+	return &HealthScanner{
+		interval:    interval,
+		cluster:     server.Cluster,
+		clientset:   server.K8sClientSet,
+		scope:       server.Namespaces,
+		excluded:    server.ExcludedNamespaces,
+		history:     newHealthHistory(retention),
+		deployments: server.scanDeploymentsHealth,
+	}
+}
+
+// scanDeploymentsHealth reads the health of every deployment in scope as an unfiltered request of
+// clusterDeploymentsInfoHandler would, the excluded namespaces included.
+func (s Server) scanDeploymentsHealth(ctx context.Context) (*ClusterDeploymentsInfo, error) {
+	prometheus := s.Prometheus
+	if !s.Features.Enabled(featurePrometheusChecks) {
+		prometheus = nil
+	}
+	return s.workloadsHealth(ctx, s.Namespaces, prometheus, labels.Everything(), labels.Everything(), deploymentsPage{})
+}
+
+// reportedDeploymentsHealth is the health of the deployments the workers notify and export, graced and without the
+// excluded namespaces: that of the latest scan of the health scanner when it runs, read from the deployment cache or
+// the API server otherwise. The report may be shared, callers must not change it.
+func (s Server) reportedDeploymentsHealth(ctx context.Context) (*ClusterDeploymentsInfo, error) {
+	if s.Scanner != nil {
+		return s.Scanner.latestDeployments()
+	}
+
+	deployments, err := s.scanDeploymentsHealth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deployments.excludeNamespaces(s.ExcludedNamespaces)
+	return deployments, nil
+}
+
+// run scans every interval until ctx is done.
+func (s *HealthScanner) run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.scanOnce(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scanOnce runs every check, for up to an interval, and stores their results.
+func (s *HealthScanner) scanOnce(ctx context.Context, now time.Time) {
+	ctx, cancel := context.WithTimeout(ctx, s.interval)
+	defer cancel()
+
+	scan := &HealthScan{StartedAt: now.UTC(), Errors: map[string]string{}}
+	if previous := s.get(); previous != nil {
+		scan.Deployments, scan.Nodes, scan.ProblemPods = previous.Deployments, previous.Nodes, previous.ProblemPods
+	}
+	failed := func(check string, err error) {
+		slog.WarnContext(ctx, "Health scan check failed", "check", check, "error", err)
+		scan.Errors[check] = err.Error()
+	}
+
+	// The health metrics are those of the deployments served by GET /healthscan
+	deployments, err := s.deployments(ctx)
+	if err != nil {
+		recordHealthScan(s.cluster, nil, err, now)
+		failed(scanCheckDeployments, err)
+	} else {
+		deployments.excludeNamespaces(s.excluded)
+		recordHealthScan(s.cluster, deployments, nil, now)
+		scan.Deployments = deployments
+		s.history.record(now, deployments)
+	}
+	if nodes, err := getNodesHealth(ctx, s.clientset, labels.Everything()); err != nil {
+		failed(scanCheckNodes, err)
+	} else {
+		scan.Nodes = nodes
+	}
+	options := problemPodsOptions{RestartThreshold: defaultRestartThreshold, OOMWindow: defaultOOMWindow}
+	if pods, err := getProblemPods(ctx, s.clientset, s.scope, labels.Everything(), options, now); err != nil {
+		failed(scanCheckProblemPods, err)
+	} else {
+		pods.excludeNamespaces(s.excluded)
+		scan.ProblemPods = pods
+	}
+
+	scan.CompletedAt = time.Now().UTC()
+	if len(scan.Errors) == 0 {
+		scan.Errors = nil
+	}
+	slog.DebugContext(ctx, "Health scan", "duration", scan.CompletedAt.Sub(scan.StartedAt), "errors", len(scan.Errors))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latest = scan
+}
+
+// get returns the latest scan, nil before the first one completed.
+func (s *HealthScanner) get() *HealthScan {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.latest
+}
+
+// latestDeployments returns the deployments of the latest scan, or why its deployments check failed.
+func (s *HealthScanner) latestDeployments() (*ClusterDeploymentsInfo, error) {
+	latest := s.get()
+	switch {
+	case latest == nil:
+		return nil, errors.New("the first health scan hasn't completed yet")
+	case latest.Errors[scanCheckDeployments] != "":
+		return nil, errors.New(latest.Errors[scanCheckDeployments])
+	}
+	return latest.Deployments, nil
+}
+
+// Reports the latest health scan from memory, with when it started and completed
+//
+// A 503 tells the scanner is disabled, with --scan-interval=0, or hasn't completed its first scan yet.
+func (s *Server) healthScanHandler(w http.ResponseWriter, r *http.Request) {
+	if s.Scanner == nil {
+		writeErrorf(w, http.StatusServiceUnavailable, "The health scanner is disabled, set --scan-interval")
+		return
+	}
+	latest := s.Scanner.get()
+	if latest == nil {
+		w.Header().Set("Retry-After", "5")
+		writeErrorf(w, http.StatusServiceUnavailable, "The first health scan hasn't completed yet")
+		return
+	}
+
+	scan := *latest
+	scan.AgeSeconds = time.Since(scan.CompletedAt).Seconds()
+	w.Header().Set("Last-Modified", scan.CompletedAt.Format(http.TimeFormat))
+	writeJSON(w, http.StatusOK, scan)
+}
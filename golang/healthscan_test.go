@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestHealthScanner(t *testing.T) {
+	now := time.Now()
+	crashLoop := func(namespace string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: namespace, CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "app", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+			}},
+		}
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}},
+	}
+	clientset := fake.NewSimpleClientset(node, crashLoop("shop"), crashLoop("kube-system"))
+
+	deploymentsErr := errors.New("deployments unavailable")
+	scanner := &HealthScanner{
+		interval:    time.Minute,
+		cluster:     "scanned",
+		clientset:   clientset,
+		excluded:    []string{"kube-system"},
+		history:     newHealthHistory(time.Hour),
+		deployments: func(context.Context) (*ClusterDeploymentsInfo, error) { return nil, deploymentsErr },
+	}
+	server := &Server{}
+	recorder := httptest.NewRecorder()
+	server.healthScanHandler(recorder, httptest.NewRequest(http.MethodGet, "/healthscan", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	server.Scanner = scanner
+	recorder = httptest.NewRecorder()
+	server.healthScanHandler(recorder, httptest.NewRequest(http.MethodGet, "/healthscan", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(t, "5", recorder.Header().Get("Retry-After"))
+
+	scanner.scanOnce(context.Background(), now)
+	recorder = httptest.NewRecorder()
+	server.healthScanHandler(recorder, httptest.NewRequest(http.MethodGet, "/healthscan", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var scan HealthScan
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &scan))
+	assert.Equal(t, now.UTC(), scan.StartedAt)
+	assert.False(t, scan.CompletedAt.Before(scan.StartedAt))
+	assert.NotEmpty(t, recorder.Header().Get("Last-Modified"))
+	assert.Nil(t, scan.Deployments)
+	assert.Equal(t, map[string]string{scanCheckDeployments: "deployments unavailable"}, scan.Errors)
+	if assert.NotNil(t, scan.Nodes) {
+		assert.Len(t, scan.Nodes.ReadyNodes, 1)
+	}
+	if assert.NotNil(t, scan.ProblemPods) && assert.Len(t, scan.ProblemPods.Namespaces, 1) {
+		assert.Equal(t, "shop", scan.ProblemPods.Namespaces[0].Namespace)
+	}
+
+	// A failing check keeps its previous result
+	scanner.deployments = func(context.Context) (*ClusterDeploymentsInfo, error) {
+		return &ClusterDeploymentsInfo{ReadyDeployments: []DeploymentInfo{{Name: "cart", Namespace: "shop"}, {Name: "coredns", Namespace: "kube-system"}}}, nil
+	}
+	scanner.scanOnce(context.Background(), now.Add(time.Minute))
+	assert.Empty(t, scanner.get().Errors)
+	// The metrics leave the excluded namespaces out like the scan
+	assert.Equal(t, 1.0, testutil.ToFloat64(healthScanDeployments.WithLabelValues("scanned", "ready")))
+	scanner.deployments = func(context.Context) (*ClusterDeploymentsInfo, error) { return nil, deploymentsErr }
+	scanner.scanOnce(context.Background(), now.Add(2*time.Minute))
+	latest := scanner.get()
+	assert.Equal(t, now.Add(2*time.Minute).UTC(), latest.StartedAt)
+	assert.Contains(t, latest.Errors, scanCheckDeployments)
+	if assert.NotNil(t, latest.Deployments) {
+		assert.Len(t, latest.Deployments.ReadyDeployments, 1)
+	}
+}
+
+func TestReportedDeploymentsHealth(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "cart", Namespace: "shop", CreationTimestamp: metav1.NewTime(time.Now())},
+	})
+	server := Server{K8sClientSet: clientset, DeploymentGrace: 5 * time.Minute}
+
+	// A fresh rollout isn't notified as failed
+	deployments, err := server.reportedDeploymentsHealth(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, deployments.FailedDeployments)
+	assert.Len(t, deployments.Progressing, 1)
+
+	// The workers read the latest scan of the scanner when it runs
+	server.Scanner = newHealthScanner(server, time.Minute, time.Hour)
+	_, err = server.reportedDeploymentsHealth(context.Background())
+	assert.Error(t, err)
+	server.Scanner.scanOnce(context.Background(), time.Now())
+	deployments, err = server.reportedDeploymentsHealth(context.Background())
+	assert.NoError(t, err)
+	assert.Same(t, server.Scanner.get().Deployments, deployments)
+	assert.Len(t, deployments.Progressing, 1)
+}
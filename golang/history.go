@@ -0,0 +1,156 @@
+package main
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultHistoryRetention is how long the health history keeps the states of the deployments.
+	defaultHistoryRetention = 24 * time.Hour
+	// maxHistoryPoints bounds the changes kept by deployment, so a flapping one doesn't grow the history unbounded.
+	maxHistoryPoints = 1000
+)
+
+// DeploymentHistoryPoint is the state of a deployment from the scan which first saw it until the next point.
+type DeploymentHistoryPoint struct {
+	At            time.Time `json:"at"`
+	State         string    `json:"state"`
+	RequestedPods int32     `json:"requested_pods"`
+	ReadyPods     int32     `json:"ready_pods"`
+}
+
+// DeploymentHistory is how the ready pods and the state of a deployment changed over the scans of the health history.
+type DeploymentHistory struct {
+	Namespace string                   `json:"namespace"`
+	Name      string                   `json:"deployment_name"`
+	Points    []DeploymentHistoryPoint `json:"points"`
+	// LastScannedAt is when a scan last saw the deployment, before the latest scan for deleted ones
+	LastScannedAt time.Time `json:"last_scanned_at"`
+	// FailingSince is when the deployment stopped being ready, unset while it's ready
+	FailingSince *time.Time `json:"failing_since,omitempty"`
+}
+
+// HealthHistory keeps the changes of the deployments seen by the health scans, for up to retention. Only the points
+// where the state or pod counts of a deployment changed are kept.
+type HealthHistory struct {
+	retention time.Duration
+
+	mu sync.RWMutex
+	// deployments by namespace/name
+	deployments map[string]*DeploymentHistory
+}
+
+func newHealthHistory(retention time.Duration) *HealthHistory {
+	return &HealthHistory{retention: retention, deployments: map[string]*DeploymentHistory{}}
+}
+
+// record adds the deployments of a scan at now, and forgets what's older than the retention.
+func (h *HealthHistory) record(now time.Time, info *ClusterDeploymentsInfo) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	states := []struct {
+		state       string
+		deployments []DeploymentInfo
+	}{
+		{deploymentReady, info.ReadyDeployments},
+		{deploymentFailed, info.FailedDeployments},
+		{deploymentStuck, info.StuckRollouts},
+		{deploymentProgressing, info.Progressing},
+	}
+	for _, state := range states {
+		for _, deployment := range state.deployments {
+			key := deployment.Namespace + "/" + deployment.Name
+			history := h.deployments[key]
+			if history == nil {
+				history = &DeploymentHistory{Namespace: deployment.Namespace, Name: deployment.Name}
+				h.deployments[key] = history
+			}
+			point := DeploymentHistoryPoint{At: now, State: state.state, RequestedPods: deployment.RequestedPods, ReadyPods: deployment.ReadyPods}
+			if last := len(history.Points) - 1; last < 0 || !samePoint(history.Points[last], point) {
+				history.Points = append(history.Points, point)
+			}
+			if len(history.Points) > maxHistoryPoints {
+				history.Points = history.Points[len(history.Points)-maxHistoryPoints:]
+			}
+			history.LastScannedAt = now
+		}
+	}
+
+	cutoff := now.Add(-h.retention)
+	for key, history := range h.deployments {
+		if history.LastScannedAt.Before(cutoff) {
+			delete(h.deployments, key)
+			continue
+		}
+		history.Points = pointsSince(history.Points, cutoff)
+	}
+}
+
+// samePoint tells whether a deployment kept its state and pod counts.
+func samePoint(a, b DeploymentHistoryPoint) bool {
+	return a.State == b.State && a.RequestedPods == b.RequestedPods && a.ReadyPods == b.ReadyPods
+}
+
+// pointsSince returns the points from since on, starting with the one in effect at since.
+func pointsSince(points []DeploymentHistoryPoint, since time.Time) []DeploymentHistoryPoint {
+	start := 0
+	for start < len(points)-1 && !points[start+1].At.After(since) {
+		start++
+	}
+	return points[start:]
+}
+
+// deployment returns the history of a deployment from since on, false when no scan saw it.
+func (h *HealthHistory) deployment(namespace, name string, since time.Time) (*DeploymentHistory, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	history, ok := h.deployments[namespace+"/"+name]
+	if !ok {
+		return nil, false
+	}
+	result := *history
+	// The failure may have started before since
+	for i := len(history.Points) - 1; i >= 0 && history.Points[i].State != deploymentReady; i-- {
+		at := history.Points[i].At
+		result.FailingSince = &at
+	}
+	result.Points = append([]DeploymentHistoryPoint{}, pointsSince(history.Points, since)...)
+	return &result, true
+}
+
+// Reports how the ready pods and the state of a deployment changed over the health history, from ?since= ago, such as
+// 6h, or over the whole retention
+//
+// The history is that of the health scanner, so it needs --scan-interval, and it's kept in memory: a restart starts it
+// over, and every replica has its own.
+func (s *Server) deploymentHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	if s.Scanner == nil {
+		writeErrorf(w, http.StatusServiceUnavailable, "The health history is kept by the health scanner, set --scan-interval")
+		return
+	}
+	namespace, name := r.PathValue("namespace"), r.PathValue("name")
+	if err := s.Namespaces.check(r.Context(), s.K8sClientSet, namespace); err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+	var since time.Time
+	if value := r.URL.Query().Get("since"); value != "" {
+		window, err := time.ParseDuration(value)
+		if err != nil || window <= 0 {
+			writeErrorf(w, http.StatusBadRequest, "invalid since %q, expected a positive duration such as 6h", value)
+			return
+		}
+		since = time.Now().Add(-window)
+	}
+
+	history, ok := s.Scanner.history.deployment(namespace, name, since)
+	if !ok {
+		writeErrorf(w, http.StatusNotFound, "No health history of deployment %s/%s", namespace, name)
+		return
+	}
+	writeJSON(w, http.StatusOK, history)
+}
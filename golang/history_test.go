@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthHistory(t *testing.T) {
+	start := time.Date(2024, time.January, 31, 12, 0, 0, 0, time.UTC)
+	minute := func(m int) time.Time { return start.Add(time.Duration(m) * time.Minute) }
+	cart := func(ready int32) DeploymentInfo {
+		return DeploymentInfo{Namespace: "shop", Name: "cart", RequestedPods: 3, ReadyPods: ready}
+	}
+	history := newHealthHistory(time.Hour)
+	history.record(minute(0), &ClusterDeploymentsInfo{ReadyDeployments: []DeploymentInfo{cart(3), {Namespace: "shop", Name: "web"}}})
+	history.record(minute(1), &ClusterDeploymentsInfo{ReadyDeployments: []DeploymentInfo{cart(3)}})
+	history.record(minute(2), &ClusterDeploymentsInfo{FailedDeployments: []DeploymentInfo{cart(2)}})
+	history.record(minute(3), &ClusterDeploymentsInfo{StuckRollouts: []DeploymentInfo{cart(2)}})
+	history.record(minute(4), &ClusterDeploymentsInfo{StuckRollouts: []DeploymentInfo{cart(2)}})
+
+	// Unchanged scans aren't points
+	cartHistory, ok := history.deployment("shop", "cart", time.Time{})
+	if assert.True(t, ok) {
+		assert.Equal(t, []DeploymentHistoryPoint{
+			{At: minute(0), State: deploymentReady, RequestedPods: 3, ReadyPods: 3},
+			{At: minute(2), State: deploymentFailed, RequestedPods: 3, ReadyPods: 2},
+			{At: minute(3), State: deploymentStuck, RequestedPods: 3, ReadyPods: 2},
+		}, cartHistory.Points)
+		assert.Equal(t, minute(4), cartHistory.LastScannedAt)
+		if assert.NotNil(t, cartHistory.FailingSince) {
+			assert.Equal(t, minute(2), *cartHistory.FailingSince)
+		}
+	}
+	// The point in effect at since comes first, and the failure may have started before it
+	cartHistory, _ = history.deployment("shop", "cart", minute(3).Add(30*time.Second))
+	if assert.Len(t, cartHistory.Points, 1) {
+		assert.Equal(t, minute(3), cartHistory.Points[0].At)
+	}
+	assert.Equal(t, minute(2), *cartHistory.FailingSince)
+
+	history.record(minute(5), &ClusterDeploymentsInfo{ReadyDeployments: []DeploymentInfo{cart(3)}})
+	cartHistory, _ = history.deployment("shop", "cart", time.Time{})
+	assert.Nil(t, cartHistory.FailingSince)
+
+	// Deployments unseen for the retention are forgotten, the others keep the point in effect at the cutoff
+	history.record(minute(70), &ClusterDeploymentsInfo{ReadyDeployments: []DeploymentInfo{cart(3)}})
+	_, ok = history.deployment("shop", "web", time.Time{})
+	assert.False(t, ok)
+	cartHistory, _ = history.deployment("shop", "cart", time.Time{})
+	assert.Equal(t, []DeploymentHistoryPoint{{At: minute(5), State: deploymentReady, RequestedPods: 3, ReadyPods: 3}}, cartHistory.Points)
+
+	server := &Server{}
+	get := func(path string) *httptest.ResponseRecorder {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /history/deployments/{namespace}/{name}", server.deploymentHistoryHandler)
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder
+	}
+	assert.Equal(t, http.StatusServiceUnavailable, get("/history/deployments/shop/cart").Code)
+	server.Scanner = &HealthScanner{history: history}
+	assert.Equal(t, http.StatusNotFound, get("/history/deployments/shop/web").Code)
+	assert.Equal(t, http.StatusBadRequest, get("/history/deployments/shop/cart?since=yesterday").Code)
+	server.Namespaces = NamespaceScope{Names: []string{"payments"}}
+	assert.Equal(t, http.StatusForbidden, get("/history/deployments/shop/cart").Code)
+	server.Namespaces = NamespaceScope{}
+	recorder := get("/history/deployments/shop/cart?since=6h")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var result DeploymentHistory
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+	assert.Equal(t, "cart", result.Name)
+	assert.Len(t, result.Points, 1)
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+type HPAInfo struct {
+	Name      string `json:"hpa_name"`
+	Namespace string `json:"namespace"`
+	// Target is the workload it scales, e.g. Deployment/web
+	Target          string `json:"target"`
+	MinReplicas     int32  `json:"min_replicas"`
+	MaxReplicas     int32  `json:"max_replicas"`
+	CurrentReplicas int32  `json:"current_replicas"`
+	DesiredReplicas int32  `json:"desired_replicas"`
+	// AtMax is set when it runs, and wants, its maximum replicas, so it can't absorb more load
+	AtMax bool `json:"at_max"`
+	// MetricsUnavailable is set when its ScalingActive condition is false, it can't compute the replicas it needs
+	MetricsUnavailable bool                  `json:"metrics_unavailable"`
+	LastScaleTime      *time.Time            `json:"last_scale_time,omitempty"`
+	Conditions         []DeploymentCondition `json:"conditions,omitempty"`
+	CreatedAt          time.Time             `json:"created_at"`
+}
+
+type ClusterHPAsInfo struct {
+	HealthyHPAs []HPAInfo `json:"healthy_hpas"`
+	// UnhealthyHPAs are pinned at their maximum replicas, or without the metrics to scale on
+	UnhealthyHPAs []HPAInfo `json:"unhealthy_hpas"`
+}
+
+// Reports the HorizontalPodAutoscalers in scope, flagging those pinned at their maximum replicas or whose metrics are
+// unavailable, filtered like the deployments of clusterDeploymentsInfoHandler
+func (s *Server) hpaInfoHandler(w http.ResponseWriter, r *http.Request) {
+	filter, status, err := s.parseReportFilter(r)
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+
+	info, err := getHPAsHealth(r.Context(), s.K8sClientSet, filter.Scope, filter.Selector)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	info.excludeNamespaces(filter.excludedNamespaces(s.ExcludedNamespaces))
+	writeJSON(w, http.StatusOK, info)
+}
+
+// getHPAsHealth sorts the HorizontalPodAutoscalers of every namespace in scope whose own labels match selector into
+// healthy and unhealthy ones.
+func getHPAsHealth(ctx context.Context, clientset kubernetes.Interface, scope NamespaceScope, selector labels.Selector) (*ClusterHPAsInfo, error) {
+	namespaces, err := scope.resolve(ctx, clientset)
+	if err != nil {
+		return nil, err
+	}
+
+	info := new(ClusterHPAsInfo)
+	for _, namespace := range namespaces {
+		list, err := clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, err
+		}
+		for _, hpa := range list.Items {
+			current := newHPAInfo(hpa)
+			if current.AtMax || current.MetricsUnavailable {
+				info.UnhealthyHPAs = append(info.UnhealthyHPAs, current)
+			} else {
+				info.HealthyHPAs = append(info.HealthyHPAs, current)
+			}
+		}
+	}
+	slog.DebugContext(ctx, "HPAs health", "healthy", len(info.HealthyHPAs), "unhealthy", len(info.UnhealthyHPAs))
+	return info, nil
+}
+
+func newHPAInfo(hpa autoscalingv2.HorizontalPodAutoscaler) HPAInfo {
+	current := HPAInfo{
+		Name:            hpa.Name,
+		Namespace:       hpa.Namespace,
+		Target:          hpa.Spec.ScaleTargetRef.Kind + "/" + hpa.Spec.ScaleTargetRef.Name,
+		MinReplicas:     1,
+		MaxReplicas:     hpa.Spec.MaxReplicas,
+		CurrentReplicas: hpa.Status.CurrentReplicas,
+		DesiredReplicas: hpa.Status.DesiredReplicas,
+		CreatedAt:       hpa.CreationTimestamp.Time,
+	}
+	if hpa.Spec.MinReplicas != nil {
+		current.MinReplicas = *hpa.Spec.MinReplicas
+	}
+	if hpa.Status.LastScaleTime != nil {
+		current.LastScaleTime = &hpa.Status.LastScaleTime.Time
+	}
+	current.AtMax = current.MaxReplicas > 0 && current.CurrentReplicas >= current.MaxReplicas && current.DesiredReplicas >= current.MaxReplicas
+
+	for _, condition := range hpa.Status.Conditions {
+		current.Conditions = append(current.Conditions, DeploymentCondition{
+			Type:               string(condition.Type),
+			Status:             string(condition.Status),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: condition.LastTransitionTime.Time,
+		})
+		if condition.Type == autoscalingv2.ScalingActive && condition.Status == corev1.ConditionFalse {
+			current.MetricsUnavailable = true
+		}
+	}
+	return current
+}
+
+// excludeNamespaces removes the HPAs of namespaces from every category of the report.
+func (info *ClusterHPAsInfo) excludeNamespaces(namespaces []string) {
+	if len(namespaces) == 0 {
+		return
+	}
+	keep := func(hpas []HPAInfo) []HPAInfo {
+		return slices.DeleteFunc(hpas, func(hpa HPAInfo) bool { return slices.Contains(namespaces, hpa.Namespace) })
+	}
+	info.HealthyHPAs = keep(info.HealthyHPAs)
+	info.UnhealthyHPAs = keep(info.UnhealthyHPAs)
+}
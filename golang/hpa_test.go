@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetHPAsHealth(t *testing.T) {
+	hpa := func(namespace, name string, current, desired, max int32, scalingActive corev1.ConditionStatus) *autoscalingv2.HorizontalPodAutoscaler {
+		return &autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: name},
+				MaxReplicas:    max,
+			},
+			Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+				CurrentReplicas: current,
+				DesiredReplicas: desired,
+				Conditions: []autoscalingv2.HorizontalPodAutoscalerCondition{
+					{Type: autoscalingv2.ScalingActive, Status: scalingActive, Reason: "FailedGetResourceMetric"},
+				},
+			},
+		}
+	}
+	clientset := fake.NewSimpleClientset(
+		hpa("web", "frontend", 3, 3, 10, corev1.ConditionTrue),
+		hpa("web", "api", 10, 14, 10, corev1.ConditionTrue),
+		// Scaling down from its maximum
+		hpa("web", "search", 10, 6, 10, corev1.ConditionTrue),
+		hpa("payments", "ledger", 2, 2, 5, corev1.ConditionFalse),
+	)
+
+	info, err := getHPAsHealth(context.Background(), clientset, NamespaceScope{}, labels.Everything())
+	assert.NoError(t, err)
+	hpas := func(list []HPAInfo) map[string]HPAInfo {
+		result := map[string]HPAInfo{}
+		for _, hpa := range list {
+			result[hpa.Name] = hpa
+		}
+		return result
+	}
+	healthy, unhealthy := hpas(info.HealthyHPAs), hpas(info.UnhealthyHPAs)
+	assert.Len(t, healthy, 2)
+	assert.Contains(t, healthy, "frontend")
+	assert.Contains(t, healthy, "search")
+	assert.Len(t, unhealthy, 2)
+	assert.True(t, unhealthy["api"].AtMax)
+	assert.Equal(t, "Deployment/api", unhealthy["api"].Target)
+	assert.Equal(t, int32(1), unhealthy["api"].MinReplicas)
+	assert.True(t, unhealthy["ledger"].MetricsUnavailable)
+	assert.False(t, unhealthy["ledger"].AtMax)
+
+	info.excludeNamespaces([]string{"payments"})
+	assert.Len(t, info.UnhealthyHPAs, 1)
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxImpactPods bounds the pods listed for each workload of an impact preview.
+const maxImpactPods = 500
+
+// DenyImpact lists the pods a deny request would cut off from each other.
+type DenyImpact struct {
+	A DenyImpactWorkload `json:"workload_a"`
+	B DenyImpactWorkload `json:"workload_b"`
+}
+
+// DenyImpactWorkload is a workload of a deny request and the pods it currently matches, none for a CIDR.
+type DenyImpactWorkload struct {
+	Workload  string        `json:"workload"`
+	Pods      []AffectedPod `json:"pods"`
+	Truncated bool          `json:"truncated,omitempty"`
+}
+
+// AffectedPod is a pod matched by a workload, with the controller managing it.
+type AffectedPod struct {
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	Node       string `json:"node,omitempty"`
+	Controller string `json:"controller,omitempty"`
+}
+
+// Handler listing the pods a deny request currently applies to, without creating anything
+func (s *Server) denyNetworkPolicyImpactHandler(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		readBodyError(w, err)
+		return
+	}
+
+	defer r.Body.Close()
+
+	var denyNetworkRequest DenyNetworkRequest
+	if err := decodeStrictJSON(body, &denyNetworkRequest); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if status, err := s.resolveDenyRequest(r.Context(), &denyNetworkRequest); err != nil {
+		writeError(w, status, err)
+		return
+	}
+	if denyNetworkRequest.global() && s.Namespaces.IsScoped() {
+		writeErrorf(w, http.StatusForbidden, "%w: global policies need the service to cover every namespace", errNamespaceOutOfScope)
+		return
+	}
+	for _, workload := range []DenyNetworkRequestWorkload{denyNetworkRequest.A, denyNetworkRequest.B} {
+		if workload.Namespace == "" {
+			continue
+		}
+		if err := s.Namespaces.check(r.Context(), s.K8sClientSet, workload.Namespace); err != nil {
+			writeError(w, http.StatusForbidden, err)
+			return
+		}
+	}
+
+	impact, err := denyImpact(r.Context(), s.K8sClientSet, denyNetworkRequest)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, impact)
+}
+
+// denyImpact lists the pods of both workloads of request, a workload without namespace matching pods in every namespace.
+func denyImpact(ctx context.Context, clientset kubernetes.Interface, request DenyNetworkRequest) (*DenyImpact, error) {
+	controllers := podControllers{clientset: clientset, replicaSets: map[string]string{}}
+
+	impact := &DenyImpact{}
+	for _, side := range []struct {
+		workload DenyNetworkRequestWorkload
+		impact   *DenyImpactWorkload
+	}{{request.A, &impact.A}, {request.B, &impact.B}} {
+		side.impact.Workload, side.impact.Pods = side.workload.describe(), []AffectedPod{}
+		if side.workload.CIDR != "" || side.workload.Kind == workloadKindHostEndpoint {
+			continue
+		}
+
+		pods, err := clientset.CoreV1().Pods(side.workload.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: side.workload.selector().String(),
+			Limit:         maxImpactPods,
+		})
+		if err != nil {
+			return nil, err
+		}
+		side.impact.Truncated = pods.Continue != ""
+		for _, pod := range pods.Items {
+			controller, err := controllers.of(ctx, pod)
+			if err != nil {
+				return nil, err
+			}
+			side.impact.Pods = append(side.impact.Pods, AffectedPod{Namespace: pod.Namespace, Name: pod.Name, Node: pod.Spec.NodeName, Controller: controller})
+		}
+		sort.Slice(side.impact.Pods, func(i, j int) bool {
+			a, b := side.impact.Pods[i], side.impact.Pods[j]
+			if a.Namespace != b.Namespace {
+				return a.Namespace < b.Namespace
+			}
+			return a.Name < b.Name
+		})
+	}
+	return impact, nil
+}
+
+// podControllers names the controllers of pods as Kind/name, the Deployment rather than the ReplicaSet it manages pods through.
+type podControllers struct {
+	clientset   kubernetes.Interface
+	replicaSets map[string]string
+}
+
+func (c podControllers) of(ctx context.Context, pod corev1.Pod) (string, error) {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return "", nil
+	}
+	controller := owner.Kind + "/" + owner.Name
+	if owner.Kind != "ReplicaSet" {
+		return controller, nil
+	}
+
+	key := pod.Namespace + "/" + owner.Name
+	if deployment, ok := c.replicaSets[key]; ok {
+		return deployment, nil
+	}
+	// A ReplicaSet being deleted leaves its pods named after it
+	replicaSet, err := c.clientset.AppsV1().ReplicaSets(pod.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return "", fmt.Errorf("reading the owner of pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	default:
+		if deployment := metav1.GetControllerOf(replicaSet); deployment != nil && deployment.Kind == "Deployment" {
+			controller = "Deployment/" + deployment.Name
+		}
+	}
+	c.replicaSets[key] = controller
+	return controller, nil
+}
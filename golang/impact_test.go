@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDenyImpact(t *testing.T) {
+	controller := true
+	ownedBy := func(kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{Kind: kind, Name: name, Controller: &controller}}
+	}
+	clientset := fake.NewSimpleClientset(
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Namespace: "payments", Name: "ledger-5d4f", OwnerReferences: ownedBy("Deployment", "ledger")}},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "payments", Name: "ledger-5d4f-b", Labels: map[string]string{"app": "ledger"}, OwnerReferences: ownedBy("ReplicaSet", "ledger-5d4f")},
+			Spec:       corev1.PodSpec{NodeName: "node-2"},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "payments", Name: "ledger-5d4f-a", Labels: map[string]string{"app": "ledger"}, OwnerReferences: ownedBy("ReplicaSet", "ledger-5d4f")},
+			Spec:       corev1.PodSpec{NodeName: "node-1"},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "cart-0", Labels: map[string]string{"app": "cart"}, OwnerReferences: ownedBy("StatefulSet", "cart")},
+		},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "checkout", Labels: map[string]string{"app": "checkout"}}},
+	)
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}},
+	}
+
+	impact, err := denyImpact(context.Background(), clientset, request)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, DenyImpactWorkload{
+		Workload: "payments/app == 'ledger'",
+		Pods: []AffectedPod{
+			{Namespace: "payments", Name: "ledger-5d4f-a", Node: "node-1", Controller: "Deployment/ledger"},
+			{Namespace: "payments", Name: "ledger-5d4f-b", Node: "node-2", Controller: "Deployment/ledger"},
+		},
+	}, impact.A)
+	assert.Equal(t, []AffectedPod{{Namespace: "shop", Name: "cart-0", Controller: "StatefulSet/cart"}}, impact.B.Pods)
+
+	request.B = DenyNetworkRequestWorkload{CIDR: "10.12.0.0/16"}
+	impact, err = denyImpact(context.Background(), clientset, request)
+	assert.NoError(t, err)
+	assert.Equal(t, DenyImpactWorkload{Workload: "10.12.0.0/16", Pods: []AffectedPod{}}, impact.B)
+}
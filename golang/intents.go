@@ -0,0 +1,375 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const defaultIntentReconcileInterval = time.Minute
+
+var isolationIntentResource = schema.GroupVersionResource{Group: "tyk-sre-assignment.io", Version: "v1alpha1", Resource: "isolationintents"}
+
+// IsolationIntentSpec is a deny policy as the service created it, which the intent keeps in force.
+//
+// The request the policy was rendered from is in its annotations, SpecHash fingerprints its spec as the API server stored it.
+type IsolationIntentSpec struct {
+	Kind        string            `json:"kind"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Name        string            `json:"name"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	SpecHash    string            `json:"specHash"`
+}
+
+// intentBackend records an IsolationIntent for every deny policy created through the backend it wraps, and deletes it
+// with the policy. The intentReconciler recreates the policies of the intents left, which were deleted or changed out of band.
+//
+// Staged policies get an intent once promoted, quarantines don't get any.
+type intentBackend struct {
+	PolicyBackend
+	client dynamic.Interface
+}
+
+func withIsolationIntents(backend PolicyBackend, config *rest.Config) (*intentBackend, error) {
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &intentBackend{PolicyBackend: backend, client: client}, nil
+}
+
+func (b *intentBackend) tiered() bool {
+	return supportsTiers(b.PolicyBackend)
+}
+
+func (b *intentBackend) canStage() bool {
+	_, ok := stagingOf(b.PolicyBackend)
+	return ok
+}
+
+func (b *intentBackend) getStaged(ctx context.Context, namespace, name string) (PolicyObject, error) {
+	staging, ok := stagingOf(b.PolicyBackend)
+	if !ok {
+		return nil, fmt.Errorf("the %s policy backend can't stage policies", b.PolicyBackend.Name())
+	}
+	return staging.getStaged(ctx, namespace, name)
+}
+
+// promote enforces the staged policy then records its intent, the policy staying enforced when it can't be.
+func (b *intentBackend) promote(ctx context.Context, staged PolicyObject) (PolicyObject, error) {
+	staging, ok := stagingOf(b.PolicyBackend)
+	if !ok {
+		return nil, fmt.Errorf("the %s policy backend can't stage policies", b.PolicyBackend.Name())
+	}
+	policy, err := staging.promote(ctx, staged)
+	if policy == nil {
+		return nil, err
+	}
+	return policy, errors.Join(err, b.record(ctx, policy))
+}
+
+func (b *intentBackend) conflicts(ctx context.Context, request DenyNetworkRequest, peerNamespace *corev1.Namespace) ([]PolicyConflict, error) {
+	detector, ok := b.PolicyBackend.(conflictDetector)
+	if !ok {
+		return nil, nil
+	}
+	return detector.conflicts(ctx, request, peerNamespace)
+}
+
+func (b *intentBackend) renderQuarantine(request NamespaceIsolationRequest, services []DenyNetworkRequestWorkload, meta RequestMetadata) PolicyObject {
+	return b.PolicyBackend.(quarantiningBackend).renderQuarantine(request, services, meta)
+}
+
+// Create records the intent of a deny policy once created, the policy is deleted again if it can't be.
+func (b *intentBackend) Create(ctx context.Context, policy PolicyObject, dryRun bool) (PolicyObject, error) {
+	created, err := b.PolicyBackend.Create(ctx, policy, dryRun)
+	if err != nil || dryRun || !intended(policy) {
+		return created, err
+	}
+
+	if err := b.record(ctx, created); err != nil {
+		if deleteErr := b.PolicyBackend.Delete(ctx, created); deleteErr != nil {
+			err = errors.Join(err, fmt.Errorf("rolling back: %w", deleteErr))
+		}
+		return nil, err
+	}
+	return created, nil
+}
+
+// Delete removes the policy then its intent, so it isn't recreated.
+func (b *intentBackend) Delete(ctx context.Context, policy PolicyObject) error {
+	if err := b.PolicyBackend.Delete(ctx, policy); err != nil {
+		return err
+	}
+
+	name := intentName(policy.GetNamespace(), policy.GetName())
+	err := b.intents().Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("deleting IsolationIntent %s: %w", name, err)
+	}
+	return nil
+}
+
+// record stores the intent of a created policy, read back so its spec is hashed the way the reconciler reads it.
+func (b *intentBackend) record(ctx context.Context, policy PolicyObject) error {
+	stored, err := b.PolicyBackend.Get(ctx, policy.GetNamespace(), policy.GetName())
+	if err != nil {
+		return fmt.Errorf("reading policy %s/%s back: %w", policy.GetNamespace(), policy.GetName(), err)
+	}
+	hash, err := objectSpecHash(stored)
+	if err != nil {
+		return err
+	}
+
+	intent, err := newIsolationIntent(stored, hash)
+	if err != nil {
+		return err
+	}
+	_, err = b.intents().Create(ctx, intent, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		// Left by a policy of the same name deleted out of band, the new policy takes it over
+		var existing *unstructured.Unstructured
+		if existing, err = b.intents().Get(ctx, intent.GetName(), metav1.GetOptions{}); err == nil {
+			intent.SetResourceVersion(existing.GetResourceVersion())
+			_, err = b.intents().Update(ctx, intent, metav1.UpdateOptions{})
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("recording IsolationIntent %s: %w", intent.GetName(), err)
+	}
+	return nil
+}
+
+func (b *intentBackend) intents() dynamic.ResourceInterface {
+	return b.client.Resource(isolationIntentResource)
+}
+
+// intended tells whether a policy is kept in force by an intent: deny policies, once enforced.
+func intended(policy PolicyObject) bool {
+	annotations := policy.GetAnnotations()
+	return annotations[actionAnnotation] == actionDeny && annotations[stagedAnnotation] != "true"
+}
+
+// intentName names the intent of a policy, IsolationIntents being cluster-wide.
+func intentName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
+
+func newIsolationIntent(policy PolicyObject, hash string) (*unstructured.Unstructured, error) {
+	spec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&IsolationIntentSpec{
+		Kind:        policy.GetObjectKind().GroupVersionKind().Kind,
+		Namespace:   policy.GetNamespace(),
+		Name:        policy.GetName(),
+		Labels:      policy.GetLabels(),
+		Annotations: policy.GetAnnotations(),
+		SpecHash:    hash,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	intent := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	intent.SetGroupVersionKind(isolationIntentResource.GroupVersion().WithKind("IsolationIntent"))
+	intent.SetName(intentName(policy.GetNamespace(), policy.GetName()))
+	intent.SetLabels(map[string]string{managedByLabel: managedByValue})
+	return intent, nil
+}
+
+// objectSpecHash fingerprints the spec of a policy, typed or not.
+func objectSpecHash(policy PolicyObject) (string, error) {
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(policy)
+	if err != nil {
+		return "", err
+	}
+	return specHash(object["spec"]), nil
+}
+
+// intentReconciler recreates the deny policies whose intent is left but which were deleted or changed out of band, so a
+// kubectl delete doesn't silently lift an isolation. Intents whose policy expired are deleted, the reaper deletes the policy.
+type intentReconciler struct {
+	interval  time.Duration
+	backend   *intentBackend
+	clientset kubernetes.Interface
+	scope     NamespaceScope
+}
+
+// run reconciles the intents every interval until ctx is done.
+func (c *intentReconciler) run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.reconcile(ctx, time.Now()); err != nil {
+			slog.ErrorContext(ctx, "Failed reconciling isolation intents", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reconcile enforces the intents of the policies in scope at now. An intent failing to be enforced is retried on the next run.
+func (c *intentReconciler) reconcile(ctx context.Context, now time.Time) error {
+	list, err := c.backend.intents().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	var inScope map[string]bool
+	if c.scope.IsScoped() {
+		namespaces, err := c.scope.resolve(ctx, c.clientset)
+		if err != nil {
+			return err
+		}
+		inScope = map[string]bool{}
+		for _, namespace := range namespaces {
+			inScope[namespace] = true
+		}
+	}
+
+	for i := range list.Items {
+		intent := &list.Items[i]
+		spec, err := isolationIntentSpec(intent)
+		if err != nil {
+			slog.ErrorContext(ctx, "Invalid isolation intent", "name", intent.GetName(), "error", err)
+			continue
+		}
+		if inScope != nil && !inScope[spec.Namespace] {
+			continue
+		}
+
+		if expired(spec, now) {
+			if err := c.deleteExpired(ctx, intent); err != nil {
+				slog.ErrorContext(ctx, "Failed deleting expired isolation intent", "name", intent.GetName(), "error", err)
+			}
+			continue
+		}
+		if _, err := c.enforce(ctx, intent, spec); err != nil {
+			slog.ErrorContext(ctx, "Failed enforcing isolation intent", "name", intent.GetName(), "namespace", spec.Namespace, "policy", spec.Name, "error", err)
+		}
+	}
+	return nil
+}
+
+// isolationIntentSpec reads the spec of an intent.
+func isolationIntentSpec(intent *unstructured.Unstructured) (IsolationIntentSpec, error) {
+	var spec IsolationIntentSpec
+	object, _, _ := unstructured.NestedMap(intent.Object, "spec")
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(object, &spec)
+	return spec, err
+}
+
+// expired tells whether the policy of an intent expired at now, the reaper deleting it.
+func expired(spec IsolationIntentSpec, now time.Time) bool {
+	expiry := expiresAt(&metav1.ObjectMeta{Annotations: spec.Annotations})
+	return expiry != nil && !expiry.After(now)
+}
+
+func (c *intentReconciler) deleteExpired(ctx context.Context, intent *unstructured.Unstructured) error {
+	err := c.backend.intents().Delete(ctx, intent.GetName(), metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// enforce recreates the policy of an intent when it's missing or its spec changed, and records the spec it now has.
+//
+// It returns why the policy was recreated, deleted or changed, empty when it was in force.
+func (c *intentReconciler) enforce(ctx context.Context, intent *unstructured.Unstructured, spec IsolationIntentSpec) (string, error) {
+	// The wrapped backend, so replacing the policy keeps its intent
+	backend := c.backend.PolicyBackend
+
+	reason := "deleted"
+	policy, err := backend.Get(ctx, spec.Namespace, spec.Name)
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return "", err
+	default:
+		hash, err := objectSpecHash(policy)
+		if err != nil {
+			return "", err
+		}
+		if hash == spec.SpecHash {
+			return "", nil
+		}
+		reason = "changed"
+		if err := backend.Delete(ctx, policy); err != nil && !apierrors.IsNotFound(err) {
+			return "", err
+		}
+	}
+
+	rendered, err := c.render(ctx, spec)
+	if err != nil {
+		return "", err
+	}
+	audit := AuditEvent{
+		Action:          "policy.restore",
+		RequestMetadata: requestMetadata(ctx),
+		Namespace:       spec.Namespace,
+		Target:          spec.Name,
+		Result:          "recreated",
+	}
+	created, err := backend.Create(ctx, rendered, false)
+	if err != nil {
+		audit.Result, audit.Error = "failed", err.Error()
+		recordAudit(audit)
+		return "", err
+	}
+	recordAudit(audit)
+	slog.InfoContext(ctx, "Network policy restored from its isolation intent", "namespace", spec.Namespace, "name", spec.Name, "reason", reason)
+	recordEvent(ctx, c.clientset, policyReference(created), "DenyPolicyRestored", fmt.Sprintf("Recreated the policy %s out of band", reason), RequestMetadata{})
+
+	stored, err := backend.Get(ctx, spec.Namespace, spec.Name)
+	if err != nil {
+		return reason, err
+	}
+	hash, err := objectSpecHash(stored)
+	if err != nil {
+		return reason, err
+	}
+	if err := unstructured.SetNestedField(intent.Object, hash, "spec", "specHash"); err != nil {
+		return reason, err
+	}
+	_, err = c.backend.intents().Update(ctx, intent, metav1.UpdateOptions{})
+	return reason, err
+}
+
+// render renders the policy of an intent again from its request, with the labels and annotations it was created with.
+func (c *intentReconciler) render(ctx context.Context, spec IsolationIntentSpec) (PolicyObject, error) {
+	var request DenyNetworkRequest
+	if err := json.Unmarshal([]byte(spec.Annotations[requestAnnotation]), &request); err != nil {
+		return nil, fmt.Errorf("reading the request of policy %s/%s: %w", spec.Namespace, spec.Name, err)
+	}
+	policy, err := prepareDenyNetworkPolicy(ctx, c.clientset, c.backend.PolicyBackend, c.scope, request, RequestMetadata{})
+	if err != nil {
+		return nil, err
+	}
+	if policy.GetNamespace() != spec.Namespace || policy.GetName() != spec.Name {
+		return nil, fmt.Errorf("the request of policy %s/%s renders %s/%s", spec.Namespace, spec.Name, policy.GetNamespace(), policy.GetName())
+	}
+	policy.SetLabels(spec.Labels)
+	policy.SetAnnotations(spec.Annotations)
+	return policy, nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newFakeIntentBackend() *intentBackend {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		ciliumPolicyResource:            "CiliumNetworkPolicyList",
+		ciliumClusterwidePolicyResource: "CiliumClusterwideNetworkPolicyList",
+		isolationIntentResource:         "IsolationIntentList",
+	})
+	return &intentBackend{PolicyBackend: &ciliumBackend{client: client}, client: client}
+}
+
+func TestIntentReconciler(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+	)
+	backend := newFakeIntentBackend()
+	policies := backend.client.Resource(ciliumPolicyResource)
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}},
+	}
+	name, _, err := createDenyNetworkPolicy(context.Background(), clientset, backend, NamespaceScope{}, request, RequestMetadata{Principal: "alice"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// The policy and its mirror both get an intent
+	intents, err := backend.intents().List(ctx, metav1.ListOptions{})
+	if !assert.NoError(t, err) || !assert.Len(t, intents.Items, 2) {
+		return
+	}
+	intent, err := backend.intents().Get(ctx, "payments."+name, metav1.GetOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	recorded, _, _ := unstructured.NestedString(intent.Object, "spec", "specHash")
+	assert.NotEmpty(t, recorded)
+
+	reconciler := &intentReconciler{backend: backend, clientset: clientset}
+
+	// Deleted out of band
+	assert.NoError(t, policies.Namespace("payments").Delete(ctx, name, metav1.DeleteOptions{}))
+	assert.NoError(t, reconciler.reconcile(ctx, time.Now()))
+	restored, err := backend.Get(ctx, "payments", name)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "alice", restored.GetAnnotations()[createdByAnnotation])
+
+	// Changed out of band
+	changed := restored.(*unstructured.Unstructured)
+	assert.NoError(t, unstructured.SetNestedSlice(changed.Object, []interface{}{}, "spec", "ingressDeny"))
+	_, err = policies.Namespace("payments").Update(ctx, changed, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	assert.NoError(t, reconciler.reconcile(ctx, time.Now()))
+	restored, err = backend.Get(ctx, "payments", name)
+	if !assert.NoError(t, err) {
+		return
+	}
+	rules, _, _ := unstructured.NestedSlice(restored.(*unstructured.Unstructured).Object, "spec", "ingressDeny")
+	assert.Len(t, rules, 1)
+	hash, _ := objectSpecHash(restored)
+	assert.Equal(t, recorded, hash)
+
+	// Deleted through the service, with its intent
+	assert.NoError(t, backend.Delete(ctx, restored))
+	_, err = backend.intents().Get(ctx, "payments."+name, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.NoError(t, reconciler.reconcile(ctx, time.Now()))
+	_, err = backend.Get(ctx, "payments", name)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestIntentReconcilerExpiry(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}})
+	backend := newFakeIntentBackend()
+	request := DenyNetworkRequest{
+		A:   DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B:   DenyNetworkRequestWorkload{CIDR: "10.12.0.0/16"},
+		TTL: &metav1.Duration{Duration: time.Hour},
+	}
+	name, _, err := createDenyNetworkPolicy(context.Background(), clientset, backend, NamespaceScope{}, request, RequestMetadata{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, backend.client.Resource(ciliumPolicyResource).Namespace("payments").Delete(ctx, name, metav1.DeleteOptions{}))
+
+	// The expired intent is forgotten rather than enforced
+	reconciler := &intentReconciler{backend: backend, clientset: clientset}
+	assert.NoError(t, reconciler.reconcile(ctx, time.Now().Add(2*time.Hour)))
+	_, err = backend.intents().Get(ctx, "payments."+name, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = backend.Get(ctx, "payments", name)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestIntended(t *testing.T) {
+	policy := &unstructured.Unstructured{}
+	policy.SetAnnotations(map[string]string{actionAnnotation: actionDeny})
+	assert.True(t, intended(policy))
+	policy.SetAnnotations(map[string]string{actionAnnotation: actionDeny, stagedAnnotation: "true"})
+	assert.False(t, intended(policy))
+	policy.SetAnnotations(map[string]string{actionAnnotation: actionQuarantine})
+	assert.False(t, intended(policy))
+
+	assert.Equal(t, "payments.deny", intentName("payments", "deny"))
+	assert.Equal(t, "deny", intentName("", "deny"))
+}
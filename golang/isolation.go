@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+)
+
+// NamespaceIsolationStatus lists everything the service has put in place that affects a namespace.
+type NamespaceIsolationStatus struct {
+	Namespace   string                 `json:"namespace"`
+	Blocked     bool                   `json:"blocked"`
+	Policies    []ManagedPolicySummary `json:"policies"`
+	Quarantines []ManagedPolicySummary `json:"quarantines"`
+}
+
+// ManagedPolicySummary describes a policy created by the service.
+//
+// Role is "local" when the policy lives in the namespace and "peer" when it denies traffic to the namespace from elsewhere.
+type ManagedPolicySummary struct {
+	Name          string     `json:"name"`
+	Namespace     string     `json:"namespace"`
+	Role          string     `json:"role"`
+	Action        string     `json:"action"`
+	Selector      string     `json:"selector"`
+	PeerNamespace string     `json:"peer_namespace,omitempty"`
+	CreatedBy     string     `json:"created_by"`
+	CreatedAt     time.Time  `json:"created_at"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	TTLRemaining  string     `json:"ttl_remaining,omitempty"`
+}
+
+// Handler summarising the isolation in force for a namespace
+func (s *Server) namespaceIsolationHandler(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("name")
+
+	if err := s.Namespaces.check(r.Context(), s.K8sClientSet, namespace); err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	status, err := s.namespaceIsolationStatus(r.Context(), namespace, time.Now())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, status)
+}
+
+func (s *Server) namespaceIsolationStatus(ctx context.Context, namespace string, now time.Time) (*NamespaceIsolationStatus, error) {
+	policies, err := s.listManagedPolicies(ctx, s.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	return summariseNamespaceIsolation(namespace, policies, now), nil
+}
+
+// summariseNamespaceIsolation keeps the managed policies living in or pointing at namespace, ignoring expired ones.
+func summariseNamespaceIsolation(namespace string, policies []v3.NetworkPolicy, now time.Time) *NamespaceIsolationStatus {
+	status := &NamespaceIsolationStatus{
+		Namespace:   namespace,
+		Policies:    []ManagedPolicySummary{},
+		Quarantines: []ManagedPolicySummary{},
+	}
+
+	for _, policy := range policies {
+		summary := ManagedPolicySummary{
+			Name:          policy.Name,
+			Namespace:     policy.Namespace,
+			Action:        policy.Annotations[actionAnnotation],
+			Selector:      policy.Spec.Selector,
+			PeerNamespace: policy.Annotations[peerNamespaceAnnotation],
+			CreatedBy:     policy.Annotations[createdByAnnotation],
+			CreatedAt:     policy.CreationTimestamp.Time,
+			ExpiresAt:     expiresAt(&policy.ObjectMeta),
+		}
+		if summary.CreatedBy == "" {
+			summary.CreatedBy = unknownPrincipal
+		}
+
+		switch {
+		case policy.Namespace == namespace:
+			summary.Role = "local"
+		case summary.PeerNamespace == namespace:
+			summary.Role = "peer"
+		default:
+			continue
+		}
+
+		if summary.ExpiresAt != nil {
+			remaining := summary.ExpiresAt.Sub(now)
+			if remaining <= 0 {
+				continue
+			}
+			summary.TTLRemaining = remaining.Round(time.Second).String()
+		}
+
+		if summary.Action == actionQuarantine {
+			status.Quarantines = append(status.Quarantines, summary)
+		} else {
+			status.Policies = append(status.Policies, summary)
+		}
+	}
+
+	for _, list := range [][]ManagedPolicySummary{status.Policies, status.Quarantines} {
+		sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	}
+	status.Blocked = len(status.Policies) > 0 || len(status.Quarantines) > 0
+	return status
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func managedPolicy(namespace, name, action, peer string, annotations map[string]string) v3.NetworkPolicy {
+	labels, managed := managedObjectMeta(action, peer, RequestMetadata{}, nil)
+	for key, value := range annotations {
+		managed[key] = value
+	}
+	return v3.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels, Annotations: managed}}
+}
+
+func TestSummariseNamespaceIsolation(t *testing.T) {
+	now := time.Date(2024, 7, 8, 12, 0, 0, 0, time.UTC)
+	policies := []v3.NetworkPolicy{
+		managedPolicy("payments", "local", actionDeny, "shop", nil),
+		managedPolicy("shop", "peer", actionDeny, "payments", map[string]string{expiresAtAnnotation: "2024-07-08T12:30:00Z", createdByAnnotation: "alice"}),
+		managedPolicy("payments", "expired", actionDeny, "shop", map[string]string{expiresAtAnnotation: "2024-07-08T11:00:00Z"}),
+		managedPolicy("payments", "quarantine", actionQuarantine, "", nil),
+		managedPolicy("search", "unrelated", actionDeny, "shop", nil),
+	}
+
+	status := summariseNamespaceIsolation("payments", policies, now)
+	assert.True(t, status.Blocked)
+	assert.Len(t, status.Policies, 2)
+	assert.Len(t, status.Quarantines, 1)
+
+	for _, policy := range status.Policies {
+		switch policy.Name {
+		case "local":
+			assert.Equal(t, "local", policy.Role)
+			assert.Equal(t, unknownPrincipal, policy.CreatedBy)
+		case "peer":
+			assert.Equal(t, "peer", policy.Role)
+			assert.Equal(t, "alice", policy.CreatedBy)
+			assert.Equal(t, "30m0s", policy.TTLRemaining)
+		default:
+			t.Errorf("unexpected policy %s", policy.Name)
+		}
+	}
+
+	assert.False(t, summariseNamespaceIsolation("billing", policies, now).Blocked)
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// Values of --istio-policies.
+const (
+	istioPoliciesAuto   = "auto"
+	istioPoliciesAlways = "always"
+	istioPoliciesNever  = "never"
+)
+
+// istioPeerPolicySuffix names the AuthorizationPolicy protecting B.
+const istioPeerPolicySuffix = "-peer"
+
+var istioPolicyResource = schema.GroupVersionResource{Group: "security.istio.io", Version: "v1beta1", Resource: "authorizationpolicies"}
+
+// istioMeshBackend adds Istio AuthorizationPolicies to the policies of another backend, so traffic the CNI doesn't see is denied by the sidecars.
+//
+// Istio matches the source of a request by its identity, not its labels: the workloads are identified by the service accounts of their pods.
+// The sidecars only enforce on the receiving side, each workload gets a policy denying the other one.
+type istioMeshBackend struct {
+	PolicyBackend
+	clientset kubernetes.Interface
+	client    dynamic.Interface
+}
+
+// withIstioPolicies wraps backend so it also creates AuthorizationPolicies, in auto mode only when the Istio API is served.
+func withIstioPolicies(backend PolicyBackend, mode string, config *rest.Config, clientset kubernetes.Interface) (PolicyBackend, error) {
+	switch mode {
+	case istioPoliciesNever:
+		return backend, nil
+	case istioPoliciesAuto:
+		_, err := clientset.Discovery().ServerResourcesForGroupVersion(istioPolicyResource.GroupVersion().String())
+		if apierrors.IsNotFound(err) {
+			return backend, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("detecting Istio: %w", err)
+		}
+		slog.Info("Istio detected, deny policies get AuthorizationPolicies too")
+	case istioPoliciesAlways:
+	default:
+		return nil, fmt.Errorf("invalid Istio policies mode %q, expected auto, always or never", mode)
+	}
+
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &istioMeshBackend{PolicyBackend: backend, clientset: clientset, client: client}, nil
+}
+
+func (b *istioMeshBackend) Name() string {
+	return b.PolicyBackend.Name() + "+istio"
+}
+
+func (b *istioMeshBackend) tiered() bool {
+	return supportsTiers(b.PolicyBackend)
+}
+
+func (b *istioMeshBackend) canStage() bool {
+	_, ok := stagingOf(b.PolicyBackend)
+	return ok
+}
+
+func (b *istioMeshBackend) conflicts(ctx context.Context, request DenyNetworkRequest, peerNamespace *corev1.Namespace) ([]PolicyConflict, error) {
+	detector, ok := b.PolicyBackend.(conflictDetector)
+	if !ok {
+		return nil, nil
+	}
+	return detector.conflicts(ctx, request, peerNamespace)
+}
+
+// Resources adds the AuthorizationPolicies to the namespaced policies of the primary backend.
+func (b *istioMeshBackend) Resources(global bool) []schema.GroupResource {
+	resources := b.PolicyBackend.Resources(global)
+	if global {
+		return resources
+	}
+	return append(resources, istioPolicyResource.GroupResource())
+}
+
+// renderQuarantine is left to the primary backend, the sidecars of an isolated namespace can't send or receive anything.
+func (b *istioMeshBackend) renderQuarantine(request NamespaceIsolationRequest, services []DenyNetworkRequestWorkload, meta RequestMetadata) PolicyObject {
+	return b.PolicyBackend.(quarantiningBackend).renderQuarantine(request, services, meta)
+}
+
+// Create stores the policy then the AuthorizationPolicies, the policy is deleted again if they can't be created.
+//
+// Global policies are left to the primary backend, AuthorizationPolicies are namespaced. So are staged policies, which
+// only get AuthorizationPolicies once promoted: those have no staged mode. Namespace isolations only deny at the network.
+func (b *istioMeshBackend) Create(ctx context.Context, policy PolicyObject, dryRun bool) (PolicyObject, error) {
+	if policy.GetNamespace() == "" || policy.GetAnnotations()[stagedAnnotation] == "true" || policy.GetAnnotations()[actionAnnotation] != actionDeny {
+		return b.PolicyBackend.Create(ctx, policy, dryRun)
+	}
+
+	authorizationPolicies, err := b.render(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := b.PolicyBackend.Create(ctx, policy, dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.createAuthorizationPolicies(ctx, authorizationPolicies, dryRun); err != nil {
+		if !dryRun {
+			if deleteErr := b.Delete(ctx, created); deleteErr != nil {
+				err = errors.Join(err, fmt.Errorf("rolling back: %w", deleteErr))
+			}
+		}
+		return nil, err
+	}
+	return created, nil
+}
+
+func (b *istioMeshBackend) listStaged(ctx context.Context, namespace string) ([]PolicyObject, error) {
+	staging, ok := stagingOf(b.PolicyBackend)
+	if !ok {
+		return nil, fmt.Errorf("the %s policy backend can't stage policies", b.PolicyBackend.Name())
+	}
+	return staging.listStaged(ctx, namespace)
+}
+
+func (b *istioMeshBackend) getStaged(ctx context.Context, namespace, name string) (PolicyObject, error) {
+	staging, ok := stagingOf(b.PolicyBackend)
+	if !ok {
+		return nil, fmt.Errorf("the %s policy backend can't stage policies", b.PolicyBackend.Name())
+	}
+	return staging.getStaged(ctx, namespace, name)
+}
+
+// promote enforces the staged policy then creates its AuthorizationPolicies. Those failing leave the policy enforced by the CNI.
+func (b *istioMeshBackend) promote(ctx context.Context, staged PolicyObject) (PolicyObject, error) {
+	staging, ok := stagingOf(b.PolicyBackend)
+	if !ok {
+		return nil, fmt.Errorf("the %s policy backend can't stage policies", b.PolicyBackend.Name())
+	}
+	if staged.GetNamespace() == "" {
+		return staging.promote(ctx, staged)
+	}
+
+	authorizationPolicies, err := b.render(ctx, staged)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := staging.promote(ctx, staged)
+	if policy == nil {
+		return nil, err
+	}
+	return policy, errors.Join(err, b.createAuthorizationPolicies(ctx, authorizationPolicies, false))
+}
+
+// createAuthorizationPolicies creates the AuthorizationPolicies of a policy, keeping those which already exist.
+func (b *istioMeshBackend) createAuthorizationPolicies(ctx context.Context, authorizationPolicies []*unstructured.Unstructured, dryRun bool) error {
+	options := metav1.CreateOptions{}
+	if dryRun {
+		options.DryRun = []string{metav1.DryRunAll}
+	}
+	for _, authorizationPolicy := range authorizationPolicies {
+		_, err := b.client.Resource(istioPolicyResource).Namespace(authorizationPolicy.GetNamespace()).Create(ctx, authorizationPolicy, options)
+		if err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("creating AuthorizationPolicy %s/%s: %w", authorizationPolicy.GetNamespace(), authorizationPolicy.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// Delete removes the policy then its AuthorizationPolicies.
+func (b *istioMeshBackend) Delete(ctx context.Context, policy PolicyObject) error {
+	if err := b.PolicyBackend.Delete(ctx, policy); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, ref := range []struct{ namespace, name string }{
+		{policy.GetNamespace(), policy.GetName()},
+		{policy.GetAnnotations()[peerNamespaceAnnotation], policy.GetName() + istioPeerPolicySuffix},
+	} {
+		if ref.namespace == "" {
+			continue
+		}
+		err := b.client.Resource(istioPolicyResource).Namespace(ref.namespace).Delete(ctx, ref.name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("deleting AuthorizationPolicy %s/%s: %w", ref.namespace, ref.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// render builds the AuthorizationPolicies denying A and B each other, named after policy. The one of B gets a suffix as both may share a namespace.
+//
+// A CIDR only gets the policy of A: the sidecars see where requests come from, not the traffic of A to the range.
+func (b *istioMeshBackend) render(ctx context.Context, policy PolicyObject) ([]*unstructured.Unstructured, error) {
+	var request DenyNetworkRequest
+	if err := json.Unmarshal([]byte(policy.GetAnnotations()[requestAnnotation]), &request); err != nil {
+		return nil, fmt.Errorf("reading the request of policy %s/%s: %w", policy.GetNamespace(), policy.GetName(), err)
+	}
+	if len(request.A.MatchExpressions) > 0 || len(request.B.MatchExpressions) > 0 {
+		return nil, errors.New("AuthorizationPolicies only select workloads by exact labels, match_expressions can't be used with Istio policies")
+	}
+	if request.B.CIDR != "" {
+		return []*unstructured.Unstructured{
+			renderAuthorizationPolicy(policy, policy.GetName(), request.A, map[string]interface{}{"ipBlocks": []interface{}{request.B.CIDR}}),
+		}, nil
+	}
+
+	principalsA, err := b.principals(ctx, request.A)
+	if err != nil {
+		return nil, err
+	}
+	principalsB, err := b.principals(ctx, request.B)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*unstructured.Unstructured{
+		renderAuthorizationPolicy(policy, policy.GetName(), request.A, map[string]interface{}{"principals": principalsB}),
+		renderAuthorizationPolicy(policy, policy.GetName()+istioPeerPolicySuffix, request.B, map[string]interface{}{"principals": principalsA}),
+	}, nil
+}
+
+// principals returns the mesh identities of the pods of workload, matching any trust domain.
+//
+// A workload without pods is an error: a source without principals would match every request.
+func (b *istioMeshBackend) principals(ctx context.Context, workload DenyNetworkRequestWorkload) ([]interface{}, error) {
+	pods, err := b.clientset.CoreV1().Pods(workload.Namespace).List(ctx, metav1.ListOptions{LabelSelector: workload.selector().String()})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var principals []string
+	for _, pod := range pods.Items {
+		serviceAccount := pod.Spec.ServiceAccountName
+		if serviceAccount == "" {
+			serviceAccount = "default"
+		}
+		principal := fmt.Sprintf("*/ns/%s/sa/%s", workload.Namespace, serviceAccount)
+		if !seen[principal] {
+			seen[principal] = true
+			principals = append(principals, principal)
+		}
+	}
+	if len(principals) == 0 {
+		return nil, fmt.Errorf("no pods match %s in namespace %s, their mesh identity can't be denied", renderMap(workload.Labels), workload.Namespace)
+	}
+	sort.Strings(principals)
+
+	sources := make([]interface{}, 0, len(principals))
+	for _, principal := range principals {
+		sources = append(sources, principal)
+	}
+	return sources, nil
+}
+
+// renderAuthorizationPolicy denies the requests of source to workload, with the labels and annotations of policy.
+func renderAuthorizationPolicy(policy PolicyObject, name string, workload DenyNetworkRequestWorkload, source map[string]interface{}) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"selector": map[string]interface{}{"matchLabels": stringMap(workload.Labels)},
+		"action":   "DENY",
+		"rules": []interface{}{
+			map[string]interface{}{"from": []interface{}{
+				map[string]interface{}{"source": source},
+			}},
+		},
+	}
+
+	annotations := map[string]string{}
+	for key, value := range policy.GetAnnotations() {
+		annotations[key] = value
+	}
+	delete(annotations, stagedAnnotation)
+	annotations[specHashAnnotation] = specHash(spec)
+
+	authorizationPolicy := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	authorizationPolicy.SetGroupVersionKind(istioPolicyResource.GroupVersion().WithKind("AuthorizationPolicy"))
+	authorizationPolicy.SetName(name)
+	authorizationPolicy.SetNamespace(workload.Namespace)
+	authorizationPolicy.SetLabels(policy.GetLabels())
+	authorizationPolicy.SetAnnotations(annotations)
+	return authorizationPolicy
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedisco "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+func newFakeIstioMeshBackend(objects ...runtime.Object) *istioMeshBackend {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		ciliumPolicyResource: "CiliumNetworkPolicyList",
+		istioPolicyResource:  "AuthorizationPolicyList",
+	})
+	return &istioMeshBackend{
+		PolicyBackend: &ciliumBackend{client: client},
+		clientset:     fake.NewSimpleClientset(objects...),
+		client:        client,
+	}
+}
+
+func meshPod(namespace, name, app, serviceAccount string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: map[string]string{"app": app}},
+		Spec:       corev1.PodSpec{ServiceAccountName: serviceAccount},
+	}
+}
+
+func TestIstioMeshBackendCreateAndDelete(t *testing.T) {
+	ctx := context.Background()
+	backend := newFakeIstioMeshBackend(
+		meshPod("payments", "ledger-1", "ledger", "ledger"),
+		meshPod("payments", "ledger-2", "ledger", "ledger"),
+		meshPod("shop", "cart-1", "cart", ""),
+	)
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}},
+	}
+
+	created, err := backend.Create(ctx, backend.Render(request, &corev1.Namespace{}, RequestMetadata{}), false)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "cilium+istio", backend.Name())
+
+	authorizationPolicies := backend.client.Resource(istioPolicyResource)
+	local, err := authorizationPolicies.Namespace("payments").Get(ctx, created.GetName(), metav1.GetOptions{})
+	if assert.NoError(t, err) {
+		rules, _, _ := unstructured.NestedSlice(local.Object, "spec", "rules")
+		assert.Equal(t, []interface{}{map[string]interface{}{"from": []interface{}{map[string]interface{}{
+			"source": map[string]interface{}{"principals": []interface{}{"*/ns/shop/sa/default"}},
+		}}}}, rules)
+		assert.Equal(t, managedByValue, local.GetLabels()[managedByLabel])
+	}
+	peer, err := authorizationPolicies.Namespace("shop").Get(ctx, created.GetName()+istioPeerPolicySuffix, metav1.GetOptions{})
+	if assert.NoError(t, err) {
+		selector, _, _ := unstructured.NestedStringMap(peer.Object, "spec", "selector", "matchLabels")
+		assert.Equal(t, map[string]string{"app": "cart"}, selector)
+		rules, _, _ := unstructured.NestedSlice(peer.Object, "spec", "rules")
+		assert.Equal(t, []interface{}{map[string]interface{}{"from": []interface{}{map[string]interface{}{
+			"source": map[string]interface{}{"principals": []interface{}{"*/ns/payments/sa/ledger"}},
+		}}}}, rules)
+	}
+
+	assert.NoError(t, backend.Delete(ctx, created))
+	_, err = authorizationPolicies.Namespace("payments").Get(ctx, created.GetName(), metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = authorizationPolicies.Namespace("shop").Get(ctx, created.GetName()+istioPeerPolicySuffix, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = backend.Get(ctx, "payments", created.GetName())
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestIstioMeshBackendRequiresPods(t *testing.T) {
+	ctx := context.Background()
+	backend := newFakeIstioMeshBackend(meshPod("payments", "ledger-1", "ledger", "ledger"))
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}},
+	}
+
+	_, err := backend.Create(ctx, backend.Render(request, &corev1.Namespace{}, RequestMetadata{}), false)
+	assert.ErrorContains(t, err, "no pods match app == 'cart' in namespace shop")
+
+	policies, err := backend.List(ctx, "payments")
+	assert.NoError(t, err)
+	assert.Empty(t, policies)
+}
+
+func TestIstioMeshBackendCIDR(t *testing.T) {
+	ctx := context.Background()
+	backend := newFakeIstioMeshBackend()
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{CIDR: "10.12.0.0/16"},
+	}
+
+	created, err := backend.Create(ctx, backend.Render(request, nil, RequestMetadata{}), false)
+	if !assert.NoError(t, err) {
+		return
+	}
+	local, err := backend.client.Resource(istioPolicyResource).Namespace("payments").Get(ctx, created.GetName(), metav1.GetOptions{})
+	if assert.NoError(t, err) {
+		rules, _, _ := unstructured.NestedSlice(local.Object, "spec", "rules")
+		assert.Equal(t, []interface{}{map[string]interface{}{"from": []interface{}{map[string]interface{}{
+			"source": map[string]interface{}{"ipBlocks": []interface{}{"10.12.0.0/16"}},
+		}}}}, rules)
+	}
+	assert.NoError(t, backend.Delete(ctx, created))
+}
+
+func TestWithIstioPolicies(t *testing.T) {
+	config := &rest.Config{Host: "https://kubernetes.default.svc"}
+	backend := &ciliumBackend{}
+	clientset := fake.NewSimpleClientset()
+
+	wrapped, err := withIstioPolicies(backend, istioPoliciesAuto, config, clientset)
+	assert.NoError(t, err)
+	assert.Same(t, backend, wrapped)
+
+	clientset.Discovery().(*fakedisco.FakeDiscovery).Resources = []*metav1.APIResourceList{{GroupVersion: "security.istio.io/v1beta1"}}
+	wrapped, err = withIstioPolicies(backend, istioPoliciesAuto, config, clientset)
+	assert.NoError(t, err)
+	assert.IsType(t, &istioMeshBackend{}, wrapped)
+
+	wrapped, err = withIstioPolicies(backend, istioPoliciesNever, config, clientset)
+	assert.NoError(t, err)
+	assert.Same(t, backend, wrapped)
+
+	_, err = withIstioPolicies(backend, "sometimes", config, clientset)
+	assert.Error(t, err)
+}
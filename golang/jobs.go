@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// defaultCronJobFailedRuns is how many of its most recent runs have to fail in a row for a CronJob to be failing.
+const defaultCronJobFailedRuns = 3
+
+// maxMissedSchedules caps the missed scheduled times counted, the CronJob controller itself gives up after 100.
+const maxMissedSchedules = 100
+
+// missedScheduleTolerance is how late the CronJob controller may start a job before the run counts as missed, for a
+// CronJob without a starting deadline.
+const missedScheduleTolerance = 2 * time.Minute
+
+type JobInfo struct {
+	Name      string `json:"job_name"`
+	Namespace string `json:"namespace"`
+	// CronJob is the CronJob which created the job, if any
+	CronJob       string `json:"cronjob,omitempty"`
+	SucceededPods int32  `json:"succeeded_pods"`
+	FailedPods    int32  `json:"failed_pods"`
+	BackoffLimit  *int32 `json:"backoff_limit,omitempty"`
+	// Reason and Message are those of the Failed condition, such as BackoffLimitExceeded or DeadlineExceeded
+	Reason    string     `json:"reason"`
+	Message   string     `json:"message,omitempty"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+	FailedAt  time.Time  `json:"failed_at"`
+}
+
+type CronJobInfo struct {
+	Name               string     `json:"cronjob_name"`
+	Namespace          string     `json:"namespace"`
+	Schedule           string     `json:"schedule"`
+	TimeZone           string     `json:"time_zone,omitempty"`
+	LastScheduleTime   *time.Time `json:"last_schedule_time,omitempty"`
+	LastSuccessfulTime *time.Time `json:"last_successful_time,omitempty"`
+	// FailedRuns is how many of its most recent finished jobs failed in a row, the CronJob only keeping
+	// failedJobsHistoryLimit of them
+	FailedRuns int `json:"failed_runs"`
+	// MissedSchedules counts the scheduled times since the last one the CronJob didn't start a job at, the first being
+	// MissedSince
+	MissedSchedules int        `json:"missed_schedules"`
+	MissedSince     *time.Time `json:"missed_since,omitempty"`
+	// Error tells why the schedule couldn't be checked for missed times
+	Error string `json:"error,omitempty"`
+}
+
+type ClusterJobsInfo struct {
+	FailedJobs []JobInfo `json:"failed_jobs"`
+	// FailingCronJobs are the CronJobs whose most recent runs failed or which missed scheduled times
+	FailingCronJobs []CronJobInfo `json:"failing_cronjobs"`
+}
+
+// Reports the failed jobs and failing CronJobs in scope, filtered like the deployments of clusterDeploymentsInfoHandler
+//
+// ?failedRuns= is how many of its most recent runs have to fail in a row for a CronJob to be reported, 3 by default.
+func (s *Server) clusterJobsInfoHandler(w http.ResponseWriter, r *http.Request) {
+	filter, status, err := s.parseReportFilter(r)
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+	failedRuns := defaultCronJobFailedRuns
+	if value := r.URL.Query().Get("failedRuns"); value != "" {
+		failedRuns, err = strconv.Atoi(value)
+		if err != nil || failedRuns <= 0 {
+			writeErrorf(w, http.StatusBadRequest, "invalid failedRuns %q, expected a positive integer", value)
+			return
+		}
+	}
+
+	info, err := getJobsHealth(r.Context(), s.K8sClientSet, filter.Scope, filter.Selector, failedRuns, time.Now())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	info.excludeNamespaces(filter.excludedNamespaces(s.ExcludedNamespaces))
+	writeJSON(w, http.StatusOK, info)
+}
+
+// getJobsHealth lists the failed jobs and the failing CronJobs of every namespace in scope whose own labels match
+// selector. A CronJob is failing when its last failedRuns runs failed, or as many as it keeps when that's fewer, or
+// when it missed a scheduled time.
+func getJobsHealth(ctx context.Context, clientset kubernetes.Interface, scope NamespaceScope, selector labels.Selector, failedRuns int, now time.Time) (*ClusterJobsInfo, error) {
+	namespaces, err := scope.resolve(ctx, clientset)
+	if err != nil {
+		return nil, err
+	}
+
+	info := new(ClusterJobsInfo)
+	for _, namespace := range namespaces {
+		// The jobs of a CronJob are needed to count its failed runs, whatever their labels
+		jobs, err := clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		cronJobs, err := clientset.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, err
+		}
+
+		runs := map[string][]batchv1.Job{}
+		for _, job := range jobs.Items {
+			if owner := metav1.GetControllerOf(&job); owner != nil && owner.Kind == "CronJob" {
+				runs[job.Namespace+"/"+owner.Name] = append(runs[job.Namespace+"/"+owner.Name], job)
+			}
+			if failed, ok := jobFailure(job); ok && selector.Matches(labels.Set(job.Labels)) {
+				info.FailedJobs = append(info.FailedJobs, failed)
+			}
+		}
+
+		for _, cronJob := range cronJobs.Items {
+			if cronJobInfo, failing := cronJobHealth(cronJob, runs[cronJob.Namespace+"/"+cronJob.Name], failedRuns, now); failing {
+				info.FailingCronJobs = append(info.FailingCronJobs, cronJobInfo)
+			}
+		}
+	}
+	slog.DebugContext(ctx, "Jobs health", "failed_jobs", len(info.FailedJobs), "failing_cronjobs", len(info.FailingCronJobs))
+	return info, nil
+}
+
+// jobFailure returns the JobInfo of a job whose Failed condition is true, false for others.
+func jobFailure(job batchv1.Job) (JobInfo, bool) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type != batchv1.JobFailed || condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		info := JobInfo{
+			Name:          job.Name,
+			Namespace:     job.Namespace,
+			SucceededPods: job.Status.Succeeded,
+			FailedPods:    job.Status.Failed,
+			BackoffLimit:  job.Spec.BackoffLimit,
+			Reason:        condition.Reason,
+			Message:       condition.Message,
+			FailedAt:      condition.LastTransitionTime.Time,
+		}
+		if owner := metav1.GetControllerOf(&job); owner != nil && owner.Kind == "CronJob" {
+			info.CronJob = owner.Name
+		}
+		if job.Status.StartTime != nil {
+			info.StartedAt = &job.Status.StartTime.Time
+		}
+		return info, true
+	}
+	return JobInfo{}, false
+}
+
+// jobFinished reports whether the job completed or failed, and which.
+func jobFinished(job batchv1.Job) (finished, failed bool) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status == corev1.ConditionTrue && (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) {
+			return true, condition.Type == batchv1.JobFailed
+		}
+	}
+	return false, false
+}
+
+// cronJobHealth returns the CronJobInfo of a CronJob given the jobs it created, and whether it's failing.
+func cronJobHealth(cronJob batchv1.CronJob, jobs []batchv1.Job, failedRuns int, now time.Time) (CronJobInfo, bool) {
+	info := CronJobInfo{
+		Name:      cronJob.Name,
+		Namespace: cronJob.Namespace,
+		Schedule:  cronJob.Spec.Schedule,
+	}
+	if cronJob.Spec.TimeZone != nil {
+		info.TimeZone = *cronJob.Spec.TimeZone
+	}
+	if cronJob.Status.LastScheduleTime != nil {
+		info.LastScheduleTime = &cronJob.Status.LastScheduleTime.Time
+	}
+	if cronJob.Status.LastSuccessfulTime != nil {
+		info.LastSuccessfulTime = &cronJob.Status.LastSuccessfulTime.Time
+	}
+
+	sort.Slice(jobs, func(i, j int) bool { return jobs[j].CreationTimestamp.Before(&jobs[i].CreationTimestamp) })
+	for _, job := range jobs {
+		finished, failed := jobFinished(job)
+		if !finished {
+			continue
+		}
+		if !failed {
+			break
+		}
+		info.FailedRuns++
+	}
+
+	// The CronJob deletes its failed jobs beyond failedJobsHistoryLimit, 1 by default
+	kept := int32(1)
+	if cronJob.Spec.FailedJobsHistoryLimit != nil {
+		kept = *cronJob.Spec.FailedJobsHistoryLimit
+	}
+	failing := kept > 0 && info.FailedRuns >= min(failedRuns, int(kept))
+
+	if cronJob.Spec.Suspend == nil || !*cronJob.Spec.Suspend {
+		schedule, err := parseCronSchedule(cronJob.Spec.Schedule, info.TimeZone)
+		if err != nil {
+			info.Error = err.Error()
+		} else {
+			tolerance := missedScheduleTolerance
+			if cronJob.Spec.StartingDeadlineSeconds != nil {
+				tolerance = time.Duration(*cronJob.Spec.StartingDeadlineSeconds) * time.Second
+			}
+			since := cronJob.CreationTimestamp.Time
+			if info.LastScheduleTime != nil && info.LastScheduleTime.After(since) {
+				since = *info.LastScheduleTime
+			}
+			for scheduled := schedule.next(since); !scheduled.IsZero() && scheduled.Add(tolerance).Before(now); scheduled = schedule.next(scheduled) {
+				if info.MissedSince == nil {
+					missed := scheduled
+					info.MissedSince = &missed
+				}
+				if info.MissedSchedules++; info.MissedSchedules == maxMissedSchedules {
+					break
+				}
+			}
+		}
+	}
+	return info, failing || info.MissedSchedules > 0
+}
+
+// excludeNamespaces removes the jobs and CronJobs of namespaces from the report.
+func (info *ClusterJobsInfo) excludeNamespaces(namespaces []string) {
+	if len(namespaces) == 0 {
+		return
+	}
+	jobs := info.FailedJobs[:0]
+	for _, job := range info.FailedJobs {
+		if !slices.Contains(namespaces, job.Namespace) {
+			jobs = append(jobs, job)
+		}
+	}
+	info.FailedJobs = jobs
+	cronJobs := info.FailingCronJobs[:0]
+	for _, cronJob := range info.FailingCronJobs {
+		if !slices.Contains(namespaces, cronJob.Namespace) {
+			cronJobs = append(cronJobs, cronJob)
+		}
+	}
+	info.FailingCronJobs = cronJobs
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetJobsHealth(t *testing.T) {
+	now := time.Date(2024, time.January, 31, 12, 0, 0, 0, time.UTC)
+	cronJob := func(name, schedule string, lastSchedule time.Time, failedHistory int32) *batchv1.CronJob {
+		return &batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "batch", CreationTimestamp: metav1.NewTime(now.AddDate(0, -1, 0))},
+			Spec:       batchv1.CronJobSpec{Schedule: schedule, FailedJobsHistoryLimit: &failedHistory},
+			Status:     batchv1.CronJobStatus{LastScheduleTime: &metav1.Time{Time: lastSchedule}},
+		}
+	}
+	job := func(name, cronJob string, created time.Time, condition batchv1.JobConditionType) *batchv1.Job {
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "batch", CreationTimestamp: metav1.NewTime(created)}}
+		if cronJob != "" {
+			controller := true
+			job.OwnerReferences = []metav1.OwnerReference{{Kind: "CronJob", Name: cronJob, Controller: &controller}}
+		}
+		if condition != "" {
+			job.Status.Conditions = []batchv1.JobCondition{{Type: condition, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded"}}
+		}
+		return job
+	}
+	hour := func(h int) time.Time { return now.Add(time.Duration(-h) * time.Hour) }
+
+	clientset := fake.NewSimpleClientset(
+		job("migrate", "", hour(5), batchv1.JobFailed),
+		job("backfill", "", hour(5), batchv1.JobComplete),
+		// Its last three runs failed
+		cronJob("report", "0 * * * *", hour(0), 5),
+		job("report-1", "report", hour(4), batchv1.JobComplete),
+		job("report-2", "report", hour(3), batchv1.JobFailed),
+		job("report-3", "report", hour(2), batchv1.JobFailed),
+		job("report-4", "report", hour(1), batchv1.JobFailed),
+		job("report-5", "report", hour(0), ""),
+		// A success after its failures
+		cronJob("sync", "0 * * * *", hour(0), 5),
+		job("sync-1", "sync", hour(2), batchv1.JobFailed),
+		job("sync-2", "sync", hour(1), batchv1.JobFailed),
+		job("sync-3", "sync", hour(0), batchv1.JobComplete),
+		// Keeps a single failed job, which failed
+		cronJob("cleanup", "0 * * * *", hour(0), 1),
+		job("cleanup-1", "cleanup", hour(0), batchv1.JobFailed),
+		// Last scheduled three hours ago, missing the runs of the next two
+		cronJob("backup", "0 * * * *", hour(3), 1),
+	)
+
+	info, err := getJobsHealth(context.Background(), clientset, NamespaceScope{}, labels.Everything(), defaultCronJobFailedRuns, now)
+	assert.NoError(t, err)
+
+	var failed []string
+	for _, job := range info.FailedJobs {
+		failed = append(failed, job.Name)
+		if job.Name == "report-2" {
+			assert.Equal(t, "report", job.CronJob)
+			assert.Equal(t, "BackoffLimitExceeded", job.Reason)
+		}
+	}
+	assert.ElementsMatch(t, []string{"migrate", "report-2", "report-3", "report-4", "sync-1", "sync-2", "cleanup-1"}, failed)
+
+	failing := map[string]CronJobInfo{}
+	for _, cronJob := range info.FailingCronJobs {
+		failing[cronJob.Name] = cronJob
+	}
+	assert.Len(t, failing, 3)
+	assert.Equal(t, 3, failing["report"].FailedRuns)
+	assert.Equal(t, 1, failing["cleanup"].FailedRuns)
+	assert.Equal(t, 0, failing["backup"].FailedRuns)
+	assert.Equal(t, 2, failing["backup"].MissedSchedules)
+	if assert.NotNil(t, failing["backup"].MissedSince) {
+		assert.Equal(t, hour(2), *failing["backup"].MissedSince)
+	}
+
+	info, err = getJobsHealth(context.Background(), clientset, NamespaceScope{}, labels.Everything(), 4, now)
+	assert.NoError(t, err)
+	assert.Len(t, info.FailingCronJobs, 2)
+	info.excludeNamespaces([]string{"batch"})
+	assert.Empty(t, info.FailedJobs)
+	assert.Empty(t, info.FailingCronJobs)
+}
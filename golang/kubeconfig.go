@@ -0,0 +1,26 @@
+package main
+
+import (
+	"errors"
+	"os"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// loadKubeConfig returns the configuration to reach the cluster with: the kubeconfig file at path when set, those of
+// $KUBECONFIG otherwise, or in a pod the in-cluster configuration of its service account. Out of a pod without either
+// ~/.kube/config is used. context picks a context of the kubeconfig files instead of their current one.
+func loadKubeConfig(path, context string) (*rest.Config, error) {
+	if path == "" && context == "" && os.Getenv(clientcmd.RecommendedConfigPathEnvVar) == "" {
+		config, err := rest.InClusterConfig()
+		if !errors.Is(err, rest.ErrNotInCluster) {
+			return config, err
+		}
+	}
+
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = path
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: context}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides).ClientConfig()
+}
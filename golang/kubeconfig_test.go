@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+current-context: staging
+clusters:
+- name: staging
+  cluster: {server: "https://staging.example.com"}
+- name: production
+  cluster: {server: "https://production.example.com"}
+contexts:
+- name: staging
+  context: {cluster: staging, user: sre}
+- name: production
+  context: {cluster: production, user: sre}
+users:
+- name: sre
+  user: {token: secret}
+`
+
+func TestLoadKubeConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	if !assert.NoError(t, os.WriteFile(path, []byte(testKubeconfig), 0o600)) {
+		return
+	}
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	t.Setenv("HOME", t.TempDir())
+
+	config, err := loadKubeConfig(path, "")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "https://staging.example.com", config.Host)
+	}
+	config, err = loadKubeConfig(path, "production")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "https://production.example.com", config.Host)
+	}
+	_, err = loadKubeConfig(path, "development")
+	assert.Error(t, err)
+
+	// $KUBECONFIG when no path is given
+	t.Setenv("KUBECONFIG", path)
+	config, err = loadKubeConfig("", "production")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "https://production.example.com", config.Host)
+	}
+
+	// Neither a kubeconfig nor a pod
+	t.Setenv("KUBECONFIG", "")
+	_, err = loadKubeConfig("", "")
+	assert.Error(t, err)
+}
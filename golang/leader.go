@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+const defaultLeaseName = "tyk-sre-assignment"
+
+// serviceAccountNamespaceFile holds the namespace of the pod, where the Lease is by default.
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// The timings of the Lease, those of the Kubernetes controllers: a replica which stops renewing the Lease loses it
+// within 15s, and the workers of a leader failing to renew it for 10s stop.
+const (
+	defaultLeaseDuration = 15 * time.Second
+	defaultRenewDeadline = 10 * time.Second
+	defaultRetryPeriod   = 2 * time.Second
+)
+
+// leaderElector runs the background workers (reaper, intent reconciler, exporter, ticketing) on the single replica
+// holding a Lease, so several replicas serve the HTTP API without making the same changes twice.
+//
+// A replica losing the Lease stops its workers and runs for it again.
+type leaderElector struct {
+	clientset kubernetes.Interface
+	namespace string
+	name      string
+	identity  string
+
+	leaseDuration time.Duration
+	renewDeadline time.Duration
+	retryPeriod   time.Duration
+}
+
+// newLeaderElector elects with the Lease namespace/name, in the namespace of the pod when namespace is empty.
+func newLeaderElector(clientset kubernetes.Interface, namespace, name string) (*leaderElector, error) {
+	if namespace == "" {
+		data, err := os.ReadFile(serviceAccountNamespaceFile)
+		if err != nil {
+			return nil, errors.New("the namespace of the Lease must be set outside a pod")
+		}
+		namespace = strings.TrimSpace(string(data))
+	}
+
+	// The pod name, made unique so a restarted replica doesn't take over the Lease of its previous run
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	return &leaderElector{
+		clientset:     clientset,
+		namespace:     namespace,
+		name:          name,
+		identity:      hostname + "_" + uuid.New().String(),
+		leaseDuration: defaultLeaseDuration,
+		renewDeadline: defaultRenewDeadline,
+		retryPeriod:   defaultRetryPeriod,
+	}, nil
+}
+
+// run runs for the Lease until ctx is done, running workers for as long as it holds it.
+func (e *leaderElector) run(ctx context.Context, workers ...func(context.Context)) {
+	for ctx.Err() == nil {
+		var leading atomic.Bool
+		stopped := make(chan struct{})
+		leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+			Lock: &resourcelock.LeaseLock{
+				LeaseMeta:  metav1.ObjectMeta{Namespace: e.namespace, Name: e.name},
+				Client:     e.clientset.CoordinationV1(),
+				LockConfig: resourcelock.ResourceLockConfig{Identity: e.identity},
+			},
+			LeaseDuration:   e.leaseDuration,
+			RenewDeadline:   e.renewDeadline,
+			RetryPeriod:     e.retryPeriod,
+			ReleaseOnCancel: true,
+			Name:            e.name,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: func(ctx context.Context) {
+					leading.Store(true)
+					defer close(stopped)
+					slog.InfoContext(ctx, "Elected leader, starting the background workers", "lease", e.namespace+"/"+e.name, "identity", e.identity)
+					runWorkers(ctx, workers)
+				},
+				OnStoppedLeading: func() {
+					slog.Info("No longer the leader, stopping the background workers", "lease", e.namespace+"/"+e.name, "identity", e.identity)
+				},
+				OnNewLeader: func(identity string) {
+					if identity != e.identity {
+						slog.Info("Another replica leads", "lease", e.namespace+"/"+e.name, "leader", identity)
+					}
+				},
+			},
+		})
+
+		// Workers of the last term are done before running for the next one
+		if leading.Load() {
+			<-stopped
+		}
+	}
+}
+
+// runWorkers runs workers until ctx is done, and waits for them to return.
+func runWorkers(ctx context.Context, workers []func(context.Context)) {
+	var wg sync.WaitGroup
+	for _, worker := range workers {
+		wg.Add(1)
+		go func(worker func(context.Context)) {
+			defer wg.Done()
+			worker(ctx)
+		}(worker)
+	}
+	wg.Wait()
+}
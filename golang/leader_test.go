@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestLeaderElector(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	newElector := func(identity string) *leaderElector {
+		return &leaderElector{
+			clientset:     clientset,
+			namespace:     "ops",
+			name:          defaultLeaseName,
+			identity:      identity,
+			leaseDuration: time.Second,
+			renewDeadline: 500 * time.Millisecond,
+			retryPeriod:   100 * time.Millisecond,
+		}
+	}
+
+	var running atomic.Int32
+	worker := func(ctx context.Context) {
+		running.Add(1)
+		defer running.Add(-1)
+		<-ctx.Done()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go newElector("a").run(ctx, worker, worker)
+	assert.Eventually(t, func() bool { return running.Load() == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	// The Lease is held, the second replica doesn't run its workers
+	otherCtx, otherCancel := context.WithCancel(context.Background())
+	defer otherCancel()
+	go newElector("b").run(otherCtx, worker)
+	time.Sleep(300 * time.Millisecond)
+	assert.Equal(t, int32(2), running.Load())
+
+	lease, err := clientset.CoordinationV1().Leases("ops").Get(context.Background(), defaultLeaseName, metav1.GetOptions{})
+	if assert.NoError(t, err) && assert.NotNil(t, lease.Spec.HolderIdentity) {
+		assert.Equal(t, "a", *lease.Spec.HolderIdentity)
+	}
+
+	// Stopping the leader releases the Lease, which the other replica takes over
+	cancel()
+	assert.Eventually(t, func() bool {
+		lease, err := clientset.CoordinationV1().Leases("ops").Get(context.Background(), defaultLeaseName, metav1.GetOptions{})
+		return err == nil && lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity == "b" && running.Load() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestRunWorkers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var stopped atomic.Int32
+	worker := func(ctx context.Context) {
+		<-ctx.Done()
+		stopped.Add(1)
+	}
+	done := make(chan struct{})
+	go func() {
+		runWorkers(ctx, []func(context.Context){worker, worker})
+		close(done)
+	}()
+	cancel()
+	<-done
+	assert.Equal(t, int32(2), stopped.Load())
+}
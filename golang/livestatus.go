@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"golang.org/x/net/websocket"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Topics the clients of GET /ws subscribe to
+const (
+	topicDeployments = "deployments"
+	topicNodes       = "nodes"
+	topicEvents      = "events"
+	topicPolicies    = "policies"
+)
+
+var liveTopics = []string{topicDeployments, topicNodes, topicEvents, topicPolicies}
+
+// livePollInterval is how often the topics without an informer are read again, their updates pushed when they changed.
+const livePollInterval = 15 * time.Second
+
+// Types of the messages pushed to the clients of GET /ws
+const (
+	liveSubscribed = "subscribed"
+	liveUpdate     = "update"
+	liveError      = "error"
+)
+
+// liveRequest is a message of a client of GET /ws, subscribing to or unsubscribing from topics.
+type liveRequest struct {
+	Action string   `json:"action"`
+	Topics []string `json:"topics"`
+}
+
+// LiveMessage is a message pushed to a client of GET /ws: the topics it's subscribed to after a request, an update of
+// a topic, or an error.
+type LiveMessage struct {
+	Type   string      `json:"type"`
+	Topic  string      `json:"topic,omitempty"`
+	Topics []string    `json:"topics,omitempty"`
+	Data   interface{} `json:"data,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// Pushes the live status of the cluster over a WebSocket, the topics being subscribed to and unsubscribed from with
+// {"action": "subscribe", "topics": ["deployments", "nodes"]}
+//
+// The deployments topic pushes the transitions the deployment cache sees, like streamDeploymentsHandler. The nodes
+// and policies topics push the node health and the managed deny policies when they change, and the events topic the
+// new warning events, all read every livePollInterval.
+func (s *Server) liveStatusHandler(w http.ResponseWriter, r *http.Request) {
+	websocket.Server{Handler: func(conn *websocket.Conn) {
+		session := &liveSession{server: s, clientset: s.K8sClientSet, conn: conn}
+		session.run(conn.Request().Context())
+	}}.ServeHTTP(w, r)
+}
+
+// liveSession is the connection of a client of GET /ws and the state of its topics.
+type liveSession struct {
+	server    *Server
+	clientset kubernetes.Interface
+	conn      *websocket.Conn
+
+	topics map[string]bool
+	// last is the JSON last pushed by polled topic, eventsSince when the events were last read
+	last        map[string]string
+	eventsSince time.Time
+}
+
+// run serves the client until it disconnects or the server shuts down.
+func (l *liveSession) run(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	l.topics, l.last, l.eventsSince = map[string]bool{}, map[string]string{}, time.Now().Add(-healthEventsWindow)
+
+	requests := make(chan liveRequest)
+	go func() {
+		defer cancel()
+		for {
+			var message string
+			if err := websocket.Message.Receive(l.conn, &message); err != nil {
+				return
+			}
+			var request liveRequest
+			if err := json.Unmarshal([]byte(message), &request); err != nil {
+				request = liveRequest{Action: fmt.Sprintf("invalid request: %v", err)}
+			}
+			select {
+			case requests <- request:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// updates of the deployments, nil until the topic is subscribed to
+	var updates <-chan deploymentUpdate
+	unsubscribe := func() {}
+	defer func() { unsubscribe() }()
+	namespaces, err := l.server.Namespaces.resolve(ctx, l.clientset)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed resolving the namespaces of the live status", "error", err)
+		return
+	}
+
+	poll := time.NewTicker(livePollInterval)
+	defer poll.Stop()
+	for {
+		var messages []LiveMessage
+		select {
+		case <-ctx.Done():
+			return
+		case request := <-requests:
+			var subscribed []string
+			messages, subscribed = l.apply(request)
+			switch {
+			case l.topics[topicDeployments] && updates == nil && l.server.Deployments != nil:
+				updates, unsubscribe = l.server.Deployments.subscribe()
+			case !l.topics[topicDeployments] && updates != nil:
+				unsubscribe()
+				updates, unsubscribe = nil, func() {}
+			}
+			// Newly subscribed topics get their current state right away
+			for _, topic := range subscribed {
+				messages = append(messages, l.poll(ctx, topic)...)
+			}
+		case <-poll.C:
+			for _, topic := range liveTopics {
+				if l.topics[topic] {
+					messages = append(messages, l.poll(ctx, topic)...)
+				}
+			}
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+			if transition, ok := l.server.deploymentTransition(ctx, update, namespaces, l.server.ExcludedNamespaces, labels.Everything()); ok {
+				messages = append(messages, LiveMessage{Type: liveUpdate, Topic: topicDeployments, Data: transition})
+			}
+		}
+
+		for _, message := range messages {
+			if err := websocket.JSON.Send(l.conn, message); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// apply updates the topics after a request, returning the messages answering it and the topics newly subscribed to.
+func (l *liveSession) apply(request liveRequest) (messages []LiveMessage, subscribed []string) {
+	if request.Action != "subscribe" && request.Action != "unsubscribe" {
+		return []LiveMessage{{Type: liveError, Error: fmt.Sprintf("unknown action %q, expected subscribe or unsubscribe", request.Action)}}, nil
+	}
+	for _, topic := range request.Topics {
+		switch {
+		case !slices.Contains(liveTopics, topic):
+			messages = append(messages, LiveMessage{Type: liveError, Topic: topic, Error: fmt.Sprintf("unknown topic, expected one of %v", liveTopics)})
+		case topic == topicDeployments && l.server.Deployments == nil:
+			messages = append(messages, LiveMessage{Type: liveError, Topic: topic, Error: "the deployments topic needs the deployment cache, enable --deployment-cache"})
+		case request.Action == "unsubscribe":
+			delete(l.topics, topic)
+			delete(l.last, topic)
+		case !l.topics[topic]:
+			l.topics[topic] = true
+			subscribed = append(subscribed, topic)
+		}
+	}
+
+	current := []string{}
+	for _, topic := range liveTopics {
+		if l.topics[topic] {
+			current = append(current, topic)
+		}
+	}
+	return append(messages, LiveMessage{Type: liveSubscribed, Topics: current}), subscribed
+}
+
+// poll reads a topic without an informer, returning its update when it changed since it was last pushed.
+func (l *liveSession) poll(ctx context.Context, topic string) []LiveMessage {
+	var data interface{}
+	var err error
+	switch topic {
+	case topicNodes:
+		data, err = getNodesHealth(ctx, l.clientset, labels.Everything())
+	case topicPolicies:
+		var policies []PolicyObject
+		if policies, err = l.server.listBackendPolicies(ctx, l.server.Namespaces); err == nil {
+			data = summariseDenyPolicies(l.server.Policies, policies, time.Now())
+		}
+	case topicEvents:
+		now := time.Now()
+		var report *EventsReport
+		if report, err = getWarningEvents(ctx, l.clientset, l.server.Namespaces, nil, l.eventsSince); err == nil {
+			report.Events = slices.DeleteFunc(report.Events, func(event NamespacedWarningEvent) bool {
+				return slices.Contains(l.server.ExcludedNamespaces, event.Namespace) || !event.LastSeen.After(l.eventsSince)
+			})
+			l.eventsSince = now
+			if len(report.Events) == 0 {
+				return nil
+			}
+			return []LiveMessage{{Type: liveUpdate, Topic: topic, Data: report.Events}}
+		}
+	default:
+		return nil
+	}
+	if err != nil {
+		return []LiveMessage{{Type: liveError, Topic: topic, Error: err.Error()}}
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil || string(encoded) == l.last[topic] {
+		return nil
+	}
+	l.last[topic] = string(encoded)
+	return []LiveMessage{{Type: liveUpdate, Topic: topic, Data: json.RawMessage(encoded)}}
+}
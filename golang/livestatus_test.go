@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/websocket"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestLiveStatus(t *testing.T) {
+	replicas := int32(2)
+	deployment := func(ready int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "cart", Namespace: "shop", Generation: int64(ready) + 1},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: ready, AvailableReplicas: ready},
+		}
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}},
+	}
+	clientset := fake.NewSimpleClientset(deployment(2), node)
+	deployments := newDeploymentCache(clientset, NamespaceScope{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go deployments.run(ctx)
+	assert.Eventually(t, deployments.synced, 5*time.Second, 10*time.Millisecond)
+
+	server := &Server{Deployments: deployments}
+	ts := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		session := &liveSession{server: server, clientset: clientset, conn: conn}
+		session.run(ctx)
+	}))
+	defer ts.Close()
+	conn, err := websocket.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), "", ts.URL)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer conn.Close()
+	assert.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+	receive := func() LiveMessage {
+		var message LiveMessage
+		assert.NoError(t, websocket.JSON.Receive(conn, &message))
+		return message
+	}
+
+	assert.NoError(t, websocket.JSON.Send(conn, liveRequest{Action: "subscribe", Topics: []string{"deployments", "nodes", "pods"}}))
+	message := receive()
+	assert.Equal(t, liveError, message.Type)
+	assert.Equal(t, "pods", message.Topic)
+	message = receive()
+	assert.Equal(t, liveSubscribed, message.Type)
+	assert.Equal(t, []string{topicDeployments, topicNodes}, message.Topics)
+
+	// The nodes are pushed on subscription
+	message = receive()
+	assert.Equal(t, liveUpdate, message.Type)
+	assert.Equal(t, topicNodes, message.Topic)
+	var nodes ClusterNodesInfo
+	data, _ := json.Marshal(message.Data)
+	assert.NoError(t, json.Unmarshal(data, &nodes))
+	if assert.Len(t, nodes.ReadyNodes, 1) {
+		assert.Equal(t, "worker", nodes.ReadyNodes[0].Name)
+	}
+
+	_, err = clientset.AppsV1().Deployments("shop").Update(ctx, deployment(0), metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	message = receive()
+	assert.Equal(t, liveUpdate, message.Type)
+	assert.Equal(t, topicDeployments, message.Topic)
+	var transition DeploymentTransition
+	data, _ = json.Marshal(message.Data)
+	assert.NoError(t, json.Unmarshal(data, &transition))
+	assert.Equal(t, "cart", transition.Name)
+	assert.Equal(t, deploymentReady, transition.From)
+	assert.Equal(t, deploymentFailed, transition.To)
+
+	assert.NoError(t, websocket.Message.Send(conn, `{"action": "unsubscribe", "topics": ["deployments", "nodes"]}`))
+	message = receive()
+	assert.Equal(t, liveSubscribed, message.Type)
+	assert.Empty(t, message.Topics)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// newLogger returns a logger writing text or JSON records at level and above to w.
+//
+// Records logged with a request context carry its correlation ID and traceparent.
+func newLogger(w io.Writer, format, level string) (*slog.Logger, error) {
+	var minLevel slog.Level
+	if err := minLevel.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q, expected debug, info, warn or error", level)
+	}
+
+	options := &slog.HandlerOptions{Level: minLevel}
+	var handler slog.Handler
+	switch strings.ToLower(format) {
+	case "text":
+		handler = slog.NewTextHandler(w, options)
+	case "json":
+		handler = slog.NewJSONHandler(w, options)
+	default:
+		return nil, fmt.Errorf("invalid log format %q, expected text or json", format)
+	}
+	return slog.New(requestMetadataHandler{handler}), nil
+}
+
+// requestMetadataHandler adds the metadata of the request found in the context to every record.
+type requestMetadataHandler struct {
+	slog.Handler
+}
+
+func (h requestMetadataHandler) Handle(ctx context.Context, record slog.Record) error {
+	meta := requestMetadata(ctx)
+	if meta.CorrelationID != "" {
+		record.AddAttrs(slog.String("correlation_id", meta.CorrelationID))
+	}
+	if meta.TraceParent != "" {
+		record.AddAttrs(slog.String("traceparent", meta.TraceParent))
+	}
+	if meta.Cluster != "" {
+		record.AddAttrs(slog.String("cluster", meta.Cluster))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h requestMetadataHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return requestMetadataHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h requestMetadataHandler) WithGroup(name string) slog.Handler {
+	return requestMetadataHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLogger(t *testing.T) {
+	var out bytes.Buffer
+	logger, err := newLogger(&out, "json", "info")
+	assert.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), requestMetadataKey{}, RequestMetadata{CorrelationID: "run-42"})
+	logger.DebugContext(ctx, "Not logged")
+	logger.With("component", "ticketing").InfoContext(ctx, "Opened ticket", "ticket", "OPS-1")
+
+	var record map[string]interface{}
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &record))
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, "Opened ticket", record["msg"])
+	assert.Equal(t, "OPS-1", record["ticket"])
+	assert.Equal(t, "ticketing", record["component"])
+	assert.Equal(t, "run-42", record["correlation_id"])
+	assert.NotContains(t, record, "traceparent")
+
+	_, err = newLogger(&out, "xml", "info")
+	assert.Error(t, err)
+	_, err = newLogger(&out, "text", "verbose")
+	assert.Error(t, err)
+}
@@ -1,83 +1,736 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
 	"strings"
+	"syscall"
+	"time"
 
-	"github.com/google/uuid"
-	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
 	"github.com/projectcalico/api/pkg/client/clientset_generated/clientset"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+)
+
+// defaultShutdownTimeout leaves a margin under the 30s termination grace period Kubernetes gives pods by default.
+const defaultShutdownTimeout = 25 * time.Second
+
+// Limits of the connections and requests of the listeners, so slow or oversized requests can't tie the server up.
+// Handlers waiting on the cluster for longer than the write timeout extend their own deadline.
+const (
+	defaultReadHeaderTimeout = 10 * time.Second
+	defaultReadTimeout       = 30 * time.Second
+	defaultWriteTimeout      = 60 * time.Second
+	defaultIdleTimeout       = 120 * time.Second
+	defaultMaxBodyBytes      = 1 << 20
+	// defaultKubeRequestTimeout stays under the write timeout, so a request slowed down by the API server gets its 504
+	defaultKubeRequestTimeout = 45 * time.Second
+	// writeDeadlineMargin leaves time to write the response of a handler once its own timeout is over
+	writeDeadlineMargin = 10 * time.Second
 )
 
 type Server struct {
-	K8sClientSet    *kubernetes.Clientset
-	CalicoClientSet *clientset.Clientset
+	Cluster         string
+	K8sConfig       *rest.Config
+	K8sClientSet    kubernetes.Interface
+	CalicoClientSet clientset.Interface
+	Policies        PolicyBackend
+	Namespaces      NamespaceScope
+	Prometheus      *PrometheusClient
+	Dependencies    *DependenciesConfig
+	DebugImage      string
+	SelftestImage   string
+	Verifier        *RequestVerifier
+	Features        *FeatureFlags
+	Snapshots       *SnapshotStore
+	// ResourceMetrics reads the usage of pods and nodes from metrics-server, when the cluster runs it
+	ResourceMetrics *MetricsClient
+	// Deployments caches the deployments in scope, nil to list them from the API server on every request
+	Deployments *DeploymentCache
+	// Scanner keeps the latest background health scan, nil with --scan-interval=0
+	Scanner *HealthScanner
+	// Alerts evaluates the alerting rules of the home cluster and keeps their silences, nil without an alerting config
+	Alerts *AlertEngine
+	// Health decides when a deployment is ready, nil once every requested pod is
+	Health *HealthCriteria
+	// DeploymentGrace keeps the failing deployments updated within it out of the failed ones, 0 to report them failed
+	DeploymentGrace time.Duration
+	// StatefulSetRolloutDeadline is how long a StatefulSet may roll out before it's reported as stuck
+	StatefulSetRolloutDeadline time.Duration
+	// CapacityThreshold is the percentage of their allocatable CPU or memory requested beyond which nodes are flagged
+	CapacityThreshold float64
+	// ExcludedNamespaces are left out of the deployment health reports, unless a report asks for one of them
+	ExcludedNamespaces []string
+	Authenticator      *TokenAuthenticator
+	Authorizer         *AuthorizerChain
+	Aliases            WorkloadAliases
+	Guardrails         *RateGuard
+	PolicyConflicts    string
+	// KubeRequestTimeout bounds the Kubernetes calls made for a request, 0 leaving them to the request context
+	KubeRequestTimeout time.Duration
+}
+
+// kubeContext returns the context of the Kubernetes calls made for r, cancelled when the client goes away or once
+// KubeRequestTimeout is over.
+func (s *Server) kubeContext(r *http.Request) (context.Context, context.CancelFunc) {
+	if s.KubeRequestTimeout <= 0 {
+		return context.WithCancel(r.Context())
+	}
+	return context.WithTimeout(r.Context(), s.KubeRequestTimeout)
+}
+
+// kubeErrorStatus is the status of a failed Kubernetes call, a 504 when it ran out of time.
+func kubeErrorStatus(err error) int {
+	if errors.Is(err, context.DeadlineExceeded) || apierrors.IsTimeout(err) {
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
 }
 
 type DeploymentInfo struct {
 	Name          string `json:"deployment_name"`
+	Namespace     string `json:"namespace"`
 	RequestedPods int32  `json:"requested_pods"`
 	ReadyPods     int32  `json:"ready_pods"`
+
+	AvailableReplicas   int32                 `json:"available_replicas"`
+	UpdatedReplicas     int32                 `json:"updated_replicas"`
+	UnavailableReplicas int32                 `json:"unavailable_replicas"`
+	Conditions          []DeploymentCondition `json:"conditions,omitempty"`
+	CreatedAt           time.Time             `json:"created_at"`
+	// UpdatedAt is when the deployment was created or its current rollout last progressed
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Checks []PromQLCheckResult `json:"checks,omitempty"`
+	// Failure tells why a failed deployment is failing, when it was diagnosed
+	Failure *FailureDiagnosis `json:"failure,omitempty"`
+}
+
+// DeploymentCondition is a condition of the deployment status, such as Available or Progressing, telling why it's failing.
+// Node conditions are reported the same way.
+type DeploymentCondition struct {
+	Type               string    `json:"type"`
+	Status             string    `json:"status"`
+	Reason             string    `json:"reason,omitempty"`
+	Message            string    `json:"message,omitempty"`
+	LastTransitionTime time.Time `json:"last_transition_time"`
 }
 
 type ClusterDeploymentsInfo struct {
 	ReadyDeployments  []DeploymentInfo `json:"ready_deployments"`
 	FailedDeployments []DeploymentInfo `json:"failed_deployments"`
+	// StuckRollouts are the unhealthy deployments whose rollout exceeded its progress deadline, which won't converge
+	// without a change, unlike the failed ones which may still be rolling out
+	StuckRollouts []DeploymentInfo `json:"stuck_rollouts"`
+	// Progressing are the unhealthy deployments created or updated within the grace period, out of FailedDeployments
+	Progressing []DeploymentInfo `json:"progressing"`
+	// Continue is the token of the next page of a paginated listing, empty on the last one
+	Continue string `json:"continue,omitempty"`
+	// Cached is set when the deployments were read from the deployment cache, at ResourceVersion when it's a single informer
+	Cached          bool   `json:"cached,omitempty"`
+	ResourceVersion string `json:"resource_version,omitempty"`
 }
 
 type DenyNetworkRequestWorkload struct {
-	Namespace string            `json:"namespace"`
-	Labels    map[string]string `json:"labels"`
+	Alias            string                            `json:"alias,omitempty"`
+	Kind             string                            `json:"kind,omitempty"`
+	Name             string                            `json:"name,omitempty"`
+	Namespace        string                            `json:"namespace"`
+	Labels           map[string]string                 `json:"labels"`
+	MatchExpressions []metav1.LabelSelectorRequirement `json:"match_expressions,omitempty"`
+	CIDR             string                            `json:"cidr,omitempty"`
+}
+
+// describe renders the workload for logs and events.
+func (w DenyNetworkRequestWorkload) describe() string {
+	switch {
+	case w.CIDR != "":
+		return w.CIDR
+	case w.Kind == workloadKindHostEndpoint:
+		return "host endpoints " + renderSelector(w)
+	case w.Namespace == "":
+		return renderSelector(w) + " in every namespace"
+	}
+	return w.Namespace + "/" + renderSelector(w)
+}
+
+// selector returns the Kubernetes selector of the pods of the workload, matching nothing if the workload wasn't validated.
+func (w DenyNetworkRequestWorkload) selector() labels.Selector {
+	selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchLabels: w.Labels, MatchExpressions: w.MatchExpressions})
+	if err != nil {
+		return labels.Nothing()
+	}
+	return selector
+}
+
+// selects tells whether the workload selects its pods by anything, without it every pod matches.
+func (w DenyNetworkRequestWorkload) selects() bool {
+	return len(w.Labels) > 0 || len(w.MatchExpressions) > 0
+}
+
+// validateLabels checks labels are valid Kubernetes labels, which keeps quotes and operators out of the selectors they're rendered into.
+func validateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if err := validateLabel(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateWorkloadSelector checks the labels and expressions of a workload, expressions only using In, NotIn, Exists and DoesNotExist.
+func validateWorkloadSelector(workload DenyNetworkRequestWorkload) error {
+	if err := validateLabels(workload.Labels); err != nil {
+		return err
+	}
+	for _, expression := range workload.MatchExpressions {
+		if err := validateExpression(expression); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateExpression(expression metav1.LabelSelectorRequirement) error {
+	switch expression.Operator {
+	case metav1.LabelSelectorOpIn, metav1.LabelSelectorOpNotIn:
+		if len(expression.Values) == 0 {
+			return fmt.Errorf("expression on %q: operator %s needs values", expression.Key, expression.Operator)
+		}
+	case metav1.LabelSelectorOpExists, metav1.LabelSelectorOpDoesNotExist:
+		if len(expression.Values) > 0 {
+			return fmt.Errorf("expression on %q: operator %s takes no values", expression.Key, expression.Operator)
+		}
+	default:
+		return fmt.Errorf("expression on %q: invalid operator %q, expected In, NotIn, Exists or DoesNotExist", expression.Key, expression.Operator)
+	}
+	if err := validateLabel(expression.Key, ""); err != nil {
+		return err
+	}
+	for _, value := range expression.Values {
+		if err := validateLabel(expression.Key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateLabel(key, value string) error {
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, ", "))
+	}
+	if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+		return fmt.Errorf("invalid label value %q: %s", value, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// normalizePeer checks a workload given as a network range sets nothing else, and puts its CIDR in canonical form.
+func normalizePeer(workload *DenyNetworkRequestWorkload) error {
+	if workload.CIDR == "" {
+		return nil
+	}
+	if workload.Namespace != "" || workload.selects() {
+		return fmt.Errorf("workload %s sets both a CIDR and a namespace or labels", workload.CIDR)
+	}
+	_, network, err := net.ParseCIDR(strings.TrimSpace(workload.CIDR))
+	if err != nil {
+		return fmt.Errorf("invalid CIDR %q", workload.CIDR)
+	}
+	workload.CIDR = network.String()
+	return nil
 }
 
 type DenyNetworkRequest struct {
-	A DenyNetworkRequestWorkload `json:"workload_a"`
-	B DenyNetworkRequestWorkload `json:"workload_b"`
+	A         DenyNetworkRequestWorkload `json:"workload_a"`
+	B         DenyNetworkRequestWorkload `json:"workload_b"`
+	Scope     string                     `json:"scope,omitempty"`
+	Tier      string                     `json:"tier,omitempty"`
+	Staged    bool                       `json:"staged,omitempty"`
+	Symmetric *bool                      `json:"symmetric,omitempty"`
+	TTL       *metav1.Duration           `json:"ttl,omitempty"`
+	DryRun    bool                       `json:"dry_run,omitempty"`
+}
+
+// Values of the scope of a deny request, namespaced by default.
+const (
+	policyScopeNamespace = "namespace"
+	policyScopeGlobal    = "global"
+)
+
+// validate checks only B is given as a network range, the policy living in the namespace of A.
+//
+// A global policy selects A in every namespace, and B too when it has no namespace. The default Calico tier is the same as no tier.
+// A selecting Calico HostEndpoints needs a global policy.
+func (r *DenyNetworkRequest) validate() error {
+	if r.A.CIDR != "" {
+		return invalidField("workload_a.cidr", errors.New("workload_a can't be a CIDR, only workload_b can"))
+	}
+	if r.TTL != nil && r.TTL.Duration < 0 {
+		return invalidField("ttl", fmt.Errorf("invalid ttl %s, it can't be negative", r.TTL.Duration))
+	}
+	switch r.Scope {
+	case "", policyScopeNamespace:
+	case policyScopeGlobal:
+		if r.A.Namespace != "" {
+			return invalidField("workload_a.namespace", errors.New("workload_a of a global policy is selected in every namespace, it can't set a namespace"))
+		}
+		if !r.A.selects() {
+			return invalidField("workload_a.labels", errors.New("workload_a of a global policy needs labels, it would select every pod of the cluster"))
+		}
+	default:
+		return invalidField("scope", fmt.Errorf("invalid scope %q, expected namespace or global", r.Scope))
+	}
+	if r.A.Kind == workloadKindHostEndpoint && !r.global() {
+		return invalidField("scope", errors.New("workload_a selecting host endpoints needs the global scope, host endpoints aren't namespaced"))
+	}
+	// An empty selector matches every pod of the namespace, too wide for a deny to be what was meant
+	if !r.global() && r.A.Namespace == "" {
+		return invalidField("workload_a.namespace", errors.New("workload_a needs a namespace, the policy lives there"))
+	}
+	if !r.A.selects() {
+		return invalidField("workload_a.labels", errors.New("workload_a needs labels or match_expressions, it would select every pod of its namespace"))
+	}
+	if r.B.CIDR == "" {
+		if !r.global() && r.B.Namespace == "" {
+			return invalidField("workload_b.namespace", errors.New("workload_b needs a namespace or a CIDR"))
+		}
+		if !r.B.selects() {
+			return invalidField("workload_b.labels", errors.New("workload_b needs labels or match_expressions, it would select every pod of its namespace"))
+		}
+	}
+	if err := validateWorkloadSelector(r.A); err != nil {
+		return invalidField("workload_a", fmt.Errorf("workload_a: %w", err))
+	}
+	if err := validateWorkloadSelector(r.B); err != nil {
+		return invalidField("workload_b", fmt.Errorf("workload_b: %w", err))
+	}
+	if r.Tier == calicoDefaultTier {
+		r.Tier = ""
+	}
+	if r.Tier != "" {
+		if errs := validation.IsDNS1123Label(r.Tier); len(errs) > 0 {
+			return invalidField("tier", fmt.Errorf("invalid tier %q: %s", r.Tier, strings.Join(errs, ", ")))
+		}
+	}
+	if err := normalizePeer(&r.B); err != nil {
+		return invalidField("workload_b.cidr", err)
+	}
+	return nil
+}
+
+func (r DenyNetworkRequest) global() bool {
+	return r.Scope == policyScopeGlobal
+}
+
+// mirror returns the request denying the same traffic with a policy in the namespace of B, when the request is symmetric.
+//
+// Global requests and CIDRs have no namespace of B to mirror the policy into, and NetworkSets have no pods to select.
+func (r DenyNetworkRequest) mirror() (DenyNetworkRequest, bool) {
+	if r.Symmetric != nil && !*r.Symmetric || r.global() || r.B.CIDR != "" || r.B.Namespace == "" || r.B.Kind == workloadKindNetworkSet {
+		return DenyNetworkRequest{}, false
+	}
+	symmetric := false
+	return DenyNetworkRequest{A: r.B, B: r.A, Scope: r.Scope, Tier: r.Tier, Staged: r.Staged, Symmetric: &symmetric, TTL: r.TTL}, true
+}
+
+// operation is what the caller needs to be allowed to create the policy.
+func (r DenyNetworkRequest) operation() Operation {
+	if r.global() {
+		return operationDenyGlobal
+	}
+	return operationDenyPolicy
 }
 
 func main() {
-	kubeconfig := flag.String("kubeconfig", "", "path to kubeconfig, leave empty for in-cluster")
+	kubeconfig := flag.String("kubeconfig", "", "path to kubeconfig, leave empty for $KUBECONFIG or, in a pod, the in-cluster configuration")
+	kubeContext := flag.String("context", "", "kubeconfig context to use instead of the current one")
+	clusterName := flag.String("cluster-name", defaultClusterName, "name of the cluster of --kubeconfig among the managed clusters")
+	clusterContexts := flag.String("cluster-contexts", "", "comma-separated list of further kubeconfig contexts to manage, each selected with ?cluster=<context>")
 	listenAddr := flag.String("address", ":8080", "HTTP server listen address")
+	adminAddr := flag.String("admin-address", "", "listen address of the admin endpoints (pprof, config dump, feature flags), leave empty to disable them")
+	adminTokenFile := flag.String("admin-token-file", "", "path to the bearer token required on the admin listener, mandatory with --admin-address")
+	debugImage := flag.String("debug-image", defaultDebugImage, "default image of the ephemeral debug containers")
+	selftestImage := flag.String("selftest-image", defaultDebugImage, "image of the self-test pods, it must provide sh, httpd and wget")
+	signingSecretFile := flag.String("request-signing-secret-file", "", "path to a shared secret required to sign mutating requests, leave empty to accept unsigned requests")
+	replayWindow := flag.Duration("replay-window", defaultReplayWindow, "maximum age of a signed request before it's rejected")
+	configPath := flag.String("config", "", "path to an optional YAML or JSON configuration file")
+	namespaces := flag.String("namespaces", "", "comma-separated list of namespaces or a namespace label selector to restrict the service to, leave empty for all namespaces")
+	tlsCert := flag.String("tls-cert", "", "path to a PEM certificate to serve HTTPS with, on both listeners")
+	tlsKey := flag.String("tls-key", "", "path to the PEM private key of --tls-cert")
+	logFormat := flag.String("log-format", "text", "log format, text or json")
+	logLevel := flag.String("log-level", "info", "minimum log level, debug, info, warn or error")
+	policyBackend := flag.String("policy-backend", defaultPolicyBackend, "backend enforcing the deny policies, calico or cilium")
+	createCalicoTiers := flag.Bool("create-calico-tiers", false, "create the Calico tier a deny request asks for when it doesn't exist")
+	istioPolicies := flag.String("istio-policies", istioPoliciesAuto, "also deny traffic with Istio AuthorizationPolicies: auto when the Istio API is served, always or never")
+	policyConflicts := flag.String("policy-conflicts", policyConflictsWarn, "what to do when a deny conflicts with a policy allowing the traffic in its tier: warn, reject with a 409, or off")
+	reaperInterval := flag.Duration("policy-reaper-interval", defaultReaperInterval, "how often the policies whose ttl is over are deleted, 0 to keep them")
+	isolationIntents := flag.Bool("isolation-intents", false, "record every deny policy as an IsolationIntent and recreate the policies deleted or changed out of band, the CRD must be installed")
+	intentInterval := flag.Duration("intent-reconcile-interval", defaultIntentReconcileInterval, "how often the policies of the isolation intents are checked")
+	mode := flag.String("mode", modeServer, "server, or operator to also run a controller reconciling the IsolationIntents and publishing their status, which implies --isolation-intents")
+	leaderElect := flag.Bool("leader-elect", false, "run the background workers (reaper, intent reconciler or operator, exporter, ticketing, webhooks, Slack, alerting) only on the replica holding a Lease, for multi-replica deployments")
+	leaseNamespace := flag.String("leader-election-namespace", "", "namespace of the leader election Lease, leave empty for the namespace of the pod")
+	leaseName := flag.String("leader-election-name", defaultLeaseName, "name of the leader election Lease")
+	excludeNamespaces := flag.String("exclude-namespaces", "", "comma-separated list of namespaces, such as kube-system, left out of the deployment health reports")
+	deploymentGrace := flag.Duration("deployment-grace", 0, "report the unhealthy deployments created or updated within this window as progressing rather than failed, e.g. 5m")
+	statefulSetDeadline := flag.Duration("statefulset-rollout-deadline", defaultStatefulSetRolloutDeadline, "report the unhealthy StatefulSets rolling out for longer than this as stuck_rollouts")
+	capacityThreshold := flag.Float64("capacity-threshold", defaultCapacityThreshold, "percentage of their allocatable CPU or memory requested beyond which GET /capacity flags nodes")
+	cacheDeployments := flag.Bool("deployment-cache", true, "serve the deployment health from an informer cache of the deployments in scope instead of listing them on every request")
+	scanInterval := flag.Duration("scan-interval", 0, "run the deployment, node and problem pod checks in the background this often, serving their latest results from GET /healthscan, 0 to disable")
+	historyRetention := flag.Duration("history-retention", defaultHistoryRetention, "how long the health scanner keeps the history of the deployments, served by GET /history/deployments/{namespace}/{name}")
+	shutdownTimeout := flag.Duration("shutdown-timeout", defaultShutdownTimeout, "how long in-flight requests may run after SIGINT or SIGTERM before the server exits")
+	kubeRequestTimeout := flag.Duration("kube-request-timeout", defaultKubeRequestTimeout, "how long the Kubernetes calls made for an API request may take before it fails with a 504, 0 for no limit")
+	readHeaderTimeout := flag.Duration("read-header-timeout", defaultReadHeaderTimeout, "how long a client may take to send the headers of a request, 0 for no limit")
+	readTimeout := flag.Duration("read-timeout", defaultReadTimeout, "how long a client may take to send a whole request, 0 for no limit")
+	writeTimeout := flag.Duration("write-timeout", defaultWriteTimeout, "how long a response may take from the end of the request headers, 0 for no limit; streams, drains, rollout waits and self-tests outlive it")
+	idleTimeout := flag.Duration("idle-timeout", defaultIdleTimeout, "how long an idle keep-alive connection is kept open, 0 to use --read-timeout")
+	maxBodyBytes := flag.Int64("max-request-body-bytes", defaultMaxBodyBytes, "maximum size of a request body, larger ones get a 413, 0 for no limit")
+	auditLogFile := flag.String("audit-log-file", "", "path to a file the audit events are appended to as JSON lines and GET /audit reads, leave empty to keep only the latest ones in memory")
 
 	flag.Parse()
 
-	kConfig, err := clientcmd.BuildConfigFromFlags("", *kubeconfig)
+	logger, err := newLogger(os.Stderr, *logFormat, *logLevel)
 	if err != nil {
 		panic(err)
 	}
+	slog.SetDefault(logger)
 
-	clientsetVanilla, err := kubernetes.NewForConfig(kConfig)
+	config, err := loadConfig(*configPath)
 	if err != nil {
 		panic(err)
 	}
 
-	clientsetCalico, err := clientset.NewForConfig(kConfig)
+	namespaceScope, err := parseNamespaceScope(*namespaces)
 	if err != nil {
 		panic(err)
 	}
 
+	excludedNamespaces, err := parseExcludedNamespaces(*excludeNamespaces)
+	if err != nil {
+		panic(err)
+	}
+
+	healthCriteria, err := newHealthCriteria(config.Health)
+	if err != nil {
+		panic(err)
+	}
+
+	aliases, err := newWorkloadAliases(config.Aliases)
+	if err != nil {
+		panic(err)
+	}
+
+	guardrails, err := newRateGuard(config.Guardrails)
+	if err != nil {
+		panic(err)
+	}
+
+	verifier, err := loadRequestVerifier(*signingSecretFile, *replayWindow)
+	if err != nil {
+		panic(err)
+	}
+
+	features, err := newFeatureFlags(config.Features)
+	if err != nil {
+		panic(err)
+	}
+
+	adminToken, err := loadAdminToken(*adminAddr, *adminTokenFile)
+	if err != nil {
+		panic(err)
+	}
+
+	if *auditLogFile != "" {
+		if err := auditLog.openFile(*auditLogFile); err != nil {
+			panic(err)
+		}
+	}
+
+	var prometheusClient *PrometheusClient
+	if config.Prometheus != nil {
+		prometheusClient, err = newPrometheusClient(config.Prometheus)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	kConfig, err := loadKubeConfig(*kubeconfig, *kubeContext)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := validatePolicyConflictsMode(*policyConflicts); err != nil {
+		panic(err)
+	}
+
+	if err := validateMode(*mode); err != nil {
+		panic(err)
+	}
+
+	options := clusterOptions{
+		policyBackend:    *policyBackend,
+		backendOptions:   policyBackendOptions{createTiers: *createCalicoTiers},
+		istioPolicies:    *istioPolicies,
+		isolationIntents: *isolationIntents || *mode == modeOperator,
+	}
+	home, err := connectCluster(kConfig, options)
+	if err != nil {
+		panic(err)
+	}
+	clientsetVanilla, clientsetCalico, policies := home.clientset, home.calico, home.policies
+
+	if *cacheDeployments {
+		home.deployments = newDeploymentCache(home.clientset, namespaceScope)
+	}
+
+	clusterConfigs := append(parseClusterContexts(*clusterContexts), config.Clusters...)
+	if err := validateClusters(*clusterName, clusterConfigs); err != nil {
+		panic(err)
+	}
+	connections := map[string]*clusterConnection{}
+	for _, cluster := range clusterConfigs {
+		clusterConfig, err := loadKubeConfig(cluster.Kubeconfig, cluster.Context)
+		if err != nil {
+			panic(fmt.Errorf("cluster %s: %w", cluster.Name, err))
+		}
+		if connections[cluster.Name], err = connectCluster(clusterConfig, options); err != nil {
+			panic(fmt.Errorf("cluster %s: %w", cluster.Name, err))
+		}
+		version, err := getKubernetesVersion(connections[cluster.Name].clientset)
+		if err != nil {
+			panic(fmt.Errorf("cluster %s: %w", cluster.Name, err))
+		}
+		slog.Info("Connected to Kubernetes", "cluster", cluster.Name, "version", version)
+		if *cacheDeployments {
+			connections[cluster.Name].deployments = newDeploymentCache(connections[cluster.Name].clientset, namespaceScope)
+		}
+	}
+
+	authenticator, err := newTokenAuthenticator(config.Authentication, clientsetVanilla)
+	if err != nil {
+		panic(err)
+	}
+
+	authorizer, err := newAuthorizerChain(config.Authorization, authenticator != nil, clientsetVanilla)
+	if err != nil {
+		panic(err)
+	}
+	metricsRegistry.MustRegister(newManagedPoliciesCollector(clientsetVanilla, policies, namespaceScope))
+
 	version, err := getKubernetesVersion(clientsetVanilla)
 	if err != nil {
 		panic(err)
 	}
 
-	fmt.Printf("Connected to Kubernetes %s\n", version)
+	slog.Info("Connected to Kubernetes", "version", version, "namespaces", namespaceScope.String())
 	server := Server{
-		K8sClientSet:    clientsetVanilla,
-		CalicoClientSet: clientsetCalico,
+		Cluster:                    *clusterName,
+		K8sConfig:                  kConfig,
+		K8sClientSet:               clientsetVanilla,
+		ResourceMetrics:            newMetricsClient(clientsetVanilla.CoreV1().RESTClient()),
+		CalicoClientSet:            clientsetCalico,
+		Policies:                   policies,
+		Namespaces:                 namespaceScope,
+		Prometheus:                 prometheusClient,
+		Dependencies:               config.Dependencies,
+		DebugImage:                 *debugImage,
+		SelftestImage:              *selftestImage,
+		Verifier:                   verifier,
+		Features:                   features,
+		Snapshots:                  newSnapshotStore(),
+		Deployments:                home.deployments,
+		Authenticator:              authenticator,
+		Authorizer:                 authorizer,
+		Aliases:                    aliases,
+		Guardrails:                 guardrails,
+		PolicyConflicts:            *policyConflicts,
+		Health:                     healthCriteria,
+		DeploymentGrace:            *deploymentGrace,
+		StatefulSetRolloutDeadline: *statefulSetDeadline,
+		CapacityThreshold:          *capacityThreshold,
+		ExcludedNamespaces:         excludedNamespaces,
+		KubeRequestTimeout:         *kubeRequestTimeout,
+	}
+	clusters := map[string]Server{}
+	for name, connection := range connections {
+		clusterGuardrails, err := newRateGuard(config.Guardrails)
+		if err != nil {
+			panic(err)
+		}
+		clusters[name] = server.forCluster(name, connection, clusterGuardrails)
+	}
+	// Every cluster gets its own scanner
+	if *scanInterval > 0 {
+		server.Scanner = newHealthScanner(server, *scanInterval, *historyRetention)
+		for name, cluster := range clusters {
+			cluster.Scanner = newHealthScanner(cluster, *scanInterval, *historyRetention)
+			clusters[name] = cluster
+		}
+	}
+	// The workers notify the deployments health as the scanner reports it, graced and without the excluded namespaces
+	if config.Alerting != nil {
+		if server.Alerts, err = newAlertEngine(config.Alerting, *clusterName, clientsetVanilla, namespaceScope, excludedNamespaces, server.reportedDeploymentsHealth); err != nil {
+			panic(err)
+		}
+	}
+	admin := &AdminServer{
+		Token:    adminToken,
+		Config:   config,
+		Flags:    commandLineFlags(flag.CommandLine),
+		Features: features,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Every replica serves requests, and so caches the deployments
+	if *cacheDeployments {
+		go home.deployments.run(ctx)
+		for _, connection := range connections {
+			go connection.deployments.run(ctx)
+		}
+	}
+	// Every replica serves the scans too
+	if *scanInterval > 0 {
+		go server.Scanner.run(ctx)
+		for _, cluster := range clusters {
+			go cluster.Scanner.run(ctx)
+		}
+	}
+
+	// The workers changing the cluster or reporting outside of it, which a single replica runs with --leader-elect
+	var workers []func(context.Context)
+
+	if config.Export != nil {
+		exporter, err := newSnapshotExporter(config.Export, server.reportedDeploymentsHealth)
+		if err != nil {
+			panic(err)
+		}
+		workers = append(workers, exporter.run)
 	}
+
+	if config.Ticketing != nil {
+		tickets, err := newTicketManager(config.Ticketing, server.reportedDeploymentsHealth)
+		if err != nil {
+			panic(err)
+		}
+		workers = append(workers, tickets.run)
+	}
+
+	if config.Webhooks != nil {
+		notifier, err := newWebhookNotifier(config.Webhooks, *clusterName, clientsetVanilla, server.reportedDeploymentsHealth)
+		if err != nil {
+			panic(err)
+		}
+		workers = append(workers, notifier.run)
+	}
+
+	if config.Slack != nil {
+		slack, err := newSlackNotifier(config.Slack, *clusterName, clientsetVanilla, namespaceScope, excludedNamespaces, server.reportedDeploymentsHealth)
+		if err != nil {
+			panic(err)
+		}
+		workers = append(workers, slack.run)
+	}
+
+	if server.Alerts != nil {
+		workers = append(workers, server.Alerts.run)
+	}
+
+	// Every cluster gets its reaper and intent reconciler, their audit records naming it
+	operatorErr := make(chan error, 1)
+	addClusterWorkers := func(name string, connection *clusterConnection) {
+		if *reaperInterval > 0 {
+			reaper := &policyReaper{interval: *reaperInterval, backend: connection.policies, clientset: connection.clientset, scope: namespaceScope}
+			workers = append(workers, func(ctx context.Context) { reaper.run(withCluster(ctx, name)) })
+		}
+
+		if connection.intents == nil {
+			return
+		}
+		reconciler := &intentReconciler{interval: *intentInterval, backend: connection.intents, clientset: connection.clientset, scope: namespaceScope}
+		switch {
+		case *mode == modeOperator:
+			// A manager can't be started twice, each term of a leader gets its own
+			workers = append(workers, func(ctx context.Context) {
+				operator, err := newIntentOperator(connection.config, reconciler)
+				if err == nil {
+					err = operator.Start(withCluster(ctx, name))
+				}
+				// The server shuts down with the operator, rather than running without it
+				if err != nil {
+					select {
+					case operatorErr <- err:
+					default:
+					}
+					stop()
+				}
+			})
+		case *intentInterval > 0:
+			workers = append(workers, func(ctx context.Context) { reconciler.run(withCluster(ctx, name)) })
+		}
+	}
+	addClusterWorkers(*clusterName, home)
+	for name, connection := range connections {
+		addClusterWorkers(name, connection)
+	}
+
+	if *leaderElect {
+		elector, err := newLeaderElector(clientsetVanilla, *leaseNamespace, *leaseName)
+		if err != nil {
+			panic(err)
+		}
+		go elector.run(ctx, workers...)
+	} else {
+		for _, worker := range workers {
+			go worker(ctx)
+		}
+	}
+
 	//getDeploymentsHealth(clientset)
-	if err := startServer(*listenAddr, server); err != nil {
+	listen := ListenConfig{
+		Address:         *listenAddr,
+		AdminAddress:    *adminAddr,
+		TLSCert:         *tlsCert,
+		TLSKey:          *tlsKey,
+		ShutdownTimeout: *shutdownTimeout,
+
+		ReadHeaderTimeout: *readHeaderTimeout,
+		ReadTimeout:       *readTimeout,
+		WriteTimeout:      *writeTimeout,
+		IdleTimeout:       *idleTimeout,
+		MaxBodyBytes:      *maxBodyBytes,
+		ClientCAs:         authorizer.clientCAs(),
+	}
+	if err := startServer(ctx, listen, server, clusters, admin); err != nil {
 		panic(err)
 	}
+	select {
+	case err := <-operatorErr:
+		panic(err)
+	default:
+	}
 }
 
 // getKubernetesVersion returns a string GitVersion of the Kubernetes server defined by the clientset.
@@ -92,17 +745,214 @@ func getKubernetesVersion(clientset kubernetes.Interface) (string, error) {
 	return version.String(), nil
 }
 
-// startServer launches an HTTP server with defined handlers and blocks until it's terminated or fails with an error.
+// ListenConfig tells startServer where and how to listen.
+type ListenConfig struct {
+	Address         string
+	AdminAddress    string
+	TLSCert         string
+	TLSKey          string
+	ShutdownTimeout time.Duration
+
+	// The timeouts of the connections, 0 for none, as in http.Server
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	// MaxBodyBytes caps the size of the request bodies, 0 for no limit
+	MaxBodyBytes int64
+	// ClientCAs verify the client certificates of the trusted proxies, none are requested when nil
+	ClientCAs *x509.CertPool
+}
+
+// newHTTPServer returns the server of a listener of listen, with its timeouts and body limit.
+func (listen ListenConfig) newHTTPServer(address string, handler http.Handler, tlsConfig *tls.Config) *http.Server {
+	return &http.Server{
+		Addr:              address,
+		Handler:           withRequestMetadata(limitRequestBodies(handler, listen.MaxBodyBytes)),
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: listen.ReadHeaderTimeout,
+		ReadTimeout:       listen.ReadTimeout,
+		WriteTimeout:      listen.WriteTimeout,
+		IdleTimeout:       listen.IdleTimeout,
+	}
+}
+
+// startServer launches an HTTP server with defined handlers and blocks until ctx is done or it fails with an error.
 //
-// Expects a listenAddr to bind to.
-func startServer(listenAddr string, server Server) error {
-	http.HandleFunc("/healthz", healthHandler)
-	http.HandleFunc("/clusterdeploymentsinfo", server.clusterDeploymentsInfoHandler)
-	http.HandleFunc("/denyNetworkPolicy", server.denyNetworkPolicyHandler)
+// Serves on listen.Address, and the admin endpoints on listen.AdminAddress when set, over HTTPS when a certificate is set.
+// GET /metrics is served by the admin listener when there's one, the API listener otherwise.
+// Requests with ?cluster= are served by the server of that cluster in clusters, GET /fleet/deploymentsinfo reports them all.
+// Once ctx is done new connections are refused and in-flight requests get up to listen.ShutdownTimeout to complete.
+// Both listeners apply the timeouts and body limit of listen.
+func startServer(ctx context.Context, listen ListenConfig, server Server, clusters map[string]Server, admin *AdminServer) error {
+	tlsConfig, err := newTLSConfig(listen.TLSCert, listen.TLSKey, listen.ClientCAs)
+	if err != nil {
+		return err
+	}
 
-	fmt.Printf("Server listening on %s\n", listenAddr)
+	handler := newAPIHandler(server, clusters, listen.AdminAddress == "")
+	servers := []*http.Server{listen.newHTTPServer(listen.Address, handler, tlsConfig)}
+	if listen.AdminAddress != "" {
+		servers = append(servers, listen.newHTTPServer(listen.AdminAddress, newAdminMux(admin), tlsConfig))
+	}
+
+	errs := make(chan error, len(servers))
+	for i, srv := range servers {
+		name := "Server"
+		if i > 0 {
+			name = "Admin server"
+		}
+		go func(srv *http.Server) {
+			var err error
+			if srv.TLSConfig != nil {
+				slog.Info(name+" listening", "address", srv.Addr, "tls", true)
+				err = srv.ListenAndServeTLS("", "")
+			} else {
+				slog.Info(name+" listening", "address", srv.Addr, "tls", false)
+				err = srv.ListenAndServe()
+			}
+			if !errors.Is(err, http.ErrServerClosed) {
+				errs <- err
+			}
+		}(srv)
+	}
+
+	select {
+	case err = <-errs:
+	case <-ctx.Done():
+		slog.Info("Shutting down, waiting for in-flight requests", "timeout", listen.ShutdownTimeout)
+	}
+
+	// Request contexts aren't cancelled by Shutdown, a policy being created is created completely or not at all
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), listen.ShutdownTimeout)
+	defer cancel()
+	for _, srv := range servers {
+		if shutdownErr := srv.Shutdown(shutdownCtx); shutdownErr != nil {
+			err = errors.Join(err, fmt.Errorf("shutting down %s: %w", srv.Addr, shutdownErr))
+		}
+	}
+	return err
+}
+
+// newAPIHandler routes the requests of the API listener to the server of their cluster, serving GET /metrics too when
+// metrics is set.
+func newAPIHandler(server Server, clusters map[string]Server, metrics bool) http.Handler {
+	fleet := fleet{server.Cluster: server}
+	for name, clusterServer := range clusters {
+		fleet[name] = clusterServer
+	}
+	handler := &clusterMux{home: server.Cluster, clusters: map[string]http.Handler{}}
+	for name, clusterServer := range fleet {
+		mux := newServerMux(clusterServer)
+		mux.HandleFunc("GET /fleet/deploymentsinfo", fleet.deploymentsInfoHandler)
+		if metrics {
+			mux.Handle("GET /metrics", metricsHandler())
+		}
+		handler.clusters[name] = withRouteErrors(mux, instrumentRequests(mux))
+	}
+	return handler
+}
+
+// newServerMux routes the requests served by server. The clients of server are interfaces, so tests serve the routes
+// with fake clientsets.
+func newServerMux(server Server) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", healthHandler)
+	mux.HandleFunc("/clusterdeploymentsinfo", server.clusterDeploymentsInfoHandler)
+	mux.HandleFunc("GET /clusterstatefulsetsinfo", server.clusterStatefulSetsInfoHandler)
+	mux.HandleFunc("GET /clusterdaemonsetsinfo", server.clusterDaemonSetsInfoHandler)
+	mux.HandleFunc("GET /clusterjobsinfo", server.clusterJobsInfoHandler)
+	mux.HandleFunc("GET /problempods", server.problemPodsHandler)
+	mux.HandleFunc("GET /logs/{namespace}/{pod}", server.sensitive(server.podLogsHandler))
+	mux.HandleFunc("POST /pods/{namespace}/{name}/evict", server.mutating(server.evictPodHandler))
+	mux.HandleFunc("GET /nodesinfo", server.nodesInfoHandler)
+	mux.HandleFunc("GET /caliconodestatus", server.calicoNodeStatusHandler)
+	mux.HandleFunc("POST /nodes/{name}/cordon", server.mutating(server.cordonNodeHandler))
+	mux.HandleFunc("POST /nodes/{name}/uncordon", server.mutating(server.uncordonNodeHandler))
+	mux.HandleFunc("POST /nodes/{name}/drain", server.mutating(server.drainNodeHandler))
+	mux.HandleFunc("GET /capacity", server.capacityHandler)
+	mux.HandleFunc("GET /usage", server.usageHandler)
+	mux.HandleFunc("GET /events", server.eventsHandler)
+	mux.HandleFunc("GET /hpainfo", server.hpaInfoHandler)
+	mux.HandleFunc("GET /pdbinfo", server.pdbInfoHandler)
+	mux.HandleFunc("GET /serviceinfo", server.serviceInfoHandler)
+	mux.HandleFunc("GET /pvcinfo", server.pvcInfoHandler)
+	mux.HandleFunc("GET /clusterhealth", server.clusterHealthHandler)
+	mux.HandleFunc("GET /healthscan", server.healthScanHandler)
+	mux.HandleFunc("GET /history/deployments/{namespace}/{name}", server.deploymentHistoryHandler)
+	mux.HandleFunc("GET /stream/deployments", server.streamDeploymentsHandler)
+	mux.HandleFunc("GET /ws", server.liveStatusHandler)
+	mux.HandleFunc("GET /denyNetworkPolicy", server.listDenyNetworkPoliciesHandler)
+	mux.HandleFunc("/denyNetworkPolicy", server.mutating(server.denyNetworkPolicyHandler))
+	mux.HandleFunc("DELETE /denyNetworkPolicy/{namespace}/{name}", server.mutating(server.deleteDenyNetworkPolicyHandler))
+	mux.HandleFunc("DELETE /denyNetworkPolicy/{name}", server.mutating(server.deleteDenyNetworkPolicyHandler))
+	mux.HandleFunc("POST /denyNetworkPolicy/render", server.renderDenyNetworkPolicyHandler)
+	mux.HandleFunc("POST /denyNetworkPolicy/impact", server.denyNetworkPolicyImpactHandler)
+	mux.HandleFunc("POST /denyNetworkPolicy/batch", server.mutating(server.denyNetworkPolicyBatchHandler))
+	mux.HandleFunc("POST /denyNetworkPolicy/{namespace}/{name}/promote", server.mutating(server.promoteDenyNetworkPolicyHandler))
+	mux.HandleFunc("POST /denyNetworkPolicy/undo/{id}", server.mutating(server.undoOperationHandler))
+	// The action is a wildcard so the undo route is more specific, instead of conflicting with it
+	mux.HandleFunc("POST /denyNetworkPolicy/{name}/{action}", server.mutating(server.promoteDenyNetworkPolicyHandler))
+	mux.HandleFunc("GET /networkPolicyCoverage", server.policyCoverageHandler)
+	mux.HandleFunc("GET /calico/ippools", server.listIPPoolsHandler)
+	mux.HandleFunc("GET /calico/networksets", server.listNetworkSetsHandler)
+	mux.HandleFunc("POST /networkpath/simulate", server.simulateNetworkPathHandler)
+	mux.HandleFunc("POST /isolateNamespace", server.mutating(server.isolateNamespaceHandler))
+	mux.HandleFunc("GET /api/v1/deployments/{namespace}/{name}/dependents", server.deploymentDependentsHandler)
+	mux.HandleFunc("POST /deployments/{namespace}/{name}/restart", server.mutating(server.restartDeploymentHandler))
+	mux.HandleFunc("POST /deployments/{namespace}/{name}/rollback", server.mutating(server.rollbackDeploymentHandler))
+	mux.HandleFunc("POST /api/v1/isolations/bulk", server.Features.require(featureBulkIsolation, server.mutating(server.bulkIsolationHandler)))
+	mux.HandleFunc("POST /api/v1/debug/{namespace}/{pod}", server.Features.require(featureDebugContainers, server.mutating(server.debugPodHandler)))
+	mux.HandleFunc("POST /api/v1/exec/{namespace}/{pod}", server.Features.require(featurePodExec, server.mutating(server.execPodHandler)))
+	mux.HandleFunc("GET /api/v1/namespaces/{name}/isolation", server.namespaceIsolationHandler)
+	mux.HandleFunc("GET /api/v1/network-policies/{namespace}/{name}/status", server.networkPolicyStatusHandler)
+	mux.HandleFunc("GET /api/v1/aliases", server.aliasesHandler)
+	mux.HandleFunc("GET /api/v1/snapshots", server.listSnapshotsHandler)
+	mux.HandleFunc("POST /api/v1/snapshots/{name}", server.mutating(server.captureSnapshotHandler))
+	mux.HandleFunc("GET /api/v1/snapshots/{name}", server.getSnapshotHandler)
+	mux.HandleFunc("GET /api/v1/snapshots/{a}/diff/{b}", server.diffSnapshotsHandler)
+	mux.HandleFunc("POST /api/v1/selftest", server.Features.require(featureSelftest, server.mutating(server.selftestHandler)))
+	mux.HandleFunc("GET /api/v1/silences", server.listSilencesHandler)
+	mux.HandleFunc("GET /audit", server.sensitive(server.auditHandler))
+	mux.HandleFunc("POST /api/v1/silences", server.mutating(server.createSilenceHandler))
+	mux.HandleFunc("DELETE /api/v1/silences/{id}", server.mutating(server.deleteSilenceHandler))
+	return mux
+}
 
-	return http.ListenAndServe(listenAddr, nil)
+// mutating guards a handler that changes the cluster with bearer token authentication and request signing.
+func (s *Server) mutating(next http.HandlerFunc) http.HandlerFunc {
+	return s.Authenticator.wrap(s.identify(s.Verifier.wrap(next)))
+}
+
+// sensitive guards a handler reading sensitive data, such as pod logs or the audit events, with bearer token
+// authentication. The handler authorizes the read itself.
+func (s *Server) sensitive(next http.HandlerFunc) http.HandlerFunc {
+	return s.Authenticator.wrap(next)
+}
+
+// newTLSConfig loads the certificate and key, returning nil to serve plaintext when neither is set.
+//
+// With clientCAs, the client certificates are verified against them when presented, as the trusted proxies do.
+func newTLSConfig(certFile, keyFile string, clientCAs *x509.CertPool) (*tls.Config, error) {
+	if certFile == "" && keyFile == "" {
+		if clientCAs != nil {
+			return nil, errors.New("proxy_client_ca requires --tls-cert and --tls-key")
+		}
+		return nil, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("--tls-cert and --tls-key must be set together")
+	}
+
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading TLS certificate: %w", err)
+	}
+	config := &tls.Config{Certificates: []tls.Certificate{certificate}, MinVersion: tls.VersionTLS12}
+	if clientCAs != nil {
+		config.ClientCAs, config.ClientAuth = clientCAs, tls.VerifyClientCertIfGiven
+	}
+	return config, nil
 }
 
 // healthHandler responds with the health status of the application.
@@ -111,143 +961,774 @@ func healthHandler(w http.ResponseWriter, r *http.Request) {
 
 	_, err := w.Write([]byte("ok"))
 	if err != nil {
-		fmt.Println("failed writing to response")
+		slog.ErrorContext(r.Context(), "Failed writing response", "error", err)
+	}
+}
+
+// limitRequestBodies caps the request bodies at limit bytes, reading further failing with an *http.MaxBytesError.
+// A limit of 0 leaves them unbounded.
+func limitRequestBodies(next http.Handler, limit int64) http.Handler {
+	if limit <= 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, limit)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// readBodyError responds to a failure reading the request body, with a 413 when the body is over the limit.
+func readBodyError(w http.ResponseWriter, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeErrorf(w, http.StatusRequestEntityTooLarge, "Request body larger than %d bytes", tooLarge.Limit)
+		return
+	}
+	writeErrorf(w, http.StatusInternalServerError, "Error reading request body")
+}
+
+// decodeStrictJSON reads the JSON body of a request into v, refusing unknown fields and trailing data: a typo such as
+// "lables" would otherwise leave a selector empty, matching every pod.
+func decodeStrictJSON(body []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		// The decoder names the unknown field without its path
+		if name, ok := strings.CutPrefix(err.Error(), `json: unknown field "`); ok {
+			name = strings.TrimSuffix(name, `"`)
+			return invalidJSON(invalidField(name, fmt.Errorf("unknown field %q", name)))
+		}
+		return invalidJSON(err)
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return invalidJSON(errors.New("unexpected data after the JSON body"))
+	}
+	return nil
+}
+
+// extendWriteDeadline lets a handler waiting on the cluster for up to timeout respond past the write timeout of the server.
+func extendWriteDeadline(w http.ResponseWriter, timeout time.Duration) {
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(timeout + writeDeadlineMargin))
+}
+
+// writeJSON responds with the status code and v encoded as JSON.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		slog.Error("Failed writing response", "error", err)
 	}
 }
 
 // Cluster Deployments Info returns the status of each deployment of the cluster
+//
+// ?namespace= restricts it to a namespace and ?labelSelector= to the deployments whose own labels match, both applied
+// by the API server when listing the deployments. ?excludeNamespaces= leaves namespaces out on top of
+// --exclude-namespaces, which don't apply to the namespace of ?namespace= or of a workload alias. ?limit= and ?continue=
+// page through large clusters. Failed deployments are diagnosed from their pods and warning events, unless
+// ?diagnose=false.
 func (s *Server) clusterDeploymentsInfoHandler(w http.ResponseWriter, r *http.Request) {
 
-	clusterDeploymentsInfo, err := getDeploymentsHealth(s.K8sClientSet)
+	prometheus := s.Prometheus
+	if !s.Features.Enabled(featurePrometheusChecks) {
+		prometheus = nil
+	}
+
+	filter, status, err := s.parseReportFilter(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, status, err)
 		return
 	}
+	scope, namespace, selector, deploymentSelector := filter.Scope, filter.Namespace, labels.Everything(), filter.Selector
+	page, err := parseDeploymentsPage(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if alias := r.URL.Query().Get("workload"); alias != "" {
+		workload, err := s.Aliases.resolve(DenyNetworkRequestWorkload{Alias: alias})
+		if err == nil && workload.CIDR != "" {
+			err = fmt.Errorf("workload %q is a network range, it has no deployments", alias)
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		if namespace != "" && namespace != workload.Namespace {
+			writeErrorf(w, http.StatusBadRequest, "workload %q is in namespace %s, not %s", alias, workload.Namespace, namespace)
+			return
+		}
+		if err := s.Namespaces.check(r.Context(), s.K8sClientSet, workload.Namespace); err != nil {
+			writeError(w, http.StatusForbidden, err)
+			return
+		}
+		scope, selector = NamespaceScope{Names: []string{workload.Namespace}}, labels.SelectorFromSet(workload.Labels)
+	}
+
+	ctx, cancel := s.kubeContext(r)
+	defer cancel()
+	clusterDeploymentsInfo, err := s.workloadsHealth(ctx, scope, prometheus, selector, deploymentSelector, page)
+	if err == nil && selector.Empty() {
+		clusterDeploymentsInfo.excludeNamespaces(filter.excludedNamespaces(s.ExcludedNamespaces))
+	}
+	switch {
+	case errors.Is(err, errInvalidContinue):
+		writeError(w, http.StatusBadRequest, err)
+		return
+	case apierrors.IsResourceExpired(err):
+		writeErrorf(w, http.StatusGone, "The continue token expired, start listing again from the first page")
+		return
+	case err != nil:
+		writeError(w, kubeErrorStatus(err), err)
+		return
+	}
+	if r.URL.Query().Get("diagnose") != "false" {
+		diagnoseFailures(ctx, s.K8sClientSet, s.ResourceMetrics, clusterDeploymentsInfo)
+	}
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 
 	err = json.NewEncoder(w).Encode(clusterDeploymentsInfo)
 	if err != nil {
-		fmt.Println("failed writing to response")
+		slog.ErrorContext(r.Context(), "Failed writing response", "error", err)
 	}
 }
 
-// Lists Deployments Health
-func getDeploymentsHealth(clientset kubernetes.Interface) (*ClusterDeploymentsInfo, error) {
-	// List deployments in all namespaces
-	deploymentsClient := clientset.AppsV1().Deployments(metav1.NamespaceAll)
-	deployments, err := deploymentsClient.List(context.TODO(), metav1.ListOptions{})
+// Lists Deployments Health of every namespace in scope, restricted to the deployments whose pod template labels match
+// selector
+//
+// When prometheus is set a deployment also has to pass every PromQL check to be reported as ready.
+func getWorkloadsHealth(ctx context.Context, clientset kubernetes.Interface, scope NamespaceScope, prometheus *PrometheusClient, criteria *HealthCriteria, selector labels.Selector) (*ClusterDeploymentsInfo, error) {
+	return listWorkloadsHealth(ctx, clientset, scope, prometheus, criteria, selector, labels.Everything(), deploymentsPage{})
+}
+
+// listWorkloadsHealth is getWorkloadsHealth only listing the deployments whose own labels match deploymentSelector.
+//
+// A paginated listing makes a single list call, in the namespace of the continue token, and returns the token of the
+// next page. A page can hold fewer deployments than its limit, when the selector drops some or a namespace runs out.
+func listWorkloadsHealth(ctx context.Context, clientset kubernetes.Interface, scope NamespaceScope, prometheus *PrometheusClient, criteria *HealthCriteria, selector, deploymentSelector labels.Selector, page deploymentsPage) (*ClusterDeploymentsInfo, error) {
+	namespaces, err := scope.resolve(ctx, clientset)
 	if err != nil {
 		return nil, err
 	}
 
+	start, token := 0, pageToken{}
+	if page.Continue != "" {
+		if token, err = decodePageToken(page.Continue); err != nil {
+			return nil, err
+		}
+		start = slices.Index(namespaces, token.Namespace)
+		if start < 0 {
+			return nil, fmt.Errorf("%w: namespace %q is not in scope", errInvalidContinue, token.Namespace)
+		}
+	}
+
+	var deployments []appsv1.Deployment
+	var next string
+	for i := start; i < len(namespaces); i++ {
+		deploymentsClient := clientset.AppsV1().Deployments(namespaces[i])
+		list, err := deploymentsClient.List(ctx, metav1.ListOptions{LabelSelector: deploymentSelector.String(), Limit: page.Limit, Continue: token.Continue})
+		if err != nil {
+			return nil, err
+		}
+		deployments = append(deployments, list.Items...)
+		token.Continue = ""
+		if !page.paginated() {
+			continue
+		}
+
+		if list.Continue != "" {
+			next = pageToken{Namespace: namespaces[i], Continue: list.Continue}.encode()
+		} else if i+1 < len(namespaces) {
+			next = pageToken{Namespace: namespaces[i+1]}.encode()
+		}
+		break
+	}
+
+	health := deploymentsHealth(ctx, deployments, prometheus, criteria, selector)
+	health.Continue = next
+	return health, nil
+}
+
+// workloadsHealth is listWorkloadsHealth reading the deployments from the deployment cache once it synced, failed
+// deployments updated within the grace period being reported as progressing.
+func (s Server) workloadsHealth(ctx context.Context, scope NamespaceScope, prometheus *PrometheusClient, selector, deploymentSelector labels.Selector, page deploymentsPage) (*ClusterDeploymentsInfo, error) {
+	health, err := s.readWorkloadsHealth(ctx, scope, prometheus, selector, deploymentSelector, page)
+	if err != nil {
+		return nil, err
+	}
+	health.applyGrace(s.DeploymentGrace, time.Now())
+	return health, nil
+}
+
+// readWorkloadsHealth reads the deployments from the cache, or from the API server for pages, which issues their
+// continue tokens, and until the cache synced.
+func (s Server) readWorkloadsHealth(ctx context.Context, scope NamespaceScope, prometheus *PrometheusClient, selector, deploymentSelector labels.Selector, page deploymentsPage) (*ClusterDeploymentsInfo, error) {
+	if s.Deployments == nil || page.paginated() || !s.Deployments.synced() {
+		return listWorkloadsHealth(ctx, s.K8sClientSet, scope, prometheus, s.Health, selector, deploymentSelector, page)
+	}
+
+	namespaces, err := scope.resolve(ctx, s.K8sClientSet)
+	if err != nil {
+		return nil, err
+	}
+	deployments, resourceVersion, ok := s.Deployments.list(namespaces, deploymentSelector)
+	if !ok {
+		return listWorkloadsHealth(ctx, s.K8sClientSet, scope, prometheus, s.Health, selector, deploymentSelector, page)
+	}
+
+	health := deploymentsHealth(ctx, deployments, prometheus, s.Health, selector)
+	health.Cached, health.ResourceVersion = true, resourceVersion
+	return health, nil
+}
+
+// deploymentsHealth sorts the deployments whose pod template labels match selector into ready, failed and stuck ones.
+func deploymentsHealth(ctx context.Context, deployments []appsv1.Deployment, prometheus *PrometheusClient, criteria *HealthCriteria, selector labels.Selector) *ClusterDeploymentsInfo {
 	clusterInfo := new(ClusterDeploymentsInfo)
+	for _, deployment := range deployments {
+		if !selector.Matches(labels.Set(deployment.Spec.Template.Labels)) {
+			continue
+		}
 
-	for _, deployment := range deployments.Items {
 		currentDeploymentInfo := DeploymentInfo{
-			Name:          deployment.Name,
-			RequestedPods: *deployment.Spec.Replicas,
-			ReadyPods:     deployment.Status.ReadyReplicas,
+			Name:                deployment.Name,
+			Namespace:           deployment.Namespace,
+			RequestedPods:       specReplicas(deployment.Spec.Replicas),
+			ReadyPods:           deployment.Status.ReadyReplicas,
+			AvailableReplicas:   deployment.Status.AvailableReplicas,
+			UpdatedReplicas:     deployment.Status.UpdatedReplicas,
+			UnavailableReplicas: deployment.Status.UnavailableReplicas,
+			CreatedAt:           deployment.CreationTimestamp.Time,
+			UpdatedAt:           lastUpdated(deployment),
+		}
+		for _, condition := range deployment.Status.Conditions {
+			currentDeploymentInfo.Conditions = append(currentDeploymentInfo.Conditions, DeploymentCondition{
+				Type:               string(condition.Type),
+				Status:             string(condition.Status),
+				Reason:             condition.Reason,
+				Message:            condition.Message,
+				LastTransitionTime: condition.LastTransitionTime.Time,
+			})
 		}
 
-		if *deployment.Spec.Replicas <= deployment.Status.ReadyReplicas {
+		healthy := criteria.ready(deployment)
+		if prometheus != nil {
+			checks, checksHealthy := prometheus.evaluate(ctx, deployment.Namespace, deployment.Name)
+			currentDeploymentInfo.Checks = checks
+			healthy = healthy && checksHealthy
+		}
+
+		switch {
+		case healthy:
 			clusterInfo.ReadyDeployments = append(clusterInfo.ReadyDeployments, currentDeploymentInfo)
-		} else {
+		case rolloutStuck(deployment):
+			clusterInfo.StuckRollouts = append(clusterInfo.StuckRollouts, currentDeploymentInfo)
+		default:
 			clusterInfo.FailedDeployments = append(clusterInfo.FailedDeployments, currentDeploymentInfo)
 		}
 	}
-	fmt.Printf("%+v\n", clusterInfo)
-	return clusterInfo, nil
+	slog.Debug("Deployments health", "ready", len(clusterInfo.ReadyDeployments), "failed", len(clusterInfo.FailedDeployments), "stuck", len(clusterInfo.StuckRollouts))
+	return clusterInfo
+}
+
+// lastUpdated returns when the deployment was created or, during a rollout, last made progress. The Progressing
+// condition keeps the time the last rollout completed once its new ReplicaSet is available.
+func lastUpdated(deployment appsv1.Deployment) time.Time {
+	updated := deployment.CreationTimestamp.Time
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.LastUpdateTime.After(updated) {
+			updated = condition.LastUpdateTime.Time
+		}
+	}
+	return updated
+}
+
+// applyGrace moves the failed deployments updated within grace of now to Progressing.
+func (info *ClusterDeploymentsInfo) applyGrace(grace time.Duration, now time.Time) {
+	if grace <= 0 {
+		return
+	}
+	failed := info.FailedDeployments[:0]
+	for _, deployment := range info.FailedDeployments {
+		if now.Sub(deployment.UpdatedAt) < grace {
+			info.Progressing = append(info.Progressing, deployment)
+		} else {
+			failed = append(failed, deployment)
+		}
+	}
+	info.FailedDeployments = failed
+}
+
+// excludeNamespaces removes the deployments of namespaces from every category of the report.
+func (info *ClusterDeploymentsInfo) excludeNamespaces(namespaces []string) {
+	if len(namespaces) == 0 {
+		return
+	}
+	keep := func(deployments []DeploymentInfo) []DeploymentInfo {
+		kept := deployments[:0]
+		for _, deployment := range deployments {
+			if !slices.Contains(namespaces, deployment.Namespace) {
+				kept = append(kept, deployment)
+			}
+		}
+		return kept
+	}
+	info.ReadyDeployments = keep(info.ReadyDeployments)
+	info.FailedDeployments = keep(info.FailedDeployments)
+	info.StuckRollouts = keep(info.StuckRollouts)
+	info.Progressing = keep(info.Progressing)
+}
+
+// rolloutStuck reports whether the Progressing condition of the deployment says its rollout exceeded its deadline.
+func rolloutStuck(deployment appsv1.Deployment) bool {
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing {
+			return condition.Status == corev1.ConditionFalse && condition.Reason == progressDeadlineExceeded
+		}
+	}
+	return false
+}
+
+// prepareDenyNetworkPolicy checks both namespaces are in scope and has the backend render the policy denying traffic between the workloads.
+func prepareDenyNetworkPolicy(ctx context.Context, clientset kubernetes.Interface, backend PolicyBackend, scope NamespaceScope, requestdetails DenyNetworkRequest, meta RequestMetadata) (PolicyObject, error) {
+	if requestdetails.global() {
+		// A cluster-wide policy would reach past the namespaces the service is restricted to
+		if scope.IsScoped() {
+			return nil, fmt.Errorf("%w: global policies need the service to cover every namespace", errNamespaceOutOfScope)
+		}
+	} else if err := scope.check(ctx, clientset, requestdetails.A.Namespace); err != nil {
+		return nil, err
+	}
+	if requestdetails.B.CIDR != "" || requestdetails.B.Namespace == "" && requestdetails.global() {
+		slog.Debug("Denying traffic", "backend", backend.Name(), "peer", requestdetails.B.describe(), "scope", requestdetails.Scope, "correlation_id", meta.CorrelationID)
+		return backend.Render(requestdetails, nil, meta), nil
+	}
+	if err := scope.check(ctx, clientset, requestdetails.B.Namespace); err != nil {
+		return nil, err
+	}
+
+	namespaceB, err := clientset.CoreV1().Namespaces().Get(ctx, requestdetails.B.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Denying traffic", "backend", backend.Name(), "selector", renderSelector(requestdetails.B), "namespace_selector", renderMap(namespaceB.Labels), "correlation_id", meta.CorrelationID)
+	return backend.Render(requestdetails, namespaceB, meta), nil
+}
+
+// errWorkloadNotFound is returned when a workload of a deny request matches nothing, the policy would have no effect.
+var errWorkloadNotFound = errors.New("workload not found")
+
+// verifyWorkloads checks the namespace of A exists and both workloads match at least a pod, so a typo doesn't silently create
+// a policy denying nothing. A CIDR is not checked.
+func verifyWorkloads(ctx context.Context, clientset kubernetes.Interface, requestdetails DenyNetworkRequest) error {
+	if requestdetails.A.Namespace != "" {
+		_, err := clientset.CoreV1().Namespaces().Get(ctx, requestdetails.A.Namespace, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("%w: namespace %s of workload_a doesn't exist", errWorkloadNotFound, requestdetails.A.Namespace)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	var errs []error
+	for _, workload := range []struct {
+		field    string
+		workload DenyNetworkRequestWorkload
+	}{{"workload_a", requestdetails.A}, {"workload_b", requestdetails.B}} {
+		// NetworkSets and host endpoints were found when resolving the reference, and select no pod
+		if workload.workload.CIDR != "" || workload.workload.Kind == workloadKindNetworkSet || workload.workload.Kind == workloadKindHostEndpoint {
+			continue
+		}
+		pods, err := clientset.CoreV1().Pods(workload.workload.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: workload.workload.selector().String(),
+			Limit:         1,
+		})
+		if err != nil {
+			return err
+		}
+		if len(pods.Items) == 0 {
+			errs = append(errs, fmt.Errorf("%w: %s %s matches no pod", errWorkloadNotFound, workload.field, workload.workload.describe()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// dryRunDenyNetworkPolicy has the API server validate the policy without persisting it, and returns it as it would be created.
+//
+// When the policy already exists the existing one is returned, as creating it would.
+func dryRunDenyNetworkPolicy(ctx context.Context, clientset kubernetes.Interface, backend PolicyBackend, scope NamespaceScope, requestdetails DenyNetworkRequest, meta RequestMetadata) (PolicyObject, error) {
+	policy, err := prepareDenyNetworkPolicy(ctx, clientset, backend, scope, requestdetails, meta)
+	if err != nil {
+		return nil, err
+	}
+	setExpiry(policy, requestdetails, time.Now())
+
+	result, err := backend.Create(ctx, policy, true)
+	if apierrors.IsAlreadyExists(err) {
+		return getDenyPolicy(ctx, backend, requestdetails, policy.GetNamespace(), policy.GetName())
+	}
+	return result, err
+}
+
+// getDenyPolicy returns the policy of a request, the staged one when the request asks for it.
+func getDenyPolicy(ctx context.Context, backend PolicyBackend, requestdetails DenyNetworkRequest, namespace, name string) (PolicyObject, error) {
+	if staging, ok := stagingOf(backend); ok && requestdetails.Staged {
+		return staging.getStaged(ctx, namespace, name)
+	}
+	return backend.Get(ctx, namespace, name)
+}
+
+// resolveDenyRequest resolves the aliases and references of a deny request and validates it against the backend,
+// returning the HTTP status of the error.
+func (s *Server) resolveDenyRequest(ctx context.Context, request *DenyNetworkRequest) (int, error) {
+	var err error
+	for _, workload := range []*DenyNetworkRequestWorkload{&request.A, &request.B} {
+		if *workload, err = s.Aliases.resolve(*workload); err != nil {
+			return http.StatusBadRequest, err
+		}
+		if !workload.referenced() {
+			continue
+		}
+		// Host endpoints have no namespace to check
+		if workload.Kind == workloadKindHostEndpoint {
+			if !supportsHostEndpoints(s.Policies) {
+				return http.StatusBadRequest, fmt.Errorf("the %s policy backend can't select HostEndpoints", s.Policies.Name())
+			}
+			if *workload, err = resolveHostEndpoint(ctx, s.CalicoClientSet, *workload); err != nil {
+				return referenceErrorStatus(err), err
+			}
+			continue
+		}
+		if err := s.Namespaces.check(ctx, s.K8sClientSet, workload.Namespace); err != nil {
+			return http.StatusForbidden, err
+		}
+		if workload.Kind == workloadKindNetworkSet {
+			if workload != &request.B {
+				return http.StatusBadRequest, fmt.Errorf("%w: only workload_b can be a NetworkSet, policies select pods", errInvalidReference)
+			}
+			if !supportsNetworkSets(s.Policies) {
+				return http.StatusBadRequest, fmt.Errorf("the %s policy backend can't select NetworkSets", s.Policies.Name())
+			}
+			*workload, err = resolveNetworkSet(ctx, s.CalicoClientSet, *workload)
+		} else {
+			*workload, err = resolveReference(ctx, s.K8sClientSet, *workload)
+		}
+		if err != nil {
+			return referenceErrorStatus(err), err
+		}
+	}
+	if err := request.validate(); err != nil {
+		return http.StatusBadRequest, err
+	}
+	if request.Tier != "" && !supportsTiers(s.Policies) {
+		return http.StatusBadRequest, fmt.Errorf("the %s policy backend has no tiers", s.Policies.Name())
+	}
+	if _, ok := stagingOf(s.Policies); request.Staged && !ok {
+		return http.StatusBadRequest, fmt.Errorf("the %s policy backend can't stage policies", s.Policies.Name())
+	}
+	return http.StatusOK, nil
 }
 
 // Handler to create deny policy based on post request
 func (s *Server) denyNetworkPolicyHandler(w http.ResponseWriter, r *http.Request) {
 	// Check if the request method is POST
 	if r.Method != http.MethodPost {
-		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		writeErrorf(w, http.StatusMethodNotAllowed, "Invalid request method")
 		return
 	}
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		http.Error(w, "Error reading request body", http.StatusInternalServerError)
+		readBodyError(w, err)
 		return
 	}
 
 	defer r.Body.Close()
 
 	var denyNetworkRequest DenyNetworkRequest
-	err = json.Unmarshal(body, &denyNetworkRequest)
-	if err != nil {
-		http.Error(w, "Error parsing JSON", http.StatusBadRequest)
+	if err := decodeStrictJSON(body, &denyNetworkRequest); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx, cancel := s.kubeContext(r)
+	defer cancel()
+	if status, err := s.resolveDenyRequest(ctx, &denyNetworkRequest); err != nil {
+		writeError(w, status, err)
+		return
+	}
+
+	// Cleared so a dry run renders the same name and payload annotation as the real request
+	dryRun := denyNetworkRequest.DryRun || r.URL.Query().Get("dryRun") == "true"
+	denyNetworkRequest.DryRun = false
+
+	// Like Kubernetes, a dry run needs the permissions of the real request
+	if !s.authorize(w, r, denyNetworkRequest.operation(), denyNetworkRequest.A.Namespace, "", denyNetworkRequest) {
+		return
+	}
+
+	if err := verifyWorkloads(ctx, s.K8sClientSet, denyNetworkRequest); errors.Is(err, errWorkloadNotFound) {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	} else if err != nil {
+		writeError(w, kubeErrorStatus(err), err)
+		return
+	}
+
+	var conflictErr *PolicyConflictError
+	if err := s.checkPolicyConflicts(ctx, w, denyNetworkRequest); errors.As(err, &conflictErr) {
+		writeError(w, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if dryRun {
+		policy, err := dryRunDenyNetworkPolicy(ctx, s.K8sClientSet, s.Policies, s.Namespaces, denyNetworkRequest, requestMetadata(r.Context()))
+		switch {
+		case errors.Is(err, errNamespaceOutOfScope):
+			writeError(w, http.StatusForbidden, err)
+		case apierrors.IsInvalid(err):
+			writeError(w, http.StatusUnprocessableEntity, err)
+		case err != nil:
+			writeError(w, kubeErrorStatus(err), err)
+		default:
+			writeObject(w, r, policy)
+		}
 		return
 	}
 
-	n, err := createDenyNetworkPolicy(s.K8sClientSet, *s.CalicoClientSet, denyNetworkRequest)
+	reservation, err := s.Guardrails.reserve(budgetPolicies, denyNetworkRequest.A.Namespace, 1, time.Now())
+	if writeGuardrailError(w, err) {
+		return
+	}
+
+	meta := requestMetadata(r.Context())
+	n, created, err := createDenyNetworkPolicy(ctx, s.K8sClientSet, s.Policies, s.Namespaces, denyNetworkRequest, meta)
+	if !created {
+		reservation.release(1)
+	}
+	audit := AuditEvent{
+		Action:          denyNetworkRequest.operation().Action,
+		RequestMetadata: meta,
+		RemoteAddr:      r.RemoteAddr,
+		Namespace:       denyNetworkRequest.A.Namespace,
+		Target:          n,
+		Request:         denyNetworkRequest,
+		Result:          "created",
+	}
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		audit.Result, audit.Error = "failed", err.Error()
+	} else if !created {
+		audit.Result = "exists"
+	}
+	recordAudit(audit)
+	if errors.Is(err, errNamespaceOutOfScope) {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+	if err != nil {
+		writeError(w, kubeErrorStatus(err), err)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
 	_, err = w.Write([]byte(n))
 	if err != nil {
-		fmt.Println("failed writing to response")
+		slog.ErrorContext(r.Context(), "Failed writing response", "error", err)
+	}
+}
+
+// writeObject responds with v as YAML when the client accepts it, as JSON otherwise.
+func writeObject(w http.ResponseWriter, r *http.Request, v interface{}) {
+	if !strings.Contains(r.Header.Get("Accept"), "yaml") {
+		writeJSON(w, http.StatusOK, v)
+		return
+	}
+
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/yaml")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(data); err != nil {
+		slog.ErrorContext(r.Context(), "Failed writing response", "error", err)
 	}
 }
 
-// Render string map to Calico selector string
+// Render string map to Calico selector string, keys sorted so the same labels always render the same selector
+//
+// Requests are validated with validateLabels, a key or value that still isn't a valid label is rendered as a selector matching
+// nothing rather than spliced into the expression.
 func renderMap(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
 	var sb strings.Builder
-	first := true
-	for key, value := range m {
-		if !first {
+	for i, key := range keys {
+		if i > 0 {
 			sb.WriteString(" && ")
 		}
-		sb.WriteString(fmt.Sprintf("%s == '%s'", key, value))
-		first = false
+		if validateLabel(key, m[key]) != nil {
+			sb.WriteString(calicoMatchNothing)
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%s == '%s'", key, m[key]))
 	}
 	return sb.String()
 }
 
+// renderSelector renders the labels then the expressions of a workload to a Calico selector, e.g. tier in {'edge', 'frontend'}.
+func renderSelector(workload DenyNetworkRequestWorkload) string {
+	terms := []string{}
+	if len(workload.Labels) > 0 {
+		terms = append(terms, renderMap(workload.Labels))
+	}
+	for _, expression := range workload.MatchExpressions {
+		if validateExpression(expression) != nil {
+			terms = append(terms, calicoMatchNothing)
+			continue
+		}
+
+		values := make([]string, 0, len(expression.Values))
+		for _, value := range expression.Values {
+			values = append(values, "'"+value+"'")
+		}
+		sort.Strings(values)
+		switch expression.Operator {
+		case metav1.LabelSelectorOpIn:
+			terms = append(terms, fmt.Sprintf("%s in {%s}", expression.Key, strings.Join(values, ", ")))
+		case metav1.LabelSelectorOpNotIn:
+			terms = append(terms, fmt.Sprintf("%s not in {%s}", expression.Key, strings.Join(values, ", ")))
+		case metav1.LabelSelectorOpExists:
+			terms = append(terms, fmt.Sprintf("has(%s)", expression.Key))
+		case metav1.LabelSelectorOpDoesNotExist:
+			terms = append(terms, fmt.Sprintf("!has(%s)", expression.Key))
+		}
+	}
+	return strings.Join(terms, " && ")
+}
+
+// calicoMatchNothing is a Calico selector no endpoint matches.
+const calicoMatchNothing = "!all()"
+
+// denyPolicyName derives the policy name from both workloads, so denying the same traffic twice finds the first policy.
+func denyPolicyName(request DenyNetworkRequest) string {
+	// A staged policy keeps its name once promoted, and the mirror of a request is named like the reversed request.
+	// Repeating a request with another TTL returns the existing policy.
+	request.A.Alias, request.B.Alias, request.Staged, request.Symmetric, request.TTL = "", "", false, nil, nil
+	// Host endpoints keep their kind, the policy selecting them apart from the pods with the same labels
+	for _, workload := range []*DenyNetworkRequestWorkload{&request.A, &request.B} {
+		if workload.Kind != workloadKindHostEndpoint {
+			workload.Kind = ""
+		}
+		workload.Name = ""
+	}
+	return "deny-network-policy-" + specHash(request)[:16]
+}
+
+// prepareDenyNetworkPolicies renders the policy of a request and its mirror, nil when the request isn't mirrored, each
+// one pointing at the other and expiring after the TTL of the request.
+func prepareDenyNetworkPolicies(ctx context.Context, clientset kubernetes.Interface, backend PolicyBackend, scope NamespaceScope, requestdetails DenyNetworkRequest, meta RequestMetadata) (policy, mirror PolicyObject, err error) {
+	policy, err = prepareDenyNetworkPolicy(ctx, clientset, backend, scope, requestdetails, meta)
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	setExpiry(policy, requestdetails, now)
+
+	mirrorRequest, mirrored := requestdetails.mirror()
+	if !mirrored {
+		return policy, nil, nil
+	}
+	if mirror, err = prepareDenyNetworkPolicy(ctx, clientset, backend, scope, mirrorRequest, meta); err != nil {
+		return nil, nil, err
+	}
+	setExpiry(mirror, requestdetails, now)
+	setAnnotation(policy, mirrorAnnotation, mirror.GetNamespace()+"/"+mirror.GetName())
+	setAnnotation(mirror, mirrorAnnotation, policy.GetNamespace()+"/"+policy.GetName())
+	return policy, mirror, nil
+}
+
 // Creates Network Policy to stop connections between two workloads by label and namespace
-func createDenyNetworkPolicy(clientset kubernetes.Interface, calicoClientset clientset.Clientset, requestdetails DenyNetworkRequest) (string, error) {
-	namespaceB, err := clientset.CoreV1().Namespaces().Get(context.TODO(), requestdetails.B.Namespace, metav1.GetOptions{})
+//
+// The name is derived from the workloads, when the policy already exists it's returned with created set to false.
+// A symmetric request also gets its mirror policy in the namespace of B, both naming the other in an annotation. When
+// the mirror can't be created the policy is deleted again, unless it already existed.
+func createDenyNetworkPolicy(ctx context.Context, clientset kubernetes.Interface, backend PolicyBackend, scope NamespaceScope, requestdetails DenyNetworkRequest, meta RequestMetadata) (name string, created bool, err error) {
+	policy, mirror, err := prepareDenyNetworkPolicies(ctx, clientset, backend, scope, requestdetails, meta)
 	if err != nil {
-		return "", err
+		return "", false, err
 	}
 
-	fmt.Println(renderMap(requestdetails.B.Labels))
-	fmt.Println(renderMap(namespaceB.Labels))
-	networkPolicy := &v3.NetworkPolicy{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("deny-network-policy-%s", uuid.New().String()),
-			Namespace: requestdetails.A.Namespace,
-		},
-		Spec: v3.NetworkPolicySpec{
-			Selector: renderMap(requestdetails.A.Labels),
-			Ingress: []v3.Rule{{
-				Action: v3.Deny,
-				Source: v3.EntityRule{
-					Selector:          renderMap(requestdetails.B.Labels),
-					NamespaceSelector: renderMap(namespaceB.Labels),
-				},
-			}},
-			Egress: []v3.Rule{{
-				Action: v3.Deny,
-				Destination: v3.EntityRule{
-					Selector:          renderMap(requestdetails.B.Labels),
-					NamespaceSelector: renderMap(namespaceB.Labels),
-				},
-			}},
-		},
-	}
-
-	n, err := calicoClientset.ProjectcalicoV3().NetworkPolicies(requestdetails.A.Namespace).Create(context.TODO(), networkPolicy, metav1.CreateOptions{})
-	if err != nil {
-		fmt.Println("Error :" + err.Error())
-		return "", err
+	stored, created, err := storeDenyNetworkPolicy(ctx, clientset, backend, requestdetails, policy, meta)
+	if err != nil {
+		return "", false, err
+	}
+	if mirror == nil {
+		return stored.GetName(), created, nil
+	}
+	mirrorRequest, _ := requestdetails.mirror()
+	if _, _, err := storeDenyNetworkPolicy(ctx, clientset, backend, mirrorRequest, mirror, meta); err != nil {
+		err = fmt.Errorf("creating the mirror policy in namespace %s: %w", mirror.GetNamespace(), err)
+		// The rollback completes even when the request was cancelled meanwhile
+		if created {
+			if deleteErr := backend.Delete(context.WithoutCancel(ctx), stored); deleteErr != nil {
+				err = errors.Join(err, fmt.Errorf("rolling back: %w", deleteErr))
+			}
+		}
+		return "", false, err
+	}
+	return stored.GetName(), created, nil
+}
+
+// storeDenyNetworkPolicy creates a rendered policy, or returns the existing one with created set to false.
+func storeDenyNetworkPolicy(ctx context.Context, clientset kubernetes.Interface, backend PolicyBackend, requestdetails DenyNetworkRequest, policy PolicyObject, meta RequestMetadata) (stored PolicyObject, created bool, err error) {
+	n, err := backend.Create(ctx, policy, false)
+	if apierrors.IsAlreadyExists(err) {
+		existing, getErr := getDenyPolicy(ctx, backend, requestdetails, policy.GetNamespace(), policy.GetName())
+		if getErr != nil {
+			return nil, false, getErr
+		}
+		if existing.GetLabels()[managedByLabel] != managedByValue {
+			return nil, false, fmt.Errorf("network policy %s/%s exists and is not managed by this service", existing.GetNamespace(), existing.GetName())
+		}
+		slog.Info("Network policy already exists", "namespace", existing.GetNamespace(), "name", existing.GetName(), "correlation_id", meta.CorrelationID)
+		return existing, false, nil
+	}
+	if err != nil {
+		slog.Error("Failed creating network policy", "namespace", requestdetails.A.Namespace, "error", err, "correlation_id", meta.CorrelationID)
+		return nil, false, err
 	}
 
-	fmt.Println("NetworkPolicy created with name:", networkPolicy.Name)
-	return n.Name, nil
+	slog.Info("Network policy created", "namespace", n.GetNamespace(), "name", n.GetName(), "correlation_id", meta.CorrelationID)
+	// The policy exists now, its event is recorded even when the request was cancelled meanwhile
+	recordEvent(context.WithoutCancel(ctx), clientset, policyReference(n), "DenyPolicyCreated", fmt.Sprintf("Denied traffic between %s and %s", backend.Selectors(n).Selector, requestdetails.B.describe()), meta)
+	return n, true, nil
+}
+
+// setAnnotation sets an annotation of a rendered object.
+func setAnnotation(object metav1.Object, key, value string) {
+	annotations := object.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[key] = value
+	object.SetAnnotations(annotations)
+}
+
+// setExpiry records when the policy of a request with a TTL expires, created at now.
+func setExpiry(policy PolicyObject, requestdetails DenyNetworkRequest, now time.Time) {
+	if requestdetails.TTL != nil && requestdetails.TTL.Duration > 0 {
+		setAnnotation(policy, expiresAtAnnotation, now.Add(requestdetails.TTL.Duration).UTC().Format(time.RFC3339))
+	}
 }
@@ -1,12 +1,35 @@
 package main
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	calicofake "github.com/projectcalico/api/pkg/client/clientset_generated/clientset/fake"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/version"
 	disco "k8s.io/client-go/discovery/fake"
 	"k8s.io/client-go/kubernetes/fake"
@@ -45,3 +68,541 @@ func TestHealthHandler(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "ok", string(resp))
 }
+
+func TestStartServerShutsDown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- startServer(ctx, ListenConfig{Address: "127.0.0.1:0", AdminAddress: "127.0.0.1:0", ShutdownTimeout: time.Second}, Server{}, nil, &AdminServer{})
+	}()
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server didn't shut down")
+	}
+}
+
+func TestStartServerFailsToListen(t *testing.T) {
+	err := startServer(context.Background(), ListenConfig{Address: "127.0.0.1:-1", ShutdownTimeout: time.Second}, Server{}, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestNewAPIHandlerMetrics(t *testing.T) {
+	serve := func(handler http.Handler) int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve(newAPIHandler(Server{}, nil, true)))
+	// The admin listener serves them instead
+	assert.Equal(t, http.StatusNotFound, serve(newAPIHandler(Server{}, nil, false)))
+}
+
+func TestListenConfigNewHTTPServer(t *testing.T) {
+	listen := ListenConfig{ReadHeaderTimeout: time.Second, ReadTimeout: 2 * time.Second, WriteTimeout: 3 * time.Second, IdleTimeout: 4 * time.Second, MaxBodyBytes: 64}
+	srv := listen.newHTTPServer("127.0.0.1:0", newServerMux(Server{}), nil)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 4 * time.Second}, []time.Duration{srv.ReadHeaderTimeout, srv.ReadTimeout, srv.WriteTimeout, srv.IdleTimeout})
+
+	rec := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/denyNetworkPolicy", strings.NewReader(`{"workload_a": {"namespace": "`+strings.Repeat("a", 64)+`"}}`)))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	var response ErrorResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, ErrorResponse{Code: errorCodePayloadTooLarge, Message: "Request body larger than 64 bytes", RequestID: rec.Header().Get(correlationIDHeader)}, response)
+
+	// Small bodies get through
+	rec = httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/denyNetworkPolicy", strings.NewReader(`{`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestServerMux(t *testing.T) {
+	server := Server{
+		K8sClientSet: fake.NewSimpleClientset(),
+		CalicoClientSet: calicofake.NewSimpleClientset(&v3.NetworkSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "known-bad", Namespace: "security", Labels: map[string]string{"feed": "known-bad"}},
+			Spec:       v3.NetworkSetSpec{Nets: []string{"198.51.100.0/24"}},
+		}),
+	}
+	mux := newServerMux(server)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/calico/networksets?namespace=security", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `[{"namespace": "security", "name": "known-bad", "labels": {"feed": "known-bad"}, "nets": ["198.51.100.0/24"]}]`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/caliconodestatus", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"nodes": [], "at_risk": 0}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/caliconodestatus?labelSelector=a%20b", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	config, err := newTLSConfig("", "", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+
+	_, err = newTLSConfig("tls.crt", "", nil)
+	assert.Error(t, err)
+
+	certFile, keyFile := writeSelfSignedCertificate(t)
+	config, err = newTLSConfig(certFile, keyFile, nil)
+	assert.NoError(t, err)
+	if assert.NotNil(t, config) {
+		assert.Len(t, config.Certificates, 1)
+		assert.Equal(t, uint16(tls.VersionTLS12), config.MinVersion)
+		assert.Equal(t, tls.NoClientCert, config.ClientAuth)
+	}
+
+	_, err = newTLSConfig(keyFile, certFile, nil)
+	assert.Error(t, err)
+
+	// The certificates of trusted proxies need HTTPS
+	clientCAs := x509.NewCertPool()
+	_, err = newTLSConfig("", "", clientCAs)
+	assert.Error(t, err)
+	config, err = newTLSConfig(certFile, keyFile, clientCAs)
+	assert.NoError(t, err)
+	if assert.NotNil(t, config) {
+		assert.Equal(t, tls.VerifyClientCertIfGiven, config.ClientAuth)
+		assert.Same(t, clientCAs, config.ClientCAs)
+	}
+}
+
+func writeSelfSignedCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "tyk-sre-assignment"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	assert.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+func TestDenyPolicyName(t *testing.T) {
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger", "tier": "backend"}},
+		B: DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}},
+	}
+	name := denyPolicyName(request)
+	assert.Regexp(t, "^deny-network-policy-[0-9a-f]{16}$", name)
+
+	aliased := request
+	aliased.A.Alias = "ledger"
+	assert.Equal(t, name, denyPolicyName(aliased))
+
+	staged := request
+	staged.Staged = true
+	assert.Equal(t, name, denyPolicyName(staged))
+
+	reversed := DenyNetworkRequest{A: request.B, B: request.A}
+	assert.NotEqual(t, name, denyPolicyName(reversed))
+
+	other := request
+	other.B = DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "checkout"}}
+	assert.NotEqual(t, name, denyPolicyName(other))
+}
+
+func TestRenderMapIsSorted(t *testing.T) {
+	labels := map[string]string{"tier": "backend", "app": "ledger", "env": "prod"}
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, "app == 'ledger' && env == 'prod' && tier == 'backend'", renderMap(labels))
+	}
+	assert.Equal(t, "", renderMap(nil))
+}
+
+func TestRenderMapRejectsInjection(t *testing.T) {
+	assert.Equal(t, "!all()", renderMap(map[string]string{"app": "x' || all() || app == 'y"}))
+	assert.Equal(t, "!all() && env == 'prod'", renderMap(map[string]string{"app) || (all": "x", "env": "prod"}))
+}
+
+func TestRenderSelector(t *testing.T) {
+	workload := DenyNetworkRequestWorkload{
+		Labels: map[string]string{"app": "web"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"frontend", "edge"}},
+			{Key: "env", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"dev"}},
+			{Key: "canary", Operator: metav1.LabelSelectorOpDoesNotExist},
+			{Key: "team", Operator: metav1.LabelSelectorOpExists},
+		},
+	}
+	assert.Equal(t, "app == 'web' && tier in {'edge', 'frontend'} && env not in {'dev'} && !has(canary) && has(team)", renderSelector(workload))
+	assert.Equal(t, "app=web,!canary,env notin (dev),team,tier in (edge,frontend)", workload.selector().String())
+
+	workload.MatchExpressions = []metav1.LabelSelectorRequirement{{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"x'} || all() || {'"}}}
+	assert.Equal(t, "app == 'web' && !all()", renderSelector(workload))
+}
+
+func TestDenyNetworkRequestValidateExpressions(t *testing.T) {
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"edge"}}}},
+		B: DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}},
+	}
+	assert.NoError(t, request.validate())
+
+	request.A.MatchExpressions[0].Operator = metav1.LabelSelectorOpExists
+	assert.EqualError(t, request.validate(), `workload_a: expression on "tier": operator Exists takes no values`)
+
+	request.A.MatchExpressions[0].Operator = "Gt"
+	assert.ErrorContains(t, request.validate(), `invalid operator "Gt"`)
+
+	request.A.MatchExpressions[0].Operator = metav1.LabelSelectorOpExists
+	request.A.MatchExpressions[0].Values = nil
+	request.B = DenyNetworkRequestWorkload{CIDR: "10.12.0.0/16", MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: metav1.LabelSelectorOpExists}}}
+	assert.EqualError(t, request.validate(), "workload 10.12.0.0/16 sets both a CIDR and a namespace or labels")
+}
+
+func TestDenyNetworkRequestValidateRequiredFields(t *testing.T) {
+	ledger := DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}}
+	for name, test := range map[string]struct {
+		request DenyNetworkRequest
+		field   string
+	}{
+		"no namespace of A": {DenyNetworkRequest{A: DenyNetworkRequestWorkload{Labels: map[string]string{"app": "cart"}}, B: ledger}, "workload_a.namespace"},
+		"no labels of A":    {DenyNetworkRequest{A: DenyNetworkRequestWorkload{Namespace: "shop"}, B: ledger}, "workload_a.labels"},
+		"no namespace of B": {DenyNetworkRequest{A: ledger, B: DenyNetworkRequestWorkload{Labels: map[string]string{"app": "cart"}}}, "workload_b.namespace"},
+		"no labels of B":    {DenyNetworkRequest{A: ledger, B: DenyNetworkRequestWorkload{Namespace: "shop"}}, "workload_b.labels"},
+		"no labels of B in every namespace": {
+			DenyNetworkRequest{A: DenyNetworkRequestWorkload{Labels: map[string]string{"app": "cart"}}, B: DenyNetworkRequestWorkload{}, Scope: policyScopeGlobal},
+			"workload_b.labels",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var fieldErr *FieldError
+			if assert.ErrorAs(t, test.request.validate(), &fieldErr) {
+				assert.Equal(t, test.field, fieldErr.Field)
+			}
+		})
+	}
+
+	// A CIDR needs neither a namespace nor labels
+	request := DenyNetworkRequest{A: ledger, B: DenyNetworkRequestWorkload{CIDR: "10.12.0.0/16"}}
+	assert.NoError(t, request.validate())
+}
+
+func TestDecodeStrictJSON(t *testing.T) {
+	var request DenyNetworkRequest
+	assert.NoError(t, decodeStrictJSON([]byte(`{"workload_a": {"namespace": "shop", "labels": {"app": "cart"}}, "workload_b": {"cidr": "10.0.0.0/8"}}`+"\n"), &request))
+	assert.Equal(t, map[string]string{"app": "cart"}, request.A.Labels)
+
+	err := decodeStrictJSON([]byte(`{"workload_a": {"namespace": "shop", "lables": {"app": "cart"}}}`), &request)
+	assert.ErrorIs(t, err, errInvalidJSON)
+	assert.Equal(t, []ErrorDetail{{Field: "lables", Reason: `unknown field "lables"`}}, errorDetails(err))
+
+	assert.ErrorIs(t, decodeStrictJSON([]byte(`{"scope": "global"} {"scope": "namespace"}`), &request), errInvalidJSON)
+	assert.ErrorIs(t, decodeStrictJSON([]byte(`{"scope": `), &request), errInvalidJSON)
+}
+
+func TestDenyNetworkRequestValidateTTL(t *testing.T) {
+	request := DenyNetworkRequest{
+		A:   DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B:   DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}},
+		TTL: &metav1.Duration{Duration: 2 * time.Hour},
+	}
+	assert.NoError(t, request.validate())
+	named := denyPolicyName(request)
+	request.TTL = nil
+	assert.Equal(t, named, denyPolicyName(request), "the TTL doesn't name the policy")
+
+	request.TTL = &metav1.Duration{Duration: -time.Minute}
+	assert.EqualError(t, request.validate(), "invalid ttl -1m0s, it can't be negative")
+}
+
+func TestDenyNetworkRequestValidateLabels(t *testing.T) {
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart' || all() || app == 'x"}},
+	}
+	assert.ErrorContains(t, request.validate(), `workload_b: invalid label value "cart' || all() || app == 'x"`)
+
+	request.B.Labels = map[string]string{"app": "cart"}
+	request.A.Labels = map[string]string{"app && all()": "ledger"}
+	assert.ErrorContains(t, request.validate(), `workload_a: invalid label key "app && all()"`)
+}
+
+func TestWriteObject(t *testing.T) {
+	object := map[string]string{"kind": "NetworkPolicy"}
+
+	r := httptest.NewRequest(http.MethodPost, "/denyNetworkPolicy?dryRun=true", nil)
+	w := httptest.NewRecorder()
+	writeObject(w, r, object)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"kind":"NetworkPolicy"}`, w.Body.String())
+
+	r.Header.Set("Accept", "application/yaml")
+	w = httptest.NewRecorder()
+	writeObject(w, r, object)
+	assert.Equal(t, "application/yaml", w.Header().Get("Content-Type"))
+	assert.Equal(t, "kind: NetworkPolicy\n", w.Body.String())
+}
+
+func TestDenyNetworkRequestValidate(t *testing.T) {
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{CIDR: " 10.12.3.4/16"},
+	}
+	assert.NoError(t, request.validate())
+	assert.Equal(t, "10.12.0.0/16", request.B.CIDR)
+
+	request.B.CIDR = "10.12.0.0"
+	assert.EqualError(t, request.validate(), `invalid CIDR "10.12.0.0"`)
+
+	request.B = DenyNetworkRequestWorkload{Namespace: "shop", CIDR: "10.12.0.0/16"}
+	assert.Error(t, request.validate())
+
+	request.A, request.B = request.B, request.A
+	assert.EqualError(t, request.validate(), "workload_a can't be a CIDR, only workload_b can")
+}
+
+func TestDenyNetworkRequestValidateScope(t *testing.T) {
+	request := DenyNetworkRequest{
+		A:     DenyNetworkRequestWorkload{Labels: map[string]string{"app": "ledger"}},
+		B:     DenyNetworkRequestWorkload{Labels: map[string]string{"app": "cart"}},
+		Scope: policyScopeGlobal,
+	}
+	assert.NoError(t, request.validate())
+	assert.Equal(t, operationDenyGlobal, request.operation())
+
+	request.A.Namespace = "payments"
+	assert.Error(t, request.validate())
+
+	request.A = DenyNetworkRequestWorkload{}
+	assert.Error(t, request.validate())
+
+	request.Scope = "cluster"
+	assert.EqualError(t, request.validate(), `invalid scope "cluster", expected namespace or global`)
+
+	// Host endpoints aren't namespaced
+	request = DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Kind: workloadKindHostEndpoint, Labels: map[string]string{"role": "worker"}},
+		B: DenyNetworkRequestWorkload{CIDR: "169.254.169.254/32"},
+	}
+	assert.EqualError(t, request.validate(), "workload_a selecting host endpoints needs the global scope, host endpoints aren't namespaced")
+	request.Scope = policyScopeGlobal
+	assert.NoError(t, request.validate())
+}
+
+func TestCreateDenyNetworkPolicySymmetric(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+	)
+	backend := newFakeCiliumBackend()
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}},
+	}
+
+	name, created, err := createDenyNetworkPolicy(context.Background(), clientset, backend, NamespaceScope{}, request, RequestMetadata{})
+	if !assert.NoError(t, err) || !assert.True(t, created) {
+		return
+	}
+	mirrorName := denyPolicyName(DenyNetworkRequest{A: request.B, B: request.A})
+	policy, err := backend.Get(ctx, "payments", name)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "shop/"+mirrorName, policy.GetAnnotations()[mirrorAnnotation])
+	}
+	mirror, err := backend.Get(ctx, "shop", mirrorName)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "payments/"+name, mirror.GetAnnotations()[mirrorAnnotation])
+		assert.Equal(t, PolicySelectors{Selector: "app=cart", PeerSelector: "app=ledger", NamespaceSelector: ciliumNamespaceLabel + "=payments"}, backend.Selectors(mirror))
+	}
+
+	symmetric := false
+	request.Symmetric = &symmetric
+	request.B.Labels = map[string]string{"app": "checkout"}
+	_, _, err = createDenyNetworkPolicy(context.Background(), clientset, backend, NamespaceScope{}, request, RequestMetadata{})
+	assert.NoError(t, err)
+	policies, err := backend.List(ctx, "shop")
+	assert.NoError(t, err)
+	assert.Len(t, policies, 1)
+}
+
+func TestVerifyWorkloads(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "payments", Name: "ledger-1", Labels: map[string]string{"app": "ledger"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "cart-1", Labels: map[string]string{"app": "cart"}}},
+	)
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}},
+	}
+	assert.NoError(t, verifyWorkloads(ctx, clientset, request))
+
+	typo := request
+	typo.A.Labels = map[string]string{"app": "ledgr"}
+	typo.B = DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "crt"}}
+	err := verifyWorkloads(ctx, clientset, typo)
+	assert.ErrorIs(t, err, errWorkloadNotFound)
+	assert.ErrorContains(t, err, "workload_a payments/app == 'ledgr' matches no pod")
+	assert.ErrorContains(t, err, "workload_b shop/app == 'crt' matches no pod")
+
+	missing := request
+	missing.A.Namespace = "paymnets"
+	assert.EqualError(t, verifyWorkloads(ctx, clientset, missing), "workload not found: namespace paymnets of workload_a doesn't exist")
+
+	cidr := request
+	cidr.B = DenyNetworkRequestWorkload{CIDR: "10.12.0.0/16"}
+	assert.NoError(t, verifyWorkloads(ctx, clientset, cidr))
+}
+
+func TestDenyNetworkRequestValidateTier(t *testing.T) {
+	request := DenyNetworkRequest{
+		A:    DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B:    DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}},
+		Tier: calicoDefaultTier,
+	}
+	assert.NoError(t, request.validate())
+	assert.Empty(t, request.Tier)
+
+	request.Tier = "Security.Team"
+	assert.ErrorContains(t, request.validate(), `invalid tier "Security.Team"`)
+}
+
+func TestDeploymentsHealth(t *testing.T) {
+	replicas := int32(3)
+	created := time.Date(2024, 7, 8, 12, 0, 0, 0, time.UTC)
+	deployment := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "ledger", Namespace: "payments", CreationTimestamp: metav1.NewTime(created)},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			ReadyReplicas: 1, AvailableReplicas: 1, UpdatedReplicas: 2, UnavailableReplicas: 2,
+			Conditions: []appsv1.DeploymentCondition{{
+				Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded",
+				Message: `ReplicaSet "ledger-7d9f" has timed out progressing.`, LastTransitionTime: metav1.NewTime(created.Add(time.Hour)),
+			}},
+		},
+	}
+
+	rollingOut := *deployment.DeepCopy()
+	rollingOut.Name, rollingOut.Status.Conditions = "cart", nil
+
+	health := deploymentsHealth(context.Background(), []appsv1.Deployment{deployment, rollingOut}, nil, nil, labels.Everything())
+	assert.Empty(t, health.ReadyDeployments)
+	if assert.Len(t, health.FailedDeployments, 1) {
+		assert.Equal(t, "cart", health.FailedDeployments[0].Name)
+	}
+	// Its rollout exceeded its progress deadline
+	assert.Equal(t, []DeploymentInfo{{
+		Name: "ledger", Namespace: "payments", RequestedPods: 3, ReadyPods: 1,
+		AvailableReplicas: 1, UpdatedReplicas: 2, UnavailableReplicas: 2, CreatedAt: created, UpdatedAt: created,
+		Conditions: []DeploymentCondition{{
+			Type: "Progressing", Status: "False", Reason: "ProgressDeadlineExceeded",
+			Message: `ReplicaSet "ledger-7d9f" has timed out progressing.`, LastTransitionTime: created.Add(time.Hour),
+		}},
+	}}, health.StuckRollouts)
+}
+
+func TestClusterDeploymentsInfoUnsetReplicas(t *testing.T) {
+	server := Server{
+		K8sClientSet: fake.NewSimpleClientset(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "ledger", Namespace: "payments"},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+		}),
+		CalicoClientSet: calicofake.NewSimpleClientset(),
+	}
+	mux := newServerMux(server)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/clusterdeploymentsinfo?diagnose=false", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var info ClusterDeploymentsInfo
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &info))
+	// The API server defaults unset replicas to 1
+	if assert.Len(t, info.ReadyDeployments, 1) {
+		assert.Equal(t, int32(1), info.ReadyDeployments[0].RequestedPods)
+	}
+}
+
+func TestApplyGrace(t *testing.T) {
+	now := time.Date(2024, 7, 8, 12, 0, 0, 0, time.UTC)
+	replicas := int32(1)
+	deployment := func(name string, created, progressed time.Time) appsv1.Deployment {
+		return appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "payments", CreationTimestamp: metav1.NewTime(created)},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status: appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue, Reason: "ReplicaSetUpdated", LastUpdateTime: metav1.NewTime(progressed)},
+			}},
+		}
+	}
+	health := deploymentsHealth(context.Background(), []appsv1.Deployment{
+		deployment("new", now.Add(-time.Minute), now.Add(-time.Minute)),
+		deployment("rolling-out", now.Add(-48*time.Hour), now.Add(-2*time.Minute)),
+		deployment("broken", now.Add(-48*time.Hour), now.Add(-time.Hour)),
+	}, nil, nil, labels.Everything())
+	assert.Equal(t, now.Add(-2*time.Minute), health.FailedDeployments[1].UpdatedAt)
+
+	health.applyGrace(5*time.Minute, now)
+	assert.Len(t, health.FailedDeployments, 1)
+	assert.Equal(t, "broken", health.FailedDeployments[0].Name)
+	if assert.Len(t, health.Progressing, 2) {
+		assert.Equal(t, "new", health.Progressing[0].Name)
+		assert.Equal(t, "rolling-out", health.Progressing[1].Name)
+	}
+
+	health = deploymentsHealth(context.Background(), []appsv1.Deployment{deployment("new", now, now)}, nil, nil, labels.Everything())
+	health.applyGrace(0, now)
+	assert.Len(t, health.FailedDeployments, 1)
+	assert.Empty(t, health.Progressing)
+}
+
+func TestExcludeNamespaces(t *testing.T) {
+	info := &ClusterDeploymentsInfo{
+		ReadyDeployments:  []DeploymentInfo{{Name: "coredns", Namespace: "kube-system"}, {Name: "api", Namespace: "payments"}},
+		FailedDeployments: []DeploymentInfo{{Name: "prometheus", Namespace: "monitoring"}},
+		Progressing:       []DeploymentInfo{{Name: "ledger", Namespace: "payments"}, {Name: "metrics-server", Namespace: "kube-system"}},
+	}
+	info.excludeNamespaces([]string{"kube-system", "monitoring"})
+	assert.Equal(t, []DeploymentInfo{{Name: "api", Namespace: "payments"}}, info.ReadyDeployments)
+	assert.Empty(t, info.FailedDeployments)
+	assert.Equal(t, []DeploymentInfo{{Name: "ledger", Namespace: "payments"}}, info.Progressing)
+}
+
+func TestKubeContext(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/deploymentsinfo", nil)
+
+	ctx, cancel := (&Server{KubeRequestTimeout: time.Second}).kubeContext(request)
+	deadline, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+
+	// Without a timeout the calls only end with the request
+	requestCtx, cancelRequest := context.WithCancel(context.Background())
+	ctx, cancel = (&Server{}).kubeContext(request.WithContext(requestCtx))
+	defer cancel()
+	_, ok = ctx.Deadline()
+	assert.False(t, ok)
+	cancelRequest()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+
+	assert.Equal(t, http.StatusGatewayTimeout, kubeErrorStatus(fmt.Errorf("listing deployments: %w", context.DeadlineExceeded)))
+	assert.Equal(t, http.StatusGatewayTimeout, kubeErrorStatus(apierrors.NewTimeoutError("list", 5)))
+	assert.Equal(t, http.StatusInternalServerError, kubeErrorStatus(errors.New("connection refused")))
+}
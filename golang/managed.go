@@ -0,0 +1,102 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Labels and annotations identifying the objects created by this service.
+const (
+	managedByLabel        = "app.kubernetes.io/managed-by"
+	managedByValue        = "tyk-sre-assignment"
+	managedObjectSelector = managedByLabel + "=" + managedByValue
+
+	annotationPrefix        = "tyk-sre-assignment/"
+	actionAnnotation        = annotationPrefix + "action"
+	peerNamespaceAnnotation = annotationPrefix + "peer-namespace"
+	createdByAnnotation     = annotationPrefix + "created-by"
+	expiresAtAnnotation     = annotationPrefix + "expires-at"
+	specHashAnnotation      = annotationPrefix + "spec-hash"
+	requestAnnotation       = annotationPrefix + "request"
+	tierAnnotation          = annotationPrefix + "tier"
+	stagedAnnotation        = annotationPrefix + "staged"
+	mirrorAnnotation        = annotationPrefix + "mirror"
+
+	actionLabel      = annotationPrefix + "action"
+	requestIDLabel   = annotationPrefix + "request-id"
+	operationIDLabel = annotationPrefix + "operation-id"
+)
+
+// Values of the action annotation.
+const (
+	actionDeny       = "deny"
+	actionQuarantine = "quarantine"
+	actionSelftest   = "selftest"
+)
+
+const unknownPrincipal = "unknown"
+
+// managedObjectMeta returns the labels and annotations stamped on every object created by the service for a request.
+//
+// Objects can be selected by action, by the operation ID of the request and by its correlation ID, when it's a valid label value.
+// The request payload, when not nil, is kept as JSON so the object can be traced back to what was asked.
+func managedObjectMeta(action, peerNamespace string, meta RequestMetadata, request interface{}) (map[string]string, map[string]string) {
+	createdBy := meta.Principal
+	if createdBy == "" {
+		createdBy = unknownPrincipal
+	}
+
+	labels := map[string]string{
+		managedByLabel: managedByValue,
+		actionLabel:    action,
+	}
+	if meta.CorrelationID != "" && len(validation.IsValidLabelValue(meta.CorrelationID)) == 0 {
+		labels[requestIDLabel] = meta.CorrelationID
+	}
+	if meta.OperationID != "" {
+		labels[operationIDLabel] = meta.OperationID
+	}
+
+	annotations := map[string]string{
+		actionAnnotation:    action,
+		createdByAnnotation: createdBy,
+	}
+	if peerNamespace != "" {
+		annotations[peerNamespaceAnnotation] = peerNamespace
+	}
+	if request != nil {
+		if payload, err := json.Marshal(request); err == nil {
+			annotations[requestAnnotation] = string(payload)
+		}
+	}
+	meta.annotate(annotations)
+	return labels, annotations
+}
+
+// expiresAt returns the expiry recorded on a managed object, if any.
+func expiresAt(object metav1.Object) *time.Time {
+	value, ok := object.GetAnnotations()[expiresAtAnnotation]
+	if !ok {
+		return nil
+	}
+	expiry, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &expiry
+}
+
+// specHash fingerprints the spec the service created an object with, so later edits can be detected as drift.
+func specHash(spec interface{}) string {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManagedObjectMeta(t *testing.T) {
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}},
+	}
+	labels, annotations := managedObjectMeta(actionDeny, "shop", RequestMetadata{CorrelationID: "run-42", Principal: "alice"}, request)
+
+	assert.Equal(t, map[string]string{managedByLabel: managedByValue, actionLabel: actionDeny, requestIDLabel: "run-42"}, labels)
+	assert.Equal(t, map[string]string{
+		actionAnnotation:        actionDeny,
+		createdByAnnotation:     "alice",
+		peerNamespaceAnnotation: "shop",
+		correlationIDAnnotation: "run-42",
+		requestAnnotation:       `{"workload_a":{"namespace":"payments","labels":{"app":"ledger"}},"workload_b":{"namespace":"shop","labels":{"app":"cart"}}}`,
+	}, annotations)
+
+	// Correlation IDs aren't always valid label values, they're still annotated
+	labels, annotations = managedObjectMeta(actionSelftest, "", RequestMetadata{CorrelationID: "ci:run:42"}, nil)
+	assert.NotContains(t, labels, requestIDLabel)
+	assert.Equal(t, "ci:run:42", annotations[correlationIDAnnotation])
+	assert.Equal(t, unknownPrincipal, annotations[createdByAnnotation])
+	assert.NotContains(t, annotations, requestAnnotation)
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	metricsNamespace       = "tyk_sre"
+	managedPoliciesTimeout = 10 * time.Second
+	unmatchedRoute         = "unmatched"
+)
+
+var (
+	metricsRegistry = newMetricsRegistry()
+
+	httpRequestsTotal = promauto.With(metricsRegistry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests served, by route pattern, method and status code.",
+	}, []string{"route", "method", "code"})
+
+	httpRequestDuration = promauto.With(metricsRegistry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of the HTTP handlers, by route pattern and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	healthScanDeployments = promauto.With(metricsRegistry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "health_scan_deployments",
+		Help:      "Deployments found ready, failed, progressing within the grace period or with a stuck rollout by the last deployment health scan of the health scanner, by cluster.",
+	}, []string{"cluster", "state"})
+
+	healthScanTimestamp = promauto.With(metricsRegistry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "health_scan_last_timestamp_seconds",
+		Help:      "Unix time of the last successful deployment health scan of the health scanner, by cluster.",
+	}, []string{"cluster"})
+
+	healthScanErrors = promauto.With(metricsRegistry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "health_scan_errors_total",
+		Help:      "Deployment health scans of the health scanner that failed, by cluster.",
+	}, []string{"cluster"})
+)
+
+func newMetricsRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	return registry
+}
+
+// metricsHandler serves the registry, a failing collector only drops its own metrics.
+func metricsHandler() http.Handler {
+	return promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError})
+}
+
+// instrumentRequests counts, times and logs the requests served by mux, labelled with the pattern of the route they matched.
+func instrumentRequests(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, route := mux.Handler(r)
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+		mux.ServeHTTP(recorder, r)
+
+		duration := time.Since(start)
+		httpRequestDuration.WithLabelValues(route, r.Method).Observe(duration.Seconds())
+		httpRequestsTotal.WithLabelValues(route, r.Method, strconv.Itoa(recorder.status)).Inc()
+
+		// Probes and scrapes would drown the other requests
+		level := slog.LevelInfo
+		if route == "/healthz" || route == "GET /metrics" {
+			level = slog.LevelDebug
+		}
+		slog.LogAttrs(r.Context(), level, "Request served",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.String("route", route),
+			slog.Int("status", recorder.status),
+			slog.Duration("duration", duration),
+			slog.String("remote_addr", r.RemoteAddr),
+		)
+	})
+}
+
+// statusRecorder keeps the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to flush streamed responses.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Hijack hands the connection over to WebSocket handlers, which switch protocols.
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := http.NewResponseController(r.ResponseWriter).Hijack()
+	if err == nil && !r.wroteHeader {
+		r.status = http.StatusSwitchingProtocols
+		r.wroteHeader = true
+	}
+	return conn, rw, err
+}
+
+// recordHealthScan exports the outcome of a deployment health scan of the cluster.
+func recordHealthScan(cluster string, info *ClusterDeploymentsInfo, err error, now time.Time) {
+	if err != nil {
+		healthScanErrors.WithLabelValues(cluster).Inc()
+		return
+	}
+
+	healthScanDeployments.WithLabelValues(cluster, "ready").Set(float64(len(info.ReadyDeployments)))
+	healthScanDeployments.WithLabelValues(cluster, "failed").Set(float64(len(info.FailedDeployments)))
+	healthScanDeployments.WithLabelValues(cluster, "stuck").Set(float64(len(info.StuckRollouts)))
+	healthScanDeployments.WithLabelValues(cluster, "progressing").Set(float64(len(info.Progressing)))
+	healthScanTimestamp.WithLabelValues(cluster).Set(float64(now.Unix()))
+}
+
+// managedPoliciesCollector counts the policies of the backend created by the service on every scrape, by action and
+// whether they're staged.
+//
+// The policies of every namespace are listed at once, the global ones included, unless the service is scoped to some
+// namespaces, which are then listed one by one as namespaced RBAC requires.
+type managedPoliciesCollector struct {
+	clientset kubernetes.Interface
+	backend   PolicyBackend
+	scope     NamespaceScope
+	desc      *prometheus.Desc
+}
+
+func newManagedPoliciesCollector(clientset kubernetes.Interface, backend PolicyBackend, scope NamespaceScope) *managedPoliciesCollector {
+	return &managedPoliciesCollector{
+		clientset: clientset,
+		backend:   backend,
+		scope:     scope,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "managed_policies"),
+			"Policies created by the service, namespaced and global, by action (deny, quarantine) and whether they're staged.",
+			[]string{"action", "staged"}, nil,
+		),
+	}
+}
+
+func (c *managedPoliciesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *managedPoliciesCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), managedPoliciesTimeout)
+	defer cancel()
+
+	counts, err := c.count(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.desc, fmt.Errorf("listing managed policies: %w", err))
+		return
+	}
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(count), key.action, strconv.FormatBool(key.staged))
+	}
+}
+
+// managedPoliciesKey are the labels of a count of managed policies.
+type managedPoliciesKey struct {
+	action string
+	staged bool
+}
+
+func (c *managedPoliciesCollector) count(ctx context.Context) (map[managedPoliciesKey]int, error) {
+	namespaces, err := c.scope.resolve(ctx, c.clientset)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[managedPoliciesKey]int{{action: actionDeny}: 0, {action: actionQuarantine}: 0}
+	staging, canStage := stagingOf(c.backend)
+	if canStage {
+		counts[managedPoliciesKey{action: actionDeny, staged: true}] = 0
+	}
+	add := func(policies []PolicyObject, staged bool) {
+		for _, policy := range policies {
+			if action := policy.GetAnnotations()[actionAnnotation]; action != "" {
+				counts[managedPoliciesKey{action: action, staged: staged}]++
+			}
+		}
+	}
+
+	for _, ns := range namespaces {
+		policies, err := c.backend.List(ctx, ns)
+		if err != nil {
+			return nil, err
+		}
+		add(policies, false)
+
+		if canStage {
+			if policies, err = staging.listStaged(ctx, ns); err != nil {
+				return nil, err
+			}
+			add(policies, true)
+		}
+	}
+	return counts, nil
+}
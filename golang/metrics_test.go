@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	calicofake "github.com/projectcalico/api/pkg/client/clientset_generated/clientset/fake"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestInstrumentRequests(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/snapshots/{name}", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	})
+	mux.HandleFunc("/healthz", healthHandler)
+	handler := instrumentRequests(mux)
+	// Other tests serve unmatched routes too
+	unmatchedBefore := testutil.ToFloat64(httpRequestsTotal.WithLabelValues(unmatchedRoute, "GET", "404"))
+
+	for _, path := range []string{"/api/v1/snapshots/before", "/api/v1/snapshots/after", "/healthz", "/nope"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(httpRequestsTotal.WithLabelValues("GET /api/v1/snapshots/{name}", "GET", "404")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(httpRequestsTotal.WithLabelValues("/healthz", "GET", "200")))
+	assert.Equal(t, unmatchedBefore+1, testutil.ToFloat64(httpRequestsTotal.WithLabelValues(unmatchedRoute, "GET", "404")))
+}
+
+func TestRecordHealthScan(t *testing.T) {
+	now := time.Unix(1720440000, 0)
+	recordHealthScan("eu-west", &ClusterDeploymentsInfo{
+		ReadyDeployments:  []DeploymentInfo{{Name: "ledger"}, {Name: "cart"}},
+		FailedDeployments: []DeploymentInfo{{Name: "indexer"}},
+	}, nil, now)
+	recordHealthScan("us-east", &ClusterDeploymentsInfo{ReadyDeployments: []DeploymentInfo{{Name: "ledger"}}}, nil, now.Add(time.Minute))
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(healthScanDeployments.WithLabelValues("eu-west", "ready")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(healthScanDeployments.WithLabelValues("eu-west", "failed")))
+	assert.Equal(t, 1720440000.0, testutil.ToFloat64(healthScanTimestamp.WithLabelValues("eu-west")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(healthScanDeployments.WithLabelValues("us-east", "ready")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(healthScanDeployments.WithLabelValues("us-east", "failed")))
+
+	errorsBefore := testutil.ToFloat64(healthScanErrors.WithLabelValues("eu-west"))
+	recordHealthScan("eu-west", nil, errors.New("forbidden"), now.Add(time.Minute))
+	assert.Equal(t, errorsBefore+1, testutil.ToFloat64(healthScanErrors.WithLabelValues("eu-west")))
+	assert.Equal(t, 1720440000.0, testutil.ToFloat64(healthScanTimestamp.WithLabelValues("eu-west")))
+}
+
+func TestManagedPoliciesCollector(t *testing.T) {
+	managed := func(namespace, name, action string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        name,
+			Labels:      map[string]string{managedByLabel: managedByValue},
+			Annotations: map[string]string{actionAnnotation: action},
+		}
+	}
+	staged := &unstructured.Unstructured{}
+	staged.SetGroupVersionKind(v3.SchemeGroupVersion.WithKind("StagedNetworkPolicy"))
+	staged.SetNamespace("shop")
+	staged.SetName("deny-staged")
+	staged.SetLabels(map[string]string{managedByLabel: managedByValue})
+	staged.SetAnnotations(map[string]string{actionAnnotation: actionDeny})
+
+	backend := &calicoBackend{
+		clientset: calicofake.NewSimpleClientset(
+			&v3.NetworkPolicy{ObjectMeta: managed("payments", "deny-a", actionDeny)},
+			&v3.NetworkPolicy{ObjectMeta: managed("shop", "quarantine-shop", actionQuarantine)},
+			&v3.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "unmanaged"}},
+			&v3.GlobalNetworkPolicy{ObjectMeta: managed("", "deny-global", actionDeny)},
+		),
+		client: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			calicoStagedPolicyResource:       "StagedNetworkPolicyList",
+			calicoStagedGlobalPolicyResource: "StagedGlobalNetworkPolicyList",
+		}, staged),
+	}
+
+	// Global and staged policies are counted too
+	counts, err := newManagedPoliciesCollector(fake.NewSimpleClientset(), backend, NamespaceScope{}).count(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[managedPoliciesKey]int{
+		{action: actionDeny}:               2,
+		{action: actionQuarantine}:         1,
+		{action: actionDeny, staged: true}: 1,
+	}, counts)
+
+	counts, err = newManagedPoliciesCollector(fake.NewSimpleClientset(), backend, NamespaceScope{Names: []string{"payments"}}).count(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[managedPoliciesKey]int{{action: actionDeny}: 1, {action: actionQuarantine}: 0, {action: actionDeny, staged: true}: 0}, counts)
+
+	// So are the policies of other backends
+	cilium := newFakeCiliumBackend()
+	_, err = cilium.Create(context.Background(), cilium.Render(DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{CIDR: "10.12.0.0/16"},
+	}, nil, RequestMetadata{}), false)
+	assert.NoError(t, err)
+	counts, err = newManagedPoliciesCollector(fake.NewSimpleClientset(), cilium, NamespaceScope{}).count(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[managedPoliciesKey]int{{action: actionDeny}: 1, {action: actionQuarantine}: 0}, counts)
+}
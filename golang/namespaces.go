@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+)
+
+var errNamespaceOutOfScope = errors.New("namespace is outside the configured --namespaces scope")
+
+// NamespaceScope restricts every subsystem of the service to a subset of namespaces.
+//
+// The zero value covers the whole cluster. Either Names or Selector is set, never both.
+type NamespaceScope struct {
+	Names    []string
+	Selector labels.Selector
+}
+
+// parseNamespaceScope builds a NamespaceScope from the value of the --namespaces flag.
+//
+// The value is either a comma-separated list of namespace names or a namespace label selector.
+func parseNamespaceScope(value string) (NamespaceScope, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return NamespaceScope{}, nil
+	}
+
+	if names, ok := splitNamespaceNames(value); ok {
+		return NamespaceScope{Names: names}, nil
+	}
+
+	selector, err := labels.Parse(value)
+	if err != nil {
+		return NamespaceScope{}, fmt.Errorf("invalid --namespaces value %q: %w", value, err)
+	}
+	return NamespaceScope{Selector: selector}, nil
+}
+
+// parseExcludedNamespaces returns the namespaces of a comma-separated list, each of which must be a valid name.
+func parseExcludedNamespaces(value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	names, ok := splitNamespaceNames(value)
+	if !ok {
+		return nil, fmt.Errorf("invalid namespace list %q", value)
+	}
+	return names, nil
+}
+
+// splitNamespaceNames returns the names of a comma-separated list, or false if any item isn't a valid namespace name
+func splitNamespaceNames(value string) ([]string, bool) {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if len(validation.IsDNS1123Label(name)) > 0 {
+			return nil, false
+		}
+		names = append(names, name)
+	}
+	return names, len(names) > 0
+}
+
+// IsScoped reports whether the service is restricted to a subset of namespaces.
+func (s NamespaceScope) IsScoped() bool {
+	return len(s.Names) > 0 || s.Selector != nil
+}
+
+// String renders the scope the way it was passed on the command line.
+func (s NamespaceScope) String() string {
+	switch {
+	case len(s.Names) > 0:
+		return strings.Join(s.Names, ",")
+	case s.Selector != nil:
+		return s.Selector.String()
+	default:
+		return "<all namespaces>"
+	}
+}
+
+// resolve returns the namespaces to query, or a single metav1.NamespaceAll when the scope covers the whole cluster.
+//
+// Only a selector scope needs to list namespaces, an explicit list works with namespaced Role RBAC.
+func (s NamespaceScope) resolve(ctx context.Context, clientset kubernetes.Interface) ([]string, error) {
+	if len(s.Names) > 0 {
+		return s.Names, nil
+	}
+	if s.Selector == nil {
+		return []string{metav1.NamespaceAll}, nil
+	}
+
+	namespaces, err := clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{LabelSelector: s.Selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(namespaces.Items))
+	for _, namespace := range namespaces.Items {
+		names = append(names, namespace.Name)
+	}
+	return names, nil
+}
+
+// check returns errNamespaceOutOfScope if namespace isn't covered by the scope.
+func (s NamespaceScope) check(ctx context.Context, clientset kubernetes.Interface, namespace string) error {
+	if len(s.Names) > 0 {
+		for _, name := range s.Names {
+			if name == namespace {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: %s", errNamespaceOutOfScope, namespace)
+	}
+	if s.Selector == nil {
+		return nil
+	}
+
+	ns, err := clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if !s.Selector.Matches(labels.Set(ns.Labels)) {
+		return fmt.Errorf("%w: %s", errNamespaceOutOfScope, namespace)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseNamespaceScope(t *testing.T) {
+	all, err := parseNamespaceScope("")
+	assert.NoError(t, err)
+	assert.False(t, all.IsScoped())
+
+	list, err := parseNamespaceScope("team-a, team-b")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"team-a", "team-b"}, list.Names)
+	assert.Nil(t, list.Selector)
+
+	selector, err := parseNamespaceScope("tenant=payments")
+	assert.NoError(t, err)
+	assert.Empty(t, selector.Names)
+	assert.Equal(t, "tenant=payments", selector.Selector.String())
+
+	_, err = parseNamespaceScope("tenant in (payments")
+	assert.Error(t, err)
+}
+
+func TestNamespaceScopeCheck(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Labels: map[string]string{"tenant": "payments"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "search"}},
+	)
+
+	list, _ := parseNamespaceScope("payments")
+	assert.NoError(t, list.check(context.TODO(), clientset, "payments"))
+	assert.ErrorIs(t, list.check(context.TODO(), clientset, "search"), errNamespaceOutOfScope)
+
+	selector, _ := parseNamespaceScope("tenant=payments")
+	assert.NoError(t, selector.check(context.TODO(), clientset, "payments"))
+	assert.ErrorIs(t, selector.check(context.TODO(), clientset, "search"), errNamespaceOutOfScope)
+}
+
+func TestReportedDeploymentsHealthScoped(t *testing.T) {
+	replicas := int32(1)
+	clientset := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "payments"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "indexer", Namespace: "search"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		},
+	)
+
+	scope, _ := parseNamespaceScope("payments")
+	info, err := Server{K8sClientSet: clientset, Namespaces: scope}.reportedDeploymentsHealth(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, info.ReadyDeployments, 1)
+	assert.Empty(t, info.FailedDeployments)
+
+	info, err = Server{K8sClientSet: clientset, ExcludedNamespaces: []string{"payments"}}.reportedDeploymentsHealth(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, info.ReadyDeployments)
+	assert.Len(t, info.FailedDeployments, 1)
+
+	info, err = Server{K8sClientSet: clientset}.reportedDeploymentsHealth(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, info.ReadyDeployments, 1)
+	assert.Len(t, info.FailedDeployments, 1)
+}
+
+func TestParseExcludedNamespaces(t *testing.T) {
+	names, err := parseExcludedNamespaces(" kube-system, monitoring ,")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"kube-system", "monitoring"}, names)
+
+	names, err = parseExcludedNamespaces("")
+	assert.NoError(t, err)
+	assert.Empty(t, names)
+
+	_, err = parseExcludedNamespaces("kube-system,Monitoring")
+	assert.Error(t, err)
+}
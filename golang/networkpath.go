@@ -0,0 +1,524 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"github.com/projectcalico/api/pkg/lib/numorstring"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// nativePolicyOrder is the order Calico evaluates Kubernetes NetworkPolicies at, in the default tier.
+const nativePolicyOrder = 1000
+
+// Directions of the traffic a policy applies to.
+const (
+	directionIngress = "ingress"
+	directionEgress  = "egress"
+)
+
+// NetworkPathRequest asks whether Source can open a connection to Port of Destination. Either end may be a CIDR, the
+// traffic coming from or going out of the cluster, but not both.
+type NetworkPathRequest struct {
+	Source      DenyNetworkRequestWorkload `json:"source"`
+	Destination DenyNetworkRequestWorkload `json:"destination"`
+	Port        int32                      `json:"port"`
+	// Protocol is TCP, UDP or SCTP, TCP by default
+	Protocol string `json:"protocol,omitempty"`
+}
+
+// protocol returns the protocol of the request in upper case, TCP when unset.
+func (r NetworkPathRequest) protocol() string {
+	if r.Protocol == "" {
+		return string(corev1.ProtocolTCP)
+	}
+	return strings.ToUpper(r.Protocol)
+}
+
+func (r *NetworkPathRequest) validate() error {
+	if r.Port < 1 || r.Port > 65535 {
+		return fmt.Errorf("invalid port %d, expected 1 to 65535", r.Port)
+	}
+	switch corev1.Protocol(r.protocol()) {
+	case corev1.ProtocolTCP, corev1.ProtocolUDP, corev1.ProtocolSCTP:
+	default:
+		return fmt.Errorf("invalid protocol %q, expected TCP, UDP or SCTP", r.Protocol)
+	}
+	if r.Source.CIDR != "" && r.Destination.CIDR != "" {
+		return errors.New("the source and destination are both CIDRs, no policy of the cluster applies between them")
+	}
+	for _, end := range []struct {
+		name     string
+		workload *DenyNetworkRequestWorkload
+	}{{"source", &r.Source}, {"destination", &r.Destination}} {
+		if err := normalizePeer(end.workload); err != nil {
+			return err
+		}
+		if end.workload.CIDR != "" {
+			continue
+		}
+		if end.workload.Namespace == "" {
+			return fmt.Errorf("the %s needs a namespace or a CIDR", end.name)
+		}
+		if err := validateWorkloadSelector(*end.workload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PathPolicy is a policy selecting an end of the path, with the action of its first rule matching the traffic.
+type PathPolicy struct {
+	// APIVersion tells Kubernetes policies, networking.k8s.io/v1, from Calico ones
+	APIVersion string   `json:"api_version"`
+	Kind       string   `json:"kind"`
+	Namespace  string   `json:"namespace,omitempty"`
+	Name       string   `json:"name"`
+	Order      *float64 `json:"order,omitempty"`
+	// Rule is the index of the matching rule among the ingress or egress ones, unset when none matches
+	Rule   *int   `json:"rule,omitempty"`
+	Action string `json:"action,omitempty"`
+}
+
+// PathVerdict is the outcome of the policies of an end of the path, the egress ones of the source or the ingress ones
+// of the destination.
+type PathVerdict struct {
+	Direction string `json:"direction"`
+	Allowed   bool   `json:"allowed"`
+	// Policy is the one which decided, unset when no policy selects the endpoint or none of them matches the traffic
+	Policy *PathPolicy `json:"policy,omitempty"`
+	// Policies are those selecting the endpoint, in the order they're evaluated
+	Policies []PathPolicy `json:"policies"`
+	Reason   string       `json:"reason"`
+}
+
+// NetworkPathResult tells whether the traffic of a NetworkPathRequest is allowed. Egress is unset for a CIDR source and
+// Ingress for a CIDR destination, the policies of the cluster not applying outside of it.
+type NetworkPathResult struct {
+	Allowed bool         `json:"allowed"`
+	Egress  *PathVerdict `json:"egress,omitempty"`
+	Ingress *PathVerdict `json:"ingress,omitempty"`
+}
+
+// pathEndpoint is an end of the path and its namespace, nil for a CIDR.
+type pathEndpoint struct {
+	workload  DenyNetworkRequestWorkload
+	namespace *corev1.Namespace
+}
+
+// Simulates whether the source of the request can connect to the port of its destination, evaluating the Kubernetes
+// and Calico policies selecting them, without sending any traffic
+//
+// Workloads are given like those of deny requests, by alias, reference, labels or CIDR.
+func (s *Server) simulateNetworkPathHandler(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		readBodyError(w, err)
+		return
+	}
+
+	defer r.Body.Close()
+
+	var request NetworkPathRequest
+	if err := decodeStrictJSON(body, &request); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	for _, workload := range []*DenyNetworkRequestWorkload{&request.Source, &request.Destination} {
+		if *workload, err = s.Aliases.resolve(*workload); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		if workload.Namespace != "" {
+			if err := s.Namespaces.check(r.Context(), s.K8sClientSet, workload.Namespace); err != nil {
+				writeError(w, http.StatusForbidden, err)
+				return
+			}
+		}
+		if *workload, err = resolveReference(r.Context(), s.K8sClientSet, *workload); err != nil {
+			writeError(w, referenceErrorStatus(err), err)
+			return
+		}
+	}
+	if err := request.validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := s.simulateNetworkPath(r.Context(), request)
+	if apierrors.IsNotFound(err) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// simulateNetworkPath lists the policies of the namespaces of both ends of the request, and the global ones, to
+// evaluate them. Clusters without Calico only have Kubernetes policies.
+func (s *Server) simulateNetworkPath(ctx context.Context, request NetworkPathRequest) (*NetworkPathResult, error) {
+	var global []v3.GlobalNetworkPolicy
+	globalList, err := s.CalicoClientSet.ProjectcalicoV3().GlobalNetworkPolicies().List(ctx, metav1.ListOptions{})
+	calicoInstalled := !apierrors.IsNotFound(err)
+	if err != nil && calicoInstalled {
+		return nil, err
+	}
+	if calicoInstalled {
+		global = globalList.Items
+	}
+
+	policies := coveragePolicies{global: global}
+	ends := []pathEndpoint{{workload: request.Source}, {workload: request.Destination}}
+	for i := range ends {
+		name := ends[i].workload.Namespace
+		if name == "" {
+			continue
+		}
+		if ends[i].namespace, err = s.K8sClientSet.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{}); err != nil {
+			return nil, err
+		}
+		if i == 1 && name == ends[0].workload.Namespace {
+			continue
+		}
+		native, err := s.K8sClientSet.NetworkingV1().NetworkPolicies(name).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		policies.native = append(policies.native, native.Items...)
+		if calicoInstalled {
+			calico, err := s.CalicoClientSet.ProjectcalicoV3().NetworkPolicies(name).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			policies.calico = append(policies.calico, calico.Items...)
+		}
+	}
+
+	result := evaluateNetworkPath(request, ends[0], ends[1], policies)
+	return &result, nil
+}
+
+// evaluateNetworkPath evaluates the egress policies of the source and the ingress ones of the destination: the traffic
+// is allowed when both allow it.
+func evaluateNetworkPath(request NetworkPathRequest, source, destination pathEndpoint, policies coveragePolicies) NetworkPathResult {
+	result := NetworkPathResult{Allowed: true}
+	if source.namespace != nil {
+		verdict := pathVerdict(directionEgress, request, source, destination, policies)
+		result.Egress = &verdict
+		result.Allowed = verdict.Allowed
+	}
+	if destination.namespace != nil {
+		verdict := pathVerdict(directionIngress, request, destination, source, policies)
+		result.Ingress = &verdict
+		result.Allowed = result.Allowed && verdict.Allowed
+	}
+	return result
+}
+
+// pathVerdict evaluates the policies selecting endpoint in direction like Calico does, by order then name, Kubernetes
+// policies being ordered 1000: the first rule matching the traffic with peer which allows, denies or passes it decides.
+// When policies select the endpoint but none matches, the traffic is denied, and allowed when none selects it.
+//
+// Tiers aren't read, the policies are evaluated as those of a single tier, a pass allowing the traffic like the default
+// profile of Kubernetes endpoints does. Named ports aren't resolved and never match.
+func pathVerdict(direction string, request NetworkPathRequest, endpoint, peer pathEndpoint, policies coveragePolicies) PathVerdict {
+	namespace := endpoint.workload.Namespace
+	endpointLabels := calicoEndpointLabels(endpoint.workload)
+	namespaceLabels := map[string]string{"projectcalico.org/name": namespace}
+	for key, value := range endpoint.namespace.Labels {
+		namespaceLabels[key] = value
+	}
+
+	var selecting []PathPolicy
+	for _, policy := range policies.native {
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+		if policy.Namespace != namespace || err != nil || !selector.Matches(labels.Set(endpointLabels)) || !nativePolicyApplies(policy, direction) {
+			continue
+		}
+		order := float64(nativePolicyOrder)
+		current := PathPolicy{APIVersion: networkingv1.SchemeGroupVersion.String(), Kind: "NetworkPolicy", Namespace: policy.Namespace, Name: policy.Name, Order: &order}
+		if rule, ok := nativeRuleMatching(policy, direction, request, peer); ok {
+			current.Rule, current.Action = &rule, string(v3.Allow)
+		}
+		selecting = append(selecting, current)
+	}
+	for _, policy := range policies.calico {
+		selector, err := parseCalicoSelector(policy.Spec.Selector)
+		if policy.Namespace != namespace || err != nil || !selector.Matches(endpointLabels) || !calicoPolicyApplies(policy.Spec.Types, policy.Spec.Egress, direction) {
+			continue
+		}
+		current := PathPolicy{APIVersion: calicoPolicyTypeMeta.APIVersion, Kind: calicoPolicyTypeMeta.Kind, Namespace: policy.Namespace, Name: policy.Name, Order: policy.Spec.Order}
+		calicoRuleMatching(&current, direction, policy.Spec.Ingress, policy.Spec.Egress, namespace, request, endpoint, peer)
+		selecting = append(selecting, current)
+	}
+	for _, policy := range policies.global {
+		// Untracked and pre-DNAT policies only apply to host endpoints
+		if policy.Spec.DoNotTrack || policy.Spec.PreDNAT {
+			continue
+		}
+		namespaceSelector, err := parseCalicoSelector(policy.Spec.NamespaceSelector)
+		if err != nil || !namespaceSelector.Matches(namespaceLabels) {
+			continue
+		}
+		selector, err := parseCalicoSelector(policy.Spec.Selector)
+		if err != nil || !selector.Matches(endpointLabels) || !calicoPolicyApplies(policy.Spec.Types, policy.Spec.Egress, direction) {
+			continue
+		}
+		current := PathPolicy{APIVersion: calicoGlobalPolicyTypeMeta.APIVersion, Kind: calicoGlobalPolicyTypeMeta.Kind, Name: policy.Name, Order: policy.Spec.Order}
+		calicoRuleMatching(&current, direction, policy.Spec.Ingress, policy.Spec.Egress, "", request, endpoint, peer)
+		selecting = append(selecting, current)
+	}
+	sort.SliceStable(selecting, func(i, j int) bool {
+		a, b := selecting[i].Order, selecting[j].Order
+		switch {
+		case a != nil && b != nil && *a != *b:
+			return *a < *b
+		case (a == nil) != (b == nil):
+			return a != nil
+		}
+		return selecting[i].Name < selecting[j].Name
+	})
+
+	verdict := PathVerdict{Direction: direction, Policies: selecting}
+	if verdict.Policies == nil {
+		verdict.Policies = []PathPolicy{}
+	}
+	for i, policy := range selecting {
+		if policy.Action == "" {
+			continue
+		}
+		verdict.Policy = &selecting[i]
+		verdict.Allowed = policy.Action != string(v3.Deny)
+		verdict.Reason = fmt.Sprintf("rule %d of %s %s matches the traffic: %s", *policy.Rule, policy.Kind, policy.Name, strings.ToLower(policy.Action))
+		return verdict
+	}
+	if len(selecting) == 0 {
+		verdict.Allowed = true
+		verdict.Reason = fmt.Sprintf("no policy selects the %s endpoint, its %s traffic is allowed", endpoint.workload.describe(), direction)
+		return verdict
+	}
+	verdict.Reason = fmt.Sprintf("none of the %d policies selecting %s matches the traffic, denied by default", len(selecting), endpoint.workload.describe())
+	return verdict
+}
+
+// nativePolicyApplies tells whether a Kubernetes policy applies to direction: its policy types, or ingress plus egress
+// when it has egress rules.
+func nativePolicyApplies(policy networkingv1.NetworkPolicy, direction string) bool {
+	if len(policy.Spec.PolicyTypes) == 0 {
+		return direction == directionIngress || len(policy.Spec.Egress) > 0
+	}
+	policyType := networkingv1.PolicyTypeIngress
+	if direction == directionEgress {
+		policyType = networkingv1.PolicyTypeEgress
+	}
+	return slices.Contains(policy.Spec.PolicyTypes, policyType)
+}
+
+// calicoPolicyApplies is nativePolicyApplies for the types of a Calico policy.
+func calicoPolicyApplies(types []v3.PolicyType, egress []v3.Rule, direction string) bool {
+	if len(types) == 0 {
+		return direction == directionIngress || len(egress) > 0
+	}
+	policyType := v3.PolicyTypeIngress
+	if direction == directionEgress {
+		policyType = v3.PolicyTypeEgress
+	}
+	return slices.Contains(types, policyType)
+}
+
+// nativeRuleMatching returns the index of the first rule of a Kubernetes policy allowing the traffic with peer in
+// direction.
+func nativeRuleMatching(policy networkingv1.NetworkPolicy, direction string, request NetworkPathRequest, peer pathEndpoint) (int, bool) {
+	type rule struct {
+		ports []networkingv1.NetworkPolicyPort
+		peers []networkingv1.NetworkPolicyPeer
+	}
+	var rules []rule
+	if direction == directionIngress {
+		for _, ingress := range policy.Spec.Ingress {
+			rules = append(rules, rule{ingress.Ports, ingress.From})
+		}
+	} else {
+		for _, egress := range policy.Spec.Egress {
+			rules = append(rules, rule{egress.Ports, egress.To})
+		}
+	}
+
+	for i, rule := range rules {
+		if !nativePortsMatch(rule.ports, corev1.Protocol(request.protocol()), request.Port) {
+			continue
+		}
+		if len(rule.peers) == 0 || slices.ContainsFunc(rule.peers, func(policyPeer networkingv1.NetworkPolicyPeer) bool {
+			return nativePeerMatches(policyPeer, policy.Namespace, peer)
+		}) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// nativePortsMatch tells whether the ports of a rule of a Kubernetes policy, any when there are none, include port.
+func nativePortsMatch(ports []networkingv1.NetworkPolicyPort, protocol corev1.Protocol, port int32) bool {
+	if len(ports) == 0 {
+		return true
+	}
+	for _, policyPort := range ports {
+		if policyPort.Protocol != nil && *policyPort.Protocol != protocol || policyPort.Protocol == nil && protocol != corev1.ProtocolTCP {
+			continue
+		}
+		if policyPort.Port == nil {
+			return true
+		}
+		if policyPort.Port.Type == intstr.String {
+			continue
+		}
+		if policyPort.Port.IntVal == port || policyPort.EndPort != nil && port >= policyPort.Port.IntVal && port <= *policyPort.EndPort {
+			return true
+		}
+	}
+	return false
+}
+
+// nativePeerMatches tells whether a peer of a rule of a Kubernetes policy of namespace matches the end of the path.
+//
+// IP blocks only match a CIDR end, entirely within the block and outside its exceptions, and selectors only workloads.
+func nativePeerMatches(policyPeer networkingv1.NetworkPolicyPeer, namespace string, end pathEndpoint) bool {
+	if policyPeer.IPBlock != nil {
+		if end.workload.CIDR == "" || !cidrWithin(end.workload.CIDR, policyPeer.IPBlock.CIDR) {
+			return false
+		}
+		for _, except := range policyPeer.IPBlock.Except {
+			if cidrsOverlap(end.workload.CIDR, except) {
+				return false
+			}
+		}
+		return true
+	}
+	if end.namespace == nil {
+		return false
+	}
+
+	if policyPeer.NamespaceSelector == nil {
+		if end.namespace.Name != namespace {
+			return false
+		}
+	} else {
+		namespaceLabels := labels.Set{corev1.LabelMetadataName: end.namespace.Name}
+		for key, value := range end.namespace.Labels {
+			namespaceLabels[key] = value
+		}
+		selector, err := metav1.LabelSelectorAsSelector(policyPeer.NamespaceSelector)
+		if err != nil || !selector.Matches(namespaceLabels) {
+			return false
+		}
+	}
+	if policyPeer.PodSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(policyPeer.PodSelector)
+		if err != nil || !selector.Matches(labels.Set(calicoEndpointLabels(end.workload))) {
+			return false
+		}
+	}
+	return true
+}
+
+// cidrWithin tells whether every address of cidr is in block.
+func cidrWithin(cidr, block string) bool {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	_, blockNetwork, err := net.ParseCIDR(block)
+	if err != nil {
+		return false
+	}
+	size, _ := network.Mask.Size()
+	blockSize, _ := blockNetwork.Mask.Size()
+	return blockNetwork.Contains(network.IP) && size >= blockSize
+}
+
+// cidrsOverlap tells whether two ranges share addresses, one then containing the other.
+func cidrsOverlap(a, b string) bool {
+	return cidrWithin(a, b) || cidrWithin(b, a)
+}
+
+// calicoRuleMatching sets the index and action of the first rule of a Calico policy of namespace, empty for a global
+// one, matching the traffic with peer in direction. Log rules are skipped: they don't end the evaluation.
+//
+// Only the destination port is known, so the rules restricting the source ports don't match.
+func calicoRuleMatching(policy *PathPolicy, direction string, ingress, egress []v3.Rule, namespace string, request NetworkPathRequest, endpoint, peer pathEndpoint) {
+	rules := ingress
+	if direction == directionEgress {
+		rules = egress
+	}
+	protocol := numorstring.ProtocolFromString(request.protocol())
+	for i, rule := range rules {
+		if rule.Action == v3.Log {
+			continue
+		}
+		if rule.Protocol != nil && !sameProtocol(*rule.Protocol, protocol) || rule.NotProtocol != nil && sameProtocol(*rule.NotProtocol, protocol) {
+			continue
+		}
+		if len(rule.Source.Ports) > 0 || len(rule.Source.NotPorts) > 0 || !calicoPortsMatch(rule.Destination, request.Port) {
+			continue
+		}
+		local, remote := rule.Destination, rule.Source
+		if direction == directionEgress {
+			local, remote = rule.Source, rule.Destination
+		}
+		if !calicoEntityMatches(remote, namespace, peer.workload, peer.namespace) || !calicoEntityMatches(local, namespace, endpoint.workload, endpoint.namespace) {
+			continue
+		}
+		index := i
+		policy.Rule, policy.Action = &index, string(rule.Action)
+		return
+	}
+}
+
+// sameProtocol compares protocols given by name or number.
+func sameProtocol(a, b numorstring.Protocol) bool {
+	return strings.EqualFold(protocolName(a), protocolName(b))
+}
+
+// protocolName returns the name of the protocols with ports given by number, the protocol as is otherwise.
+func protocolName(protocol numorstring.Protocol) string {
+	number, err := protocol.NumValue()
+	if err != nil {
+		return protocol.String()
+	}
+	switch number {
+	case 6:
+		return numorstring.ProtocolTCP
+	case 17:
+		return numorstring.ProtocolUDP
+	case 132:
+		return numorstring.ProtocolSCTP
+	}
+	return protocol.String()
+}
+
+// calicoPortsMatch tells whether port is in the ports of an entity of a Calico rule, any when there are none, and not
+// in its excluded ones.
+func calicoPortsMatch(entity v3.EntityRule, port int32) bool {
+	contains := func(ports []numorstring.Port) bool {
+		return slices.ContainsFunc(ports, func(p numorstring.Port) bool {
+			return p.PortName == "" && port >= int32(p.MinPort) && port <= int32(p.MaxPort)
+		})
+	}
+	return (len(entity.Ports) == 0 || contains(entity.Ports)) && !contains(entity.NotPorts)
+}
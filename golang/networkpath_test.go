@@ -0,0 +1,145 @@
+package main
+
+import (
+	"testing"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"github.com/projectcalico/api/pkg/lib/numorstring"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestEvaluateNetworkPath(t *testing.T) {
+	shop := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop", Labels: map[string]string{"team": "shop"}}}
+	payments := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Labels: map[string]string{"team": "payments"}}}
+	frontend := pathEndpoint{DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "frontend"}}, shop}
+	ledger := pathEndpoint{DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}}, payments}
+	request := NetworkPathRequest{Port: 8080}
+
+	tcp := corev1.ProtocolTCP
+	port := intstr.FromInt(8080)
+	// The ledger only accepts the frontend of the shop on 8080
+	allowFrontend := networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "ledger-ingress", Namespace: "payments"},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "ledger"}},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &port}},
+				From: []networkingv1.NetworkPolicyPeer{{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "shop"}},
+					PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "frontend"}},
+				}},
+			}},
+		},
+	}
+
+	// No policy selects either end
+	result := evaluateNetworkPath(request, frontend, ledger, coveragePolicies{})
+	assert.True(t, result.Allowed)
+	assert.Empty(t, result.Egress.Policies)
+	assert.Nil(t, result.Ingress.Policy)
+
+	result = evaluateNetworkPath(request, frontend, ledger, coveragePolicies{native: []networkingv1.NetworkPolicy{allowFrontend}})
+	assert.True(t, result.Allowed)
+	if assert.NotNil(t, result.Ingress.Policy) {
+		assert.Equal(t, "ledger-ingress", result.Ingress.Policy.Name)
+		assert.Equal(t, "networking.k8s.io/v1", result.Ingress.Policy.APIVersion)
+		assert.Equal(t, 0, *result.Ingress.Policy.Rule)
+	}
+
+	// Another port isn't allowed, the ledger being isolated by the policy
+	result = evaluateNetworkPath(NetworkPathRequest{Port: 9090}, frontend, ledger, coveragePolicies{native: []networkingv1.NetworkPolicy{allowFrontend}})
+	assert.False(t, result.Allowed)
+	assert.True(t, result.Egress.Allowed)
+	assert.False(t, result.Ingress.Allowed)
+	assert.Nil(t, result.Ingress.Policy)
+	assert.Len(t, result.Ingress.Policies, 1)
+
+	// A Calico deny ordered before the Kubernetes policies wins
+	order := 100.0
+	protocol := numorstring.ProtocolFromString("TCP")
+	denyShop := v3.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "deny-shop", Namespace: "payments"},
+		Spec: v3.NetworkPolicySpec{
+			Order:    &order,
+			Selector: "app == 'ledger'",
+			Ingress: []v3.Rule{
+				{Action: v3.Log},
+				{Action: v3.Deny, Protocol: &protocol, Source: v3.EntityRule{NamespaceSelector: "team == 'shop'"}},
+			},
+		},
+	}
+	policies := coveragePolicies{native: []networkingv1.NetworkPolicy{allowFrontend}, calico: []v3.NetworkPolicy{denyShop}}
+	result = evaluateNetworkPath(request, frontend, ledger, policies)
+	assert.False(t, result.Allowed)
+	if assert.NotNil(t, result.Ingress.Policy) {
+		assert.Equal(t, "deny-shop", result.Ingress.Policy.Name)
+		assert.Equal(t, string(v3.Deny), result.Ingress.Policy.Action)
+		assert.Equal(t, 1, *result.Ingress.Policy.Rule)
+	}
+	assert.Equal(t, []string{"deny-shop", "ledger-ingress"}, []string{result.Ingress.Policies[0].Name, result.Ingress.Policies[1].Name})
+
+	// A global egress policy of the shop only allowing DNS
+	global := v3.GlobalNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop-egress"},
+		Spec: v3.GlobalNetworkPolicySpec{
+			NamespaceSelector: "team == 'shop'",
+			Types:             []v3.PolicyType{v3.PolicyTypeEgress},
+			Egress:            []v3.Rule{{Action: v3.Allow, Destination: v3.EntityRule{Ports: []numorstring.Port{numorstring.SinglePort(53)}}}},
+		},
+	}
+	result = evaluateNetworkPath(request, frontend, ledger, coveragePolicies{global: []v3.GlobalNetworkPolicy{global}})
+	assert.False(t, result.Allowed)
+	assert.False(t, result.Egress.Allowed)
+	assert.Equal(t, "shop-egress", result.Egress.Policies[0].Name)
+	assert.True(t, result.Ingress.Allowed)
+
+	// Traffic from outside the cluster only has the ingress policies of the destination
+	external := pathEndpoint{workload: DenyNetworkRequestWorkload{CIDR: "203.0.113.0/24"}}
+	allowExternal := networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-external", Namespace: "payments"},
+		Spec: networkingv1.NetworkPolicySpec{
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "203.0.0.0/16", Except: []string{"203.0.113.128/25"}}}},
+			}},
+		},
+	}
+	result = evaluateNetworkPath(request, external, ledger, coveragePolicies{native: []networkingv1.NetworkPolicy{allowExternal}})
+	assert.Nil(t, result.Egress)
+	assert.False(t, result.Allowed, "part of the source is excepted")
+	external.workload.CIDR = "203.0.113.0/25"
+	result = evaluateNetworkPath(request, external, ledger, coveragePolicies{native: []networkingv1.NetworkPolicy{allowExternal}})
+	assert.True(t, result.Allowed)
+}
+
+func TestNetworkPathRequestValidate(t *testing.T) {
+	ledger := DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}}
+	for name, test := range map[string]struct {
+		request NetworkPathRequest
+		valid   bool
+	}{
+		"workloads":       {NetworkPathRequest{Source: ledger, Destination: ledger, Port: 80}, true},
+		"udp":             {NetworkPathRequest{Source: ledger, Destination: ledger, Port: 53, Protocol: "udp"}, true},
+		"external source": {NetworkPathRequest{Source: DenyNetworkRequestWorkload{CIDR: "10.0.0.1/8"}, Destination: ledger, Port: 443}, true},
+		"no port":         {NetworkPathRequest{Source: ledger, Destination: ledger}, false},
+		"icmp":            {NetworkPathRequest{Source: ledger, Destination: ledger, Port: 80, Protocol: "ICMP"}, false},
+		"no namespace":    {NetworkPathRequest{Source: DenyNetworkRequestWorkload{Labels: map[string]string{"app": "api"}}, Destination: ledger, Port: 80}, false},
+		"both CIDRs": {NetworkPathRequest{
+			Source:      DenyNetworkRequestWorkload{CIDR: "10.0.0.0/8"},
+			Destination: DenyNetworkRequestWorkload{CIDR: "192.168.0.0/16"},
+			Port:        80,
+		}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := test.request.validate()
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	defaultDrainTimeout = 5 * time.Minute
+	// maxDrainTimeout caps how long a request may wait for the pods of a node to be evicted.
+	maxDrainTimeout = 15 * time.Minute
+	// drainPollInterval is how often evictions refused by a PodDisruptionBudget are retried, and evicted pods checked.
+	drainPollInterval = 5 * time.Second
+)
+
+// Reasons a pod of a node is skipped or blocks its drain
+const (
+	drainSkipDaemonSet   = "DaemonSet"
+	drainSkipMirror      = "MirrorPod"
+	drainBlockUnmanaged  = "NoController"
+	drainBlockLocalData  = "LocalStorage"
+	drainPendingBudget   = "DisruptionBudget"
+	drainPendingDeletion = "Terminating"
+)
+
+// NodeMaintenance is the schedulability of a node after cordoning or uncordoning it.
+type NodeMaintenance struct {
+	Node          string `json:"node_name"`
+	Unschedulable bool   `json:"unschedulable"`
+}
+
+// DrainedPod is a pod of a drained node, Reason telling why it was skipped, blocks the drain or is still pending.
+type DrainedPod struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"pod_name"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+type DrainResult struct {
+	Node string `json:"node_name"`
+	// Complete is set once every pod was evicted and is gone
+	Complete bool         `json:"complete"`
+	Evicted  []DrainedPod `json:"evicted"`
+	// Skipped are the pods of DaemonSets and static pods, which would come back on the node
+	Skipped []DrainedPod `json:"skipped"`
+	// Blocking are the pods which can't be evicted without ?force=true, the node is then left as it was
+	Blocking []DrainedPod `json:"blocking,omitempty"`
+	// Pending are the pods whose eviction a PodDisruptionBudget still refused, or still terminating, at the timeout
+	Pending []DrainedPod `json:"pending,omitempty"`
+}
+
+// drainOptions are how long to wait for the evictions and whether to evict the pods which won't be recreated.
+type drainOptions struct {
+	Timeout time.Duration
+	// Force evicts the pods without a controller and those with emptyDir volumes, whose data is lost
+	Force        bool
+	PollInterval time.Duration
+}
+
+// Handler marking a node unschedulable, the pods already on it keep running
+func (s *Server) cordonNodeHandler(w http.ResponseWriter, r *http.Request) {
+	s.setNodeSchedulable(w, r, operationNodeCordon, true)
+}
+
+// Handler marking a node schedulable again
+func (s *Server) uncordonNodeHandler(w http.ResponseWriter, r *http.Request) {
+	s.setNodeSchedulable(w, r, operationNodeUncordon, false)
+}
+
+func (s *Server) setNodeSchedulable(w http.ResponseWriter, r *http.Request, operation Operation, unschedulable bool) {
+	name := r.PathValue("name")
+	if !s.authorize(w, r, operation, "", name, nil) {
+		return
+	}
+
+	reservation, err := s.Guardrails.reserve(budgetNodeOperations, "", 1, time.Now())
+	if writeGuardrailError(w, err) {
+		return
+	}
+
+	audit := AuditEvent{
+		Action:          operation.Action,
+		RequestMetadata: requestMetadata(r.Context()),
+		RemoteAddr:      r.RemoteAddr,
+		Target:          name,
+		Result:          "unschedulable=" + strconv.FormatBool(unschedulable),
+	}
+	node, err := cordonNode(r.Context(), s.K8sClientSet, name, unschedulable)
+	if err != nil {
+		reservation.release(1)
+		audit.Result, audit.Error = "failed", err.Error()
+		recordAudit(audit)
+		if apierrors.IsNotFound(err) {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	recordAudit(audit)
+
+	slog.InfoContext(r.Context(), "Node schedulability changed", "node", name, "unschedulable", unschedulable)
+	writeJSON(w, http.StatusOK, NodeMaintenance{Node: node.Name, Unschedulable: node.Spec.Unschedulable})
+}
+
+// Handler cordoning a node and evicting its pods through the eviction API, which honours the PodDisruptionBudgets
+//
+// The pods of DaemonSets and static pods are skipped. Pods without a controller or with emptyDir volumes block the
+// drain with a 409, leaving the node as it was, unless ?force=true. Evictions refused by a budget are retried until
+// ?timeout=, 5m by default, answering a 202 with the pods pending when some remain.
+func (s *Server) drainNodeHandler(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	options := drainOptions{Timeout: defaultDrainTimeout, PollInterval: drainPollInterval}
+	if value := r.URL.Query().Get("timeout"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 || parsed > maxDrainTimeout {
+			writeErrorf(w, http.StatusBadRequest, "invalid timeout %q, expected a positive duration up to %s", value, maxDrainTimeout)
+			return
+		}
+		options.Timeout = parsed
+	}
+	if value := r.URL.Query().Get("force"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			writeErrorf(w, http.StatusBadRequest, "invalid force %q, expected true or false", value)
+			return
+		}
+		options.Force = parsed
+	}
+
+	if !s.authorize(w, r, operationNodeDrain, "", name, nil) {
+		return
+	}
+
+	reservation, err := s.Guardrails.reserve(budgetNodeOperations, "", 1, time.Now())
+	if writeGuardrailError(w, err) {
+		return
+	}
+
+	audit := AuditEvent{
+		Action:          operationNodeDrain.Action,
+		RequestMetadata: requestMetadata(r.Context()),
+		RemoteAddr:      r.RemoteAddr,
+		Target:          name,
+		Request:         map[string]interface{}{"timeout": options.Timeout.String(), "force": options.Force},
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), options.Timeout)
+	defer cancel()
+	extendWriteDeadline(w, options.Timeout)
+	result, err := drainNode(ctx, s.K8sClientSet, name, options)
+	if err != nil {
+		reservation.release(1)
+		audit.Result, audit.Error = "failed", err.Error()
+		recordAudit(audit)
+		if apierrors.IsNotFound(err) {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	audit.Result = fmt.Sprintf("evicted %d pods, %d pending", len(result.Evicted), len(result.Pending))
+	if len(result.Blocking) > 0 {
+		// The node was left as it was
+		reservation.release(1)
+		audit.Result = fmt.Sprintf("blocked by %d pods", len(result.Blocking))
+	}
+	recordAudit(audit)
+
+	slog.InfoContext(r.Context(), "Node drained", "node", name, "evicted", len(result.Evicted), "pending", len(result.Pending), "blocking", len(result.Blocking))
+	switch {
+	case len(result.Blocking) > 0:
+		writeJSON(w, http.StatusConflict, result)
+	case result.Complete:
+		writeJSON(w, http.StatusOK, result)
+	default:
+		writeJSON(w, http.StatusAccepted, result)
+	}
+}
+
+// cordonNode sets whether a node is unschedulable.
+func cordonNode(ctx context.Context, clientset kubernetes.Interface, name string, unschedulable bool) (*corev1.Node, error) {
+	patch := fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable)
+	return clientset.CoreV1().Nodes().Patch(ctx, name, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+}
+
+// drainNode cordons a node and evicts its pods until they're gone or ctx is done, unless some pods block the drain.
+func drainNode(ctx context.Context, clientset kubernetes.Interface, name string, options drainOptions) (*DrainResult, error) {
+	if _, err := clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{}); err != nil {
+		return nil, err
+	}
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{FieldSelector: "spec.nodeName=" + name})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DrainResult{Node: name, Evicted: []DrainedPod{}, Skipped: []DrainedPod{}}
+	var evict []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != name {
+			continue
+		}
+		current := DrainedPod{Namespace: pod.Namespace, Name: pod.Name}
+		if current.Reason = drainSkipReason(pod); current.Reason != "" {
+			result.Skipped = append(result.Skipped, current)
+			continue
+		}
+		if current.Reason = drainBlockReason(pod); current.Reason != "" && !options.Force {
+			result.Blocking = append(result.Blocking, current)
+			continue
+		}
+		evict = append(evict, pod)
+	}
+	if len(result.Blocking) > 0 {
+		return result, nil
+	}
+
+	if _, err := cordonNode(ctx, clientset, name, true); err != nil {
+		return nil, err
+	}
+
+	// Evictions refused by a budget are retried, then the evicted pods waited for
+	evicted := map[types.UID]corev1.Pod{}
+	for {
+		evict = slices.DeleteFunc(evict, func(pod corev1.Pod) bool {
+			err := evictPod(ctx, clientset, pod.Namespace, pod.Name)
+			switch {
+			case err == nil || apierrors.IsNotFound(err):
+				evicted[pod.UID] = pod
+				return true
+			case !apierrors.IsTooManyRequests(err):
+				slog.WarnContext(ctx, "Failed evicting a pod", "node", name, "namespace", pod.Namespace, "pod", pod.Name, "error", err)
+			}
+			return false
+		})
+		for uid, pod := range evicted {
+			current, err := clientset.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) || (err == nil && current.UID != uid) {
+				result.Evicted = append(result.Evicted, DrainedPod{Namespace: pod.Namespace, Name: pod.Name})
+				delete(evicted, uid)
+			}
+		}
+		if len(evict) == 0 && len(evicted) == 0 {
+			result.Complete = true
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			for _, pod := range evict {
+				result.Pending = append(result.Pending, DrainedPod{Namespace: pod.Namespace, Name: pod.Name, Reason: drainPendingBudget})
+			}
+			for _, pod := range evicted {
+				result.Pending = append(result.Pending, DrainedPod{Namespace: pod.Namespace, Name: pod.Name, Reason: drainPendingDeletion})
+			}
+			return result, nil
+		case <-time.After(options.PollInterval):
+		}
+	}
+}
+
+// drainSkipReason tells why a pod is left on a drained node, empty when it's evicted.
+func drainSkipReason(pod corev1.Pod) string {
+	// Mirror pods are the API copies of static pods, which the kubelet runs whatever the API server says
+	if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+		return drainSkipMirror
+	}
+	if owner := metav1.GetControllerOf(&pod); owner != nil && owner.Kind == "DaemonSet" {
+		return drainSkipDaemonSet
+	}
+	return ""
+}
+
+// drainBlockReason tells why evicting a pod needs to be forced, empty when it doesn't. Terminated pods don't block.
+func drainBlockReason(pod corev1.Pod) string {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return ""
+	}
+	if metav1.GetControllerOf(&pod) == nil {
+		return drainBlockUnmanaged
+	}
+	if slices.ContainsFunc(pod.Spec.Volumes, func(volume corev1.Volume) bool { return volume.EmptyDir != nil }) {
+		return drainBlockLocalData
+	}
+	return ""
+}
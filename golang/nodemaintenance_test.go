@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestDrainNode(t *testing.T) {
+	controller := true
+	pod := func(namespace, name, node, owner string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: types.UID("uid-" + name)},
+			Spec:       corev1.PodSpec{NodeName: node},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if owner != "" {
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: owner, Name: name, Controller: &controller}}
+		}
+		return pod
+	}
+	scratch := pod("shop", "scratch", "worker", "ReplicaSet")
+	scratch.Spec.Volumes = []corev1.Volume{{Name: "tmp", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+	static := pod("kube-system", "etcd-worker", "worker", "Node")
+	static.Annotations = map[string]string{corev1.MirrorPodAnnotationKey: "hash"}
+
+	clientset := fake.NewSimpleClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker"}},
+		pod("shop", "cart", "worker", "ReplicaSet"),
+		pod("payments", "ledger", "worker", "ReplicaSet"),
+		pod("kube-system", "calico-node", "worker", "DaemonSet"),
+		static,
+		pod("shop", "debug", "worker", ""),
+		scratch,
+		pod("shop", "web", "other", "ReplicaSet"),
+	)
+	// The budget of the ledger refuses its first eviction
+	refusals := 1
+	clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		eviction := action.(k8stesting.CreateAction).GetObject().(*policyv1.Eviction)
+		if eviction.Name == "ledger" && refusals > 0 {
+			refusals--
+			return true, nil, apierrors.NewTooManyRequests("disruption budget", 10)
+		}
+		return true, nil, clientset.Tracker().Delete(corev1.SchemeGroupVersion.WithResource("pods"), eviction.Namespace, eviction.Name)
+	})
+	options := drainOptions{Timeout: time.Minute, PollInterval: time.Millisecond}
+
+	// The bare pod and the one with local data block the drain, the node is left schedulable
+	result, err := drainNode(context.TODO(), clientset, "worker", options)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.False(t, result.Complete)
+	assert.ElementsMatch(t, []DrainedPod{
+		{Namespace: "shop", Name: "debug", Reason: drainBlockUnmanaged},
+		{Namespace: "shop", Name: "scratch", Reason: drainBlockLocalData},
+	}, result.Blocking)
+	node, err := clientset.CoreV1().Nodes().Get(context.TODO(), "worker", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.False(t, node.Spec.Unschedulable)
+
+	options.Force = true
+	result, err = drainNode(context.TODO(), clientset, "worker", options)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, result.Complete)
+	assert.Empty(t, result.Blocking)
+	assert.Empty(t, result.Pending)
+	assert.ElementsMatch(t, []DrainedPod{
+		{Namespace: "shop", Name: "cart"}, {Namespace: "payments", Name: "ledger"}, {Namespace: "shop", Name: "debug"}, {Namespace: "shop", Name: "scratch"},
+	}, result.Evicted)
+	assert.ElementsMatch(t, []DrainedPod{
+		{Namespace: "kube-system", Name: "calico-node", Reason: drainSkipDaemonSet},
+		{Namespace: "kube-system", Name: "etcd-worker", Reason: drainSkipMirror},
+	}, result.Skipped)
+	node, err = clientset.CoreV1().Nodes().Get(context.TODO(), "worker", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.True(t, node.Spec.Unschedulable)
+
+	_, err = clientset.CoreV1().Pods("shop").Get(context.TODO(), "web", metav1.GetOptions{})
+	assert.NoError(t, err, "the pods of other nodes are left alone")
+
+	node, err = cordonNode(context.TODO(), clientset, "worker", false)
+	if assert.NoError(t, err) {
+		assert.False(t, node.Spec.Unschedulable)
+	}
+	_, err = drainNode(context.TODO(), clientset, "missing", options)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestCordonNodeBudget(t *testing.T) {
+	guard, err := newRateGuard(&GuardrailsConfig{Global: map[string]int{budgetNodeOperations: 1}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	server := Server{
+		K8sClientSet: fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}),
+		Guardrails:   guard,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /nodes/{name}/cordon", server.cordonNodeHandler)
+	mux.HandleFunc("POST /nodes/{name}/uncordon", server.uncordonNodeHandler)
+	serve := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, target, nil))
+		return rec
+	}
+
+	// Failures don't use up the budget
+	assert.Equal(t, http.StatusNotFound, serve("/nodes/missing/cordon").Code)
+	assert.Equal(t, http.StatusOK, serve("/nodes/worker/cordon").Code)
+	rec := serve("/nodes/worker/uncordon")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+}
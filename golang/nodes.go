@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// nodePressureConditions are the node conditions which are true when the node is short of a resource.
+var nodePressureConditions = []corev1.NodeConditionType{
+	corev1.NodeMemoryPressure,
+	corev1.NodeDiskPressure,
+	corev1.NodePIDPressure,
+	corev1.NodeNetworkUnavailable,
+}
+
+type NodeInfo struct {
+	Name  string `json:"node_name"`
+	Ready bool   `json:"ready"`
+	// Pressure are the pressure conditions which are true, such as MemoryPressure or DiskPressure
+	Pressure []string `json:"pressure,omitempty"`
+	// Unschedulable is set for cordoned nodes
+	Unschedulable  bool   `json:"unschedulable"`
+	KubeletVersion string `json:"kubelet_version"`
+	// Pods counts the pods bound to the node which haven't terminated, out of the PodCapacity it can run
+	Pods        int                   `json:"pods"`
+	PodCapacity int64                 `json:"pod_capacity"`
+	Conditions  []DeploymentCondition `json:"conditions,omitempty"`
+	CreatedAt   time.Time             `json:"created_at"`
+}
+
+type ClusterNodesInfo struct {
+	ReadyNodes []NodeInfo `json:"ready_nodes"`
+	// UnhealthyNodes aren't ready, or are under pressure
+	UnhealthyNodes []NodeInfo `json:"unhealthy_nodes"`
+}
+
+// Reports the health of the nodes, those whose labels match ?labelSelector= only
+//
+// Nodes aren't namespaced, so --namespaces doesn't restrict the report, and the pods of every namespace are counted.
+func (s *Server) nodesInfoHandler(w http.ResponseWriter, r *http.Request) {
+	selector := labels.Everything()
+	if value := r.URL.Query().Get("labelSelector"); value != "" {
+		parsed, err := labels.Parse(value)
+		if err != nil {
+			writeErrorf(w, http.StatusBadRequest, "invalid labelSelector: %v", err)
+			return
+		}
+		selector = parsed
+	}
+
+	info, err := getNodesHealth(r.Context(), s.K8sClientSet, selector)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, info)
+}
+
+// getNodesHealth sorts the nodes whose labels match selector into ready and unhealthy ones.
+func getNodesHealth(ctx context.Context, clientset kubernetes.Interface, selector labels.Selector) (*ClusterNodesInfo, error) {
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{FieldSelector: "status.phase!=Succeeded,status.phase!=Failed"})
+	if err != nil {
+		return nil, err
+	}
+	podCounts := map[string]int{}
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != "" && pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+			podCounts[pod.Spec.NodeName]++
+		}
+	}
+
+	info := new(ClusterNodesInfo)
+	sort.Slice(nodes.Items, func(i, j int) bool { return nodes.Items[i].Name < nodes.Items[j].Name })
+	for _, node := range nodes.Items {
+		current := NodeInfo{
+			Name:           node.Name,
+			Unschedulable:  node.Spec.Unschedulable,
+			KubeletVersion: node.Status.NodeInfo.KubeletVersion,
+			Pods:           podCounts[node.Name],
+			PodCapacity:    node.Status.Allocatable.Pods().Value(),
+			CreatedAt:      node.CreationTimestamp.Time,
+		}
+		for _, condition := range node.Status.Conditions {
+			current.Conditions = append(current.Conditions, DeploymentCondition{
+				Type:               string(condition.Type),
+				Status:             string(condition.Status),
+				Reason:             condition.Reason,
+				Message:            condition.Message,
+				LastTransitionTime: condition.LastTransitionTime.Time,
+			})
+			switch {
+			case condition.Type == corev1.NodeReady:
+				current.Ready = condition.Status == corev1.ConditionTrue
+			case condition.Status == corev1.ConditionTrue && slices.Contains(nodePressureConditions, condition.Type):
+				current.Pressure = append(current.Pressure, string(condition.Type))
+			}
+		}
+
+		if current.Ready && len(current.Pressure) == 0 {
+			info.ReadyNodes = append(info.ReadyNodes, current)
+		} else {
+			info.UnhealthyNodes = append(info.UnhealthyNodes, current)
+		}
+	}
+	slog.DebugContext(ctx, "Nodes health", "ready", len(info.ReadyNodes), "unhealthy", len(info.UnhealthyNodes))
+	return info, nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetNodesHealth(t *testing.T) {
+	node := func(name string, ready corev1.ConditionStatus, cordoned bool, pressure ...corev1.NodeConditionType) *corev1.Node {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"pool": "general"}},
+			Spec:       corev1.NodeSpec{Unschedulable: cordoned},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("110")},
+				NodeInfo:    corev1.NodeSystemInfo{KubeletVersion: "v1.27.10"},
+				Conditions: []corev1.NodeCondition{
+					{Type: corev1.NodeReady, Status: ready},
+					{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse},
+				},
+			},
+		}
+		for _, condition := range pressure {
+			node.Status.Conditions = append(node.Status.Conditions, corev1.NodeCondition{Type: condition, Status: corev1.ConditionTrue})
+		}
+		return node
+	}
+	pod := func(name, node string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "payments"}, Spec: corev1.PodSpec{NodeName: node}, Status: corev1.PodStatus{Phase: phase}}
+	}
+	clientset := fake.NewSimpleClientset(
+		node("node-c", corev1.ConditionTrue, true),
+		node("node-a", corev1.ConditionTrue, false),
+		node("node-b", corev1.ConditionTrue, false, corev1.NodeDiskPressure),
+		node("node-d", corev1.ConditionUnknown, false),
+		pod("api-1", "node-a", corev1.PodRunning),
+		pod("api-2", "node-a", corev1.PodPending),
+		pod("migrate", "node-a", corev1.PodSucceeded),
+		pod("api-3", "", corev1.PodPending),
+	)
+
+	info, err := getNodesHealth(context.Background(), clientset, labels.Everything())
+	assert.NoError(t, err)
+	if assert.Len(t, info.ReadyNodes, 2) {
+		assert.Equal(t, "node-a", info.ReadyNodes[0].Name)
+		assert.Equal(t, 2, info.ReadyNodes[0].Pods)
+		assert.Equal(t, int64(110), info.ReadyNodes[0].PodCapacity)
+		assert.Equal(t, "v1.27.10", info.ReadyNodes[0].KubeletVersion)
+		assert.True(t, info.ReadyNodes[1].Unschedulable)
+	}
+	if assert.Len(t, info.UnhealthyNodes, 2) {
+		assert.Equal(t, "node-b", info.UnhealthyNodes[0].Name)
+		assert.Equal(t, []string{"DiskPressure"}, info.UnhealthyNodes[0].Pressure)
+		assert.Equal(t, "node-d", info.UnhealthyNodes[1].Name)
+		assert.False(t, info.UnhealthyNodes[1].Ready)
+	}
+}
@@ -0,0 +1,321 @@
+package main
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	jose "github.com/go-jose/go-jose/v4"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultOIDCUsernameClaim = "sub"
+	defaultOIDCGroupsClaim   = "groups"
+	defaultJWKSCacheTTL      = time.Hour
+	// minJWKSRefreshInterval bounds how often tokens signed with an unknown key refetch the keys of the provider
+	minJWKSRefreshInterval = 10 * time.Second
+	oidcRequestTimeout     = 10 * time.Second
+	maxOIDCResponseBytes   = 1 << 20
+)
+
+// oidcSigningAlgorithms are the asymmetric algorithms accepted for the tokens, symmetric ones and unsigned tokens are
+// refused.
+var oidcSigningAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512, jose.PS256, jose.PS384, jose.PS512, jose.ES256, jose.ES384, jose.ES512,
+}
+
+// OIDCConfig validates the ID tokens of an OpenID Connect provider, like the kube-apiserver --oidc-* flags.
+//
+// The username and groups are read from UsernameClaim and GroupsClaim, with optional prefixes keeping them apart from
+// the Kubernetes users and groups, e.g. "oidc:".
+type OIDCConfig struct {
+	IssuerURL      string          `json:"issuer_url"`
+	Audiences      []string        `json:"audiences"`
+	UsernameClaim  string          `json:"username_claim,omitempty"`
+	UsernamePrefix string          `json:"username_prefix,omitempty"`
+	GroupsClaim    string          `json:"groups_claim,omitempty"`
+	GroupsPrefix   string          `json:"groups_prefix,omitempty"`
+	JWKSCacheTTL   metav1.Duration `json:"jwks_cache_ttl,omitempty"`
+}
+
+// oidcVerifier checks the signature and claims of the tokens of an issuer, with the keys of its discovery document,
+// cached for ttl. The keys are fetched without holding mu, concurrent verifications waiting for the same fetch.
+type oidcVerifier struct {
+	config     OIDCConfig
+	ttl        time.Duration
+	httpClient *http.Client
+	now        func() time.Time
+
+	mu      sync.Mutex
+	jwksURI string
+	keys    map[string]crypto.PublicKey
+	fetched time.Time
+	// attempted is when the keys were last fetched, fetchErr why that failed
+	attempted time.Time
+	fetchErr  error
+	fetching  *jwksFetch
+}
+
+// jwksFetch is a fetch of the keys in flight, done being closed once it completed.
+type jwksFetch struct {
+	done chan struct{}
+}
+
+func newOIDCVerifier(config *OIDCConfig) (*oidcVerifier, error) {
+	issuer, err := url.Parse(config.IssuerURL)
+	if err != nil || issuer.Scheme != "https" || issuer.Host == "" {
+		return nil, fmt.Errorf("invalid oidc issuer_url %q, expected an https URL", config.IssuerURL)
+	}
+	if len(config.Audiences) == 0 {
+		return nil, errors.New("oidc requires the audiences of the tokens, such as the client ID")
+	}
+
+	verifier := &oidcVerifier{
+		config:     *config,
+		ttl:        config.JWKSCacheTTL.Duration,
+		httpClient: &http.Client{Timeout: oidcRequestTimeout},
+		now:        time.Now,
+	}
+	if verifier.config.UsernameClaim == "" {
+		verifier.config.UsernameClaim = defaultOIDCUsernameClaim
+	}
+	if verifier.config.GroupsClaim == "" {
+		verifier.config.GroupsClaim = defaultOIDCGroupsClaim
+	}
+	if verifier.ttl == 0 {
+		verifier.ttl = defaultJWKSCacheTTL
+	}
+	return verifier, nil
+}
+
+// parseJWT parses a signed token without verifying it.
+func parseJWT(token string) (*jose.JSONWebSignature, error) {
+	parsed, err := jose.ParseSigned(token, oidcSigningAlgorithms)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidToken, err)
+	}
+	if len(parsed.Signatures) != 1 {
+		return nil, fmt.Errorf("%w: expected a single signature", errInvalidToken)
+	}
+	return parsed, nil
+}
+
+// issues tells whether token claims to be issued by the provider, the other tokens being left to TokenReview.
+func (v *oidcVerifier) issues(token string) bool {
+	parsed, err := parseJWT(token)
+	if err != nil {
+		return false
+	}
+	var claims struct {
+		Issuer string `json:"iss"`
+	}
+	return json.Unmarshal(parsed.UnsafePayloadWithoutVerification(), &claims) == nil && claims.Issuer == v.config.IssuerURL
+}
+
+// verify returns the principal of a valid token, errInvalidToken wrapping the reason a token isn't.
+func (v *oidcVerifier) verify(ctx context.Context, token string) (*Principal, error) {
+	parsed, err := parseJWT(token)
+	if err != nil {
+		return nil, err
+	}
+	key, err := v.key(ctx, parsed.Signatures[0].Header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The signature, issuer and validity period are checked by go-oidc, the audiences here as there can be several
+	verifier := oidc.NewVerifier(v.config.IssuerURL, &oidc.StaticKeySet{PublicKeys: []crypto.PublicKey{key}}, &oidc.Config{
+		SkipClientIDCheck:    true,
+		SupportedSigningAlgs: algorithmNames(oidcSigningAlgorithms),
+		Now:                  v.now,
+	})
+	idToken, err := verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidToken, err)
+	}
+	if !slices.ContainsFunc(idToken.Audience, func(audience string) bool { return slices.Contains(v.config.Audiences, audience) }) {
+		return nil, fmt.Errorf("%w: audience %s isn't one of %s", errInvalidToken, strings.Join(idToken.Audience, ", "), strings.Join(v.config.Audiences, ", "))
+	}
+
+	var claims map[string]interface{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidToken, err)
+	}
+	return v.principal(claims)
+}
+
+func algorithmNames(algorithms []jose.SignatureAlgorithm) []string {
+	names := make([]string, 0, len(algorithms))
+	for _, algorithm := range algorithms {
+		names = append(names, string(algorithm))
+	}
+	return names
+}
+
+// principal reads the username and groups of a verified token.
+func (v *oidcVerifier) principal(claims map[string]interface{}) (*Principal, error) {
+	username, _ := claims[v.config.UsernameClaim].(string)
+	if username == "" {
+		return nil, fmt.Errorf("%w: no %s claim", errInvalidToken, v.config.UsernameClaim)
+	}
+	// Like the kube-apiserver, an email only identifies the caller once the provider verified it
+	if v.config.UsernameClaim == "email" {
+		if verified, ok := claims["email_verified"].(bool); ok && !verified {
+			return nil, fmt.Errorf("%w: email %s isn't verified", errInvalidToken, username)
+		}
+	}
+	principal := &Principal{User: v.config.UsernamePrefix + username}
+
+	var groups []interface{}
+	switch claim := claims[v.config.GroupsClaim].(type) {
+	case string:
+		groups = []interface{}{claim}
+	case []interface{}:
+		groups = claim
+	}
+	for _, group := range groups {
+		if group, ok := group.(string); ok && group != "" {
+			principal.Groups = append(principal.Groups, v.config.GroupsPrefix+group)
+		}
+	}
+	return principal, nil
+}
+
+// key returns the signing key kid of the provider, refetching its keys when they're older than the TTL or when kid is
+// unknown, at most every minJWKSRefreshInterval, failed fetches included. Until a fetch succeeds the last keys fetched
+// are used, a kid they don't have getting the error of the fetch. A token without a kid needs the provider to have a
+// single key.
+func (v *oidcVerifier) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	for refetched := false; ; refetched = true {
+		v.mu.Lock()
+		now := v.now()
+		key, ok := v.lookup(kid)
+		stale := v.keys == nil || !now.Before(v.fetched.Add(v.ttl))
+		if refetched || now.Before(v.attempted.Add(minJWKSRefreshInterval)) || (!stale && ok) {
+			fetchErr := v.fetchErr
+			v.mu.Unlock()
+			switch {
+			case ok:
+				return key, nil
+			case fetchErr != nil:
+				return nil, fetchErr
+			}
+			return nil, fmt.Errorf("%w: unknown signing key %q", errInvalidToken, kid)
+		}
+
+		fetch := v.fetching
+		if fetch == nil {
+			fetch = &jwksFetch{done: make(chan struct{})}
+			v.fetching = fetch
+			// The fetch outlives the request starting it, as other verifications may be waiting for it
+			go v.fetchKeys(context.WithoutCancel(ctx), fetch, now)
+		}
+		v.mu.Unlock()
+
+		select {
+		case <-fetch.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (v *oidcVerifier) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, true
+		}
+	}
+	key, ok := v.keys[kid]
+	return key, ok
+}
+
+// fetchKeys reads the JSON Web Key Set of the provider, discovering its URL the first time, then completes fetch.
+func (v *oidcVerifier) fetchKeys(ctx context.Context, fetch *jwksFetch, now time.Time) {
+	v.mu.Lock()
+	jwksURI := v.jwksURI
+	v.mu.Unlock()
+
+	keys, jwksURI, err := v.discoverKeys(ctx, jwksURI)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if err == nil {
+		v.jwksURI, v.keys, v.fetched = jwksURI, keys, now
+	}
+	v.attempted, v.fetchErr = now, err
+	v.fetching = nil
+	close(fetch.done)
+}
+
+// discoverKeys fetches the keys of the provider from jwksURI, read from its discovery document when empty.
+func (v *oidcVerifier) discoverKeys(ctx context.Context, jwksURI string) (map[string]crypto.PublicKey, string, error) {
+	if jwksURI == "" {
+		var discovery struct {
+			Issuer  string `json:"issuer"`
+			JWKSURI string `json:"jwks_uri"`
+		}
+		if err := v.get(ctx, strings.TrimSuffix(v.config.IssuerURL, "/")+"/.well-known/openid-configuration", &discovery); err != nil {
+			return nil, "", fmt.Errorf("discovering the OIDC provider: %w", err)
+		}
+		if discovery.Issuer != v.config.IssuerURL {
+			return nil, "", fmt.Errorf("discovering the OIDC provider: issuer %q isn't %s", discovery.Issuer, v.config.IssuerURL)
+		}
+		if discovery.JWKSURI == "" {
+			return nil, "", errors.New("discovering the OIDC provider: no jwks_uri")
+		}
+		jwksURI = discovery.JWKSURI
+	}
+
+	var set struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if err := v.get(ctx, jwksURI, &set); err != nil {
+		return nil, "", fmt.Errorf("fetching the OIDC signing keys: %w", err)
+	}
+	keys := map[string]crypto.PublicKey{}
+	for _, raw := range set.Keys {
+		// Keys of types or curves go-jose doesn't support are skipped, tokens they sign are then rejected
+		var jwk jose.JSONWebKey
+		if err := json.Unmarshal(raw, &jwk); err != nil || !jwk.IsPublic() || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		keys[jwk.KeyID] = jwk.Key
+	}
+	return keys, jwksURI, nil
+}
+
+func (v *oidcVerifier) get(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxOIDCResponseBytes))
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("invalid response from %s: %w", url, err)
+	}
+	return nil
+}
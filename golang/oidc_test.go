@@ -0,0 +1,317 @@
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// testOIDCProvider serves the discovery document and the keys of an OIDC provider, and signs its tokens.
+type testOIDCProvider struct {
+	server *httptest.Server
+
+	mu      sync.Mutex
+	keys    []jose.JSONWebKey
+	fetches int
+	// blocked holds the responses with the keys until it's closed, when set
+	blocked chan struct{}
+	// failing has the requests of the keys fail
+	failing bool
+}
+
+func newTestOIDCProvider(t *testing.T) *testOIDCProvider {
+	provider := &testOIDCProvider{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"issuer": provider.server.URL, "jwks_uri": provider.server.URL + "/keys"})
+	})
+	mux.HandleFunc("GET /keys", func(w http.ResponseWriter, r *http.Request) {
+		provider.mu.Lock()
+		blocked := provider.blocked
+		provider.mu.Unlock()
+		if blocked != nil {
+			<-blocked
+		}
+
+		provider.mu.Lock()
+		defer provider.mu.Unlock()
+		provider.fetches++
+		if provider.failing {
+			writeErrorf(w, http.StatusServiceUnavailable, "Unavailable")
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"keys": provider.keys})
+	})
+	provider.server = httptest.NewTLSServer(mux)
+	t.Cleanup(provider.server.Close)
+	return provider
+}
+
+func (p *testOIDCProvider) addRSAKey(t *testing.T, kid string) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys = append(p.keys, jose.JSONWebKey{Key: &key.PublicKey, KeyID: kid, Use: "sig"})
+	return key
+}
+
+func (p *testOIDCProvider) addECKey(t *testing.T, kid string) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys = append(p.keys, jose.JSONWebKey{Key: &key.PublicKey, KeyID: kid})
+	return key
+}
+
+func (p *testOIDCProvider) verifier(t *testing.T, now *time.Time) *oidcVerifier {
+	verifier, err := newOIDCVerifier(&OIDCConfig{IssuerURL: p.server.URL, Audiences: []string{"tyk-sre-assignment"}, GroupsPrefix: "oidc:"})
+	assert.NoError(t, err)
+	verifier.httpClient = p.server.Client()
+	verifier.now = func() time.Time { return *now }
+	return verifier
+}
+
+// signJWT signs claims with key, an *rsa.PrivateKey for RS256 or an *ecdsa.PrivateKey for ES256.
+func signJWT(t *testing.T, algorithm, kid string, key crypto.Signer, claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": algorithm, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	sum := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+		assert.NoError(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, key, sum[:])
+		assert.NoError(t, err)
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestOIDCVerifier(t *testing.T) {
+	provider := newTestOIDCProvider(t)
+	rsaKey := provider.addRSAKey(t, "rsa-1")
+	ecKey := provider.addECKey(t, "ec-1")
+	now := time.Unix(1720440000, 0)
+	verifier := provider.verifier(t, &now)
+	claims := func(changes map[string]interface{}) map[string]interface{} {
+		claims := map[string]interface{}{
+			"iss":    provider.server.URL,
+			"aud":    []string{"tyk-sre-assignment", "grafana"},
+			"sub":    "alice@example.com",
+			"exp":    now.Add(time.Hour).Unix(),
+			"groups": []string{"sre", "payments"},
+		}
+		for claim, value := range changes {
+			claims[claim] = value
+		}
+		return claims
+	}
+
+	principal, err := verifier.verify(context.Background(), signJWT(t, "RS256", "rsa-1", rsaKey, claims(nil)))
+	assert.NoError(t, err)
+	assert.Equal(t, &Principal{User: "alice@example.com", Groups: []string{"oidc:sre", "oidc:payments"}}, principal)
+	principal, err = verifier.verify(context.Background(), signJWT(t, "ES256", "ec-1", ecKey, claims(map[string]interface{}{"aud": "tyk-sre-assignment", "groups": "sre"})))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"oidc:sre"}, principal.Groups)
+
+	tampered := signJWT(t, "RS256", "rsa-1", rsaKey, claims(nil))
+	for name, token := range map[string]string{
+		"audience":      signJWT(t, "RS256", "rsa-1", rsaKey, claims(map[string]interface{}{"aud": "grafana"})),
+		"issuer":        signJWT(t, "RS256", "rsa-1", rsaKey, claims(map[string]interface{}{"iss": "https://evil.example.com"})),
+		"expired":       signJWT(t, "RS256", "rsa-1", rsaKey, claims(map[string]interface{}{"exp": now.Add(-time.Hour).Unix()})),
+		"no expiry":     signJWT(t, "RS256", "rsa-1", rsaKey, claims(map[string]interface{}{"exp": nil})),
+		"not yet valid": signJWT(t, "RS256", "rsa-1", rsaKey, claims(map[string]interface{}{"nbf": now.Add(time.Hour).Unix()})),
+		"no subject":    signJWT(t, "RS256", "rsa-1", rsaKey, claims(map[string]interface{}{"sub": ""})),
+		"wrong key":     signJWT(t, "RS256", "ec-1", rsaKey, claims(nil)),
+		"algorithm":     signJWT(t, "ES256", "rsa-1", ecKey, claims(nil)),
+		"symmetric":     signJWT(t, "HS256", "rsa-1", rsaKey, claims(nil)),
+		"tampered":      tampered[:len(tampered)-4] + "AAAA",
+		"not a JWT":     "opaque-token",
+	} {
+		_, err := verifier.verify(context.Background(), token)
+		assert.ErrorIs(t, err, errInvalidToken, name)
+	}
+	assert.Equal(t, 1, provider.fetches)
+
+	// A rotated key is fetched, while unknown keys don't refetch the keys more than every minJWKSRefreshInterval
+	rotated := provider.addRSAKey(t, "rsa-2")
+	_, err = verifier.verify(context.Background(), signJWT(t, "RS256", "rsa-2", rotated, claims(nil)))
+	assert.ErrorIs(t, err, errInvalidToken)
+	now = now.Add(minJWKSRefreshInterval)
+	_, err = verifier.verify(context.Background(), signJWT(t, "RS256", "rsa-2", rotated, claims(nil)))
+	assert.NoError(t, err)
+	_, err = verifier.verify(context.Background(), signJWT(t, "RS256", "unknown", rotated, claims(nil)))
+	assert.ErrorIs(t, err, errInvalidToken)
+	assert.Equal(t, 2, provider.fetches)
+
+	// An unreachable provider isn't the caller's fault
+	provider.server.Close()
+	now = now.Add(defaultJWKSCacheTTL)
+	_, err = verifier.verify(context.Background(), signJWT(t, "RS256", "rsa-3", rotated, claims(nil)))
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, errInvalidToken)
+
+	for _, config := range []OIDCConfig{
+		{IssuerURL: "http://idp.example.com", Audiences: []string{"tyk-sre-assignment"}},
+		{IssuerURL: "https://idp.example.com"},
+	} {
+		_, err := newOIDCVerifier(&config)
+		assert.Error(t, err)
+	}
+}
+
+func TestOIDCVerifierFailingProvider(t *testing.T) {
+	provider := newTestOIDCProvider(t)
+	key := provider.addRSAKey(t, "rsa-1")
+	now := time.Unix(1720440000, 0)
+	verifier := provider.verifier(t, &now)
+	sign := func(kid string) string {
+		return signJWT(t, "RS256", kid, key, map[string]interface{}{
+			"iss": provider.server.URL,
+			"aud": "tyk-sre-assignment",
+			"sub": "alice@example.com",
+			"exp": now.Add(2 * defaultJWKSCacheTTL).Unix(),
+		})
+	}
+	_, err := verifier.verify(context.Background(), sign("rsa-1"))
+	assert.NoError(t, err)
+
+	// Expired keys are still used while the provider fails, which isn't asked again more than every minJWKSRefreshInterval
+	provider.mu.Lock()
+	provider.failing = true
+	provider.mu.Unlock()
+	now = now.Add(defaultJWKSCacheTTL)
+	for i := 0; i < 3; i++ {
+		_, err = verifier.verify(context.Background(), sign("rsa-1"))
+		assert.NoError(t, err)
+	}
+	_, err = verifier.verify(context.Background(), sign("rsa-2"))
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, errInvalidToken)
+	assert.Equal(t, 2, provider.fetches)
+
+	now = now.Add(minJWKSRefreshInterval)
+	_, err = verifier.verify(context.Background(), sign("rsa-1"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, provider.fetches)
+
+	// Once the provider recovers its keys are fetched again
+	provider.mu.Lock()
+	provider.failing = false
+	provider.mu.Unlock()
+	now = now.Add(minJWKSRefreshInterval)
+	_, err = verifier.verify(context.Background(), sign("rsa-1"))
+	assert.NoError(t, err)
+	_, err = verifier.verify(context.Background(), sign("rsa-1"))
+	assert.NoError(t, err)
+	assert.Equal(t, 4, provider.fetches)
+}
+
+func TestOIDCVerifierConcurrentFetch(t *testing.T) {
+	provider := newTestOIDCProvider(t)
+	key := provider.addRSAKey(t, "rsa-1")
+	provider.blocked = make(chan struct{})
+	now := time.Unix(1720440000, 0)
+	verifier := provider.verifier(t, &now)
+	token := signJWT(t, "RS256", "rsa-1", key, map[string]interface{}{
+		"iss": provider.server.URL,
+		"aud": "tyk-sre-assignment",
+		"sub": "alice@example.com",
+		"exp": now.Add(time.Hour).Unix(),
+	})
+
+	// The verifications wait for the same fetch
+	errs := make(chan error, 5)
+	for i := 0; i < cap(errs); i++ {
+		go func() {
+			_, err := verifier.verify(context.Background(), token)
+			errs <- err
+		}()
+	}
+
+	// Nor do they hold the verifier, a request giving up doesn't wait for the fetch to finish
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := verifier.verify(ctx, token)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(provider.blocked)
+	for i := 0; i < cap(errs); i++ {
+		assert.NoError(t, <-errs)
+	}
+	assert.Equal(t, 1, provider.fetches)
+}
+
+func TestTokenAuthenticatorOIDC(t *testing.T) {
+	provider := newTestOIDCProvider(t)
+	key := provider.addRSAKey(t, "rsa-1")
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if review.Spec.Token != "sa-token" {
+			return true, nil, errors.New("unexpected TokenReview")
+		}
+		review.Status.Authenticated = true
+		review.Status.User = authenticationv1.UserInfo{Username: "system:serviceaccount:ci:deployer"}
+		return true, review, nil
+	})
+
+	authenticator, err := newTokenAuthenticator(&AuthenticationConfig{
+		OIDC:        &OIDCConfig{IssuerURL: provider.server.URL, Audiences: []string{"tyk-sre-assignment"}, UsernameClaim: "email"},
+		TokenReview: &TokenReviewConfig{},
+	}, clientset)
+	assert.NoError(t, err)
+	authenticator.oidc.httpClient = provider.server.Client()
+
+	// The tokens of the provider are verified against it, the others reviewed by Kubernetes
+	token := signJWT(t, "RS256", "rsa-1", key, map[string]interface{}{
+		"iss":            provider.server.URL,
+		"aud":            "tyk-sre-assignment",
+		"sub":            "00u1",
+		"email":          "alice@example.com",
+		"email_verified": true,
+		"exp":            time.Now().Add(time.Hour).Unix(),
+	})
+	principal, err := authenticator.authenticate(context.Background(), "Bearer "+token)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice@example.com", principal.User)
+	principal, err = authenticator.authenticate(context.Background(), "Bearer sa-token")
+	assert.NoError(t, err)
+	assert.Equal(t, "system:serviceaccount:ci:deployer", principal.User)
+
+	unverified := signJWT(t, "RS256", "rsa-1", key, map[string]interface{}{
+		"iss":            provider.server.URL,
+		"aud":            "tyk-sre-assignment",
+		"email":          "mallory@example.com",
+		"email_verified": false,
+		"exp":            time.Now().Add(time.Hour).Unix(),
+	})
+	_, err = authenticator.authenticate(context.Background(), "Bearer "+unverified)
+	assert.ErrorIs(t, err, errInvalidToken)
+}
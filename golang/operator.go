@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/go-logr/logr/slogr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// The modes of the service: serving the HTTP API alone, or also running the IsolationIntent controller.
+const (
+	modeServer   = "server"
+	modeOperator = "operator"
+)
+
+// intentConditionEnforced is the condition of an IsolationIntent telling whether its policy is in force.
+const intentConditionEnforced = "Enforced"
+
+// The reasons of the Enforced condition.
+const (
+	intentReasonInForce       = "InForce"
+	intentReasonRestored      = "Restored"
+	intentReasonEnforceFailed = "EnforceFailed"
+	intentReasonInvalid       = "Invalid"
+)
+
+// IsolationIntentStatus is the status the operator publishes on an IsolationIntent.
+type IsolationIntentStatus struct {
+	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
+	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+}
+
+func validateMode(mode string) error {
+	switch mode {
+	case modeServer, modeOperator:
+		return nil
+	}
+	return fmt.Errorf("invalid mode %q, expected %s or %s", mode, modeServer, modeOperator)
+}
+
+// intentController reconciles the IsolationIntents as a controller-runtime controller, instead of listing them every
+// interval: an intent is enforced as soon as it's created or changed, then again every interval since the policies
+// themselves aren't watched, and the outcome is published as its Enforced condition.
+type intentController struct {
+	client     client.Client
+	reconciler *intentReconciler
+	now        func() time.Time
+}
+
+// newIntentOperator builds the manager running the IsolationIntent controller. Its own metrics, health probes and
+// leader election are off, the HTTP server has them and --leader-elect runs it on a single replica.
+func newIntentOperator(config *rest.Config, reconciler *intentReconciler) (manager.Manager, error) {
+	ctrl.SetLogger(slogr.NewLogr(slog.Default().Handler()))
+
+	mgr, err := ctrl.NewManager(config, ctrl.Options{
+		MetricsBindAddress:     "0",
+		HealthProbeBindAddress: "0",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	controller := &intentController{client: mgr.GetClient(), reconciler: reconciler, now: time.Now}
+	err = ctrl.NewControllerManagedBy(mgr).
+		Named("isolationintent").
+		// Publishing the status changes the intent without changing its generation
+		For(newIsolationIntentObject(), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(controller)
+	if err != nil {
+		return nil, err
+	}
+	return mgr, nil
+}
+
+func newIsolationIntentObject() *unstructured.Unstructured {
+	intent := &unstructured.Unstructured{}
+	intent.SetGroupVersionKind(isolationIntentResource.GroupVersion().WithKind("IsolationIntent"))
+	return intent
+}
+
+// Reconcile enforces an intent and publishes the outcome. Intents out of scope are left to the replica covering them,
+// expired ones are deleted.
+func (c *intentController) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	intent := newIsolationIntentObject()
+	if err := c.client.Get(ctx, request.NamespacedName, intent); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	spec, err := isolationIntentSpec(intent)
+	if err != nil {
+		// Retrying won't fix it, the intent has to be changed
+		return ctrl.Result{}, c.publish(ctx, intent, metav1.ConditionFalse, intentReasonInvalid, err.Error())
+	}
+	inScope, err := c.inScope(ctx, spec)
+	if err != nil || !inScope {
+		return ctrl.Result{}, err
+	}
+
+	if expired(spec, c.now()) {
+		return ctrl.Result{}, c.reconciler.deleteExpired(ctx, intent)
+	}
+
+	// The spec hash enforce records is another update, the status is published on the intent as read
+	published := intent.DeepCopy()
+	reason, err := c.reconciler.enforce(ctx, intent, spec)
+	if err != nil {
+		return ctrl.Result{}, errors.Join(err, c.publish(ctx, published, metav1.ConditionFalse, intentReasonEnforceFailed, err.Error()))
+	}
+	if reason != "" {
+		err = c.publish(ctx, published, metav1.ConditionTrue, intentReasonRestored, fmt.Sprintf("Recreated the policy %s out of band", reason))
+	} else {
+		err = c.publish(ctx, published, metav1.ConditionTrue, intentReasonInForce, "The policy is in force")
+	}
+	return ctrl.Result{RequeueAfter: c.reconciler.interval}, err
+}
+
+// inScope tells whether the policy of an intent is in the namespaces of the service, global policies needing it to
+// cover every namespace.
+func (c *intentController) inScope(ctx context.Context, spec IsolationIntentSpec) (bool, error) {
+	scope := c.reconciler.scope
+	if !scope.IsScoped() {
+		return true, nil
+	}
+	if spec.Namespace == "" {
+		return false, nil
+	}
+	err := scope.check(ctx, c.reconciler.clientset, spec.Namespace)
+	if errors.Is(err, errNamespaceOutOfScope) || apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// publish sets the Enforced condition of an intent, with a merge patch of its status so it doesn't conflict with the
+// spec hash updates.
+func (c *intentController) publish(ctx context.Context, intent *unstructured.Unstructured, status metav1.ConditionStatus, reason, message string) error {
+	patch := client.MergeFrom(intent.DeepCopy())
+	err := setIntentCondition(intent, metav1.Condition{Type: intentConditionEnforced, Status: status, Reason: reason, Message: message})
+	if err != nil {
+		return err
+	}
+	if err := c.client.Status().Patch(ctx, intent, patch); err != nil {
+		return fmt.Errorf("publishing the status of IsolationIntent %s: %w", intent.GetName(), err)
+	}
+	return nil
+}
+
+// setIntentCondition sets a condition in the status of an intent, its transition time only changing with its status.
+func setIntentCondition(intent *unstructured.Unstructured, condition metav1.Condition) error {
+	var status IsolationIntentStatus
+	object, _, _ := unstructured.NestedMap(intent.Object, "status")
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object, &status); err != nil {
+		return err
+	}
+
+	status.ObservedGeneration = intent.GetGeneration()
+	condition.ObservedGeneration = intent.GetGeneration()
+	meta.SetStatusCondition(&status.Conditions, condition)
+
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&status)
+	if err != nil {
+		return err
+	}
+	return unstructured.SetNestedMap(intent.Object, object, "status")
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// enforcedCondition reads the Enforced condition the controller published on an intent.
+func enforcedCondition(t *testing.T, controller *intentController, name string) *metav1.Condition {
+	intent := newIsolationIntentObject()
+	if !assert.NoError(t, controller.client.Get(context.Background(), types.NamespacedName{Name: name}, intent)) {
+		return nil
+	}
+	var status IsolationIntentStatus
+	object, _, _ := unstructured.NestedMap(intent.Object, "status")
+	assert.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(object, &status))
+	return meta.FindStatusCondition(status.Conditions, intentConditionEnforced)
+}
+
+func TestIntentController(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}})
+	backend := newFakeIntentBackend()
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{CIDR: "10.12.0.0/16"},
+	}
+	name, _, err := createDenyNetworkPolicy(context.Background(), clientset, backend, NamespaceScope{}, request, RequestMetadata{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	intent, err := backend.intents().Get(ctx, "payments."+name, metav1.GetOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	scheme := runtime.NewScheme()
+	kind := isolationIntentResource.GroupVersion().WithKind("IsolationIntent")
+	scheme.AddKnownTypeWithName(kind, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(kind.GroupVersion().WithKind("IsolationIntentList"), &unstructured.UnstructuredList{})
+	intent.SetResourceVersion("")
+	controller := &intentController{
+		client:     ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(intent).WithStatusSubresource(intent).Build(),
+		reconciler: &intentReconciler{interval: time.Minute, backend: backend, clientset: clientset},
+		now:        time.Now,
+	}
+	reconcile := ctrl.Request{NamespacedName: types.NamespacedName{Name: intent.GetName()}}
+
+	result, err := controller.Reconcile(ctx, reconcile)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, result.RequeueAfter)
+	if condition := enforcedCondition(t, controller, intent.GetName()); assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, intentReasonInForce, condition.Reason)
+	}
+
+	// Deleted out of band
+	assert.NoError(t, backend.client.Resource(ciliumPolicyResource).Namespace("payments").Delete(ctx, name, metav1.DeleteOptions{}))
+	_, err = controller.Reconcile(ctx, reconcile)
+	assert.NoError(t, err)
+	_, err = backend.Get(ctx, "payments", name)
+	assert.NoError(t, err)
+	if condition := enforcedCondition(t, controller, intent.GetName()); assert.NotNil(t, condition) {
+		assert.Equal(t, intentReasonRestored, condition.Reason)
+		assert.Equal(t, "Recreated the policy deleted out of band", condition.Message)
+	}
+
+	// Out of scope, left alone
+	controller.reconciler.scope = NamespaceScope{Names: []string{"shop"}}
+	assert.NoError(t, backend.client.Resource(ciliumPolicyResource).Namespace("payments").Delete(ctx, name, metav1.DeleteOptions{}))
+	result, err = controller.Reconcile(ctx, reconcile)
+	assert.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+	_, err = backend.Get(ctx, "payments", name)
+	assert.Error(t, err)
+}
+
+func TestSetIntentCondition(t *testing.T) {
+	intent := newIsolationIntentObject()
+	intent.SetGeneration(2)
+	assert.NoError(t, setIntentCondition(intent, metav1.Condition{Type: intentConditionEnforced, Status: metav1.ConditionTrue, Reason: intentReasonInForce}))
+	observed, _, _ := unstructured.NestedInt64(intent.Object, "status", "observedGeneration")
+	assert.Equal(t, int64(2), observed)
+	conditions, _, _ := unstructured.NestedSlice(intent.Object, "status", "conditions")
+	if !assert.Len(t, conditions, 1) {
+		return
+	}
+	transition := conditions[0].(map[string]interface{})["lastTransitionTime"]
+	assert.NotEmpty(t, transition)
+
+	// The transition time only changes with the status
+	assert.NoError(t, setIntentCondition(intent, metav1.Condition{Type: intentConditionEnforced, Status: metav1.ConditionTrue, Reason: intentReasonRestored}))
+	conditions, _, _ = unstructured.NestedSlice(intent.Object, "status", "conditions")
+	assert.Equal(t, transition, conditions[0].(map[string]interface{})["lastTransitionTime"])
+	assert.Equal(t, intentReasonRestored, conditions[0].(map[string]interface{})["reason"])
+
+	assert.NoError(t, validateMode(modeOperator))
+	assert.Error(t, validateMode("controller"))
+}
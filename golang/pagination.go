@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// errInvalidContinue is returned for a continue token which wasn't issued for the namespaces in scope.
+var errInvalidContinue = errors.New("invalid continue token")
+
+// deploymentsPage asks for a page of the deployments in scope: a single list call of at most Limit deployments, from
+// the position given by Continue. A zero Limit lists every deployment.
+type deploymentsPage struct {
+	Limit    int64
+	Continue string
+}
+
+// paginated reports whether only a page of the deployments is listed.
+func (p deploymentsPage) paginated() bool {
+	return p.Limit > 0 || p.Continue != ""
+}
+
+// parseDeploymentsPage reads ?limit= and ?continue= of a request.
+func parseDeploymentsPage(query url.Values) (deploymentsPage, error) {
+	page := deploymentsPage{Continue: query.Get("continue")}
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || limit <= 0 {
+			return page, fmt.Errorf("invalid limit %q, expected a positive integer", value)
+		}
+		page.Limit = limit
+	}
+	return page, nil
+}
+
+// pageToken is the position of the next page: the namespace to list, metav1.NamespaceAll for the whole cluster, and
+// the continue token the API server returned for it, if any. Scopes listing several namespaces page through them in
+// turn, so the token has to name the namespace as well.
+type pageToken struct {
+	Namespace string `json:"namespace"`
+	Continue  string `json:"continue,omitempty"`
+}
+
+func (t pageToken) encode() string {
+	data, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageToken(value string) (pageToken, error) {
+	var token pageToken
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return token, errInvalidContinue
+	}
+	if err := json.Unmarshal(data, &token); err != nil {
+		return token, errInvalidContinue
+	}
+	return token, nil
+}
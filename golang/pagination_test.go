@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestParseDeploymentsPage(t *testing.T) {
+	page, err := parseDeploymentsPage(url.Values{"limit": {"50"}, "continue": {"abc"}})
+	assert.NoError(t, err)
+	assert.Equal(t, deploymentsPage{Limit: 50, Continue: "abc"}, page)
+	assert.True(t, page.paginated())
+
+	page, err = parseDeploymentsPage(url.Values{})
+	assert.NoError(t, err)
+	assert.False(t, page.paginated())
+
+	for _, limit := range []string{"0", "-1", "ten"} {
+		_, err = parseDeploymentsPage(url.Values{"limit": {limit}})
+		assert.Error(t, err, limit)
+	}
+}
+
+func TestListWorkloadsHealthPages(t *testing.T) {
+	replicas := int32(1)
+	deployment := func(namespace, name string) *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: appsv1.DeploymentSpec{Replicas: &replicas}}
+	}
+	clientset := fake.NewSimpleClientset(deployment("payments", "api"), deployment("shop", "cart"))
+	// The fake clientset ignores limits and doesn't pass on continue tokens, have payments take two pages
+	paymentsPages := 0
+	clientset.PrependReactor("list", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetNamespace() != "payments" {
+			return false, nil, nil
+		}
+		if paymentsPages++; paymentsPages == 1 {
+			return true, &appsv1.DeploymentList{ListMeta: metav1.ListMeta{Continue: "page-2"}, Items: []appsv1.Deployment{*deployment("payments", "api")}}, nil
+		}
+		return true, &appsv1.DeploymentList{Items: []appsv1.Deployment{*deployment("payments", "ledger")}}, nil
+	})
+	scope := NamespaceScope{Names: []string{"payments", "shop"}}
+	list := func(page deploymentsPage) ([]string, string) {
+		health, err := listWorkloadsHealth(context.Background(), clientset, scope, nil, nil, labels.Everything(), labels.Everything(), page)
+		assert.NoError(t, err)
+		var names []string
+		for _, deployment := range health.FailedDeployments {
+			names = append(names, deployment.Name)
+		}
+		return names, health.Continue
+	}
+
+	names, next := list(deploymentsPage{Limit: 1})
+	assert.Equal(t, []string{"api"}, names)
+	assert.Equal(t, pageToken{Namespace: "payments", Continue: "page-2"}.encode(), next)
+
+	names, next = list(deploymentsPage{Limit: 1, Continue: next})
+	assert.Equal(t, []string{"ledger"}, names)
+	assert.Equal(t, pageToken{Namespace: "shop"}.encode(), next)
+
+	names, next = list(deploymentsPage{Limit: 1, Continue: next})
+	assert.Equal(t, []string{"cart"}, names)
+	assert.Empty(t, next)
+
+	_, err := listWorkloadsHealth(context.Background(), clientset, scope, nil, nil, labels.Everything(), labels.Everything(), deploymentsPage{Continue: pageToken{Namespace: "kube-system"}.encode()})
+	assert.ErrorIs(t, err, errInvalidContinue)
+	_, err = listWorkloadsHealth(context.Background(), clientset, scope, nil, nil, labels.Everything(), labels.Everything(), deploymentsPage{Continue: "not a token"})
+	assert.ErrorIs(t, err, errInvalidContinue)
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+type PDBInfo struct {
+	Name      string `json:"pdb_name"`
+	Namespace string `json:"namespace"`
+	Selector  string `json:"selector"`
+	// MinAvailable and MaxUnavailable are a number or a percentage of pods, only one of them being set
+	MinAvailable   string `json:"min_available,omitempty"`
+	MaxUnavailable string `json:"max_unavailable,omitempty"`
+	// MatchedPods counts the pods which haven't terminated its selector matches
+	MatchedPods        int   `json:"matched_pods"`
+	CurrentHealthy     int32 `json:"current_healthy"`
+	DesiredHealthy     int32 `json:"desired_healthy"`
+	DisruptionsAllowed int32 `json:"disruptions_allowed"`
+}
+
+// UnprotectedWorkload is a Deployment or StatefulSet whose pods no PDB selects, which a drain may evict all at once.
+type UnprotectedWorkload struct {
+	WorkloadReference
+	Replicas int32 `json:"replicas"`
+}
+
+type PDBReport struct {
+	// BlockingPDBs allow no disruption of the pods they match, so a drain evicting them blocks
+	BlockingPDBs []PDBInfo `json:"blocking_pdbs"`
+	// UnmatchedPDBs select no pods, they most likely have a stale selector
+	UnmatchedPDBs        []PDBInfo             `json:"unmatched_pdbs"`
+	UnprotectedWorkloads []UnprotectedWorkload `json:"unprotected_workloads"`
+}
+
+// Reports the PodDisruptionBudgets which would block a node drain or match no pods, and the workloads without any,
+// filtered like the deployments of clusterDeploymentsInfoHandler
+//
+// ?labelSelector= matches the labels of the PDBs and workloads themselves, the pods being matched whatever their labels.
+func (s *Server) pdbInfoHandler(w http.ResponseWriter, r *http.Request) {
+	filter, status, err := s.parseReportFilter(r)
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+
+	report, err := getPDBReport(r.Context(), s.K8sClientSet, filter.Scope, filter.Selector)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	report.excludeNamespaces(filter.excludedNamespaces(s.ExcludedNamespaces))
+	writeJSON(w, http.StatusOK, report)
+}
+
+// getPDBReport checks the PDBs of every namespace in scope against the pods of their namespace, and the Deployments
+// and StatefulSets with replicas against the PDBs, those whose own labels match selector.
+func getPDBReport(ctx context.Context, clientset kubernetes.Interface, scope NamespaceScope, selector labels.Selector) (*PDBReport, error) {
+	namespaces, err := scope.resolve(ctx, clientset)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PDBReport{BlockingPDBs: []PDBInfo{}, UnmatchedPDBs: []PDBInfo{}, UnprotectedWorkloads: []UnprotectedWorkload{}}
+	for _, namespace := range namespaces {
+		pdbs, err := clientset.PolicyV1().PodDisruptionBudgets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{FieldSelector: "status.phase!=Succeeded,status.phase!=Failed"})
+		if err != nil {
+			return nil, err
+		}
+		workloads, err := listPDBWorkloads(ctx, clientset, namespace, selector)
+		if err != nil {
+			return nil, err
+		}
+
+		// The selectors of the PDBs by namespace, a workload being protected by any of its namespace
+		selectors := map[string][]labels.Selector{}
+		for _, pdb := range pdbs.Items {
+			pdbSelector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+			if err != nil {
+				slog.WarnContext(ctx, "Invalid PDB selector", "namespace", pdb.Namespace, "pdb", pdb.Name, "error", err)
+				continue
+			}
+			// A policy/v1 PDB without a selector matches no pods
+			if pdb.Spec.Selector == nil {
+				pdbSelector = labels.Nothing()
+			}
+			selectors[pdb.Namespace] = append(selectors[pdb.Namespace], pdbSelector)
+			if !selector.Matches(labels.Set(pdb.Labels)) {
+				continue
+			}
+
+			info := newPDBInfo(pdb)
+			for _, pod := range pods.Items {
+				if pod.Namespace == pdb.Namespace && pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed && pdbSelector.Matches(labels.Set(pod.Labels)) {
+					info.MatchedPods++
+				}
+			}
+			switch {
+			case info.MatchedPods == 0:
+				report.UnmatchedPDBs = append(report.UnmatchedPDBs, info)
+			case info.DisruptionsAllowed == 0:
+				report.BlockingPDBs = append(report.BlockingPDBs, info)
+			}
+		}
+
+		for _, workload := range workloads {
+			protected := slices.ContainsFunc(selectors[workload.Namespace], func(pdbSelector labels.Selector) bool {
+				return pdbSelector.Matches(labels.Set(workload.template.Labels))
+			})
+			if !protected {
+				report.UnprotectedWorkloads = append(report.UnprotectedWorkloads, workload.UnprotectedWorkload)
+			}
+		}
+	}
+	sort.Slice(report.UnprotectedWorkloads, func(i, j int) bool {
+		a, b := report.UnprotectedWorkloads[i], report.UnprotectedWorkloads[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	slog.DebugContext(ctx, "PDB report", "blocking", len(report.BlockingPDBs), "unmatched", len(report.UnmatchedPDBs), "unprotected", len(report.UnprotectedWorkloads))
+	return report, nil
+}
+
+func newPDBInfo(pdb policyv1.PodDisruptionBudget) PDBInfo {
+	info := PDBInfo{
+		Name:               pdb.Name,
+		Namespace:          pdb.Namespace,
+		Selector:           metav1.FormatLabelSelector(pdb.Spec.Selector),
+		CurrentHealthy:     pdb.Status.CurrentHealthy,
+		DesiredHealthy:     pdb.Status.DesiredHealthy,
+		DisruptionsAllowed: pdb.Status.DisruptionsAllowed,
+	}
+	if pdb.Spec.MinAvailable != nil {
+		info.MinAvailable = pdb.Spec.MinAvailable.String()
+	}
+	if pdb.Spec.MaxUnavailable != nil {
+		info.MaxUnavailable = pdb.Spec.MaxUnavailable.String()
+	}
+	return info
+}
+
+// pdbWorkload is a workload checked by the labels of its pod template.
+type pdbWorkload struct {
+	UnprotectedWorkload
+	template corev1.PodTemplateSpec
+}
+
+// listPDBWorkloads returns the Deployments and StatefulSets of namespace with replicas whose own labels match selector.
+func listPDBWorkloads(ctx context.Context, clientset kubernetes.Interface, namespace string, selector labels.Selector) ([]pdbWorkload, error) {
+	var workloads []pdbWorkload
+	add := func(kind string, meta metav1.ObjectMeta, replicas *int32, template corev1.PodTemplateSpec) {
+		if replicas != nil && *replicas == 0 {
+			return
+		}
+		workload := pdbWorkload{UnprotectedWorkload{WorkloadReference{kind, meta.Namespace, meta.Name}, 1}, template}
+		if replicas != nil {
+			workload.Replicas = *replicas
+		}
+		workloads = append(workloads, workload)
+	}
+
+	deployments, err := clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	for _, deployment := range deployments.Items {
+		add(workloadKindDeployment, deployment.ObjectMeta, deployment.Spec.Replicas, deployment.Spec.Template)
+	}
+	statefulSets, err := clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	for _, statefulSet := range statefulSets.Items {
+		add("StatefulSet", statefulSet.ObjectMeta, statefulSet.Spec.Replicas, statefulSet.Spec.Template)
+	}
+	return workloads, nil
+}
+
+// excludeNamespaces removes the PDBs and workloads of namespaces from every category of the report.
+func (report *PDBReport) excludeNamespaces(namespaces []string) {
+	if len(namespaces) == 0 {
+		return
+	}
+	excluded := func(pdb PDBInfo) bool { return slices.Contains(namespaces, pdb.Namespace) }
+	report.BlockingPDBs = slices.DeleteFunc(report.BlockingPDBs, excluded)
+	report.UnmatchedPDBs = slices.DeleteFunc(report.UnmatchedPDBs, excluded)
+	report.UnprotectedWorkloads = slices.DeleteFunc(report.UnprotectedWorkloads, func(workload UnprotectedWorkload) bool {
+		return slices.Contains(namespaces, workload.Namespace)
+	})
+}
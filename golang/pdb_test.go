@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetPDBReport(t *testing.T) {
+	pdb := func(name, app string, allowed int32) *policyv1.PodDisruptionBudget {
+		minAvailable := intstr.FromInt(1)
+		return &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "payments"},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				MinAvailable: &minAvailable,
+				Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+			},
+			Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: allowed},
+		}
+	}
+	pod := func(name, app string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "payments", Labels: map[string]string{"app": app}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+	deployment := func(name string, replicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "payments"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": name}}},
+			},
+		}
+	}
+	clientset := fake.NewSimpleClientset(
+		pdb("ledger", "ledger", 1),
+		pdb("gateway", "gateway", 0),
+		pdb("legacy", "billing-v1", 0),
+		pod("ledger-1", "ledger"),
+		pod("ledger-2", "ledger"),
+		pod("gateway-1", "gateway"),
+		deployment("ledger", 2),
+		deployment("gateway", 1),
+		deployment("worker", 3),
+		deployment("scaled-down", 0),
+	)
+
+	report, err := getPDBReport(context.Background(), clientset, NamespaceScope{}, labels.Everything())
+	assert.NoError(t, err)
+	if assert.Len(t, report.BlockingPDBs, 1) {
+		assert.Equal(t, "gateway", report.BlockingPDBs[0].Name)
+		assert.Equal(t, 1, report.BlockingPDBs[0].MatchedPods)
+		assert.Equal(t, "1", report.BlockingPDBs[0].MinAvailable)
+		assert.Equal(t, "app=gateway", report.BlockingPDBs[0].Selector)
+	}
+	if assert.Len(t, report.UnmatchedPDBs, 1) {
+		assert.Equal(t, "legacy", report.UnmatchedPDBs[0].Name)
+	}
+	assert.Equal(t, []UnprotectedWorkload{{WorkloadReference{"Deployment", "payments", "worker"}, 3}}, report.UnprotectedWorkloads)
+
+	report.excludeNamespaces([]string{"payments"})
+	assert.Empty(t, report.BlockingPDBs)
+	assert.Empty(t, report.UnprotectedWorkloads)
+}
@@ -0,0 +1,312 @@
+// Package client is a typed Go client of the tyk-sre-assignment API, generated from api/openapi.yaml into
+// client.gen.go.
+//
+// The generated methods of Client, such as DenyNetworkPolicyWithResponse, send their requests through a transport
+// retrying them when the server didn't process them (429 and 503 responses) and, for reads only, on network errors
+// and gateway errors. Mutating requests are signed when a signing secret is set. The responses which aren't 2xx are
+// returned as an *APIError, so only the success fields of the generated responses, such as JSON200, are ever set.
+package client
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@v2.5.0 -config oapi-codegen.yaml ../../api/openapi.yaml
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultBackoff    = time.Second
+	maxBackoff        = 30 * time.Second
+	maxErrorBody      = 1 << 20
+)
+
+// Client calls the API at a base URL, it's safe for concurrent use.
+type Client struct {
+	*ClientWithResponses
+
+	baseURL       *url.URL
+	httpClient    HttpRequestDoer
+	maxRetries    int
+	backoff       time.Duration
+	bearerToken   string
+	user          string
+	groups        []string
+	signingSecret []byte
+	cluster       string
+	now           func() time.Time
+	sleep         func(ctx context.Context, d time.Duration) error
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPDoer replaces http.DefaultClient, e.g. with an *http.Client setting timeouts or TLS settings.
+func WithHTTPDoer(doer HttpRequestDoer) Option {
+	return func(c *Client) { c.httpClient = doer }
+}
+
+// WithRetries sets how many times a request is retried and the initial backoff, doubled on every attempt.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+// WithBearerToken sends the token in the Authorization header of every request.
+func WithBearerToken(token string) Option {
+	return func(c *Client) { c.bearerToken = token }
+}
+
+// WithProxyUser asserts the caller identity as a trusted authenticating proxy. The server only honours it on
+// connections presenting a client certificate signed by its proxy_client_ca, set on the transport of WithHTTPDoer.
+func WithProxyUser(user string, groups ...string) Option {
+	return func(c *Client) {
+		c.user = user
+		c.groups = groups
+	}
+}
+
+// WithSigningSecret signs mutating requests with the secret passed to the server with --request-signing-secret-file.
+func WithSigningSecret(secret []byte) Option {
+	return func(c *Client) { c.signingSecret = bytes.TrimSpace(secret) }
+}
+
+// WithCluster sends every request to the cluster the server manages under name, instead of its default one, unless
+// the request sets its own cluster parameter.
+func WithCluster(name string) Option {
+	return func(c *Client) { c.cluster = name }
+}
+
+// New returns a client of the API served at baseURL, e.g. http://tyk-sre-assignment.ops:8080.
+func New(baseURL string, options ...Option) (*Client, error) {
+	parsed, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("base URL %q must be http or https", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    parsed,
+		httpClient: http.DefaultClient,
+		maxRetries: defaultMaxRetries,
+		backoff:    defaultBackoff,
+		now:        time.Now,
+		sleep:      sleepContext,
+	}
+	for _, option := range options {
+		option(c)
+	}
+
+	if c.ClientWithResponses, err = NewClientWithResponses(parsed.String(), WithHTTPClient(doerFunc(c.do))); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// doerFunc sends the requests of the generated client.
+type doerFunc func(*http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// APIError is a non-2xx response of the API.
+//
+// Code and Details are those of the JSON error response, empty when the error didn't come from the API itself, such
+// as the plain text error of a proxy, whose body is the Message. Body holds the response, such as the SelftestReport
+// of a failed self-test.
+type APIError struct {
+	StatusCode    int
+	Code          string
+	Message       string
+	Details       []ErrorDetail
+	CorrelationID string
+	RetryAfter    time.Duration
+	Body          []byte
+}
+
+func (e *APIError) Error() string {
+	if e.CorrelationID != "" {
+		return fmt.Sprintf("%d %s (correlation ID %s)", e.StatusCode, e.Message, e.CorrelationID)
+	}
+	return fmt.Sprintf("%d %s", e.StatusCode, e.Message)
+}
+
+type correlationIDKey struct{}
+
+// WithCorrelationID sends the correlation ID with every request made with the returned context, unless the request sets
+// its own X-Correlation-ID parameter.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// do sends a request built by the generated client, retrying it when that's safe, and returns the first successful
+// response, the caller must close its body.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		resp, err := c.attempt(req, body)
+		if err == nil && resp.StatusCode/100 == 2 {
+			return resp, nil
+		}
+
+		var retryAfter time.Duration
+		retryable := err != nil && isIdempotent(req.Method)
+		if err == nil {
+			apiErr := newAPIError(resp)
+			retryAfter = apiErr.RetryAfter
+			retryable = isRetryable(req.Method, resp.StatusCode)
+			err = apiErr
+		}
+		if !retryable || attempt >= c.maxRetries || ctx.Err() != nil {
+			return nil, err
+		}
+
+		if err := c.sleep(ctx, c.retryDelay(attempt, retryAfter)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) attempt(original *http.Request, body []byte) (*http.Response, error) {
+	req := original.Clone(original.Context())
+	req.Body, req.ContentLength = http.NoBody, 0
+	if body != nil {
+		req.Body, req.ContentLength = io.NopCloser(bytes.NewReader(body)), int64(len(body))
+	}
+	if query := req.URL.Query(); c.cluster != "" && query.Get("cluster") == "" {
+		query.Set("cluster", c.cluster)
+		req.URL.RawQuery = query.Encode()
+	}
+
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
+	if c.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+	}
+	if c.user != "" {
+		req.Header.Set("X-Remote-User", c.user)
+		for _, group := range c.groups {
+			req.Header.Add("X-Remote-Group", group)
+		}
+	}
+	ctx := req.Context()
+	if id, ok := ctx.Value(correlationIDKey{}).(string); ok && req.Header.Get("X-Correlation-ID") == "" {
+		req.Header.Set("X-Correlation-ID", id)
+	}
+	if id, ok := ctx.Value(lastEventIDKey{}).(string); ok {
+		req.Header.Set("Last-Event-ID", id)
+	}
+	// Signed on every attempt, the server rejects a signature it has already seen
+	if c.signingSecret != nil && req.Method != http.MethodGet && req.Method != http.MethodHead {
+		timestamp := strconv.FormatInt(c.now().Unix(), 10)
+		req.Header.Set("X-Signature-Timestamp", timestamp)
+		req.Header.Set("X-Signature", sign(c.signingSecret, timestamp, req.Method, req.URL.RequestURI(), body))
+	}
+
+	return c.httpClient.Do(req)
+}
+
+// sign returns the signature of a request to requestURI, its escaped path and query string.
+func sign(secret []byte, timestamp, method, requestURI string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp + "\n" + method + "\n" + requestURI + "\n"))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newAPIError(resp *http.Response) *APIError {
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	apiErr := &APIError{
+		StatusCode:    resp.StatusCode,
+		Message:       strings.TrimSpace(string(data)),
+		CorrelationID: resp.Header.Get("X-Correlation-ID"),
+		Body:          data,
+	}
+	var body ErrorResponse
+	if json.Unmarshal(data, &body) == nil && body.Code != "" {
+		apiErr.Code, apiErr.Message, apiErr.Details = body.Code, body.Message, body.Details
+		if apiErr.CorrelationID == "" && body.RequestID != nil {
+			apiErr.CorrelationID = *body.RequestID
+		}
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return apiErr
+}
+
+func isIdempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodPut || method == http.MethodDelete
+}
+
+// isRetryable reports whether a request that got status can be sent again.
+//
+// 429 and 503 are answered before anything is changed, gateway errors may hide a processed request so only reads are retried.
+func isRetryable(method string, status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	}
+	return false
+}
+
+func (c *Client) retryDelay(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+
+	delay := c.backoff << attempt
+	if delay > maxBackoff || delay <= 0 {
+		delay = maxBackoff
+	}
+	// Signatures have a one second resolution, a retry within the same second would be rejected as a replay
+	if c.signingSecret != nil && delay < time.Second {
+		delay = time.Second
+	}
+	// Up to 20% jitter so clients rejected together don't retry together
+	return delay + time.Duration(rand.Int63n(int64(delay)/5+1))
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
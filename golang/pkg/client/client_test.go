@@ -0,0 +1,261 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc, options ...Option) *Client {
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	c, err := New(ts.URL, append([]Option{WithRetries(3, time.Millisecond)}, options...)...)
+	assert.NoError(t, err)
+	c.sleep = func(context.Context, time.Duration) error { return nil }
+	return c
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestRetriesRejectedRequests(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "budget exhausted", http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(BulkIsolationResult{Matched: ptr(2)})
+	})
+
+	resp, err := c.BulkIsolateWithResponse(context.Background(), nil, BulkIsolationRequest{Selector: "app=legacy", Target: Workload{Alias: ptr("ledger")}})
+	assert.NoError(t, err)
+	if assert.NotNil(t, resp.JSON200) {
+		assert.Equal(t, 2, *resp.JSON200.Matched)
+	}
+	assert.Equal(t, int32(3), calls)
+}
+
+func TestDoesNotRetryUnsafeRequests(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("X-Correlation-ID", "run-42")
+		http.Error(w, "upstream failed", http.StatusBadGateway)
+	})
+
+	_, err := c.DenyNetworkPolicyWithResponse(context.Background(), nil, DenyNetworkRequest{})
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+		assert.Equal(t, "upstream failed", apiErr.Message)
+		assert.Equal(t, "run-42", apiErr.CorrelationID)
+	}
+	assert.Equal(t, int32(1), calls)
+
+	_, err = c.GetClusterDeploymentsInfoWithResponse(context.Background(), nil)
+	assert.Error(t, err)
+	assert.Equal(t, int32(5), calls)
+}
+
+func TestAPIErrorReadsErrorResponses(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code": "invalid_request", "message": "invalid scope \"cluster\", expected namespace or global", "details": [{"field": "scope", "reason": "invalid scope \"cluster\", expected namespace or global"}], "request_id": "run-42"}`))
+	})
+
+	_, err := c.DenyNetworkPolicyWithResponse(context.Background(), nil, DenyNetworkRequest{Scope: ptr(DenyNetworkRequestScope("cluster"))})
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, "invalid_request", apiErr.Code)
+		assert.Equal(t, `invalid scope "cluster", expected namespace or global`, apiErr.Message)
+		assert.Equal(t, []ErrorDetail{{Field: ptr("scope"), Reason: `invalid scope "cluster", expected namespace or global`}}, apiErr.Details)
+		assert.Equal(t, "run-42", apiErr.CorrelationID)
+	}
+}
+
+func TestAuthAndSigningHeaders(t *testing.T) {
+	secret := []byte("s3cret")
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, "alice", r.Header.Get("X-Remote-User"))
+		assert.Equal(t, []string{"sre", "oncall"}, r.Header.Values("X-Remote-Group"))
+		assert.Equal(t, "run-42", r.Header.Get("X-Correlation-ID"))
+		assert.Equal(t, "/api/v1/exec/payments/ledger-1", r.URL.Path)
+
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"command":"nslookup","args":["ledger.payments"]}`, string(body))
+		assert.Equal(t, "1720440000", r.Header.Get("X-Signature-Timestamp"))
+		assert.Equal(t, sign(secret, "1720440000", http.MethodPost, r.URL.RequestURI(), body), r.Header.Get("X-Signature"))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ExecResult{ExitCode: ptr(0), Stdout: ptr("Address: 10.0.0.1")})
+	}, WithBearerToken("token"), WithProxyUser("alice", "sre", "oncall"), WithSigningSecret(secret))
+	c.now = func() time.Time { return time.Unix(1720440000, 0) }
+
+	ctx := WithCorrelationID(context.Background(), "run-42")
+	resp, err := c.ExecPodWithResponse(ctx, "payments", "ledger-1", nil, ExecRequest{Command: Nslookup, Args: []string{"ledger.payments"}})
+	assert.NoError(t, err)
+	if assert.NotNil(t, resp.JSON200) {
+		assert.Equal(t, "Address: 10.0.0.1", *resp.JSON200.Stdout)
+	}
+}
+
+func TestWithCluster(t *testing.T) {
+	secret := []byte("s3cret")
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "eu-west", r.URL.Query().Get("cluster"))
+		assert.Equal(t, "true", r.URL.Query().Get("dryRun"))
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, sign(secret, r.Header.Get("X-Signature-Timestamp"), http.MethodPost, "/denyNetworkPolicy?cluster=eu-west&dryRun=true", body), r.Header.Get("X-Signature"))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{})
+	}, WithCluster("eu-west"), WithSigningSecret(secret))
+
+	_, err := c.DenyNetworkPolicyWithResponse(context.Background(), &DenyNetworkPolicyParams{DryRun: ptr(true)}, DenyNetworkRequest{})
+	assert.NoError(t, err)
+
+	c = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "us-east", r.URL.Query().Get("cluster"))
+	}, WithCluster("eu-west"))
+
+	_, err = c.PromoteGlobalDenyNetworkPolicyWithResponse(context.Background(), "deny-ledger", Promote, &PromoteGlobalDenyNetworkPolicyParams{Cluster: ptr(Cluster("us-east"))})
+	assert.NoError(t, err)
+}
+
+func TestFilteredClusterDeploymentsInfo(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/clusterdeploymentsinfo", r.URL.Path)
+		assert.Equal(t, url.Values{"namespace": {"payments"}, "labelSelector": {"tier in (web,edge)"}, "limit": {"100"}, "continue": {"abc"}}, r.URL.Query())
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ClusterDeploymentsInfo{})
+	})
+
+	resp, err := c.GetClusterDeploymentsInfoWithResponse(context.Background(), &GetClusterDeploymentsInfoParams{Namespace: ptr("payments"), LabelSelector: ptr("tier in (web,edge)"), Limit: ptr(int64(100)), Continue: ptr("abc")})
+	assert.NoError(t, err)
+	assert.NotNil(t, resp.JSON200)
+}
+
+func TestClusterDaemonSetsInfo(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/clusterdaemonsetsinfo", r.URL.Path)
+		assert.Equal(t, url.Values{"labelSelector": {"k8s-app=calico-node"}, "excludeNamespaces": {"logging,monitoring"}}, r.URL.Query())
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ClusterDaemonSetsInfo{})
+	})
+
+	resp, err := c.GetClusterDaemonSetsInfoWithResponse(context.Background(), &GetClusterDaemonSetsInfoParams{LabelSelector: ptr("k8s-app=calico-node"), ExcludeNamespaces: ptr("logging,monitoring")})
+	assert.NoError(t, err)
+	assert.NotNil(t, resp.JSON200)
+}
+
+func TestStream(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+		if atomic.AddInt32(&calls, 1) == 1 {
+			assert.Empty(t, r.Header.Get("Last-Event-ID"))
+			fmt.Fprint(w, ": keepalive\n\nid: 1\nevent: health\ndata: {\"a\":\ndata: 1}\n\nid: 2\ndata: second\n\n")
+			return
+		}
+		assert.Equal(t, "2", r.Header.Get("Last-Event-ID"))
+		fmt.Fprint(w, "id: 3\nevent: health\ndata: third\n\n")
+	})
+
+	var events []Event
+	err := c.Stream(context.Background(), "/api/v1/events", nil, func(event Event) error {
+		events = append(events, event)
+		if event.ID == "3" {
+			return ErrStopStream
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []Event{
+		{ID: "1", Type: "health", Data: "{\"a\":\n1}"},
+		{ID: "2", Data: "second"},
+		{ID: "3", Type: "health", Data: "third"},
+	}, events)
+}
+
+func TestStreamDeployments(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/stream/deployments", r.URL.Path)
+		assert.Equal(t, url.Values{"namespace": {"shop"}, "cluster": {"eu-west"}}, r.URL.Query())
+		fmt.Fprint(w, ": keep-alive\n\nevent: transition\ndata: {\"namespace\":\"shop\",\"deployment_name\":\"cart\",\"from\":\"ready\",\"to\":\"failed\"}\n\n")
+	}, WithCluster("eu-west"))
+
+	var transitions []DeploymentTransition
+	err := c.StreamDeployments(context.Background(), &StreamDeploymentsParams{Namespace: ptr("shop")}, func(transition DeploymentTransition) error {
+		transitions = append(transitions, transition)
+		return ErrStopStream
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []DeploymentTransition{{Namespace: ptr("shop"), DeploymentName: ptr("cart"), From: ptr(DeploymentTransitionFrom("ready")), To: ptr(DeploymentTransitionTo("failed"))}}, transitions)
+}
+
+func TestSelftestReturnsFailedReport(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(SelftestReport{Namespace: ptr("selftest-abcde"), Steps: []SelftestStep{{Name: ptr("deploy test pods"), Error: ptr("image pull failed")}}})
+	})
+
+	_, err := c.RunSelftestWithResponse(context.Background(), nil)
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		var report SelftestReport
+		assert.NoError(t, json.Unmarshal(apiErr.Body, &report))
+		assert.Equal(t, "selftest-abcde", *report.Namespace)
+		assert.Equal(t, "image pull failed", *report.Steps[0].Error)
+	}
+}
+
+func TestNewRejectsInvalidBaseURL(t *testing.T) {
+	_, err := New("tyk-sre-assignment:8080")
+	assert.Error(t, err)
+}
+
+func TestDryRunDenyNetworkPolicy(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("dryRun"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"NetworkPolicy","metadata":{"name":"deny-network-policy-0123456789abcdef"}}`)
+	})
+
+	resp, err := c.DenyNetworkPolicyWithResponse(context.Background(), &DenyNetworkPolicyParams{DryRun: ptr(true)}, DenyNetworkRequest{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"kind":"NetworkPolicy","metadata":{"name":"deny-network-policy-0123456789abcdef"}}`, string(resp.Body))
+}
+
+func TestAuditEvents(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/audit", r.URL.Path)
+		assert.Equal(t, url.Values{"principal": {"alice"}, "since": {"6h"}, "limit": {"20"}}, r.URL.Query())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"time": "2024-03-01T12:00:00Z", "action": "node.drain", "principal": "alice", "target": "worker-1", "result": "drained"}]`))
+	})
+
+	resp, err := c.ListAuditEventsWithResponse(context.Background(), &ListAuditEventsParams{Principal: ptr("alice"), Since: ptr("6h"), Limit: ptr(20)})
+	assert.NoError(t, err)
+	if assert.NotNil(t, resp.JSON200) && assert.Len(t, *resp.JSON200, 1) {
+		events := *resp.JSON200
+		assert.Equal(t, "worker-1", *events[0].Target)
+		assert.Equal(t, "alice", *events[0].Principal)
+	}
+}
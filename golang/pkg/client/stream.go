@@ -0,0 +1,141 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Event is a Server-Sent Event read from a streaming endpoint.
+type Event struct {
+	ID   string
+	Type string
+	Data string
+}
+
+// ErrStopStream can be returned by a stream handler to stop streaming without an error.
+var ErrStopStream = errors.New("stop stream")
+
+// Stream reads the Server-Sent Events of a streaming endpoint and calls handle for each of them until ctx is done,
+// handle returns an error or the retries are exhausted.
+//
+// Dropped connections are resumed with the Last-Event-ID of the last event handled.
+func (c *Client) Stream(ctx context.Context, path string, query url.Values, handle func(Event) error) error {
+	return c.stream(ctx, func() (*http.Request, error) {
+		// path holds escaped segments, parsing keeps them escaped on the wire and unescaped in u.Path
+		u, err := url.Parse(c.baseURL.String() + path)
+		if err != nil {
+			return nil, err
+		}
+		u.RawQuery = query.Encode()
+		return http.NewRequest(http.MethodGet, u.String(), nil)
+	}, handle)
+}
+
+// StreamDeployments calls handle with each transition of a deployment matching every set field of params between the
+// ready, failed, stuck and progressing states, until ctx is done or handle returns an error, reconnecting like Stream.
+func (c *Client) StreamDeployments(ctx context.Context, params *StreamDeploymentsParams, handle func(DeploymentTransition) error) error {
+	return c.stream(ctx, func() (*http.Request, error) {
+		// The generated requests resolve their path against the server URL, which needs a trailing slash
+		return NewStreamDeploymentsRequest(c.baseURL.String()+"/", params)
+	}, func(event Event) error {
+		if event.Type != "transition" {
+			return nil
+		}
+		var transition DeploymentTransition
+		if err := json.Unmarshal([]byte(event.Data), &transition); err != nil {
+			return err
+		}
+		return handle(transition)
+	})
+}
+
+func (c *Client) stream(ctx context.Context, newRequest func() (*http.Request, error), handle func(Event) error) error {
+	lastEventID := ""
+	for {
+		streamCtx := ctx
+		if lastEventID != "" {
+			streamCtx = context.WithValue(ctx, lastEventIDKey{}, lastEventID)
+		}
+
+		req, err := newRequest()
+		if err != nil {
+			return err
+		}
+		req = req.WithContext(streamCtx)
+		req.Header.Set("Accept", "text/event-stream")
+		resp, err := c.do(req)
+		if err != nil {
+			return err
+		}
+
+		err = readEvents(resp.Body, func(event Event) error {
+			if event.ID != "" {
+				lastEventID = event.ID
+			}
+			return handle(event)
+		})
+		resp.Body.Close()
+
+		switch {
+		case errors.Is(err, ErrStopStream):
+			return nil
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case err != nil && !isStreamInterrupted(err):
+			return err
+		}
+
+		if err := c.sleep(ctx, c.retryDelay(0, 0)); err != nil {
+			return err
+		}
+	}
+}
+
+type lastEventIDKey struct{}
+
+// readEvents parses the event stream, returning nil when the server closes it.
+func readEvents(r io.Reader, handle func(Event) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var event Event
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if len(data) > 0 {
+				event.Data = strings.Join(data, "\n")
+				if err := handle(event); err != nil {
+					return err
+				}
+			}
+			event, data = Event{}, nil
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			event.ID = value
+		case "event":
+			event.Type = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+	return scanner.Err()
+}
+
+func isStreamInterrupted(err error) bool {
+	return errors.Is(err, io.ErrUnexpectedEOF) || strings.Contains(err.Error(), "connection reset")
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// logsBufferSize is how much of the logs is read at once, each read flushed to the client when following.
+const logsBufferSize = 32 * 1024
+
+// Handler proxying the logs of a container of a pod from its kubelet through the API server
+//
+// ?container= picks the container, which pods with several need, ?tailLines= the number of lines from the end and
+// ?previous=true the logs of the previous run of a restarted container. With ?follow=true the response is streamed
+// until the container stops or the client goes away.
+func (s *Server) podLogsHandler(w http.ResponseWriter, r *http.Request) {
+	namespace, pod := r.PathValue("namespace"), r.PathValue("pod")
+	if !s.authorize(w, r, operationPodLogs, namespace, pod, nil) {
+		return
+	}
+
+	if err := s.Namespaces.check(r.Context(), s.K8sClientSet, namespace); err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+	options, err := parseLogOptions(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	stream, err := s.K8sClientSet.CoreV1().Pods(namespace).GetLogs(pod, options).Stream(r.Context())
+	switch {
+	case apierrors.IsNotFound(err):
+		writeError(w, http.StatusNotFound, err)
+		return
+	case apierrors.IsBadRequest(err):
+		// Such as a missing container name, or no previous run
+		writeError(w, http.StatusBadRequest, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	defer stream.Close()
+
+	slog.DebugContext(r.Context(), "Streaming pod logs", "namespace", namespace, "pod", pod, "container", options.Container, "follow", options.Follow)
+	writeLogs(r.Context(), w, stream, options.Follow)
+}
+
+// parseLogOptions reads the log options of a request.
+func parseLogOptions(query url.Values) (*corev1.PodLogOptions, error) {
+	options := &corev1.PodLogOptions{Container: query.Get("container")}
+	if value := query.Get("tailLines"); value != "" {
+		lines, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || lines < 0 {
+			return nil, fmt.Errorf("invalid tailLines %q, expected a positive integer", value)
+		}
+		options.TailLines = &lines
+	}
+	for name, field := range map[string]*bool{"follow": &options.Follow, "previous": &options.Previous} {
+		if value := query.Get(name); value != "" {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s %q, expected true or false", name, value)
+			}
+			*field = parsed
+		}
+	}
+	return options, nil
+}
+
+// writeLogs copies the logs to the response, flushing every read when following them.
+func writeLogs(ctx context.Context, w http.ResponseWriter, stream io.Reader, follow bool) {
+	controller := http.NewResponseController(w)
+	if follow {
+		// Following outlives any write deadline of the server
+		_ = controller.SetWriteDeadline(time.Time{})
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	buffer := make([]byte, logsBufferSize)
+	for {
+		n, err := stream.Read(buffer)
+		if n > 0 {
+			if _, err := w.Write(buffer[:n]); err != nil {
+				return
+			}
+			if follow {
+				_ = controller.Flush()
+			}
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+				slog.WarnContext(ctx, "Failed reading pod logs", "error", err)
+			}
+			return
+		}
+	}
+}
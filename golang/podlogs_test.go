@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLogOptions(t *testing.T) {
+	options, err := parseLogOptions(url.Values{"container": {"app"}, "tailLines": {"100"}, "follow": {"true"}, "previous": {"1"}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "app", options.Container)
+		assert.Equal(t, int64(100), *options.TailLines)
+		assert.True(t, options.Follow)
+		assert.True(t, options.Previous)
+	}
+
+	options, err = parseLogOptions(url.Values{})
+	if assert.NoError(t, err) {
+		assert.Nil(t, options.TailLines)
+		assert.False(t, options.Follow)
+	}
+
+	for _, query := range []url.Values{{"tailLines": {"-1"}}, {"tailLines": {"ten"}}, {"follow": {"yes please"}}} {
+		_, err := parseLogOptions(query)
+		assert.Error(t, err, query.Encode())
+	}
+}
+
+func TestWriteLogs(t *testing.T) {
+	rec := httptest.NewRecorder()
+	// Small reads, as a followed stream gets them
+	writeLogs(context.TODO(), rec, iotest.OneByteReader(strings.NewReader("line 1\nline 2\n")), true)
+	assert.Equal(t, "line 1\nline 2\n", rec.Body.String())
+	assert.Equal(t, "text/plain; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.True(t, rec.Flushed)
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Results of a pod remediation
+const (
+	podEvicted      = "evicted"
+	podForceDeleted = "force_deleted"
+)
+
+type PodRemediation struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod_name"`
+	Result    string `json:"result"`
+}
+
+// Handler evicting a pod through the eviction API, which a PodDisruptionBudget refuses with a 429
+//
+// With ?force=true a pod stuck terminating is deleted without waiting for its kubelet to confirm its containers
+// stopped, like kubectl delete --force --grace-period=0. Pods which aren't terminating get a 409.
+func (s *Server) evictPodHandler(w http.ResponseWriter, r *http.Request) {
+	namespace, name := r.PathValue("namespace"), r.PathValue("name")
+
+	if err := s.Namespaces.check(r.Context(), s.K8sClientSet, namespace); err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+	var force bool
+	if value := r.URL.Query().Get("force"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			writeErrorf(w, http.StatusBadRequest, "invalid force %q, expected true or false", value)
+			return
+		}
+		force = parsed
+	}
+	operation, result := operationPodEvict, podEvicted
+	if force {
+		operation, result = operationPodForceDelete, podForceDeleted
+	}
+
+	if !s.authorize(w, r, operation, namespace, name, nil) {
+		return
+	}
+
+	pod, err := s.K8sClientSet.CoreV1().Pods(namespace).Get(r.Context(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if force && pod.DeletionTimestamp == nil {
+		writeErrorf(w, http.StatusConflict, "Pod %s/%s isn't terminating, evict it rather than force deleting it", namespace, name)
+		return
+	}
+
+	reservation, err := s.Guardrails.reserve(budgetPodOperations, namespace, 1, time.Now())
+	if writeGuardrailError(w, err) {
+		return
+	}
+
+	meta := requestMetadata(r.Context())
+	audit := AuditEvent{
+		Action:          operation.Action,
+		RequestMetadata: meta,
+		RemoteAddr:      r.RemoteAddr,
+		Namespace:       namespace,
+		Target:          name,
+		Result:          result,
+	}
+	if force {
+		err = forceDeletePod(r.Context(), s.K8sClientSet, pod)
+	} else {
+		err = evictPod(r.Context(), s.K8sClientSet, namespace, name)
+	}
+	if err != nil {
+		reservation.release(1)
+		audit.Result, audit.Error = "failed", err.Error()
+		recordAudit(audit)
+		switch {
+		case apierrors.IsTooManyRequests(err):
+			// The eviction would violate a PodDisruptionBudget
+			if seconds, ok := apierrors.SuggestsClientDelay(err); ok {
+				w.Header().Set("Retry-After", strconv.Itoa(seconds))
+			}
+			writeError(w, http.StatusTooManyRequests, err)
+		case apierrors.IsNotFound(err):
+			writeError(w, http.StatusNotFound, err)
+		case apierrors.IsConflict(err):
+			writeError(w, http.StatusConflict, err)
+		default:
+			writeError(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	recordAudit(audit)
+
+	slog.InfoContext(r.Context(), "Pod remediated", "namespace", namespace, "pod", name, "result", result)
+	reason, message := "PodEvicted", "Evicted the pod"
+	if force {
+		reason, message = "PodForceDeleted", "Force deleted the pod stuck terminating"
+	}
+	recordEvent(r.Context(), s.K8sClientSet, corev1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Namespace:  namespace,
+		Name:       name,
+		UID:        pod.UID,
+	}, reason, message, meta)
+	writeJSON(w, http.StatusOK, PodRemediation{Namespace: namespace, Pod: name, Result: result})
+}
+
+// evictPod evicts a pod through the eviction API, which returns a TooManyRequests error when a PodDisruptionBudget
+// refuses it.
+func evictPod(ctx context.Context, clientset kubernetes.Interface, namespace, name string) error {
+	return clientset.CoreV1().Pods(namespace).EvictV1(ctx, &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}})
+}
+
+// forceDeletePod deletes a pod right away, unless it was replaced by a pod of the same name since it was read.
+func forceDeletePod(ctx context.Context, clientset kubernetes.Interface, pod *corev1.Pod) error {
+	gracePeriod := int64(0)
+	return clientset.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{
+		GracePeriodSeconds: &gracePeriod,
+		Preconditions:      &metav1.Preconditions{UID: &pod.UID},
+	})
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestEvictPod(t *testing.T) {
+	now := metav1.Now()
+	clientset := fake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "cart", Namespace: "shop"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "ledger", Namespace: "payments"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "stuck", Namespace: "shop", UID: "stuck-uid", DeletionTimestamp: &now}},
+	)
+	var evictions []string
+	clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		eviction := action.(k8stesting.CreateAction).GetObject().(*policyv1.Eviction)
+		evictions = append(evictions, eviction.Namespace+"/"+eviction.Name)
+		if eviction.Namespace == "payments" {
+			return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 10)
+		}
+		return true, nil, nil
+	})
+
+	assert.NoError(t, evictPod(context.TODO(), clientset, "shop", "cart"))
+	err := evictPod(context.TODO(), clientset, "payments", "ledger")
+	assert.True(t, apierrors.IsTooManyRequests(err))
+	seconds, ok := apierrors.SuggestsClientDelay(err)
+	assert.True(t, ok)
+	assert.Equal(t, 10, seconds)
+	assert.Equal(t, []string{"shop/cart", "payments/ledger"}, evictions)
+
+	stuck, err := clientset.CoreV1().Pods("shop").Get(context.TODO(), "stuck", metav1.GetOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	var deletion metav1.DeleteOptions
+	clientset.PrependReactor("delete", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		deletion = action.(k8stesting.DeleteAction).GetDeleteOptions()
+		return false, nil, nil
+	})
+	assert.NoError(t, forceDeletePod(context.TODO(), clientset, stuck))
+	if assert.NotNil(t, deletion.GracePeriodSeconds) && assert.NotNil(t, deletion.Preconditions) {
+		assert.Equal(t, int64(0), *deletion.GracePeriodSeconds)
+		assert.Equal(t, stuck.UID, *deletion.Preconditions.UID)
+	}
+	_, err = clientset.CoreV1().Pods("shop").Get(context.TODO(), "stuck", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
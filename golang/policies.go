@@ -0,0 +1,354 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ManagedDenyPolicy describes a deny policy created by the service, with the selectors of both workloads.
+type ManagedDenyPolicy struct {
+	Name              string     `json:"name"`
+	Namespace         string     `json:"namespace"`
+	Selector          string     `json:"selector"`
+	PeerSelector      string     `json:"peer_selector"`
+	NamespaceSelector string     `json:"namespace_selector"`
+	PeerCIDR          string     `json:"peer_cidr,omitempty"`
+	PeerNamespace     string     `json:"peer_namespace,omitempty"`
+	Tier              string     `json:"tier,omitempty"`
+	Mirror            string     `json:"mirror,omitempty"`
+	CreatedBy         string     `json:"created_by"`
+	CreatedAt         time.Time  `json:"created_at"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+}
+
+// getManagedDenyPolicy reads a policy with get and checks the service created it as a deny, writing the error otherwise.
+func (s *Server) getManagedDenyPolicy(w http.ResponseWriter, r *http.Request, get func(ctx context.Context, namespace, name string) (PolicyObject, error), namespace, name string) (PolicyObject, bool) {
+	policy, err := get(r.Context(), namespace, name)
+	if apierrors.IsNotFound(err) {
+		writeError(w, http.StatusNotFound, err)
+		return nil, false
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return nil, false
+	}
+	if policy.GetLabels()[managedByLabel] != managedByValue || policy.GetAnnotations()[actionAnnotation] != actionDeny {
+		writeErrorf(w, http.StatusForbidden, "network policy %s/%s is not a deny policy managed by this service", namespace, name)
+		return nil, false
+	}
+	return policy, true
+}
+
+// Handler enforcing a staged deny policy, a global one when the path has no namespace
+//
+// Promoting needs the permissions of creating the policy.
+func (s *Server) promoteDenyNetworkPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	namespace, name := r.PathValue("namespace"), r.PathValue("name")
+	if action := r.PathValue("action"); action != "" && action != "promote" {
+		writeErrorf(w, http.StatusNotFound, "Unknown action %q of policy %s, expected promote", action, name)
+		return
+	}
+
+	staging, ok := stagingOf(s.Policies)
+	if !ok {
+		writeErrorf(w, http.StatusBadRequest, "the %s policy backend can't stage policies", s.Policies.Name())
+		return
+	}
+
+	operation := operationDenyPolicy
+	if namespace == "" {
+		operation = operationDenyGlobal
+		if s.Namespaces.IsScoped() {
+			writeErrorf(w, http.StatusForbidden, "%w: global policies need the service to cover every namespace", errNamespaceOutOfScope)
+			return
+		}
+	} else if err := s.Namespaces.check(r.Context(), s.K8sClientSet, namespace); err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	staged, ok := s.getManagedDenyPolicy(w, r, staging.getStaged, namespace, name)
+	if !ok {
+		return
+	}
+	if !s.authorize(w, r, operation, namespace, name, nil) {
+		return
+	}
+
+	policy, err := staging.promote(r.Context(), staged)
+	if mirrorNamespace, mirrorName, ok := mirrorOf(staged); ok && policy != nil {
+		mirror, mirrorErr := staging.getStaged(r.Context(), mirrorNamespace, mirrorName)
+		if mirrorErr == nil {
+			_, mirrorErr = staging.promote(r.Context(), mirror)
+		}
+		if mirrorErr != nil && !apierrors.IsNotFound(mirrorErr) {
+			err = errors.Join(err, fmt.Errorf("promoting the mirror %s/%s: %w", mirrorNamespace, mirrorName, mirrorErr))
+		}
+	}
+	meta := requestMetadata(r.Context())
+	audit := AuditEvent{
+		Action:          operation.Action,
+		RequestMetadata: meta,
+		RemoteAddr:      r.RemoteAddr,
+		Namespace:       namespace,
+		Target:          name,
+		Result:          "promoted",
+	}
+	if policy == nil {
+		audit.Result, audit.Error = "failed", err.Error()
+		recordAudit(audit)
+
+		status := http.StatusInternalServerError
+		if apierrors.IsAlreadyExists(err) {
+			status = http.StatusConflict
+		}
+		writeError(w, status, err)
+		return
+	}
+	recordAudit(audit)
+
+	slog.InfoContext(r.Context(), "Staged network policy promoted", "namespace", namespace, "name", name)
+	recordEvent(r.Context(), s.K8sClientSet, policyReference(policy), "DenyPolicyPromoted", fmt.Sprintf("Enforcing the staged deny of %s", s.Policies.Selectors(policy).Selector), meta)
+	if err != nil {
+		// Enforced, but the staged policy or the mesh policies are left behind
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte(policy.GetName())); err != nil {
+		slog.ErrorContext(r.Context(), "Failed writing response", "error", err)
+	}
+}
+
+// Handler listing the deny policies created by the service in every namespace in scope, or in ?namespace=
+func (s *Server) listDenyNetworkPoliciesHandler(w http.ResponseWriter, r *http.Request) {
+	scope := s.Namespaces
+	if namespace := r.URL.Query().Get("namespace"); namespace != "" {
+		if err := s.Namespaces.check(r.Context(), s.K8sClientSet, namespace); err != nil {
+			writeError(w, http.StatusForbidden, err)
+			return
+		}
+		scope = NamespaceScope{Names: []string{namespace}}
+	}
+
+	policies, err := s.listBackendPolicies(r.Context(), scope)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, summariseDenyPolicies(s.Policies, policies, time.Now()))
+}
+
+// Handler deleting a deny policy created by the service, policies created by anything else are left alone
+//
+// Global policies have no namespace in their path.
+func (s *Server) deleteDenyNetworkPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	namespace, name := r.PathValue("namespace"), r.PathValue("name")
+
+	operation := operationDeletePolicy
+	if namespace == "" {
+		operation = operationDeleteGlobal
+		if s.Namespaces.IsScoped() {
+			writeErrorf(w, http.StatusForbidden, "%w: global policies need the service to cover every namespace", errNamespaceOutOfScope)
+			return
+		}
+	} else if err := s.Namespaces.check(r.Context(), s.K8sClientSet, namespace); err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	get := s.Policies.Get
+	if r.URL.Query().Get("staged") == "true" {
+		staging, ok := stagingOf(s.Policies)
+		if !ok {
+			writeErrorf(w, http.StatusBadRequest, "the %s policy backend can't stage policies", s.Policies.Name())
+			return
+		}
+		get = staging.getStaged
+	}
+	policy, ok := s.getManagedDenyPolicy(w, r, get, namespace, name)
+	if !ok {
+		return
+	}
+
+	if !s.authorize(w, r, operation, namespace, name, nil) {
+		return
+	}
+
+	reservation, err := s.Guardrails.reserve(budgetPolicies, namespace, 1, time.Now())
+	if writeGuardrailError(w, err) {
+		return
+	}
+
+	// The backend keeps a policy recreated under the same name in the meantime
+	err = s.Policies.Delete(r.Context(), policy)
+	meta := requestMetadata(r.Context())
+	audit := AuditEvent{
+		Action:          operation.Action,
+		RequestMetadata: meta,
+		RemoteAddr:      r.RemoteAddr,
+		Namespace:       namespace,
+		Target:          name,
+		Result:          "deleted",
+	}
+	if err != nil {
+		reservation.release(1)
+		audit.Result, audit.Error = "failed", err.Error()
+		recordAudit(audit)
+
+		status := http.StatusInternalServerError
+		switch {
+		case apierrors.IsNotFound(err):
+			status = http.StatusNotFound
+		case apierrors.IsConflict(err):
+			status = http.StatusConflict
+		}
+		writeError(w, status, err)
+		return
+	}
+	recordAudit(audit)
+
+	slog.InfoContext(r.Context(), "Network policy deleted", "namespace", namespace, "name", name)
+	selectors := s.Policies.Selectors(policy)
+	peer := policy.GetAnnotations()[peerNamespaceAnnotation]
+	if selectors.PeerCIDR != "" {
+		peer = selectors.PeerCIDR
+	}
+	recordEvent(r.Context(), s.K8sClientSet, policyReference(policy), "DenyPolicyDeleted", fmt.Sprintf("Allowed traffic between %s and %s again", selectors.Selector, peer), meta)
+
+	if mirrorNamespace, mirrorName, ok := mirrorOf(policy); ok {
+		if err := s.deleteMirrorPolicy(r.Context(), get, mirrorNamespace, mirrorName); err != nil {
+			writeErrorf(w, http.StatusInternalServerError, "policy deleted, but not its mirror %s/%s: %v", mirrorNamespace, mirrorName, err)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// mirrorOf returns where the mirror of a deny policy lives, if it has one.
+func mirrorOf(policy PolicyObject) (namespace, name string, ok bool) {
+	return strings.Cut(policy.GetAnnotations()[mirrorAnnotation], "/")
+}
+
+// deleteMirrorPolicy deletes the mirror of a deleted policy, unless it's gone or isn't a deny policy of the service any more.
+func (s *Server) deleteMirrorPolicy(ctx context.Context, get func(ctx context.Context, namespace, name string) (PolicyObject, error), namespace, name string) error {
+	mirror, err := get(ctx, namespace, name)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if mirror.GetLabels()[managedByLabel] != managedByValue || mirror.GetAnnotations()[actionAnnotation] != actionDeny {
+		return nil
+	}
+	if err := s.Policies.Delete(ctx, mirror); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	slog.InfoContext(ctx, "Mirror network policy deleted", "namespace", namespace, "name", name)
+	return nil
+}
+
+// listManagedPolicies returns the Calico policies created by the service in the namespaces of scope.
+func (s *Server) listManagedPolicies(ctx context.Context, scope NamespaceScope) ([]v3.NetworkPolicy, error) {
+	namespaces, err := scope.resolve(ctx, s.K8sClientSet)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []v3.NetworkPolicy
+	for _, ns := range namespaces {
+		list, err := s.CalicoClientSet.ProjectcalicoV3().NetworkPolicies(ns).List(ctx, metav1.ListOptions{LabelSelector: managedObjectSelector})
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, list.Items...)
+	}
+	return policies, nil
+}
+
+// listBackendPolicies returns the policies created by the service through the policy backend in the namespaces of scope.
+func (s *Server) listBackendPolicies(ctx context.Context, scope NamespaceScope) ([]PolicyObject, error) {
+	namespaces, err := scope.resolve(ctx, s.K8sClientSet)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []PolicyObject
+	for _, ns := range namespaces {
+		list, err := s.Policies.List(ctx, ns)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, list...)
+	}
+	return policies, nil
+}
+
+// listStagedPolicies returns the staged policies created by the service in the namespaces of scope, none when the policy
+// backend can't stage policies.
+func (s *Server) listStagedPolicies(ctx context.Context, scope NamespaceScope) ([]PolicyObject, error) {
+	staging, ok := stagingOf(s.Policies)
+	if !ok {
+		return nil, nil
+	}
+	namespaces, err := scope.resolve(ctx, s.K8sClientSet)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []PolicyObject
+	for _, ns := range namespaces {
+		list, err := staging.listStaged(ctx, ns)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, list...)
+	}
+	return policies, nil
+}
+
+// summariseDenyPolicies keeps the deny policies that haven't expired, oldest first.
+func summariseDenyPolicies(backend PolicyBackend, policies []PolicyObject, now time.Time) []ManagedDenyPolicy {
+	summaries := []ManagedDenyPolicy{}
+	for _, policy := range policies {
+		annotations := policy.GetAnnotations()
+		if annotations[actionAnnotation] != actionDeny {
+			continue
+		}
+
+		summary := ManagedDenyPolicy{
+			Name:          policy.GetName(),
+			Namespace:     policy.GetNamespace(),
+			PeerNamespace: annotations[peerNamespaceAnnotation],
+			Tier:          annotations[tierAnnotation],
+			Mirror:        annotations[mirrorAnnotation],
+			CreatedBy:     annotations[createdByAnnotation],
+			CreatedAt:     policy.GetCreationTimestamp().Time,
+			ExpiresAt:     expiresAt(policy),
+		}
+		if summary.CreatedBy == "" {
+			summary.CreatedBy = unknownPrincipal
+		}
+		if summary.ExpiresAt != nil && !summary.ExpiresAt.After(now) {
+			continue
+		}
+		selectors := backend.Selectors(policy)
+		summary.Selector, summary.PeerSelector, summary.NamespaceSelector, summary.PeerCIDR = selectors.Selector, selectors.PeerSelector, selectors.NamespaceSelector, selectors.PeerCIDR
+		summaries = append(summaries, summary)
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool { return summaries[i].CreatedAt.Before(summaries[j].CreatedAt) })
+	return summaries
+}
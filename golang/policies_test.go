@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSummariseDenyPolicies(t *testing.T) {
+	now := time.Date(2024, 7, 8, 12, 0, 0, 0, time.UTC)
+	newer := managedPolicy("payments", "newer", actionDeny, "shop", map[string]string{createdByAnnotation: "alice"})
+	newer.CreationTimestamp = metav1.NewTime(now.Add(-time.Minute))
+	newer.Spec = v3.NetworkPolicySpec{
+		Selector: "app == 'ledger'",
+		Ingress:  []v3.Rule{{Action: v3.Deny, Source: v3.EntityRule{Selector: "app == 'cart'", NamespaceSelector: "tenant == 'shop'"}}},
+	}
+	older := managedPolicy("search", "older", actionDeny, "", nil)
+	older.CreationTimestamp = metav1.NewTime(now.Add(-time.Hour))
+
+	expired := managedPolicy("payments", "expired", actionDeny, "shop", map[string]string{expiresAtAnnotation: "2024-07-08T11:00:00Z"})
+	quarantine := managedPolicy("payments", "quarantine", actionQuarantine, "", nil)
+	policies := []PolicyObject{&newer, &older, &expired, &quarantine}
+
+	summaries := summariseDenyPolicies(&calicoBackend{}, policies, now)
+	if assert.Len(t, summaries, 2) {
+		assert.Equal(t, "older", summaries[0].Name)
+		assert.Equal(t, unknownPrincipal, summaries[0].CreatedBy)
+		assert.Equal(t, ManagedDenyPolicy{
+			Name:              "newer",
+			Namespace:         "payments",
+			Selector:          "app == 'ledger'",
+			PeerSelector:      "app == 'cart'",
+			NamespaceSelector: "tenant == 'shop'",
+			PeerNamespace:     "shop",
+			CreatedBy:         "alice",
+			CreatedAt:         now.Add(-time.Minute),
+		}, summaries[1])
+	}
+
+	assert.Equal(t, []ManagedDenyPolicy{}, summariseDenyPolicies(&calicoBackend{}, nil, now))
+}
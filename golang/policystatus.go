@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const calicoNodeSelector = "k8s-app=calico-node"
+
+// Results of a drift check.
+const (
+	driftInSync  = "in_sync"
+	driftDrifted = "drifted"
+	driftUnknown = "unknown"
+)
+
+// NetworkPolicyStatus tells whether a policy is actually enforced, not just stored by the API server.
+//
+// Calico doesn't report per-policy status, so a policy counts as programmed when calico-node (Felix) is ready on every node
+// hosting an endpoint it selects, and as enforcing when it's also programmed, selects at least one endpoint and hasn't drifted.
+type NetworkPolicyStatus struct {
+	Namespace         string             `json:"namespace"`
+	Name              string             `json:"name"`
+	Selector          string             `json:"selector"`
+	Programmed        bool               `json:"programmed"`
+	Enforcing         bool               `json:"enforcing"`
+	Reasons           []string           `json:"reasons,omitempty"`
+	SelectedEndpoints int                `json:"selected_endpoints"`
+	Endpoints         []SelectedEndpoint `json:"endpoints"`
+	UnprogrammedNodes []string           `json:"unprogrammed_nodes,omitempty"`
+	Drift             DriftCheckResult   `json:"drift"`
+}
+
+// SelectedEndpoint is a workload endpoint matched by the policy selector.
+type SelectedEndpoint struct {
+	Pod        string `json:"pod"`
+	Node       string `json:"node"`
+	Programmed bool   `json:"programmed"`
+}
+
+// DriftCheckResult compares the live policy spec with the one the service created.
+type DriftCheckResult struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Status    string    `json:"status"`
+	Detail    string    `json:"detail,omitempty"`
+}
+
+// Handler reporting whether a Calico network policy is enforced
+func (s *Server) networkPolicyStatusHandler(w http.ResponseWriter, r *http.Request) {
+	namespace, name := r.PathValue("namespace"), r.PathValue("name")
+
+	if err := s.Namespaces.check(r.Context(), s.K8sClientSet, namespace); err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	policy, err := s.CalicoClientSet.ProjectcalicoV3().NetworkPolicies(namespace).Get(r.Context(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	pods, err := s.K8sClientSet.CoreV1().Pods(namespace).List(r.Context(), metav1.ListOptions{})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	felixReady, err := calicoNodeReadiness(r.Context(), s.K8sClientSet)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Failed listing calico-node pods", "error", err)
+	}
+
+	writeJSON(w, http.StatusOK, evaluatePolicyStatus(*policy, pods.Items, felixReady, time.Now()))
+}
+
+// calicoNodeReadiness maps every node running calico-node to whether it's ready, nil when calico-node can't be listed.
+func calicoNodeReadiness(ctx context.Context, clientset kubernetes.Interface) (map[string]bool, error) {
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: calicoNodeSelector})
+	if err != nil {
+		return nil, err
+	}
+
+	ready := map[string]bool{}
+	for _, pod := range pods.Items {
+		ready[pod.Spec.NodeName] = ready[pod.Spec.NodeName] || podReady(pod)
+	}
+	return ready, nil
+}
+
+func podReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// evaluatePolicyStatus matches the policy selector against the pods of its namespace and checks Felix and drift.
+//
+// A nil felixReady means calico-node readiness is unknown, and the policy is then never reported as programmed.
+func evaluatePolicyStatus(policy v3.NetworkPolicy, pods []corev1.Pod, felixReady map[string]bool, now time.Time) *NetworkPolicyStatus {
+	status := &NetworkPolicyStatus{
+		Namespace: policy.Namespace,
+		Name:      policy.Name,
+		Selector:  policy.Spec.Selector,
+		Endpoints: []SelectedEndpoint{},
+		Drift:     checkPolicyDrift(policy, now),
+	}
+
+	selector, err := parseCalicoSelector(policy.Spec.Selector)
+	if err != nil {
+		status.Reasons = append(status.Reasons, fmt.Sprintf("can't evaluate selector: %s", err))
+		return status
+	}
+
+	unprogrammed := map[string]bool{}
+	for _, pod := range pods {
+		if !isWorkloadEndpoint(pod) || !selector.Matches(endpointLabels(pod)) {
+			continue
+		}
+
+		endpoint := SelectedEndpoint{Pod: pod.Name, Node: pod.Spec.NodeName, Programmed: felixReady[pod.Spec.NodeName]}
+		if !endpoint.Programmed {
+			unprogrammed[pod.Spec.NodeName] = true
+		}
+		status.Endpoints = append(status.Endpoints, endpoint)
+	}
+	sort.Slice(status.Endpoints, func(i, j int) bool { return status.Endpoints[i].Pod < status.Endpoints[j].Pod })
+	status.SelectedEndpoints = len(status.Endpoints)
+
+	for node := range unprogrammed {
+		status.UnprogrammedNodes = append(status.UnprogrammedNodes, node)
+	}
+	sort.Strings(status.UnprogrammedNodes)
+
+	status.Programmed = felixReady != nil && len(unprogrammed) == 0
+	switch {
+	case felixReady == nil:
+		status.Reasons = append(status.Reasons, "calico-node readiness is unknown")
+	case len(unprogrammed) > 0:
+		status.Reasons = append(status.Reasons, fmt.Sprintf("calico-node isn't ready on %d node(s)", len(unprogrammed)))
+	}
+	if status.SelectedEndpoints == 0 {
+		status.Reasons = append(status.Reasons, "selector matches no endpoints")
+	}
+	if status.Drift.Status == driftDrifted {
+		status.Reasons = append(status.Reasons, "spec drifted from what was created")
+	}
+
+	status.Enforcing = status.Programmed && status.SelectedEndpoints > 0 && status.Drift.Status != driftDrifted
+	return status
+}
+
+// isWorkloadEndpoint reports whether Calico manages an endpoint for the pod, host-networked and finished pods have none.
+func isWorkloadEndpoint(pod corev1.Pod) bool {
+	if pod.Spec.HostNetwork || pod.Spec.NodeName == "" {
+		return false
+	}
+	return pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed
+}
+
+// endpointLabels returns the labels Calico evaluates selectors against, the pod labels plus the ones it adds itself.
+func endpointLabels(pod corev1.Pod) map[string]string {
+	labels := map[string]string{
+		"projectcalico.org/namespace":    pod.Namespace,
+		"projectcalico.org/orchestrator": "k8s",
+	}
+	if pod.Spec.ServiceAccountName != "" {
+		labels["projectcalico.org/serviceaccount"] = pod.Spec.ServiceAccountName
+	}
+	for key, value := range pod.Labels {
+		labels[key] = value
+	}
+	return labels
+}
+
+// checkPolicyDrift compares the spec with the hash recorded when the service created the policy.
+func checkPolicyDrift(policy v3.NetworkPolicy, now time.Time) DriftCheckResult {
+	result := DriftCheckResult{CheckedAt: now, Status: driftUnknown}
+
+	recorded, ok := policy.Annotations[specHashAnnotation]
+	if !ok {
+		result.Detail = "policy has no recorded spec hash"
+		return result
+	}
+
+	if specHash(policy.Spec) != recorded {
+		result.Status = driftDrifted
+		result.Detail = "spec was modified after creation"
+		return result
+	}
+
+	result.Status = driftInSync
+	return result
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testPod(name, node string, labels map[string]string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "payments", Labels: labels},
+		Spec:       corev1.PodSpec{NodeName: node},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func TestEvaluatePolicyStatus(t *testing.T) {
+	now := time.Date(2024, 7, 8, 12, 0, 0, 0, time.UTC)
+	policy := managedPolicy("payments", "deny", actionDeny, "shop", nil)
+	policy.Spec = v3.NetworkPolicySpec{Selector: "app == 'ledger'"}
+	policy.Annotations[specHashAnnotation] = specHash(policy.Spec)
+
+	hostNetwork := testPod("ledger-host", "node-a", map[string]string{"app": "ledger"})
+	hostNetwork.Spec.HostNetwork = true
+	pods := []corev1.Pod{
+		testPod("ledger-2", "node-b", map[string]string{"app": "ledger"}),
+		testPod("ledger-1", "node-a", map[string]string{"app": "ledger"}),
+		testPod("shop-1", "node-a", map[string]string{"app": "shop"}),
+		hostNetwork,
+	}
+
+	status := evaluatePolicyStatus(policy, pods, map[string]bool{"node-a": true, "node-b": true}, now)
+	assert.True(t, status.Programmed)
+	assert.True(t, status.Enforcing)
+	assert.Equal(t, 2, status.SelectedEndpoints)
+	assert.Equal(t, "ledger-1", status.Endpoints[0].Pod)
+	assert.Equal(t, driftInSync, status.Drift.Status)
+	assert.Empty(t, status.Reasons)
+
+	status = evaluatePolicyStatus(policy, pods, map[string]bool{"node-a": true, "node-b": false}, now)
+	assert.False(t, status.Programmed)
+	assert.False(t, status.Enforcing)
+	assert.Equal(t, []string{"node-b"}, status.UnprogrammedNodes)
+
+	status = evaluatePolicyStatus(policy, pods, nil, now)
+	assert.False(t, status.Programmed)
+	assert.Contains(t, status.Reasons, "calico-node readiness is unknown")
+
+	policy.Spec.Selector = "app == 'search'"
+	status = evaluatePolicyStatus(policy, pods, map[string]bool{"node-a": true, "node-b": true}, now)
+	assert.True(t, status.Programmed)
+	assert.False(t, status.Enforcing)
+	assert.Equal(t, driftDrifted, status.Drift.Status)
+	assert.Zero(t, status.SelectedEndpoints)
+}
+
+func TestCheckPolicyDriftWithoutHash(t *testing.T) {
+	policy := v3.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "payments"}}
+	assert.Equal(t, driftUnknown, checkPolicyDrift(policy, time.Now()).Status)
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Problems a pod can have
+const (
+	problemPending          = "Pending"
+	problemCrashLoop        = "CrashLoopBackOff"
+	problemOOMKilled        = "OOMKilled"
+	problemStuckTerminating = "StuckTerminating"
+	problemRestarts         = "Restarts"
+)
+
+const (
+	// defaultRestartThreshold is how many restarts of its containers make a pod a problem.
+	defaultRestartThreshold = 5
+	// defaultOOMWindow is how recently a container must have been OOM killed for its pod to be a problem.
+	defaultOOMWindow = time.Hour
+	// pendingTolerance is how long a pod may be pending before it's a problem, the time to schedule and pull images.
+	pendingTolerance = 2 * time.Minute
+	// terminatingTolerance is how long a pod may outlive its deletion grace period before it's stuck terminating.
+	terminatingTolerance = 5 * time.Minute
+)
+
+// PodProblem is something wrong with a pod, of a container of it when Container is set.
+type PodProblem struct {
+	Type      string `json:"type"`
+	Container string `json:"container,omitempty"`
+	// Reason is that the pod is pending for, such as Unschedulable or ImagePullBackOff
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type ProblemPod struct {
+	Name      string       `json:"pod_name"`
+	Node      string       `json:"node,omitempty"`
+	Phase     string       `json:"phase"`
+	Restarts  int32        `json:"restarts"`
+	Problems  []PodProblem `json:"problems"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// PodOwner is the workload the problem pods belong to, its deployment rather than its ReplicaSet, Pod for bare pods.
+type PodOwner struct {
+	Kind string       `json:"kind"`
+	Name string       `json:"name"`
+	Pods []ProblemPod `json:"pods"`
+}
+
+type NamespaceProblemPods struct {
+	Namespace string     `json:"namespace"`
+	Owners    []PodOwner `json:"owners"`
+}
+
+type ProblemPodsReport struct {
+	Namespaces []NamespaceProblemPods `json:"namespaces"`
+}
+
+// problemPodsOptions are the thresholds a pod is a problem beyond.
+type problemPodsOptions struct {
+	RestartThreshold int32
+	OOMWindow        time.Duration
+}
+
+// Reports the pods which are pending, crash looping, recently OOM killed, stuck terminating or restarting too often,
+// filtered like the deployments of clusterDeploymentsInfoHandler
+//
+// ?restartThreshold= is how many restarts make a pod a problem, 5 by default, and ?oomWindow= how recently, 1h by
+// default, a container must have been OOM killed.
+func (s *Server) problemPodsHandler(w http.ResponseWriter, r *http.Request) {
+	filter, status, err := s.parseReportFilter(r)
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+	options := problemPodsOptions{RestartThreshold: defaultRestartThreshold, OOMWindow: defaultOOMWindow}
+	if value := r.URL.Query().Get("restartThreshold"); value != "" {
+		threshold, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || threshold <= 0 {
+			writeErrorf(w, http.StatusBadRequest, "invalid restartThreshold %q, expected a positive integer", value)
+			return
+		}
+		options.RestartThreshold = int32(threshold)
+	}
+	if value := r.URL.Query().Get("oomWindow"); value != "" {
+		window, err := time.ParseDuration(value)
+		if err != nil || window <= 0 {
+			writeErrorf(w, http.StatusBadRequest, "invalid oomWindow %q, expected a positive duration such as 30m", value)
+			return
+		}
+		options.OOMWindow = window
+	}
+
+	report, err := getProblemPods(r.Context(), s.K8sClientSet, filter.Scope, filter.Selector, options, time.Now())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	report.excludeNamespaces(filter.excludedNamespaces(s.ExcludedNamespaces))
+	writeJSON(w, http.StatusOK, report)
+}
+
+// getProblemPods lists the problem pods of every namespace in scope whose labels match selector, grouped by namespace
+// and owner.
+func getProblemPods(ctx context.Context, clientset kubernetes.Interface, scope NamespaceScope, selector labels.Selector, options problemPodsOptions, now time.Time) (*ProblemPodsReport, error) {
+	namespaces, err := scope.resolve(ctx, clientset)
+	if err != nil {
+		return nil, err
+	}
+
+	// Owners by namespace, then by kind and name
+	owners := map[string]map[string]*PodOwner{}
+	var count int
+	for _, namespace := range namespaces {
+		pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range pods.Items {
+			problem, ok := problemPod(pod, options, now)
+			if !ok {
+				continue
+			}
+			kind, name := podOwner(pod)
+			if owners[pod.Namespace] == nil {
+				owners[pod.Namespace] = map[string]*PodOwner{}
+			}
+			owner := owners[pod.Namespace][kind+"/"+name]
+			if owner == nil {
+				owner = &PodOwner{Kind: kind, Name: name}
+				owners[pod.Namespace][kind+"/"+name] = owner
+			}
+			owner.Pods = append(owner.Pods, problem)
+			count++
+		}
+	}
+
+	report := &ProblemPodsReport{Namespaces: []NamespaceProblemPods{}}
+	for namespace, byName := range owners {
+		problems := NamespaceProblemPods{Namespace: namespace}
+		for _, owner := range byName {
+			problems.Owners = append(problems.Owners, *owner)
+		}
+		sort.Slice(problems.Owners, func(i, j int) bool {
+			if problems.Owners[i].Kind != problems.Owners[j].Kind {
+				return problems.Owners[i].Kind < problems.Owners[j].Kind
+			}
+			return problems.Owners[i].Name < problems.Owners[j].Name
+		})
+		report.Namespaces = append(report.Namespaces, problems)
+	}
+	sort.Slice(report.Namespaces, func(i, j int) bool { return report.Namespaces[i].Namespace < report.Namespaces[j].Namespace })
+	slog.DebugContext(ctx, "Problem pods", "pods", count)
+	return report, nil
+}
+
+// problemPod returns the ProblemPod of a pod with problems, false for a healthy one.
+func problemPod(pod corev1.Pod, options problemPodsOptions, now time.Time) (ProblemPod, bool) {
+	result := ProblemPod{
+		Name:      pod.Name,
+		Node:      pod.Spec.NodeName,
+		Phase:     string(pod.Status.Phase),
+		CreatedAt: pod.CreationTimestamp.Time,
+	}
+
+	if pod.DeletionTimestamp != nil {
+		// The deletion timestamp is when the grace period of the pod ends
+		if now.Sub(pod.DeletionTimestamp.Time) > terminatingTolerance {
+			result.Problems = append(result.Problems, PodProblem{
+				Type:    problemStuckTerminating,
+				Message: fmt.Sprintf("deletion grace period ended at %s", pod.DeletionTimestamp.UTC().Format(time.RFC3339)),
+			})
+		}
+	} else if pod.Status.Phase == corev1.PodPending && now.Sub(pod.CreationTimestamp.Time) > pendingTolerance {
+		reason, message := podFailure(pod)
+		result.Problems = append(result.Problems, PodProblem{Type: problemPending, Reason: reason, Message: message})
+	}
+
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		result.Restarts += status.RestartCount
+		if waiting := status.State.Waiting; waiting != nil && waiting.Reason == problemCrashLoop {
+			result.Problems = append(result.Problems, PodProblem{Type: problemCrashLoop, Container: status.Name, Message: waiting.Message})
+		}
+		if terminated := status.LastTerminationState.Terminated; terminated != nil && terminated.Reason == problemOOMKilled && now.Sub(terminated.FinishedAt.Time) <= options.OOMWindow {
+			result.Problems = append(result.Problems, PodProblem{
+				Type:      problemOOMKilled,
+				Container: status.Name,
+				Message:   fmt.Sprintf("OOM killed at %s", terminated.FinishedAt.UTC().Format(time.RFC3339)),
+			})
+		}
+	}
+	if result.Restarts >= options.RestartThreshold {
+		result.Problems = append(result.Problems, PodProblem{Type: problemRestarts, Message: fmt.Sprintf("%d restarts", result.Restarts)})
+	}
+	return result, len(result.Problems) > 0
+}
+
+// podOwner returns the kind and name of the workload of a pod: the deployment of its ReplicaSet, named after the
+// deployment and the pod-template-hash, its controller otherwise, or the pod itself.
+func podOwner(pod corev1.Pod) (kind, name string) {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return "Pod", pod.Name
+	}
+	if hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; owner.Kind == "ReplicaSet" && hash != "" {
+		if deployment, ok := strings.CutSuffix(owner.Name, "-"+hash); ok {
+			return "Deployment", deployment
+		}
+	}
+	return owner.Kind, owner.Name
+}
+
+// excludeNamespaces removes the pods of namespaces from the report.
+func (report *ProblemPodsReport) excludeNamespaces(namespaces []string) {
+	report.Namespaces = slices.DeleteFunc(report.Namespaces, func(problems NamespaceProblemPods) bool {
+		return slices.Contains(namespaces, problems.Namespace)
+	})
+}
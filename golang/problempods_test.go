@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetProblemPods(t *testing.T) {
+	now := time.Now()
+	controller := true
+	pod := func(namespace, name string, owner *metav1.OwnerReference, status corev1.PodStatus) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)), Labels: map[string]string{"pod-template-hash": "5d4f"}},
+			Status:     status,
+		}
+		if owner != nil {
+			pod.OwnerReferences = []metav1.OwnerReference{*owner}
+		}
+		return pod
+	}
+	replicaSet := &metav1.OwnerReference{Kind: "ReplicaSet", Name: "api-5d4f", Controller: &controller}
+	statefulSet := &metav1.OwnerReference{Kind: "StatefulSet", Name: "postgres", Controller: &controller}
+	container := func(restarts int32, state corev1.ContainerState, last corev1.ContainerState) corev1.PodStatus {
+		return corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{{Name: "app", RestartCount: restarts, State: state, LastTerminationState: last}}}
+	}
+	crashLoop := corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off 5m0s"}}
+	oomKilled := func(at time.Time) corev1.ContainerState {
+		return corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", FinishedAt: metav1.NewTime(at)}}
+	}
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+
+	terminating := pod("payments", "api-5d4f-old", replicaSet, container(0, running, corev1.ContainerState{}))
+	terminating.DeletionTimestamp = &metav1.Time{Time: now.Add(-10 * time.Minute)}
+	clientset := fake.NewSimpleClientset(
+		pod("payments", "api-5d4f-a", replicaSet, container(7, crashLoop, corev1.ContainerState{})),
+		pod("payments", "api-5d4f-b", replicaSet, container(1, running, oomKilled(now.Add(-10*time.Minute)))),
+		pod("payments", "api-5d4f-c", replicaSet, container(1, running, oomKilled(now.Add(-2*time.Hour)))),
+		terminating,
+		pod("payments", "debug", nil, corev1.PodStatus{Phase: corev1.PodPending, Conditions: []corev1.PodCondition{{
+			Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: "Unschedulable", Message: "0/3 nodes are available: 3 Insufficient memory.",
+		}}}),
+		pod("data", "postgres-0", statefulSet, container(5, running, corev1.ContainerState{})),
+		pod("data", "postgres-1", statefulSet, container(4, running, corev1.ContainerState{})),
+	)
+
+	report, err := getProblemPods(context.Background(), clientset, NamespaceScope{}, labels.Everything(), problemPodsOptions{RestartThreshold: 5, OOMWindow: time.Hour}, now)
+	assert.NoError(t, err)
+	problems := map[string][]string{}
+	var namespaces []string
+	for _, namespace := range report.Namespaces {
+		namespaces = append(namespaces, namespace.Namespace)
+		for _, owner := range namespace.Owners {
+			for _, pod := range owner.Pods {
+				key := namespace.Namespace + "/" + owner.Kind + "/" + owner.Name + "/" + pod.Name
+				for _, problem := range pod.Problems {
+					problems[key] = append(problems[key], problem.Type)
+				}
+			}
+		}
+	}
+	assert.Equal(t, []string{"data", "payments"}, namespaces)
+	assert.Equal(t, map[string][]string{
+		"payments/Deployment/api/api-5d4f-a":   {problemCrashLoop, problemRestarts},
+		"payments/Deployment/api/api-5d4f-b":   {problemOOMKilled},
+		"payments/Deployment/api/api-5d4f-old": {problemStuckTerminating},
+		"payments/Pod/debug/debug":             {problemPending},
+		"data/StatefulSet/postgres/postgres-0": {problemRestarts},
+	}, problems)
+
+	pending := report.Namespaces[1].Owners[1].Pods[0]
+	assert.Equal(t, "Unschedulable", pending.Problems[0].Reason)
+	assert.Contains(t, pending.Problems[0].Message, "Insufficient memory")
+
+	report.excludeNamespaces([]string{"payments"})
+	assert.Len(t, report.Namespaces, 1)
+}
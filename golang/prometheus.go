@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const defaultPrometheusTimeout = 5 * time.Second
+
+var errNoPrometheusData = errors.New("query returned no data")
+
+// PrometheusConfig configures the PromQL checks evaluated for every deployment.
+type PrometheusConfig struct {
+	URL     string          `json:"url"`
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+	Checks  []PromQLCheck   `json:"checks"`
+}
+
+// PromQLCheck is a user-defined health check, the query is a Go template rendered with the deployment .Namespace and .Name.
+//
+// The check fails when the returned value compared to Threshold with FailWhen (>, >=, < or <=, defaults to >) is true.
+type PromQLCheck struct {
+	Name      string  `json:"name"`
+	Query     string  `json:"query"`
+	Threshold float64 `json:"threshold"`
+	FailWhen  string  `json:"fail_when,omitempty"`
+}
+
+// PromQLCheckResult is the outcome of a PromQLCheck for one deployment.
+//
+// Checks that can't be evaluated carry an Error and don't mark the deployment as failed.
+type PromQLCheckResult struct {
+	Name      string   `json:"name"`
+	Value     *float64 `json:"value,omitempty"`
+	Threshold float64  `json:"threshold"`
+	Healthy   bool     `json:"healthy"`
+	Error     string   `json:"error,omitempty"`
+}
+
+type promQLCheck struct {
+	PromQLCheck
+	query *template.Template
+}
+
+// PrometheusClient evaluates the configured PromQL checks against the Prometheus HTTP API.
+type PrometheusClient struct {
+	url        string
+	httpClient *http.Client
+	checks     []promQLCheck
+}
+
+type prometheusQueryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// newPrometheusClient validates the configuration and parses the check templates.
+func newPrometheusClient(config *PrometheusConfig) (*PrometheusClient, error) {
+	if config.URL == "" {
+		return nil, errors.New("prometheus.url is required")
+	}
+
+	timeout := config.Timeout.Duration
+	if timeout == 0 {
+		timeout = defaultPrometheusTimeout
+	}
+
+	client := &PrometheusClient{
+		url:        strings.TrimSuffix(config.URL, "/"),
+		httpClient: &http.Client{Timeout: timeout},
+	}
+
+	for _, check := range config.Checks {
+		if check.Name == "" || check.Query == "" {
+			return nil, errors.New("prometheus checks need a name and a query")
+		}
+		if check.FailWhen == "" {
+			check.FailWhen = ">"
+		}
+		if _, err := compareThreshold(check.FailWhen, 0, 0); err != nil {
+			return nil, fmt.Errorf("check %s: %w", check.Name, err)
+		}
+
+		query, err := template.New(check.Name).Option("missingkey=error").Parse(check.Query)
+		if err != nil {
+			return nil, fmt.Errorf("check %s: %w", check.Name, err)
+		}
+		client.checks = append(client.checks, promQLCheck{PromQLCheck: check, query: query})
+	}
+	return client, nil
+}
+
+// evaluate runs every check for the deployment and reports whether none of them failed.
+func (p *PrometheusClient) evaluate(ctx context.Context, namespace, name string) ([]PromQLCheckResult, bool) {
+	healthy := true
+	results := make([]PromQLCheckResult, 0, len(p.checks))
+
+	for _, check := range p.checks {
+		result := PromQLCheckResult{Name: check.Name, Threshold: check.Threshold, Healthy: true}
+
+		value, err := p.evaluateCheck(ctx, check, namespace, name)
+		if err != nil {
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		failed, _ := compareThreshold(check.FailWhen, value, check.Threshold)
+		result.Value = &value
+		result.Healthy = !failed
+		healthy = healthy && result.Healthy
+		results = append(results, result)
+	}
+	return results, healthy
+}
+
+func (p *PrometheusClient) evaluateCheck(ctx context.Context, check promQLCheck, namespace, name string) (float64, error) {
+	var query bytes.Buffer
+	err := check.query.Execute(&query, map[string]string{"Namespace": namespace, "Name": name})
+	if err != nil {
+		return 0, err
+	}
+	return p.query(ctx, query.String())
+}
+
+// query runs an instant query and returns the value of the first sample.
+func (p *PrometheusClient) query(ctx context.Context, query string) (float64, error) {
+	endpoint := p.url + "/api/v1/query?" + url.Values{"query": {query}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var body prometheusQueryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return 0, fmt.Errorf("decoding prometheus response: %w", err)
+	}
+	if body.Status != "success" {
+		return 0, fmt.Errorf("prometheus query failed: %s", body.Error)
+	}
+
+	var sample []interface{}
+	switch body.Data.ResultType {
+	case "vector":
+		var vector []struct {
+			Value []interface{} `json:"value"`
+		}
+		if err := json.Unmarshal(body.Data.Result, &vector); err != nil {
+			return 0, err
+		}
+		if len(vector) == 0 {
+			return 0, errNoPrometheusData
+		}
+		sample = vector[0].Value
+	case "scalar":
+		if err := json.Unmarshal(body.Data.Result, &sample); err != nil {
+			return 0, err
+		}
+	default:
+		return 0, fmt.Errorf("unsupported result type %q", body.Data.ResultType)
+	}
+
+	if len(sample) != 2 {
+		return 0, errNoPrometheusData
+	}
+	raw, ok := sample[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected sample value %v", sample[1])
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(value) {
+		return 0, errNoPrometheusData
+	}
+	return value, nil
+}
+
+// compareThreshold applies the operator to value and threshold
+func compareThreshold(operator string, value, threshold float64) (bool, error) {
+	switch operator {
+	case ">":
+		return value > threshold, nil
+	case ">=":
+		return value >= threshold, nil
+	case "<":
+		return value < threshold, nil
+	case "<=":
+		return value <= threshold, nil
+	default:
+		return false, fmt.Errorf("unsupported fail_when operator %q", operator)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrometheusClientEvaluate(t *testing.T) {
+	var queries []string
+	prometheus := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query().Get("query")
+		queries = append(queries, query)
+
+		switch query {
+		case `error_rate{namespace="payments",deployment="api"}`:
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"0.2"]}]}}`)
+		case `p99{namespace="payments",deployment="api"}`:
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"0.1"]}}`)
+		default:
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[]}}`)
+		}
+	}))
+	defer prometheus.Close()
+
+	client, err := newPrometheusClient(&PrometheusConfig{
+		URL: prometheus.URL,
+		Checks: []PromQLCheck{
+			{Name: "error_rate", Query: `error_rate{namespace="{{.Namespace}}",deployment="{{.Name}}"}`, Threshold: 0.05},
+			{Name: "p99", Query: `p99{namespace="{{.Namespace}}",deployment="{{.Name}}"}`, Threshold: 0.5, FailWhen: ">="},
+			{Name: "missing", Query: `missing`, Threshold: 1},
+		},
+	})
+	assert.NoError(t, err)
+
+	results, healthy := client.evaluate(context.TODO(), "payments", "api")
+	assert.False(t, healthy)
+	assert.Len(t, results, 3)
+	assert.False(t, results[0].Healthy)
+	assert.Equal(t, 0.2, *results[0].Value)
+	assert.True(t, results[1].Healthy)
+	assert.True(t, results[2].Healthy)
+	assert.Equal(t, errNoPrometheusData.Error(), results[2].Error)
+	assert.Len(t, queries, 3)
+}
+
+func TestNewPrometheusClientValidation(t *testing.T) {
+	_, err := newPrometheusClient(&PrometheusConfig{})
+	assert.Error(t, err)
+
+	_, err = newPrometheusClient(&PrometheusConfig{URL: "http://prometheus", Checks: []PromQLCheck{{Name: "x", Query: "up", FailWhen: "=="}}})
+	assert.Error(t, err)
+}
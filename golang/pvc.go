@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// defaultVolumeFullThreshold is the percentage of its capacity used beyond which a volume is nearly full.
+const defaultVolumeFullThreshold = 90
+
+type PVCInfo struct {
+	Name         string `json:"pvc_name"`
+	Namespace    string `json:"namespace"`
+	Phase        string `json:"phase"`
+	StorageClass string `json:"storage_class,omitempty"`
+	VolumeName   string `json:"volume_name,omitempty"`
+	// Requested is the storage the claim requests, and Capacity that of its volume once bound, in bytes
+	Requested int64 `json:"requested"`
+	Capacity  int64 `json:"capacity,omitempty"`
+	// UsedBytes, AvailableBytes and UsedPercent are read from the kubelet of a node mounting the volume, when it can be
+	UsedBytes      *uint64  `json:"used_bytes,omitempty"`
+	AvailableBytes *uint64  `json:"available_bytes,omitempty"`
+	UsedPercent    *float64 `json:"used_percent,omitempty"`
+	// Pods are the pods mounting the claim
+	Pods      []string  `json:"pods"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PVCReport struct {
+	ThresholdPercent float64 `json:"threshold_percent"`
+	// Pending claims aren't bound after pendingTolerance, besides those waiting for a pod to be scheduled
+	Pending []PVCInfo `json:"pending"`
+	// Lost claims have lost their volume
+	Lost       []PVCInfo `json:"lost"`
+	NearlyFull []PVCInfo `json:"nearly_full"`
+}
+
+// Reports the PersistentVolumeClaims which are pending, lost, or whose volume is used beyond ?threshold= percent, 90
+// by default, with the pods mounting them, filtered like the deployments of clusterDeploymentsInfoHandler
+//
+// The usage of volumes is read from the kubelets, through the node proxy of the API server, and is missing when that
+// is forbidden or the volume isn't mounted.
+func (s *Server) pvcInfoHandler(w http.ResponseWriter, r *http.Request) {
+	filter, status, err := s.parseReportFilter(r)
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+	threshold := float64(defaultVolumeFullThreshold)
+	if value := r.URL.Query().Get("threshold"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed <= 0 || parsed > 100 {
+			writeErrorf(w, http.StatusBadRequest, "invalid threshold %q, expected a percentage", value)
+			return
+		}
+		threshold = parsed
+	}
+
+	report, err := getPVCReport(r.Context(), s.K8sClientSet, s.ResourceMetrics, filter.Scope, filter.Selector, threshold, time.Now())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	report.excludeNamespaces(filter.excludedNamespaces(s.ExcludedNamespaces))
+	writeJSON(w, http.StatusOK, report)
+}
+
+// getPVCReport checks the claims of every namespace in scope whose own labels match selector, reading the usage of
+// those mounted from the kubelets when metrics isn't nil.
+func getPVCReport(ctx context.Context, clientset kubernetes.Interface, metrics *MetricsClient, scope NamespaceScope, selector labels.Selector, threshold float64, now time.Time) (*PVCReport, error) {
+	namespaces, err := scope.resolve(ctx, clientset)
+	if err != nil {
+		return nil, err
+	}
+	waitForConsumer := waitForFirstConsumerClasses(ctx, clientset)
+
+	report := &PVCReport{ThresholdPercent: threshold, Pending: []PVCInfo{}, Lost: []PVCInfo{}, NearlyFull: []PVCInfo{}}
+	// The stats of the nodes read so far, nil for those which couldn't be
+	nodeStats := map[string]map[string]volumeStats{}
+	for _, namespace := range namespaces {
+		claims, err := clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, err
+		}
+		if len(claims.Items) == 0 {
+			continue
+		}
+		pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{FieldSelector: "status.phase!=Succeeded,status.phase!=Failed"})
+		if err != nil {
+			return nil, err
+		}
+		// The pods mounting each claim, and the nodes they run on, by namespace and name of the claim
+		mounts, nodes := map[string][]string{}, map[string][]string{}
+		for _, pod := range pods.Items {
+			if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+				continue
+			}
+			for _, volume := range pod.Spec.Volumes {
+				if volume.PersistentVolumeClaim == nil {
+					continue
+				}
+				key := pod.Namespace + "/" + volume.PersistentVolumeClaim.ClaimName
+				mounts[key] = append(mounts[key], pod.Name)
+				if pod.Spec.NodeName != "" && !slices.Contains(nodes[key], pod.Spec.NodeName) {
+					nodes[key] = append(nodes[key], pod.Spec.NodeName)
+				}
+			}
+		}
+
+		for _, claim := range claims.Items {
+			key := claim.Namespace + "/" + claim.Name
+			info := newPVCInfo(claim, mounts[key])
+			switch claim.Status.Phase {
+			case corev1.ClaimLost:
+				report.Lost = append(report.Lost, info)
+			case corev1.ClaimPending:
+				if now.Sub(claim.CreationTimestamp.Time) > pendingTolerance && (len(info.Pods) > 0 || !waitForConsumer[info.StorageClass]) {
+					report.Pending = append(report.Pending, info)
+				}
+			case corev1.ClaimBound:
+				if metrics == nil {
+					continue
+				}
+				for _, node := range nodes[key] {
+					if _, ok := nodeStats[node]; !ok {
+						stats, err := metrics.volumeStats(ctx, node)
+						if err != nil {
+							slog.DebugContext(ctx, "No volume stats", "node", node, "error", err)
+						}
+						nodeStats[node] = stats
+					}
+					if stats, ok := nodeStats[node][key]; ok && stats.CapacityBytes > 0 {
+						percent := float64(stats.UsedBytes) * 100 / float64(stats.CapacityBytes)
+						info.UsedBytes, info.AvailableBytes, info.UsedPercent = &stats.UsedBytes, &stats.AvailableBytes, &percent
+						if percent >= threshold {
+							report.NearlyFull = append(report.NearlyFull, info)
+						}
+						break
+					}
+				}
+			}
+		}
+	}
+	for _, list := range [][]PVCInfo{report.Pending, report.Lost, report.NearlyFull} {
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].Namespace != list[j].Namespace {
+				return list[i].Namespace < list[j].Namespace
+			}
+			return list[i].Name < list[j].Name
+		})
+	}
+	slog.DebugContext(ctx, "PVC report", "pending", len(report.Pending), "lost", len(report.Lost), "nearlyFull", len(report.NearlyFull))
+	return report, nil
+}
+
+func newPVCInfo(claim corev1.PersistentVolumeClaim, pods []string) PVCInfo {
+	info := PVCInfo{
+		Name:       claim.Name,
+		Namespace:  claim.Namespace,
+		Phase:      string(claim.Status.Phase),
+		VolumeName: claim.Spec.VolumeName,
+		Requested:  claim.Spec.Resources.Requests.Storage().Value(),
+		Capacity:   claim.Status.Capacity.Storage().Value(),
+		Pods:       append([]string{}, pods...),
+		CreatedAt:  claim.CreationTimestamp.Time,
+	}
+	if claim.Spec.StorageClassName != nil {
+		info.StorageClass = *claim.Spec.StorageClassName
+	}
+	sort.Strings(info.Pods)
+	return info
+}
+
+// waitForFirstConsumerClasses returns the storage classes binding their claims once a pod using them is scheduled, the
+// claims of which stay pending until then. It's empty when storage classes can't be listed.
+func waitForFirstConsumerClasses(ctx context.Context, clientset kubernetes.Interface) map[string]bool {
+	classes, err := clientset.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		slog.DebugContext(ctx, "Failed listing storage classes", "error", err)
+		return nil
+	}
+	result := map[string]bool{}
+	for _, class := range classes.Items {
+		if class.VolumeBindingMode != nil && *class.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer {
+			result[class.Name] = true
+		}
+	}
+	return result
+}
+
+// excludeNamespaces removes the claims of namespaces from every category of the report.
+func (report *PVCReport) excludeNamespaces(namespaces []string) {
+	if len(namespaces) == 0 {
+		return
+	}
+	excluded := func(claim PVCInfo) bool { return slices.Contains(namespaces, claim.Namespace) }
+	report.Pending = slices.DeleteFunc(report.Pending, excluded)
+	report.Lost = slices.DeleteFunc(report.Lost, excluded)
+	report.NearlyFull = slices.DeleteFunc(report.NearlyFull, excluded)
+}
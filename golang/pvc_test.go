@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetPVCReport(t *testing.T) {
+	now := time.Date(2024, time.January, 31, 12, 0, 0, 0, time.UTC)
+	claim := func(name, class string, phase corev1.PersistentVolumeClaimPhase) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "data", CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: &class,
+				Resources:        corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")}},
+			},
+			Status: corev1.PersistentVolumeClaimStatus{Phase: phase},
+		}
+	}
+	pod := func(name, claim string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "data"},
+			Spec: corev1.PodSpec{NodeName: "node-1", Volumes: []corev1.Volume{{
+				Name:         "data",
+				VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim}},
+			}}},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+	waitForConsumer := storagev1.VolumeBindingWaitForFirstConsumer
+	clientset := fake.NewSimpleClientset(
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "local"}, VolumeBindingMode: &waitForConsumer},
+		claim("postgres", "standard", corev1.ClaimBound), pod("postgres-0", "postgres"),
+		claim("redis", "standard", corev1.ClaimBound), pod("redis-0", "redis"),
+		claim("kafka", "standard", corev1.ClaimPending), pod("kafka-0", "kafka"),
+		// Waits for a pod to use it
+		claim("scratch", "local", corev1.ClaimPending),
+		claim("archive", "standard", corev1.ClaimLost),
+	)
+	metrics := fakeMetricsClient(map[string]string{
+		"/api/v1/nodes/node-1/proxy/stats/summary": `{"pods":[
+			{"volume":[{"name":"data","pvcRef":{"name":"postgres","namespace":"data"},"capacityBytes":100,"usedBytes":95,"availableBytes":5}]},
+			{"volume":[{"name":"data","pvcRef":{"name":"redis","namespace":"data"},"capacityBytes":100,"usedBytes":40,"availableBytes":60}]},
+			{"volume":[{"name":"kube-api-access"}]}]}`,
+	})
+
+	report, err := getPVCReport(context.Background(), clientset, metrics, NamespaceScope{}, labels.Everything(), defaultVolumeFullThreshold, now)
+	assert.NoError(t, err)
+	names := func(claims []PVCInfo) []string {
+		var result []string
+		for _, claim := range claims {
+			result = append(result, claim.Name)
+		}
+		return result
+	}
+	assert.Equal(t, []string{"kafka"}, names(report.Pending))
+	assert.Equal(t, []string{"kafka-0"}, report.Pending[0].Pods)
+	assert.Equal(t, []string{"archive"}, names(report.Lost))
+	if assert.Equal(t, []string{"postgres"}, names(report.NearlyFull)) {
+		assert.Equal(t, 95.0, *report.NearlyFull[0].UsedPercent)
+		assert.Equal(t, int64(10<<30), report.NearlyFull[0].Requested)
+	}
+
+	// Without the kubelet stats, nothing is nearly full
+	report, err = getPVCReport(context.Background(), clientset, fakeMetricsClient(nil), NamespaceScope{}, labels.Everything(), defaultVolumeFullThreshold, now)
+	assert.NoError(t, err)
+	assert.Empty(t, report.NearlyFull)
+	report.excludeNamespaces([]string{"data"})
+	assert.Empty(t, report.Pending)
+	assert.Empty(t, report.Lost)
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// quarantinePolicyName names the single policy isolating a namespace, so isolating it twice finds the first one.
+const quarantinePolicyName = "isolate-namespace"
+
+// The cluster DNS pods, which an isolated namespace can still query with allow_dns.
+const (
+	kubeSystemNamespace = "kube-system"
+	kubeDNSLabel        = "k8s-app"
+	kubeDNSValue        = "kube-dns"
+	dnsPort             = 53
+)
+
+// NamespaceIsolationRequest denies all the ingress and egress of the pods of a namespace, but with the allowed peers.
+//
+// Services are given by name, in the isolated namespace, or as namespace/name.
+type NamespaceIsolationRequest struct {
+	Namespace       string           `json:"namespace"`
+	AllowDNS        bool             `json:"allow_dns,omitempty"`
+	AllowKubeSystem bool             `json:"allow_kube_system,omitempty"`
+	AllowServices   []string         `json:"allow_services,omitempty"`
+	TTL             *metav1.Duration `json:"ttl,omitempty"`
+}
+
+// NamespaceIsolationResult names the policy isolating a namespace, Existing when the namespace was already isolated.
+type NamespaceIsolationResult struct {
+	Namespace string `json:"namespace"`
+	Policy    string `json:"policy"`
+	Existing  bool   `json:"existing,omitempty"`
+}
+
+// quarantiningBackend is implemented by the backends able to isolate a whole namespace.
+type quarantiningBackend interface {
+	// renderQuarantine builds the policy denying all the traffic of the pods of the namespace of request, but with the
+	// cluster DNS, kube-system and the pods of services, the allowed services resolved to their selectors.
+	renderQuarantine(request NamespaceIsolationRequest, services []DenyNetworkRequestWorkload, meta RequestMetadata) PolicyObject
+}
+
+func (r NamespaceIsolationRequest) validate() error {
+	if errs := validation.IsDNS1123Label(r.Namespace); len(errs) > 0 {
+		return fmt.Errorf("invalid namespace %q: %s", r.Namespace, strings.Join(errs, ", "))
+	}
+	if r.TTL != nil && r.TTL.Duration < 0 {
+		return fmt.Errorf("invalid ttl %s, it can't be negative", r.TTL.Duration)
+	}
+	for _, service := range r.AllowServices {
+		if _, err := r.service(service); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// service returns the reference to an allowed service, in the isolated namespace unless it names another one.
+func (r NamespaceIsolationRequest) service(value string) (DenyNetworkRequestWorkload, error) {
+	namespace, name, found := strings.Cut(value, "/")
+	if !found {
+		namespace, name = r.Namespace, value
+	}
+	if len(validation.IsDNS1123Label(namespace)) > 0 || len(validation.IsDNS1123Label(name)) > 0 {
+		return DenyNetworkRequestWorkload{}, fmt.Errorf("%w: invalid service %q, expected a name or namespace/name", errInvalidReference, value)
+	}
+	return DenyNetworkRequestWorkload{Kind: workloadKindService, Namespace: namespace, Name: name}, nil
+}
+
+// Handler denying all the traffic of a namespace but the allowed exceptions
+//
+// A namespace has a single isolation: isolating it again returns the existing policy, whatever its exceptions.
+func (s *Server) isolateNamespaceHandler(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		readBodyError(w, err)
+		return
+	}
+
+	defer r.Body.Close()
+
+	var request NamespaceIsolationRequest
+	if err := decodeStrictJSON(body, &request); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := request.validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	quarantining, ok := s.Policies.(quarantiningBackend)
+	if !ok {
+		writeErrorf(w, http.StatusBadRequest, "the %s policy backend can't isolate namespaces", s.Policies.Name())
+		return
+	}
+	if err := s.Namespaces.check(r.Context(), s.K8sClientSet, request.Namespace); err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	services, status, err := s.resolveAllowedServices(r.Context(), request)
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+
+	if !s.authorize(w, r, operationIsolateNamespace, request.Namespace, "", request) {
+		return
+	}
+
+	_, err = s.K8sClientSet.CoreV1().Namespaces().Get(r.Context(), request.Namespace, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		writeErrorf(w, http.StatusUnprocessableEntity, "%w: namespace %s doesn't exist", errWorkloadNotFound, request.Namespace)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	meta := requestMetadata(r.Context())
+	policy := quarantining.renderQuarantine(request, services, meta)
+	if request.TTL != nil && request.TTL.Duration > 0 {
+		setAnnotation(policy, expiresAtAnnotation, time.Now().Add(request.TTL.Duration).UTC().Format(time.RFC3339))
+	}
+
+	if r.URL.Query().Get("dryRun") == "true" {
+		result, err := s.Policies.Create(r.Context(), policy, true)
+		if apierrors.IsAlreadyExists(err) {
+			result, err = s.Policies.Get(r.Context(), policy.GetNamespace(), policy.GetName())
+		}
+		switch {
+		case apierrors.IsInvalid(err):
+			writeError(w, http.StatusUnprocessableEntity, err)
+		case err != nil:
+			writeError(w, http.StatusInternalServerError, err)
+		default:
+			writeObject(w, r, result)
+		}
+		return
+	}
+
+	reservation, err := s.Guardrails.reserve(budgetPolicies, request.Namespace, 1, time.Now())
+	if writeGuardrailError(w, err) {
+		return
+	}
+
+	audit := AuditEvent{
+		Action:          operationIsolateNamespace.Action,
+		RequestMetadata: meta,
+		RemoteAddr:      r.RemoteAddr,
+		Namespace:       request.Namespace,
+		Target:          policy.GetName(),
+		Request:         request,
+		Result:          "isolated",
+	}
+	created, err := s.Policies.Create(r.Context(), policy, false)
+	if apierrors.IsAlreadyExists(err) {
+		reservation.release(1)
+		existing, err := s.Policies.Get(r.Context(), policy.GetNamespace(), policy.GetName())
+		if err == nil && existing.GetLabels()[managedByLabel] != managedByValue {
+			err = fmt.Errorf("network policy %s/%s exists and is not managed by this service", existing.GetNamespace(), existing.GetName())
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		slog.InfoContext(r.Context(), "Namespace already isolated", "namespace", request.Namespace, "name", existing.GetName())
+		writeJSON(w, http.StatusOK, NamespaceIsolationResult{Namespace: request.Namespace, Policy: existing.GetName(), Existing: true})
+		return
+	}
+	if err != nil {
+		reservation.release(1)
+		audit.Result, audit.Error = "failed", err.Error()
+		recordAudit(audit)
+		writeError(w, denyPolicyErrorStatus(err), err)
+		return
+	}
+	recordAudit(audit)
+
+	slog.InfoContext(r.Context(), "Namespace isolated", "namespace", request.Namespace, "name", created.GetName())
+	recordEvent(r.Context(), s.K8sClientSet, policyReference(created), "NamespaceIsolated", fmt.Sprintf("Denied all the traffic of the namespace but %s", describeExceptions(request)), meta)
+	writeJSON(w, http.StatusOK, NamespaceIsolationResult{Namespace: request.Namespace, Policy: created.GetName()})
+}
+
+// resolveAllowedServices returns the pod selectors of the allowed services, with the HTTP status of the error.
+func (s *Server) resolveAllowedServices(ctx context.Context, request NamespaceIsolationRequest) ([]DenyNetworkRequestWorkload, int, error) {
+	services := make([]DenyNetworkRequestWorkload, 0, len(request.AllowServices))
+	for _, value := range request.AllowServices {
+		service, err := request.service(value)
+		if err == nil && service.Namespace != request.Namespace {
+			if err := s.Namespaces.check(ctx, s.K8sClientSet, service.Namespace); err != nil {
+				return nil, http.StatusForbidden, err
+			}
+		}
+		if err == nil {
+			service, err = resolveReference(ctx, s.K8sClientSet, service)
+		}
+		if err != nil {
+			return nil, referenceErrorStatus(err), err
+		}
+		services = append(services, service)
+	}
+	return services, http.StatusOK, nil
+}
+
+// describeExceptions lists the traffic an isolation still allows, for events.
+func describeExceptions(request NamespaceIsolationRequest) string {
+	var exceptions []string
+	if request.AllowDNS {
+		exceptions = append(exceptions, "DNS")
+	}
+	if request.AllowKubeSystem {
+		exceptions = append(exceptions, kubeSystemNamespace)
+	}
+	exceptions = append(exceptions, request.AllowServices...)
+	if len(exceptions) == 0 {
+		return "nothing"
+	}
+	return strings.Join(exceptions, ", ")
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNamespaceIsolationRequestValidate(t *testing.T) {
+	assert.NoError(t, NamespaceIsolationRequest{Namespace: "payments", AllowServices: []string{"ledger", "monitoring/prometheus"}}.validate())
+	assert.Error(t, NamespaceIsolationRequest{}.validate())
+	assert.Error(t, NamespaceIsolationRequest{Namespace: "Payments"}.validate())
+	assert.Error(t, NamespaceIsolationRequest{Namespace: "payments", TTL: &metav1.Duration{Duration: -1}}.validate())
+	assert.ErrorIs(t, NamespaceIsolationRequest{Namespace: "payments", AllowServices: []string{"a/b/c"}}.validate(), errInvalidReference)
+
+	request := NamespaceIsolationRequest{Namespace: "payments"}
+	service, err := request.service("ledger")
+	assert.NoError(t, err)
+	assert.Equal(t, DenyNetworkRequestWorkload{Kind: workloadKindService, Namespace: "payments", Name: "ledger"}, service)
+	service, err = request.service("monitoring/prometheus")
+	assert.NoError(t, err)
+	assert.Equal(t, "monitoring", service.Namespace)
+}
+
+func TestDescribeExceptions(t *testing.T) {
+	assert.Equal(t, "nothing", describeExceptions(NamespaceIsolationRequest{Namespace: "payments"}))
+	assert.Equal(t, "DNS, kube-system, monitoring/prometheus", describeExceptions(NamespaceIsolationRequest{
+		Namespace:       "payments",
+		AllowDNS:        true,
+		AllowKubeSystem: true,
+		AllowServices:   []string{"monitoring/prometheus"},
+	}))
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+)
+
+const defaultReaperInterval = time.Minute
+
+// policyReaper deletes the managed policies whose TTL is over, so policies created during an incident don't outlive it.
+//
+// The mirror of a policy carries the same expiry and is deleted on its own. Staged policies aren't listed by the
+// backends and never expire.
+type policyReaper struct {
+	interval  time.Duration
+	backend   PolicyBackend
+	clientset kubernetes.Interface
+	scope     NamespaceScope
+}
+
+// run deletes the expired policies every interval until ctx is done.
+func (p *policyReaper) run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.reap(ctx, time.Now()); err != nil {
+			slog.ErrorContext(ctx, "Failed reaping expired network policies", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reap deletes the policies in scope expired at now. A policy failing to be deleted is retried on the next run.
+func (p *policyReaper) reap(ctx context.Context, now time.Time) error {
+	namespaces, err := p.scope.resolve(ctx, p.clientset)
+	if err != nil {
+		return err
+	}
+
+	for _, namespace := range namespaces {
+		policies, err := p.backend.List(ctx, namespace)
+		if err != nil {
+			return fmt.Errorf("listing the policies of namespace %q: %w", namespace, err)
+		}
+		for _, policy := range policies {
+			if expiry := expiresAt(policy); expiry == nil || expiry.After(now) {
+				continue
+			}
+			p.expire(ctx, policy)
+		}
+	}
+	return nil
+}
+
+func (p *policyReaper) expire(ctx context.Context, policy PolicyObject) {
+	audit := AuditEvent{
+		Action:          "policy.expire",
+		RequestMetadata: requestMetadata(ctx),
+		Namespace:       policy.GetNamespace(),
+		Target:          policy.GetName(),
+		Result:          "deleted",
+	}
+
+	// The backend keeps a policy recreated under the same name since it was listed
+	err := p.backend.Delete(ctx, policy)
+	if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed deleting expired network policy", "namespace", policy.GetNamespace(), "name", policy.GetName(), "error", err)
+		audit.Result, audit.Error = "failed", err.Error()
+		recordAudit(audit)
+		return
+	}
+	recordAudit(audit)
+
+	slog.InfoContext(ctx, "Expired network policy deleted", "namespace", policy.GetNamespace(), "name", policy.GetName(), "expired_at", policy.GetAnnotations()[expiresAtAnnotation])
+	recordEvent(ctx, p.clientset, policyReference(policy), "DenyPolicyExpired", fmt.Sprintf("Allowed traffic again, the policy expired at %s", policy.GetAnnotations()[expiresAtAnnotation]), RequestMetadata{})
+}
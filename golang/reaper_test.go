@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPolicyReaper(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+	)
+	backend := newFakeCiliumBackend()
+	request := DenyNetworkRequest{
+		A:   DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B:   DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}},
+		TTL: &metav1.Duration{Duration: time.Hour},
+	}
+	expiring, _, err := createDenyNetworkPolicy(context.Background(), clientset, backend, NamespaceScope{}, request, RequestMetadata{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	request.TTL = nil
+	request.B = DenyNetworkRequestWorkload{CIDR: "10.12.0.0/16"}
+	kept, _, err := createDenyNetworkPolicy(context.Background(), clientset, backend, NamespaceScope{}, request, RequestMetadata{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	policy, err := backend.Get(ctx, "payments", expiring)
+	if !assert.NoError(t, err) || !assert.NotNil(t, expiresAt(policy)) {
+		return
+	}
+	expiry := *expiresAt(policy)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expiry, time.Minute)
+
+	reaper := &policyReaper{backend: backend, clientset: clientset}
+	assert.NoError(t, reaper.reap(ctx, expiry.Add(-time.Second)))
+	_, err = backend.Get(ctx, "payments", expiring)
+	assert.NoError(t, err)
+
+	assert.NoError(t, reaper.reap(ctx, expiry))
+	_, err = backend.Get(ctx, "payments", expiring)
+	assert.True(t, apierrors.IsNotFound(err))
+	mirrors, err := backend.List(ctx, "shop")
+	assert.NoError(t, err)
+	assert.Empty(t, mirrors)
+	_, err = backend.Get(ctx, "payments", kept)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Kinds a workload can be referenced by instead of its labels.
+const (
+	workloadKindDeployment = "Deployment"
+	workloadKindService    = "Service"
+)
+
+var errInvalidReference = errors.New("invalid workload reference")
+
+// referenced tells whether the workload is given by kind and name.
+func (w DenyNetworkRequestWorkload) referenced() bool {
+	return w.Kind != "" || w.Name != ""
+}
+
+// resolveReference replaces a workload given by kind and name with the pod selector of that Deployment or Service.
+//
+// The kind and name are kept in the request for the record, the policy name doesn't depend on them.
+func resolveReference(ctx context.Context, clientset kubernetes.Interface, workload DenyNetworkRequestWorkload) (DenyNetworkRequestWorkload, error) {
+	if !workload.referenced() {
+		return workload, nil
+	}
+	if workload.Kind == "" || workload.Name == "" || workload.Namespace == "" {
+		return workload, fmt.Errorf("%w: a kind, a name and a namespace are required", errInvalidReference)
+	}
+	if workload.selects() || workload.CIDR != "" {
+		return workload, fmt.Errorf("%w: %s %s sets labels or a CIDR too", errInvalidReference, workload.Kind, workload.Name)
+	}
+
+	switch workload.Kind {
+	case workloadKindDeployment:
+		deployment, err := clientset.AppsV1().Deployments(workload.Namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return workload, fmt.Errorf("%w: deployment %s/%s doesn't exist", errWorkloadNotFound, workload.Namespace, workload.Name)
+		}
+		if err != nil {
+			return workload, err
+		}
+		if deployment.Spec.Selector == nil {
+			return workload, fmt.Errorf("%w: deployment %s/%s has no selector", errWorkloadNotFound, workload.Namespace, workload.Name)
+		}
+		workload.Labels, workload.MatchExpressions = deployment.Spec.Selector.MatchLabels, deployment.Spec.Selector.MatchExpressions
+	case workloadKindService:
+		service, err := clientset.CoreV1().Services(workload.Namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return workload, fmt.Errorf("%w: service %s/%s doesn't exist", errWorkloadNotFound, workload.Namespace, workload.Name)
+		}
+		if err != nil {
+			return workload, err
+		}
+		// Its endpoints are managed by hand, there are no pods to select
+		if len(service.Spec.Selector) == 0 {
+			return workload, fmt.Errorf("%w: service %s/%s has no selector", errWorkloadNotFound, workload.Namespace, workload.Name)
+		}
+		workload.Labels = service.Spec.Selector
+	default:
+		return workload, fmt.Errorf("%w: unknown kind %q, expected Deployment or Service", errInvalidReference, workload.Kind)
+	}
+	return workload, nil
+}
+
+// referenceErrorStatus is the HTTP status of an error resolving a reference: a 400 for an invalid one, a 422 for a missing workload.
+func referenceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, errInvalidReference):
+		return http.StatusBadRequest
+	case errors.Is(err, errWorkloadNotFound):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, errCalicoAPIUnavailable):
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
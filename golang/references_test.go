@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResolveReference(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "payments", Name: "ledger"},
+			Spec: appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{
+				MatchLabels:      map[string]string{"app": "ledger"},
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "track", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"canary"}}},
+			}},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "cart"},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "cart"}},
+		},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "external"}},
+	)
+
+	workload, err := resolveReference(ctx, clientset, DenyNetworkRequestWorkload{Kind: workloadKindDeployment, Name: "ledger", Namespace: "payments"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"app": "ledger"}, workload.Labels)
+	assert.Equal(t, []metav1.LabelSelectorRequirement{{Key: "track", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"canary"}}}, workload.MatchExpressions)
+
+	workload, err = resolveReference(ctx, clientset, DenyNetworkRequestWorkload{Kind: workloadKindService, Name: "cart", Namespace: "shop"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"app": "cart"}, workload.Labels)
+
+	_, err = resolveReference(ctx, clientset, DenyNetworkRequestWorkload{Kind: workloadKindService, Name: "external", Namespace: "shop"})
+	assert.ErrorIs(t, err, errWorkloadNotFound)
+	_, err = resolveReference(ctx, clientset, DenyNetworkRequestWorkload{Kind: workloadKindDeployment, Name: "ledgr", Namespace: "payments"})
+	assert.ErrorIs(t, err, errWorkloadNotFound)
+	assert.Equal(t, 422, referenceErrorStatus(err))
+
+	for _, invalid := range []DenyNetworkRequestWorkload{
+		{Kind: "StatefulSet", Name: "ledger", Namespace: "payments"},
+		{Kind: workloadKindDeployment, Name: "ledger"},
+		{Name: "ledger", Namespace: "payments"},
+		{Kind: workloadKindDeployment, Name: "ledger", Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+	} {
+		_, err = resolveReference(ctx, clientset, invalid)
+		assert.ErrorIs(t, err, errInvalidReference, "%+v", invalid)
+		assert.Equal(t, 400, referenceErrorStatus(err))
+	}
+
+	plain := DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}}
+	workload, err = resolveReference(ctx, clientset, plain)
+	assert.NoError(t, err)
+	assert.Equal(t, plain, workload)
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// RemoteWriteConfig points at a Prometheus remote-write compatible endpoint (Prometheus, Mimir, Thanos, VictoriaMetrics).
+type RemoteWriteConfig struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+type remoteWriteSink struct {
+	url        string
+	headers    map[string]string
+	httpClient *http.Client
+}
+
+type remoteWriteSeries struct {
+	labels map[string]string
+	value  float64
+}
+
+func newRemoteWriteSink(config *RemoteWriteConfig) (*remoteWriteSink, error) {
+	if config.URL == "" {
+		return nil, errors.New("export.remote_write.url is required")
+	}
+	return &remoteWriteSink{url: config.URL, headers: config.Headers, httpClient: &http.Client{Timeout: 30 * time.Second}}, nil
+}
+
+func (s *remoteWriteSink) Name() string {
+	return "remote_write"
+}
+
+// Export sends one sample per deployment for the requested pods, ready pods and health of the snapshot.
+func (s *remoteWriteSink) Export(ctx context.Context, snapshot HealthSnapshot) error {
+	var series []remoteWriteSeries
+	add := func(deployment DeploymentInfo, healthy bool) {
+		ready := 0.0
+		if healthy {
+			ready = 1
+		}
+		labels := func(name string) map[string]string {
+			return map[string]string{"__name__": name, "namespace": deployment.Namespace, "deployment": deployment.Name}
+		}
+		series = append(series,
+			remoteWriteSeries{labels: labels("tyk_sre_deployment_requested_pods"), value: float64(deployment.RequestedPods)},
+			remoteWriteSeries{labels: labels("tyk_sre_deployment_ready_pods"), value: float64(deployment.ReadyPods)},
+			remoteWriteSeries{labels: labels("tyk_sre_deployment_healthy"), value: ready},
+		)
+	}
+	for _, deployment := range snapshot.Deployments.ReadyDeployments {
+		add(deployment, true)
+	}
+	for _, deployment := range snapshot.Deployments.FailedDeployments {
+		add(deployment, false)
+	}
+	for _, deployment := range snapshot.Deployments.StuckRollouts {
+		add(deployment, false)
+	}
+
+	body := snappyEncode(encodeWriteRequest(series, snapshot.Time))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	for key, value := range s.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("remote write returned %s: %s", resp.Status, message)
+	}
+	return nil
+}
+
+// encodeWriteRequest encodes a prometheus.WriteRequest protobuf message without depending on the generated types.
+//
+// WriteRequest{1: repeated TimeSeries}, TimeSeries{1: repeated Label, 2: repeated Sample}, Label{1: name, 2: value}, Sample{1: double value, 2: int64 timestamp}.
+func encodeWriteRequest(series []remoteWriteSeries, timestamp time.Time) []byte {
+	var request []byte
+	for _, s := range series {
+		names := make([]string, 0, len(s.labels))
+		for name := range s.labels {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var timeSeries []byte
+		for _, name := range names {
+			var label []byte
+			label = protowire.AppendTag(label, 1, protowire.BytesType)
+			label = protowire.AppendString(label, name)
+			label = protowire.AppendTag(label, 2, protowire.BytesType)
+			label = protowire.AppendString(label, s.labels[name])
+
+			timeSeries = protowire.AppendTag(timeSeries, 1, protowire.BytesType)
+			timeSeries = protowire.AppendBytes(timeSeries, label)
+		}
+
+		var sample []byte
+		sample = protowire.AppendTag(sample, 1, protowire.Fixed64Type)
+		sample = protowire.AppendFixed64(sample, math.Float64bits(s.value))
+		sample = protowire.AppendTag(sample, 2, protowire.VarintType)
+		sample = protowire.AppendVarint(sample, uint64(timestamp.UnixMilli()))
+
+		timeSeries = protowire.AppendTag(timeSeries, 2, protowire.BytesType)
+		timeSeries = protowire.AppendBytes(timeSeries, sample)
+
+		request = protowire.AppendTag(request, 1, protowire.BytesType)
+		request = protowire.AppendBytes(request, timeSeries)
+	}
+	return request
+}
+
+// snappyEncode wraps src in a valid snappy block made only of literals.
+//
+// Snapshots are small, so skipping compression is cheaper than pulling in a snappy implementation.
+func snappyEncode(src []byte) []byte {
+	dst := binary.AppendUvarint(nil, uint64(len(src)))
+
+	for len(src) > 0 {
+		chunk := src
+		if len(chunk) > math.MaxUint16+1 {
+			chunk = chunk[:math.MaxUint16+1]
+		}
+		src = src[len(chunk):]
+
+		n := len(chunk) - 1
+		switch {
+		case n < 60:
+			dst = append(dst, byte(n)<<2)
+		case n < 1<<8:
+			dst = append(dst, 60<<2, byte(n))
+		default:
+			dst = append(dst, 61<<2, byte(n), byte(n>>8))
+		}
+		dst = append(dst, chunk...)
+	}
+	return dst
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"io"
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Handler returning the manifests of a deny request without creating them, e.g. to commit them to Git.
+//
+// Unlike a dry run, the API server doesn't validate the policies, and the workloads don't need to have pods yet. A
+// symmetric request renders a v1 List holding the policy and its mirror. AuthorizationPolicies, which depend on the
+// pods running, aren't rendered.
+func (s *Server) renderDenyNetworkPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		readBodyError(w, err)
+		return
+	}
+
+	defer r.Body.Close()
+
+	var denyNetworkRequest DenyNetworkRequest
+	if err := decodeStrictJSON(body, &denyNetworkRequest); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	denyNetworkRequest.DryRun = false
+
+	ctx, cancel := s.kubeContext(r)
+	defer cancel()
+	if status, err := s.resolveDenyRequest(ctx, &denyNetworkRequest); err != nil {
+		writeError(w, status, err)
+		return
+	}
+
+	policy, mirror, err := prepareDenyNetworkPolicies(ctx, s.K8sClientSet, s.Policies, s.Namespaces, denyNetworkRequest, requestMetadata(r.Context()))
+	if err != nil {
+		writeError(w, denyPolicyErrorStatus(err), err)
+		return
+	}
+	if mirror == nil {
+		writeObject(w, r, policy)
+		return
+	}
+	writeObject(w, r, policyList(policy, mirror))
+}
+
+// policyList wraps policies in a v1 List, which kubectl applies like separate manifests.
+func policyList(policies ...PolicyObject) *corev1.List {
+	list := &corev1.List{}
+	list.APIVersion, list.Kind = "v1", "List"
+	for _, policy := range policies {
+		list.Items = append(list.Items, runtime.RawExtension{Object: policy})
+	}
+	return list
+}
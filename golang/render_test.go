@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/yaml"
+)
+
+func TestPrepareDenyNetworkPolicies(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+	)
+	backend := newFakeCiliumBackend()
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}},
+	}
+
+	policy, mirror, err := prepareDenyNetworkPolicies(context.Background(), clientset, backend, NamespaceScope{}, request, RequestMetadata{})
+	if !assert.NoError(t, err) || !assert.NotNil(t, mirror) {
+		return
+	}
+	assert.Equal(t, "shop/"+mirror.GetName(), policy.GetAnnotations()[mirrorAnnotation])
+	assert.Equal(t, "payments/"+policy.GetName(), mirror.GetAnnotations()[mirrorAnnotation])
+
+	r := httptest.NewRequest(http.MethodPost, "/denyNetworkPolicy/render", nil)
+	r.Header.Set("Accept", "application/yaml")
+	w := httptest.NewRecorder()
+	writeObject(w, r, policyList(policy, mirror))
+	var list struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+		Items      []struct {
+			Kind     string            `json:"kind"`
+			Metadata metav1.ObjectMeta `json:"metadata"`
+		} `json:"items"`
+	}
+	if assert.NoError(t, yaml.Unmarshal(w.Body.Bytes(), &list)) && assert.Len(t, list.Items, 2) {
+		assert.Equal(t, "v1", list.APIVersion)
+		assert.Equal(t, "List", list.Kind)
+		assert.Equal(t, "CiliumNetworkPolicy", list.Items[0].Kind)
+		assert.Equal(t, "payments", list.Items[0].Metadata.Namespace)
+		assert.Equal(t, "shop", list.Items[1].Metadata.Namespace)
+	}
+
+	// Nothing was created
+	policies, err := backend.List(r.Context(), metav1.NamespaceAll)
+	assert.NoError(t, err)
+	assert.Empty(t, policies)
+
+	request.B = DenyNetworkRequestWorkload{CIDR: "10.12.0.0/16"}
+	_, mirror, err = prepareDenyNetworkPolicies(context.Background(), clientset, backend, NamespaceScope{}, request, RequestMetadata{})
+	assert.NoError(t, err)
+	assert.Nil(t, mirror)
+}
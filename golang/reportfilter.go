@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// reportFilter restricts a workload health report to the ?namespace=, and the workloads whose own labels match the
+// ?labelSelector= of the request, and leaves the namespaces of ?excludeNamespaces= out.
+type reportFilter struct {
+	// Scope is the namespace of the request, the scope of the server without one
+	Scope     NamespaceScope
+	Namespace string
+	Selector  labels.Selector
+	Excluded  []string
+}
+
+// parseReportFilter reads the filter of a request, returning the status to reply with when it's invalid, or asks for
+// a namespace out of scope.
+func (s *Server) parseReportFilter(r *http.Request) (reportFilter, int, error) {
+	query := r.URL.Query()
+	filter := reportFilter{Scope: s.Namespaces, Namespace: query.Get("namespace"), Selector: labels.Everything()}
+	if filter.Namespace != "" {
+		if errs := validation.IsDNS1123Label(filter.Namespace); len(errs) > 0 {
+			return filter, http.StatusBadRequest, fmt.Errorf("invalid namespace %q: %s", filter.Namespace, strings.Join(errs, ", "))
+		}
+		if err := s.Namespaces.check(r.Context(), s.K8sClientSet, filter.Namespace); err != nil {
+			return filter, http.StatusForbidden, err
+		}
+		filter.Scope = NamespaceScope{Names: []string{filter.Namespace}}
+	}
+	if value := query.Get("labelSelector"); value != "" {
+		selector, err := labels.Parse(value)
+		if err != nil {
+			return filter, http.StatusBadRequest, fmt.Errorf("invalid labelSelector: %v", err)
+		}
+		filter.Selector = selector
+	}
+	excluded, err := parseExcludedNamespaces(query.Get("excludeNamespaces"))
+	if err != nil {
+		return filter, http.StatusBadRequest, err
+	}
+	filter.Excluded = excluded
+	return filter, http.StatusOK, nil
+}
+
+// excludedNamespaces returns the namespaces to leave out of the report, those of --exclude-namespaces and of the
+// request, none when the request asks for a namespace.
+func (f reportFilter) excludedNamespaces(server []string) []string {
+	if f.Namespace != "" {
+		return nil
+	}
+	return append(append([]string{}, server...), f.Excluded...)
+}
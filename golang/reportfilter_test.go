@@ -0,0 +1,36 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReportFilter(t *testing.T) {
+	server := &Server{Namespaces: NamespaceScope{Names: []string{"payments", "data"}}}
+
+	filter, _, err := server.parseReportFilter(httptest.NewRequest(http.MethodGet, "/?labelSelector=tier%3Ddb&excludeNamespaces=data", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, server.Namespaces, filter.Scope)
+	assert.Equal(t, "tier=db", filter.Selector.String())
+	assert.Equal(t, []string{"kube-system", "data"}, filter.excludedNamespaces([]string{"kube-system"}))
+
+	filter, _, err = server.parseReportFilter(httptest.NewRequest(http.MethodGet, "/?namespace=data&excludeNamespaces=payments", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"data"}, filter.Scope.Names)
+	assert.True(t, filter.Selector.Empty())
+	assert.Empty(t, filter.excludedNamespaces([]string{"kube-system"}))
+
+	for query, want := range map[string]int{
+		"namespace=Data":              http.StatusBadRequest,
+		"namespace=billing":           http.StatusForbidden,
+		"labelSelector=tier%3D%3D%3D": http.StatusBadRequest,
+		"excludeNamespaces=a_b":       http.StatusBadRequest,
+	} {
+		_, status, err := server.parseReportFilter(httptest.NewRequest(http.MethodGet, "/?"+query, nil))
+		assert.Error(t, err, query)
+		assert.Equal(t, want, status, query)
+	}
+}
@@ -0,0 +1,460 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// restartedAtAnnotation is the pod template annotation kubectl rollout restart sets, changing the template rolls
+	// out new pods.
+	restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+	defaultRolloutTimeout = 5 * time.Minute
+	// maxRolloutTimeout caps how long a request may wait for a rollout.
+	maxRolloutTimeout   = 15 * time.Minute
+	rolloutPollInterval = 2 * time.Second
+)
+
+// RolloutStatus is the progress of the rollout of a deployment, like kubectl rollout status reports it.
+type RolloutStatus struct {
+	Namespace   string    `json:"namespace"`
+	Name        string    `json:"name"`
+	RestartedAt time.Time `json:"restarted_at"`
+	// Complete is set once every replica runs the current template and is available
+	Complete bool `json:"complete"`
+	// Failed is set when the rollout exceeded its progress deadline, it won't complete by itself
+	Failed            bool   `json:"failed"`
+	Message           string `json:"message"`
+	Replicas          int32  `json:"replicas"`
+	UpdatedReplicas   int32  `json:"updated_replicas"`
+	ReadyReplicas     int32  `json:"ready_replicas"`
+	AvailableReplicas int32  `json:"available_replicas"`
+}
+
+// Handler restarting the pods of a deployment like kubectl rollout restart, answering a 202 with the progress of the
+// rollout
+//
+// With ?wait=true it waits up to ?timeout=, 5m by default, for the rollout to complete, answering a 200 once it
+// did, a 500 with the status when it exceeded its progress deadline and a 202 when it's still in progress.
+func (s *Server) restartDeploymentHandler(w http.ResponseWriter, r *http.Request) {
+	namespace, name := r.PathValue("namespace"), r.PathValue("name")
+
+	if err := s.Namespaces.check(r.Context(), s.K8sClientSet, namespace); err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+	var wait bool
+	if value := r.URL.Query().Get("wait"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			writeErrorf(w, http.StatusBadRequest, "invalid wait %q, expected true or false", value)
+			return
+		}
+		wait = parsed
+	}
+	timeout := defaultRolloutTimeout
+	if value := r.URL.Query().Get("timeout"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 || parsed > maxRolloutTimeout {
+			writeErrorf(w, http.StatusBadRequest, "invalid timeout %q, expected a positive duration up to %s", value, maxRolloutTimeout)
+			return
+		}
+		timeout = parsed
+	}
+
+	if !s.authorize(w, r, operationDeploymentRestart, namespace, name, nil) {
+		return
+	}
+
+	deployment, err := s.K8sClientSet.AppsV1().Deployments(namespace).Get(r.Context(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if deployment.Spec.Paused {
+		writeErrorf(w, http.StatusConflict, "Deployment %s/%s is paused, resume its rollout first", namespace, name)
+		return
+	}
+
+	reservation, err := s.Guardrails.reserve(budgetRestarts, namespace, 1, time.Now())
+	if writeGuardrailError(w, err) {
+		return
+	}
+
+	meta := requestMetadata(r.Context())
+	audit := AuditEvent{
+		Action:          operationDeploymentRestart.Action,
+		RequestMetadata: meta,
+		RemoteAddr:      r.RemoteAddr,
+		Namespace:       namespace,
+		Target:          name,
+		Result:          "restarted",
+	}
+	restartedAt := time.Now().UTC().Truncate(time.Second)
+	restarted, err := restartDeployment(r.Context(), s.K8sClientSet, namespace, name, restartedAt)
+	if err != nil {
+		reservation.release(1)
+		audit.Result, audit.Error = "failed", err.Error()
+		recordAudit(audit)
+		if apierrors.IsNotFound(err) {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	recordAudit(audit)
+
+	slog.InfoContext(r.Context(), "Deployment restarted", "namespace", namespace, "name", name)
+	recordEvent(r.Context(), s.K8sClientSet, corev1.ObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Namespace:  namespace,
+		Name:       name,
+		UID:        restarted.UID,
+	}, "DeploymentRestarted", "Restarted the pods of the deployment", meta)
+
+	if !wait {
+		writeJSON(w, http.StatusAccepted, rolloutStatus(restarted, restartedAt))
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+	extendWriteDeadline(w, timeout)
+	status, err := waitForRollout(ctx, s.K8sClientSet, namespace, name, restartedAt)
+	switch {
+	case err != nil && !errors.Is(err, context.DeadlineExceeded):
+		writeError(w, http.StatusInternalServerError, err)
+	case status.Failed:
+		writeJSON(w, http.StatusInternalServerError, status)
+	case status.Complete:
+		writeJSON(w, http.StatusOK, status)
+	default:
+		writeJSON(w, http.StatusAccepted, status)
+	}
+}
+
+// restartDeployment sets the restartedAt annotation of the pod template of a deployment to restartedAt.
+func restartDeployment(ctx context.Context, clientset kubernetes.Interface, namespace, name string, restartedAt time.Time) (*appsv1.Deployment, error) {
+	patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`, restartedAtAnnotation, restartedAt.Format(time.RFC3339))
+	return clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+}
+
+// waitForRollout polls a deployment until its rollout completes or fails, returning its last status along with the
+// error of ctx when it's done first.
+func waitForRollout(ctx context.Context, clientset kubernetes.Interface, namespace, name string, restartedAt time.Time) (*RolloutStatus, error) {
+	var status *RolloutStatus
+	for {
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		switch {
+		case err == nil:
+			status = rolloutStatus(deployment, restartedAt)
+			if status.Complete || status.Failed {
+				return status, nil
+			}
+		case status == nil || ctx.Err() == nil:
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, ctx.Err()
+		case <-time.After(rolloutPollInterval):
+		}
+	}
+}
+
+// rolloutStatus tells how far the rollout of a deployment got, with the checks of kubectl rollout status.
+func rolloutStatus(deployment *appsv1.Deployment, restartedAt time.Time) *RolloutStatus {
+	status := &RolloutStatus{
+		Namespace:         deployment.Namespace,
+		Name:              deployment.Name,
+		RestartedAt:       restartedAt,
+		Replicas:          specReplicas(deployment.Spec.Replicas),
+		UpdatedReplicas:   deployment.Status.UpdatedReplicas,
+		ReadyReplicas:     deployment.Status.ReadyReplicas,
+		AvailableReplicas: deployment.Status.AvailableReplicas,
+	}
+
+	switch {
+	case deployment.Status.ObservedGeneration < deployment.Generation:
+		status.Message = "waiting for the rollout to start"
+	case rolloutStuck(*deployment):
+		status.Failed = true
+		status.Message = "the rollout exceeded its progress deadline"
+	case status.UpdatedReplicas < status.Replicas:
+		status.Message = fmt.Sprintf("%d of %d new replicas have been updated", status.UpdatedReplicas, status.Replicas)
+	case deployment.Status.Replicas > status.UpdatedReplicas:
+		status.Message = fmt.Sprintf("%d old replicas are pending termination", deployment.Status.Replicas-status.UpdatedReplicas)
+	case status.AvailableReplicas < status.UpdatedReplicas:
+		status.Message = fmt.Sprintf("%d of %d updated replicas are available", status.AvailableReplicas, status.UpdatedReplicas)
+	default:
+		status.Complete = true
+		status.Message = "successfully rolled out"
+	}
+	return status
+}
+
+// revisionAnnotation numbers the revisions of a deployment on it and on its ReplicaSets.
+const revisionAnnotation = "deployment.kubernetes.io/revision"
+
+var (
+	errNoPreviousRevision = errors.New("no previous revision to roll back to")
+	errRevisionNotFound   = errors.New("revision not found")
+)
+
+// RollbackChange is a field of the pod template a rollback changes, Before or After missing when it's added or
+// removed.
+type RollbackChange struct {
+	// Path is the JSON path of the field in the pod template, such as spec.containers[0].image
+	Path   string      `json:"path"`
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+type RollbackResult struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// FromRevision is the revision the deployment ran, ToRevision the one its pod template was restored from
+	FromRevision int64            `json:"from_revision"`
+	ToRevision   int64            `json:"to_revision"`
+	DryRun       bool             `json:"dry_run"`
+	Changes      []RollbackChange `json:"changes"`
+}
+
+// Handler reverting a deployment to the pod template of its previous revision, or of ?revision=, like kubectl rollout
+// undo
+//
+// With ?dryRun=true the deployment is left as it is and the changes of the pod template are only reported.
+func (s *Server) rollbackDeploymentHandler(w http.ResponseWriter, r *http.Request) {
+	namespace, name := r.PathValue("namespace"), r.PathValue("name")
+
+	if err := s.Namespaces.check(r.Context(), s.K8sClientSet, namespace); err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+	var revision int64
+	if value := r.URL.Query().Get("revision"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed <= 0 {
+			writeErrorf(w, http.StatusBadRequest, "invalid revision %q, expected a positive integer", value)
+			return
+		}
+		revision = parsed
+	}
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+
+	if !s.authorize(w, r, operationDeploymentRollback, namespace, name, nil) {
+		return
+	}
+
+	deployment, err := s.K8sClientSet.AppsV1().Deployments(namespace).Get(r.Context(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if deployment.Spec.Paused {
+		writeErrorf(w, http.StatusConflict, "Deployment %s/%s is paused, resume its rollout first", namespace, name)
+		return
+	}
+	replicaSets, err := deploymentReplicaSets(r.Context(), s.K8sClientSet, deployment)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	target, err := rollbackTarget(deployment, replicaSets, revision)
+	switch {
+	case errors.Is(err, errRevisionNotFound):
+		writeError(w, http.StatusNotFound, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+
+	template := rollbackTemplate(target)
+	result := &RollbackResult{
+		Namespace:    namespace,
+		Name:         name,
+		FromRevision: objectRevision(deployment.ObjectMeta),
+		ToRevision:   objectRevision(target.ObjectMeta),
+		DryRun:       dryRun,
+		Changes:      templateChanges(deployment.Spec.Template, template),
+	}
+	if dryRun {
+		writeJSON(w, http.StatusOK, result)
+		return
+	}
+	if len(result.Changes) == 0 {
+		writeErrorf(w, http.StatusConflict, "Deployment %s/%s already runs the pod template of revision %d", namespace, name, result.ToRevision)
+		return
+	}
+
+	reservation, err := s.Guardrails.reserve(budgetRestarts, namespace, 1, time.Now())
+	if writeGuardrailError(w, err) {
+		return
+	}
+
+	meta := requestMetadata(r.Context())
+	audit := AuditEvent{
+		Action:          operationDeploymentRollback.Action,
+		RequestMetadata: meta,
+		RemoteAddr:      r.RemoteAddr,
+		Namespace:       namespace,
+		Target:          name,
+		Request:         map[string]int64{"revision": result.ToRevision},
+		Result:          "rolled back",
+	}
+	if err := rollbackDeployment(r.Context(), s.K8sClientSet, namespace, name, template); err != nil {
+		reservation.release(1)
+		audit.Result, audit.Error = "failed", err.Error()
+		recordAudit(audit)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	recordAudit(audit)
+
+	slog.InfoContext(r.Context(), "Deployment rolled back", "namespace", namespace, "name", name, "from", result.FromRevision, "to", result.ToRevision)
+	recordEvent(r.Context(), s.K8sClientSet, corev1.ObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Namespace:  namespace,
+		Name:       name,
+		UID:        deployment.UID,
+	}, "DeploymentRolledBack", fmt.Sprintf("Rolled back from revision %d to revision %d", result.FromRevision, result.ToRevision), meta)
+	writeJSON(w, http.StatusOK, result)
+}
+
+// deploymentReplicaSets lists the ReplicaSets a deployment controls, those of its revisions.
+func deploymentReplicaSets(ctx context.Context, clientset kubernetes.Interface, deployment *appsv1.Deployment) ([]appsv1.ReplicaSet, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	list, err := clientset.AppsV1().ReplicaSets(deployment.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	var replicaSets []appsv1.ReplicaSet
+	for _, replicaSet := range list.Items {
+		if owner := metav1.GetControllerOf(&replicaSet); owner != nil && owner.UID == deployment.UID {
+			replicaSets = append(replicaSets, replicaSet)
+		}
+	}
+	return replicaSets, nil
+}
+
+// rollbackTarget returns the ReplicaSet of revision, of the latest revision before the current one when 0.
+func rollbackTarget(deployment *appsv1.Deployment, replicaSets []appsv1.ReplicaSet, revision int64) (*appsv1.ReplicaSet, error) {
+	current := objectRevision(deployment.ObjectMeta)
+	var target *appsv1.ReplicaSet
+	for i, replicaSet := range replicaSets {
+		number := objectRevision(replicaSet.ObjectMeta)
+		switch {
+		case revision > 0 && number == revision:
+			return &replicaSets[i], nil
+		case revision == 0 && number < current && (target == nil || number > objectRevision(target.ObjectMeta)):
+			target = &replicaSets[i]
+		}
+	}
+	if revision > 0 {
+		return nil, fmt.Errorf("%w: %d", errRevisionNotFound, revision)
+	}
+	if target == nil {
+		return nil, errNoPreviousRevision
+	}
+	return target, nil
+}
+
+// objectRevision returns the revision annotation of a deployment or ReplicaSet, 0 without one.
+func objectRevision(meta metav1.ObjectMeta) int64 {
+	revision, _ := strconv.ParseInt(meta.Annotations[revisionAnnotation], 10, 64)
+	return revision
+}
+
+// rollbackTemplate returns the pod template of a ReplicaSet without the pod-template-hash label its deployment added.
+func rollbackTemplate(replicaSet *appsv1.ReplicaSet) corev1.PodTemplateSpec {
+	template := *replicaSet.Spec.Template.DeepCopy()
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	return template
+}
+
+// rollbackDeployment replaces the pod template of a deployment, rolling out its pods again.
+func rollbackDeployment(ctx context.Context, clientset kubernetes.Interface, namespace, name string, template corev1.PodTemplateSpec) error {
+	patch, err := json.Marshal([]map[string]interface{}{{"op": "replace", "path": "/spec/template", "value": template}})
+	if err != nil {
+		return err
+	}
+	_, err = clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.JSONPatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// templateChanges lists the fields which differ between two pod templates, sorted by path.
+func templateChanges(before, after corev1.PodTemplateSpec) []RollbackChange {
+	flatten := func(template corev1.PodTemplateSpec) map[string]interface{} {
+		fields := map[string]interface{}{}
+		data, _ := json.Marshal(template)
+		var value interface{}
+		_ = json.Unmarshal(data, &value)
+		flattenJSON("", value, fields)
+		return fields
+	}
+	beforeFields, afterFields := flatten(before), flatten(after)
+
+	changes := []RollbackChange{}
+	for path, value := range beforeFields {
+		if other, ok := afterFields[path]; !ok || !reflect.DeepEqual(value, other) {
+			changes = append(changes, RollbackChange{Path: path, Before: value, After: other})
+		}
+	}
+	for path, value := range afterFields {
+		if _, ok := beforeFields[path]; !ok {
+			changes = append(changes, RollbackChange{Path: path, After: value})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// flattenJSON sets the scalar fields of a decoded JSON value in fields by their path under prefix.
+func flattenJSON(prefix string, value interface{}, fields map[string]interface{}) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			flattenJSON(path, child, fields)
+		}
+	case []interface{}:
+		for i, child := range value {
+			flattenJSON(fmt.Sprintf("%s[%d]", prefix, i), child, fields)
+		}
+	default:
+		fields[prefix] = value
+	}
+}
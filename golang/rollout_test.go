@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRestartDeployment(t *testing.T) {
+	replicas := int32(3)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "cart", Namespace: "shop", Generation: 2},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"team": "shop"}}},
+		},
+		Status: appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3},
+	}
+	clientset := fake.NewSimpleClientset(deployment)
+	restartedAt := time.Date(2024, time.January, 31, 12, 0, 0, 0, time.UTC)
+
+	restarted, err := restartDeployment(context.TODO(), clientset, "shop", "cart", restartedAt)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, map[string]string{"team": "shop", restartedAtAnnotation: "2024-01-31T12:00:00Z"}, restarted.Spec.Template.Annotations)
+
+	_, err = restartDeployment(context.TODO(), clientset, "shop", "web", restartedAt)
+	assert.Error(t, err)
+
+	// The fake clientset doesn't run the deployment controller, the rollout is already complete
+	status, err := waitForRollout(context.TODO(), clientset, "shop", "cart", restartedAt)
+	if assert.NoError(t, err) {
+		assert.True(t, status.Complete)
+		assert.Equal(t, restartedAt, status.RestartedAt)
+	}
+}
+
+func TestRolloutStatus(t *testing.T) {
+	replicas := int32(3)
+	deployment := func(status appsv1.DeploymentStatus) *appsv1.Deployment {
+		if status.ObservedGeneration == 0 {
+			status.ObservedGeneration = 2
+		}
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "cart", Namespace: "shop", Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     status,
+		}
+	}
+	stuck := []appsv1.DeploymentCondition{{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: progressDeadlineExceeded}}
+
+	for name, tc := range map[string]struct {
+		status   appsv1.DeploymentStatus
+		complete bool
+		failed   bool
+		message  string
+	}{
+		"not observed": {status: appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3}, message: "waiting for the rollout to start"},
+		"updating":     {status: appsv1.DeploymentStatus{Replicas: 4, UpdatedReplicas: 1}, message: "1 of 3 new replicas have been updated"},
+		"terminating":  {status: appsv1.DeploymentStatus{Replicas: 4, UpdatedReplicas: 3, AvailableReplicas: 3}, message: "1 old replicas are pending termination"},
+		"available":    {status: appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 2}, message: "2 of 3 updated replicas are available"},
+		"complete":     {status: appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3}, complete: true, message: "successfully rolled out"},
+		"stuck":        {status: appsv1.DeploymentStatus{Replicas: 4, UpdatedReplicas: 1, Conditions: stuck}, failed: true, message: "the rollout exceeded its progress deadline"},
+	} {
+		status := rolloutStatus(deployment(tc.status), time.Time{})
+		assert.Equal(t, tc.complete, status.Complete, name)
+		assert.Equal(t, tc.failed, status.Failed, name)
+		assert.Equal(t, tc.message, status.Message, name)
+	}
+}
+
+func TestRollbackDeployment(t *testing.T) {
+	template := func(image string) corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "cart"}},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "cart", Image: image}}},
+		}
+	}
+	controller := true
+	replicaSet := func(revision, image, owner string) *appsv1.ReplicaSet {
+		replicaSet := &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "cart-" + revision,
+				Namespace:       "shop",
+				Labels:          map[string]string{"app": "cart"},
+				Annotations:     map[string]string{revisionAnnotation: revision},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "cart", UID: types.UID(owner), Controller: &controller}},
+			},
+			Spec: appsv1.ReplicaSetSpec{Template: template(image)},
+		}
+		replicaSet.Spec.Template.Labels = map[string]string{"app": "cart", appsv1.DefaultDeploymentUniqueLabelKey: "hash-" + revision}
+		return replicaSet
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "cart", Namespace: "shop", UID: "cart-uid", Annotations: map[string]string{revisionAnnotation: "3"}},
+		Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "cart"}}, Template: template("cart:3")},
+	}
+	clientset := fake.NewSimpleClientset(
+		deployment,
+		replicaSet("1", "cart:1", "cart-uid"),
+		replicaSet("2", "cart:2", "cart-uid"),
+		replicaSet("3", "cart:3", "cart-uid"),
+		// A deployment of the same name created again
+		replicaSet("4", "cart:4", "previous-uid"),
+	)
+
+	replicaSets, err := deploymentReplicaSets(context.TODO(), clientset, deployment)
+	assert.NoError(t, err)
+	assert.Len(t, replicaSets, 3)
+
+	target, err := rollbackTarget(deployment, replicaSets, 0)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "cart-2", target.Name)
+	}
+	target, err = rollbackTarget(deployment, replicaSets, 1)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "cart-1", target.Name)
+	}
+	_, err = rollbackTarget(deployment, replicaSets, 4)
+	assert.ErrorIs(t, err, errRevisionNotFound)
+	_, err = rollbackTarget(deployment, nil, 0)
+	assert.ErrorIs(t, err, errNoPreviousRevision)
+
+	restored := rollbackTemplate(target)
+	assert.Equal(t, map[string]string{"app": "cart"}, restored.Labels)
+	assert.Equal(t, []RollbackChange{{Path: "spec.containers[0].image", Before: "cart:3", After: "cart:1"}}, templateChanges(deployment.Spec.Template, restored))
+	assert.Empty(t, templateChanges(restored, restored))
+
+	assert.NoError(t, rollbackDeployment(context.TODO(), clientset, "shop", "cart", restored))
+	rolledBack, err := clientset.AppsV1().Deployments("shop").Get(context.TODO(), "cart", metav1.GetOptions{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "cart:1", rolledBack.Spec.Template.Spec.Containers[0].Image)
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// S3ExportConfig writes every snapshot as a JSON lines object to an S3 compatible bucket.
+//
+// Credentials are read from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and the optional AWS_SESSION_TOKEN.
+type S3ExportConfig struct {
+	Bucket   string `json:"bucket"`
+	Region   string `json:"region"`
+	Prefix   string `json:"prefix,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+type s3Sink struct {
+	bucket       string
+	region       string
+	prefix       string
+	endpoint     string
+	accessKey    string
+	secretKey    string
+	sessionToken string
+	httpClient   *http.Client
+}
+
+// s3SnapshotLine is a single line of the exported JSON lines object.
+type s3SnapshotLine struct {
+	Time       time.Time      `json:"time"`
+	Status     string         `json:"status"`
+	Deployment DeploymentInfo `json:"deployment"`
+}
+
+func newS3Sink(config *S3ExportConfig) (*s3Sink, error) {
+	if config.Bucket == "" || config.Region == "" {
+		return nil, errors.New("export.s3 needs a bucket and a region")
+	}
+
+	sink := &s3Sink{
+		bucket:       config.Bucket,
+		region:       config.Region,
+		prefix:       strings.Trim(config.Prefix, "/"),
+		endpoint:     strings.TrimSuffix(config.Endpoint, "/"),
+		accessKey:    os.Getenv("AWS_ACCESS_KEY_ID"),
+		secretKey:    os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		sessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+		httpClient:   &http.Client{Timeout: 30 * time.Second},
+	}
+	if sink.endpoint == "" {
+		sink.endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", config.Region)
+	}
+	if sink.accessKey == "" || sink.secretKey == "" {
+		return nil, errors.New("export.s3 needs AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+	return sink, nil
+}
+
+func (s *s3Sink) Name() string {
+	return "s3"
+}
+
+// Export uploads the snapshot to <prefix>/YYYY/MM/DD/<timestamp>.jsonl, one line per deployment.
+func (s *s3Sink) Export(ctx context.Context, snapshot HealthSnapshot) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, deployment := range snapshot.Deployments.ReadyDeployments {
+		if err := encoder.Encode(s3SnapshotLine{Time: snapshot.Time, Status: "ready", Deployment: deployment}); err != nil {
+			return err
+		}
+	}
+	for _, deployment := range snapshot.Deployments.FailedDeployments {
+		if err := encoder.Encode(s3SnapshotLine{Time: snapshot.Time, Status: "failed", Deployment: deployment}); err != nil {
+			return err
+		}
+	}
+	for _, deployment := range snapshot.Deployments.StuckRollouts {
+		if err := encoder.Encode(s3SnapshotLine{Time: snapshot.Time, Status: "stuck", Deployment: deployment}); err != nil {
+			return err
+		}
+	}
+
+	key := path.Join(s.prefix, snapshot.Time.Format("2006/01/02"), snapshot.Time.Format("20060102T150405Z")+".jsonl")
+	return s.putObject(ctx, key, body.Bytes(), snapshot.Time)
+}
+
+func (s *s3Sink) putObject(ctx context.Context, key string, body []byte, now time.Time) error {
+	objectURL, err := url.Parse(s.endpoint + "/" + s.bucket + "/" + key)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, objectURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	s.sign(req, body, now)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("s3 put %s returned %s: %s", key, resp.Status, message)
+	}
+	return nil
+}
+
+// sign adds an AWS Signature Version 4 Authorization header to the request.
+func (s *s3Sink) sign(req *http.Request, body []byte, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	signedHeaders := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	headerValues := map[string]string{"host": req.URL.Host, "x-amz-content-sha256": payloadHash, "x-amz-date": amzDate}
+	if s.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.sessionToken)
+		signedHeaders = append(signedHeaders, "x-amz-security-token")
+		headerValues["x-amz-security-token"] = s.sessionToken
+	}
+
+	var canonicalHeaders strings.Builder
+	for _, name := range signedHeaders {
+		canonicalHeaders.WriteString(name + ":" + headerValues[name] + "\n")
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, strings.Join(signedHeaders, ";"), signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
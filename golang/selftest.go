@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+)
+
+const (
+	selftestTimeout        = 5 * time.Minute
+	selftestReadyTimeout   = 2 * time.Minute
+	selftestBlockTimeout   = 30 * time.Second
+	selftestPollInterval   = 2 * time.Second
+	selftestCleanupTimeout = 30 * time.Second
+	selftestPort           = 8080
+	selftestLabel          = annotationPrefix + "selftest"
+	selftestServerApp      = "selftest-server"
+	selftestClientApp      = "selftest-client"
+)
+
+// SelftestReport is the outcome of every step of a self-test run, in order.
+type SelftestReport struct {
+	Namespace string         `json:"namespace"`
+	Passed    bool           `json:"passed"`
+	StartedAt time.Time      `json:"started_at"`
+	Duration  string         `json:"duration"`
+	Steps     []SelftestStep `json:"steps"`
+}
+
+type SelftestStep struct {
+	Name     string `json:"name"`
+	Passed   bool   `json:"passed"`
+	Duration string `json:"duration"`
+	Detail   string `json:"detail,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// run records a step, skipped once a previous step failed unless always is set, as for the cleanup.
+func (r *SelftestReport) run(name string, always bool, step func() (string, error)) {
+	if !r.Passed && !always {
+		r.Steps = append(r.Steps, SelftestStep{Name: name, Detail: "skipped after a previous failure"})
+		return
+	}
+
+	start := time.Now()
+	detail, err := step()
+	result := SelftestStep{Name: name, Passed: err == nil, Duration: time.Since(start).Round(time.Millisecond).String(), Detail: detail}
+	if err != nil {
+		result.Error = err.Error()
+		r.Passed = false
+	}
+	r.Steps = append(r.Steps, result)
+}
+
+// Handler running the end-to-end self-test in a sandbox namespace
+func (s *Server) selftestHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.authorize(w, r, operationSelftest, "", "", nil) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), selftestTimeout)
+	defer cancel()
+	extendWriteDeadline(w, selftestTimeout+selftestCleanupTimeout)
+
+	report := s.runSelftest(ctx, requestMetadata(r.Context()))
+	recordAudit(AuditEvent{
+		Action:          operationSelftest.Action,
+		RequestMetadata: requestMetadata(r.Context()),
+		RemoteAddr:      r.RemoteAddr,
+		Namespace:       report.Namespace,
+		Result:          fmt.Sprintf("passed=%t", report.Passed),
+	})
+
+	status := http.StatusOK
+	if !report.Passed {
+		status = http.StatusInternalServerError
+	}
+	writeJSON(w, status, report)
+}
+
+// runSelftest deploys a server and a client pod, checks health reporting and connectivity, denies the traffic and checks it's blocked.
+//
+// The sandbox namespace is always deleted, taking the pods and the policy with it, unless this run failed to create
+// it: a namespace that already existed isn't the self-test's to delete.
+func (s *Server) runSelftest(ctx context.Context, meta RequestMetadata) *SelftestReport {
+	namespace := "selftest-" + utilrand.String(5)
+	sandbox := NamespaceScope{Names: []string{namespace}}
+	report := &SelftestReport{Namespace: namespace, Passed: true, StartedAt: time.Now().UTC()}
+	var serverIP, clientPod string
+	var namespaceCreated bool
+
+	report.run("create sandbox namespace", false, func() (string, error) {
+		managedLabels, annotations := managedObjectMeta(actionSelftest, "", meta, nil)
+		managedLabels[selftestLabel] = "true"
+		_, err := s.K8sClientSet.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace, Labels: managedLabels, Annotations: annotations},
+		}, metav1.CreateOptions{})
+		namespaceCreated = err == nil
+		return namespace, err
+	})
+
+	report.run("deploy test pods", false, func() (string, error) {
+		for _, deployment := range selftestDeployments(namespace, s.SelftestImage) {
+			if _, err := s.K8sClientSet.AppsV1().Deployments(namespace).Create(ctx, deployment, metav1.CreateOptions{}); err != nil {
+				return "", err
+			}
+		}
+		return fmt.Sprintf("%s and %s with image %s", selftestServerApp, selftestClientApp, s.SelftestImage), nil
+	})
+
+	report.run("wait for pods to be ready", false, func() (string, error) {
+		var err error
+		serverIP, clientPod, err = s.waitForSelftestPods(ctx, namespace)
+		return fmt.Sprintf("server at %s, client %s", serverIP, clientPod), err
+	})
+
+	report.run("health reporting sees the deployments", false, func() (string, error) {
+		health, err := getWorkloadsHealth(ctx, s.K8sClientSet, sandbox, nil, nil, labels.Everything())
+		if err != nil {
+			return "", err
+		}
+		if len(health.ReadyDeployments) != 2 || len(health.FailedDeployments) != 0 || len(health.StuckRollouts) != 0 {
+			return "", fmt.Errorf("expected 2 ready deployments, got %d ready, %d failed and %d stuck", len(health.ReadyDeployments), len(health.FailedDeployments), len(health.StuckRollouts))
+		}
+		return "2 ready deployments", nil
+	})
+
+	report.run("client can reach server", false, func() (string, error) {
+		result, err := s.execInPod(ctx, namespace, clientPod, "", selftestProbe(serverIP))
+		if err != nil {
+			return "", err
+		}
+		if result.ExitCode != 0 {
+			return "", fmt.Errorf("probe exited with %d: %s", result.ExitCode, strings.TrimSpace(result.Stderr))
+		}
+		return strings.TrimSpace(result.Stdout), nil
+	})
+
+	report.run("apply deny policy", false, func() (string, error) {
+		reservation, err := s.Guardrails.reserve(budgetPolicies, namespace, 1, time.Now())
+		if err != nil {
+			return "", err
+		}
+		name, created, err := createDenyNetworkPolicy(ctx, s.K8sClientSet, s.Policies, sandbox, DenyNetworkRequest{
+			A: DenyNetworkRequestWorkload{Namespace: namespace, Labels: map[string]string{"app": selftestClientApp}},
+			B: DenyNetworkRequestWorkload{Namespace: namespace, Labels: map[string]string{"app": selftestServerApp}},
+		}, meta)
+		if !created {
+			reservation.release(1)
+		}
+		return name, err
+	})
+
+	report.run("client is blocked from server", false, func() (string, error) {
+		return s.waitForSelftestBlocked(ctx, namespace, clientPod, serverIP)
+	})
+
+	if namespaceCreated {
+		report.run("delete sandbox namespace", true, func() (string, error) {
+			// The request context may be done already, cleanup gets its own deadline
+			cleanupCtx, cancel := context.WithTimeout(context.Background(), selftestCleanupTimeout)
+			defer cancel()
+			return "", s.K8sClientSet.CoreV1().Namespaces().Delete(cleanupCtx, namespace, metav1.DeleteOptions{})
+		})
+	} else {
+		report.Steps = append(report.Steps, SelftestStep{Name: "delete sandbox namespace", Detail: "skipped, the namespace wasn't created by this run"})
+	}
+
+	report.Duration = time.Since(report.StartedAt).Round(time.Millisecond).String()
+	slog.InfoContext(ctx, "Self-test finished", "namespace", namespace, "passed", report.Passed, "duration", report.Duration)
+	return report
+}
+
+func selftestDeployments(namespace, image string) []*appsv1.Deployment {
+	server := []string{"sh", "-c", fmt.Sprintf("echo ok > /tmp/index.html && exec httpd -f -p %d -h /tmp", selftestPort)}
+	client := []string{"sh", "-c", "exec sleep 3600"}
+
+	var deployments []*appsv1.Deployment
+	for _, app := range []string{selftestServerApp, selftestClientApp} {
+		command := server
+		if app == selftestClientApp {
+			command = client
+		}
+		replicas := int32(1)
+		podLabels := map[string]string{"app": app, selftestLabel: "true"}
+		deployments = append(deployments, &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: app, Namespace: namespace, Labels: podLabels},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: app, Image: image, Command: command}},
+					},
+				},
+			},
+		})
+	}
+	return deployments
+}
+
+func selftestProbe(serverIP string) []string {
+	return []string{"wget", "-q", "-T", "2", "-O", "-", fmt.Sprintf("http://%s:%d/", serverIP, selftestPort)}
+}
+
+// waitForSelftestPods returns the server pod IP and the client pod name once both are running and ready.
+func (s *Server) waitForSelftestPods(ctx context.Context, namespace string) (string, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, selftestReadyTimeout)
+	defer cancel()
+
+	for {
+		pods, err := s.K8sClientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selftestLabel + "=true"})
+		if err != nil {
+			return "", "", err
+		}
+
+		var serverIP, clientPod string
+		for _, pod := range pods.Items {
+			if !podReady(pod) || pod.DeletionTimestamp != nil {
+				continue
+			}
+			switch pod.Labels["app"] {
+			case selftestServerApp:
+				serverIP = pod.Status.PodIP
+			case selftestClientApp:
+				clientPod = pod.Name
+			}
+		}
+		if serverIP != "" && clientPod != "" {
+			return serverIP, clientPod, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", "", errors.New("pods not ready in time")
+		case <-time.After(selftestPollInterval):
+		}
+	}
+}
+
+// waitForSelftestBlocked probes the server until the connection fails, giving the policy time to be programmed.
+func (s *Server) waitForSelftestBlocked(ctx context.Context, namespace, clientPod, serverIP string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, selftestBlockTimeout)
+	defer cancel()
+
+	for attempt := 1; ; attempt++ {
+		result, err := s.execInPod(ctx, namespace, clientPod, "", selftestProbe(serverIP))
+		if err != nil {
+			return "", err
+		}
+		if result.ExitCode != 0 {
+			return fmt.Sprintf("blocked after %d probe(s)", attempt), nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("server still reachable after %d probes", attempt)
+		case <-time.After(selftestPollInterval):
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestSelftestReportSkipsAfterFailure(t *testing.T) {
+	report := &SelftestReport{Passed: true}
+	var ran []string
+	step := func(name string, err error) func() (string, error) {
+		return func() (string, error) {
+			ran = append(ran, name)
+			return "", err
+		}
+	}
+
+	report.run("create", false, step("create", nil))
+	report.run("deploy", false, step("deploy", errors.New("image pull failed")))
+	report.run("probe", false, step("probe", nil))
+	report.run("cleanup", true, step("cleanup", nil))
+
+	assert.False(t, report.Passed)
+	assert.Equal(t, []string{"create", "deploy", "cleanup"}, ran)
+	if assert.Len(t, report.Steps, 4) {
+		assert.True(t, report.Steps[0].Passed)
+		assert.Equal(t, "image pull failed", report.Steps[1].Error)
+		assert.False(t, report.Steps[2].Passed)
+		assert.Equal(t, "skipped after a previous failure", report.Steps[2].Detail)
+		assert.True(t, report.Steps[3].Passed)
+	}
+}
+
+func TestRunSelftestExistingNamespace(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		namespace := action.(k8stesting.CreateAction).GetObject().(*corev1.Namespace)
+		return true, nil, apierrors.NewAlreadyExists(corev1.Resource("namespaces"), namespace.Name)
+	})
+
+	// A namespace the run didn't create is left alone
+	report := (&Server{K8sClientSet: clientset}).runSelftest(context.Background(), RequestMetadata{})
+	assert.False(t, report.Passed)
+	for _, action := range clientset.Actions() {
+		assert.NotEqual(t, "delete", action.GetVerb(), action.GetResource().Resource)
+	}
+	if assert.Len(t, report.Steps, 8) {
+		assert.Contains(t, report.Steps[0].Error, "already exists")
+		assert.Equal(t, "delete sandbox namespace", report.Steps[7].Name)
+		assert.False(t, report.Steps[7].Passed)
+		assert.Equal(t, "skipped, the namespace wasn't created by this run", report.Steps[7].Detail)
+	}
+}
+
+func TestSelftestDeployments(t *testing.T) {
+	deployments := selftestDeployments("selftest-abcde", "busybox:1.36")
+	if assert.Len(t, deployments, 2) {
+		server, client := deployments[0], deployments[1]
+		assert.Equal(t, selftestServerApp, server.Name)
+		assert.Equal(t, "selftest-abcde", server.Namespace)
+		assert.Equal(t, map[string]string{"app": selftestServerApp}, server.Spec.Selector.MatchLabels)
+		assert.Equal(t, "true", server.Spec.Template.Labels[selftestLabel])
+		assert.Equal(t, "busybox:1.36", server.Spec.Template.Spec.Containers[0].Image)
+		assert.Contains(t, server.Spec.Template.Spec.Containers[0].Command[2], "httpd -f -p 8080")
+
+		assert.Equal(t, selftestClientApp, client.Name)
+		assert.Equal(t, selftestClientApp, client.Spec.Template.Labels["app"])
+	}
+
+	assert.Equal(t, []string{"wget", "-q", "-T", "2", "-O", "-", "http://10.0.0.7:8080/"}, selftestProbe("10.0.0.7"))
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Problems of the wiring between ingresses, services and pods
+const (
+	problemNoReadyEndpoints      = "NoReadyEndpoints"
+	problemSelectorMatchesNoPods = "SelectorMatchesNoPods"
+	problemTargetPortNotFound    = "TargetPortNotFound"
+	problemServiceNotFound       = "ServiceNotFound"
+	problemServicePortNotFound   = "ServicePortNotFound"
+)
+
+type ServiceIssue struct {
+	Namespace string `json:"namespace"`
+	Service   string `json:"service_name"`
+	Problem   string `json:"problem"`
+	Message   string `json:"message"`
+}
+
+// IngressIssue is a backend of an ingress which doesn't lead to a service port.
+type IngressIssue struct {
+	Namespace string `json:"namespace"`
+	Ingress   string `json:"ingress_name"`
+	// Host and Path are those of the rule of the backend, empty for the default backend
+	Host    string `json:"host,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Service string `json:"service_name"`
+	Port    string `json:"port"`
+	Problem string `json:"problem"`
+	Message string `json:"message"`
+}
+
+type ServiceWiringReport struct {
+	Services  []ServiceIssue `json:"services"`
+	Ingresses []IngressIssue `json:"ingresses"`
+}
+
+// Reports the services without ready endpoints or whose selector or named target ports match no pod, and the ingress
+// backends leading to a missing service or port, filtered like the deployments of clusterDeploymentsInfoHandler
+//
+// ?labelSelector= matches the labels of the services and ingresses themselves.
+func (s *Server) serviceInfoHandler(w http.ResponseWriter, r *http.Request) {
+	filter, status, err := s.parseReportFilter(r)
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+
+	report, err := getServiceWiring(r.Context(), s.K8sClientSet, filter.Scope, filter.Selector)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	report.excludeNamespaces(filter.excludedNamespaces(s.ExcludedNamespaces))
+	writeJSON(w, http.StatusOK, report)
+}
+
+// getServiceWiring checks the services and ingresses of every namespace in scope whose own labels match selector.
+func getServiceWiring(ctx context.Context, clientset kubernetes.Interface, scope NamespaceScope, selector labels.Selector) (*ServiceWiringReport, error) {
+	namespaces, err := scope.resolve(ctx, clientset)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ServiceWiringReport{Services: []ServiceIssue{}, Ingresses: []IngressIssue{}}
+	for _, namespace := range namespaces {
+		services, err := clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		endpoints, err := clientset.CoreV1().Endpoints(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{FieldSelector: "status.phase!=Succeeded,status.phase!=Failed"})
+		if err != nil {
+			return nil, err
+		}
+		ingresses, err := clientset.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, err
+		}
+
+		// Services and endpoints by namespace and name
+		byName := map[string]corev1.Service{}
+		for _, service := range services.Items {
+			byName[service.Namespace+"/"+service.Name] = service
+		}
+		ready := map[string]bool{}
+		for _, subsets := range endpoints.Items {
+			for _, subset := range subsets.Subsets {
+				if len(subset.Addresses) > 0 {
+					ready[subsets.Namespace+"/"+subsets.Name] = true
+				}
+			}
+		}
+
+		for _, service := range services.Items {
+			if selector.Matches(labels.Set(service.Labels)) {
+				report.Services = append(report.Services, serviceIssues(service, pods.Items, ready[service.Namespace+"/"+service.Name])...)
+			}
+		}
+		for _, ingress := range ingresses.Items {
+			report.Ingresses = append(report.Ingresses, ingressIssues(ingress, byName)...)
+		}
+	}
+	sort.SliceStable(report.Services, func(i, j int) bool {
+		a, b := report.Services[i], report.Services[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Service < b.Service
+	})
+	sort.SliceStable(report.Ingresses, func(i, j int) bool {
+		a, b := report.Ingresses[i], report.Ingresses[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Ingress < b.Ingress
+	})
+	slog.DebugContext(ctx, "Service wiring", "services", len(report.Services), "ingresses", len(report.Ingresses))
+	return report, nil
+}
+
+// serviceIssues checks a service against the pods of its namespace and whether its endpoints have a ready address.
+// A selector matching no pod is reported rather than the lack of endpoints it causes.
+func serviceIssues(service corev1.Service, pods []corev1.Pod, ready bool) []ServiceIssue {
+	if service.Spec.Type == corev1.ServiceTypeExternalName {
+		return nil
+	}
+	issue := func(problem, message string) ServiceIssue {
+		return ServiceIssue{Namespace: service.Namespace, Service: service.Name, Problem: problem, Message: message}
+	}
+
+	var issues []ServiceIssue
+	if len(service.Spec.Selector) > 0 {
+		selector := labels.SelectorFromSet(service.Spec.Selector)
+		var matched []corev1.Pod
+		for _, pod := range pods {
+			if pod.Namespace == service.Namespace && pod.DeletionTimestamp == nil && pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed && selector.Matches(labels.Set(pod.Labels)) {
+				matched = append(matched, pod)
+			}
+		}
+		if len(matched) == 0 {
+			return []ServiceIssue{issue(problemSelectorMatchesNoPods, fmt.Sprintf("no pod matches the selector %s", selector))}
+		}
+
+		for _, port := range service.Spec.Ports {
+			if port.TargetPort.Type != intstr.String || podsDeclarePort(matched, port.TargetPort.StrVal) {
+				continue
+			}
+			issues = append(issues, issue(problemTargetPortNotFound, fmt.Sprintf("no pod the selector matches has a container port named %q, the target of port %d", port.TargetPort.StrVal, port.Port)))
+		}
+	}
+	if !ready {
+		issues = append(issues, issue(problemNoReadyEndpoints, "the service has no ready endpoint, its pods aren't ready"))
+	}
+	return issues
+}
+
+// podsDeclarePort tells whether a container of any of pods has a port named name.
+func podsDeclarePort(pods []corev1.Pod, name string) bool {
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			if slices.ContainsFunc(container.Ports, func(port corev1.ContainerPort) bool { return port.Name == name }) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ingressIssues checks the service backends of an ingress against services, by namespace and name.
+func ingressIssues(ingress networkingv1.Ingress, services map[string]corev1.Service) []IngressIssue {
+	var issues []IngressIssue
+	check := func(host, path string, backend *networkingv1.IngressServiceBackend) {
+		// Resource backends, such as a bucket, aren't services
+		if backend == nil {
+			return
+		}
+		issue := IngressIssue{Namespace: ingress.Namespace, Ingress: ingress.Name, Host: host, Path: path, Service: backend.Name, Port: backend.Port.Name}
+		if backend.Port.Name == "" {
+			issue.Port = strconv.Itoa(int(backend.Port.Number))
+		}
+		service, ok := services[ingress.Namespace+"/"+backend.Name]
+		switch {
+		case !ok:
+			issue.Problem, issue.Message = problemServiceNotFound, fmt.Sprintf("service %s doesn't exist", backend.Name)
+		case !slices.ContainsFunc(service.Spec.Ports, func(port corev1.ServicePort) bool {
+			return backend.Port.Name != "" && port.Name == backend.Port.Name || backend.Port.Name == "" && port.Port == backend.Port.Number
+		}):
+			issue.Problem, issue.Message = problemServicePortNotFound, fmt.Sprintf("service %s has no port %s", backend.Name, issue.Port)
+		default:
+			return
+		}
+		issues = append(issues, issue)
+	}
+
+	if ingress.Spec.DefaultBackend != nil {
+		check("", "", ingress.Spec.DefaultBackend.Service)
+	}
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			check(rule.Host, path.Path, path.Backend.Service)
+		}
+	}
+	return issues
+}
+
+// excludeNamespaces removes the services and ingresses of namespaces from the report.
+func (report *ServiceWiringReport) excludeNamespaces(namespaces []string) {
+	if len(namespaces) == 0 {
+		return
+	}
+	report.Services = slices.DeleteFunc(report.Services, func(issue ServiceIssue) bool { return slices.Contains(namespaces, issue.Namespace) })
+	report.Ingresses = slices.DeleteFunc(report.Ingresses, func(issue IngressIssue) bool { return slices.Contains(namespaces, issue.Namespace) })
+}
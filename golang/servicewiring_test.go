@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetServiceWiring(t *testing.T) {
+	service := func(name, app string, targetPort intstr.IntOrString) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "web"},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{"app": app},
+				Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: targetPort}},
+			},
+		}
+	}
+	endpoints := func(name string, ready bool) *corev1.Endpoints {
+		subset := corev1.EndpointSubset{NotReadyAddresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}}}
+		if ready {
+			subset = corev1.EndpointSubset{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}}}
+		}
+		return &corev1.Endpoints{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "web"}, Subsets: []corev1.EndpointSubset{subset}}
+	}
+	pod := func(name, app string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "web", Labels: map[string]string{"app": app}},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: app, Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}}}}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+	backend := func(service, portName string, portNumber int32) networkingv1.IngressBackend {
+		return networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+			Name: service,
+			Port: networkingv1.ServiceBackendPort{Name: portName, Number: portNumber},
+		}}
+	}
+	clientset := fake.NewSimpleClientset(
+		service("frontend", "frontend", intstr.FromString("http")), endpoints("frontend", true), pod("frontend-1", "frontend"),
+		service("api", "api", intstr.FromInt(8080)), endpoints("api", false), pod("api-1", "api"),
+		service("search", "search-v2", intstr.FromInt(8080)), pod("search-1", "search"),
+		service("admin", "admin", intstr.FromString("admin-http")), endpoints("admin", true), pod("admin-1", "admin"),
+		&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "public", Namespace: "web"},
+			Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{
+				Host: "example.com",
+				IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{
+					{Path: "/", Backend: backend("frontend", "http", 0)},
+					{Path: "/api", Backend: backend("api", "", 8080)},
+					{Path: "/old", Backend: backend("legacy", "", 80)},
+				}}},
+			}}},
+		},
+	)
+
+	report, err := getServiceWiring(context.Background(), clientset, NamespaceScope{}, labels.Everything())
+	assert.NoError(t, err)
+	problems := map[string]string{}
+	for _, issue := range report.Services {
+		problems[issue.Service] = issue.Problem
+	}
+	assert.Equal(t, map[string]string{
+		"api":    problemNoReadyEndpoints,
+		"search": problemSelectorMatchesNoPods,
+		"admin":  problemTargetPortNotFound,
+	}, problems)
+
+	if assert.Len(t, report.Ingresses, 2) {
+		assert.Equal(t, "/api", report.Ingresses[0].Path)
+		assert.Equal(t, problemServicePortNotFound, report.Ingresses[0].Problem)
+		assert.Equal(t, "8080", report.Ingresses[0].Port)
+		assert.Equal(t, "legacy", report.Ingresses[1].Service)
+		assert.Equal(t, problemServiceNotFound, report.Ingresses[1].Problem)
+	}
+
+	report.excludeNamespaces([]string{"web"})
+	assert.Empty(t, report.Services)
+	assert.Empty(t, report.Ingresses)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func signedRequest(secret string, signedAt time.Time, body string) *http.Request {
+	return signedClusterRequest(secret, signedAt, body, "/denyNetworkPolicy", "/denyNetworkPolicy")
+}
+
+// signedClusterRequest signs a request to target, a path and query string, as if it was sent to signedTarget.
+func signedClusterRequest(secret string, signedAt time.Time, body, target, signedTarget string) *http.Request {
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "\n" + http.MethodPost + "\n" + signedTarget + "\n" + body))
+
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set(signatureTimestampHeader, timestamp)
+	req.Header.Set(signatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func TestRequestVerifier(t *testing.T) {
+	now := time.Date(2024, 7, 8, 12, 0, 0, 0, time.UTC)
+	verifier := newRequestVerifier([]byte("s3cret"), defaultReplayWindow)
+	verifier.now = func() time.Time { return now }
+
+	handler := verifier.wrap(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	serve := func(req *http.Request) int {
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve(httptest.NewRequest(http.MethodGet, "/denyNetworkPolicy", nil)))
+	assert.Equal(t, http.StatusUnauthorized, serve(httptest.NewRequest(http.MethodPost, "/denyNetworkPolicy", nil)))
+
+	assert.Equal(t, http.StatusOK, serve(signedRequest("s3cret", now.Add(-time.Minute), `{"a": 1}`)))
+	assert.Equal(t, http.StatusUnauthorized, serve(signedRequest("s3cret", now.Add(-time.Minute), `{"a": 1}`)), "replay")
+	assert.Equal(t, http.StatusUnauthorized, serve(signedRequest("s3cret", now.Add(-10*time.Minute), `{"a": 2}`)), "stale")
+	assert.Equal(t, http.StatusUnauthorized, serve(signedRequest("wrong", now, `{"a": 3}`)), "bad secret")
+
+	// The cluster is signed, a request can't be pointed at another one
+	assert.Equal(t, http.StatusOK, serve(signedClusterRequest("s3cret", now, `{"a": 4}`, "/denyNetworkPolicy?cluster=eu-west", "/denyNetworkPolicy?cluster=eu-west")))
+	assert.Equal(t, http.StatusUnauthorized, serve(signedClusterRequest("s3cret", now, `{"a": 5}`, "/denyNetworkPolicy?cluster=us-east", "/denyNetworkPolicy?cluster=eu-west")))
+	assert.Equal(t, http.StatusUnauthorized, serve(signedClusterRequest("s3cret", now, `{"a": 6}`, "/denyNetworkPolicy?cluster=us-east", "/denyNetworkPolicy")))
+
+	// So is the rest of the query string
+	assert.Equal(t, http.StatusOK, serve(signedClusterRequest("s3cret", now, `{"a": 7}`, "/pods/payments/ledger-1?force=false", "/pods/payments/ledger-1?force=false")))
+	assert.Equal(t, http.StatusUnauthorized, serve(signedClusterRequest("s3cret", now, `{"a": 8}`, "/pods/payments/ledger-1?force=true", "/pods/payments/ledger-1?force=false")))
+	assert.Equal(t, http.StatusUnauthorized, serve(signedClusterRequest("s3cret", now, `{"a": 9}`, "/pods/payments/ledger-1?force=true", "/pods/payments/ledger-1")))
+	assert.Equal(t, http.StatusUnauthorized, serve(signedClusterRequest("s3cret", now, `{"a": 10}`, "/denyNetworkPolicy?dryRun=false&cluster=eu-west", "/denyNetworkPolicy?cluster=eu-west&dryRun=true")))
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+)
+
+// errAlertingDisabled is returned by the silence endpoints when the config file has no alerting section.
+var errAlertingDisabled = errors.New("alerting is not configured")
+
+// Silence mutes the alerts of Rule, of every rule when empty, about the namespaces matching Namespace, a shell pattern
+// such as payments-*, of every namespace and node when empty, from StartsAt until EndsAt. A maintenance window is a
+// silence starting later.
+type Silence struct {
+	ID        string    `json:"id"`
+	Rule      string    `json:"rule,omitempty"`
+	Namespace string    `json:"namespace,omitempty"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Comment   string    `json:"comment"`
+	CreatedBy string    `json:"created_by,omitempty"`
+}
+
+// SilenceRequest creates a silence ending at EndsAt, or Duration after it starts.
+type SilenceRequest struct {
+	Rule      string           `json:"rule,omitempty"`
+	Namespace string           `json:"namespace,omitempty"`
+	StartsAt  *time.Time       `json:"starts_at,omitempty"`
+	EndsAt    *time.Time       `json:"ends_at,omitempty"`
+	Duration  *metav1.Duration `json:"duration,omitempty"`
+	Comment   string           `json:"comment"`
+}
+
+// matches tells whether the silence mutes the alerts of rule about namespace at now.
+func (s Silence) matches(rule, namespace string, now time.Time) bool {
+	if now.Before(s.StartsAt) || !now.Before(s.EndsAt) || (s.Rule != "" && s.Rule != rule) {
+		return false
+	}
+	if s.Namespace == "" {
+		return true
+	}
+	matched, _ := path.Match(s.Namespace, namespace)
+	return matched && namespace != ""
+}
+
+// SilenceStore keeps the silences in memory, dropping them once they end. They aren't shared between replicas.
+type SilenceStore struct {
+	mu       sync.Mutex
+	silences map[string]Silence
+}
+
+func newSilenceStore() *SilenceStore {
+	return &SilenceStore{silences: map[string]Silence{}}
+}
+
+func (s *SilenceStore) add(silence Silence) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.silences[silence.ID] = silence
+}
+
+func (s *SilenceStore) remove(id string) (Silence, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	silence, ok := s.silences[id]
+	delete(s.silences, id)
+	return silence, ok
+}
+
+// list returns the silences which haven't ended at now, by start.
+func (s *SilenceStore) list(now time.Time) []Silence {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune(now)
+	result := make([]Silence, 0, len(s.silences))
+	for _, silence := range s.silences {
+		result = append(result, silence)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].StartsAt.Equal(result[j].StartsAt) {
+			return result[i].StartsAt.Before(result[j].StartsAt)
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// silenced tells whether a silence mutes the alerts of rule about namespace at now.
+func (s *SilenceStore) silenced(rule, namespace string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune(now)
+	for _, silence := range s.silences {
+		if silence.matches(rule, namespace, now) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *SilenceStore) prune(now time.Time) {
+	for id, silence := range s.silences {
+		if !now.Before(silence.EndsAt) {
+			delete(s.silences, id)
+		}
+	}
+}
+
+// Reports the silences which haven't ended, including the maintenance windows yet to start
+func (s *Server) listSilencesHandler(w http.ResponseWriter, r *http.Request) {
+	if s.Alerts == nil {
+		writeError(w, http.StatusServiceUnavailable, errAlertingDisabled)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string][]Silence{"silences": s.Alerts.Silences.list(time.Now())})
+}
+
+// Handler creating a silence of the alerts, starting now unless starts_at is set
+func (s *Server) createSilenceHandler(w http.ResponseWriter, r *http.Request) {
+	if s.Alerts == nil {
+		writeError(w, http.StatusServiceUnavailable, errAlertingDisabled)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		readBodyError(w, err)
+		return
+	}
+
+	defer r.Body.Close()
+
+	var request SilenceRequest
+	if err := decodeStrictJSON(body, &request); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	silence, err := s.Alerts.newSilence(request, time.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if !s.authorize(w, r, operationSilenceCreate, "", silence.ID, request) {
+		return
+	}
+	if _, err := s.Guardrails.reserve(budgetSilences, "", 1, time.Now()); writeGuardrailError(w, err) {
+		return
+	}
+
+	meta := requestMetadata(r.Context())
+	silence.CreatedBy = meta.Principal
+	s.Alerts.Silences.add(silence)
+	recordAudit(AuditEvent{
+		Action:          operationSilenceCreate.Action,
+		RequestMetadata: meta,
+		RemoteAddr:      r.RemoteAddr,
+		Target:          silence.ID,
+		Request:         request,
+		Result:          "created",
+	})
+	slog.InfoContext(r.Context(), "Silence created", "id", silence.ID, "rule", silence.Rule, "namespace", silence.Namespace, "ends_at", silence.EndsAt)
+	writeJSON(w, http.StatusCreated, silence)
+}
+
+// Handler deleting a silence, the alerts it muted firing again at the next evaluation
+func (s *Server) deleteSilenceHandler(w http.ResponseWriter, r *http.Request) {
+	if s.Alerts == nil {
+		writeError(w, http.StatusServiceUnavailable, errAlertingDisabled)
+		return
+	}
+	id := r.PathValue("id")
+	if !s.authorize(w, r, operationSilenceDelete, "", id, nil) {
+		return
+	}
+
+	reservation, err := s.Guardrails.reserve(budgetSilences, "", 1, time.Now())
+	if writeGuardrailError(w, err) {
+		return
+	}
+	if _, ok := s.Alerts.Silences.remove(id); !ok {
+		reservation.release(1)
+		writeErrorf(w, http.StatusNotFound, "Silence %q not found", id)
+		return
+	}
+	recordAudit(AuditEvent{
+		Action:          operationSilenceDelete.Action,
+		RequestMetadata: requestMetadata(r.Context()),
+		RemoteAddr:      r.RemoteAddr,
+		Target:          id,
+		Result:          "deleted",
+	})
+	slog.InfoContext(r.Context(), "Silence deleted", "id", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// newSilence validates a silence request against the rules of the engine.
+func (e *AlertEngine) newSilence(request SilenceRequest, now time.Time) (Silence, error) {
+	silence := Silence{
+		ID:        utilrand.String(10),
+		Rule:      request.Rule,
+		Namespace: request.Namespace,
+		StartsAt:  now.UTC(),
+		Comment:   request.Comment,
+	}
+	if request.StartsAt != nil {
+		silence.StartsAt = request.StartsAt.UTC()
+	}
+	switch {
+	case request.EndsAt != nil && request.Duration != nil:
+		return Silence{}, errors.New("set either ends_at or duration")
+	case request.EndsAt != nil:
+		silence.EndsAt = request.EndsAt.UTC()
+	case request.Duration != nil:
+		silence.EndsAt = silence.StartsAt.Add(request.Duration.Duration)
+	default:
+		return Silence{}, errors.New("ends_at or duration is required")
+	}
+
+	if _, ok := e.rule(request.Rule); request.Rule != "" && !ok {
+		return Silence{}, fmt.Errorf("unknown rule %q", request.Rule)
+	}
+	if _, err := path.Match(request.Namespace, ""); err != nil {
+		return Silence{}, fmt.Errorf("invalid namespace pattern %q", request.Namespace)
+	}
+	if !silence.EndsAt.After(silence.StartsAt) || !silence.EndsAt.After(now) {
+		return Silence{}, errors.New("the silence must end after it starts, in the future")
+	}
+	if request.Comment == "" {
+		return Silence{}, errors.New("comment is required, telling why the alerts are silenced")
+	}
+	return silence, nil
+}
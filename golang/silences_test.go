@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSilenceHandlers(t *testing.T) {
+	server := Server{}
+	rec := httptest.NewRecorder()
+	server.listSilencesHandler(rec, httptest.NewRequest(http.MethodGet, "/api/v1/silences", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	engine, err := newAlertEngine(&AlertingConfig{
+		Targets: map[string]AlertTarget{"oncall": {Webhook: &WebhookEndpoint{URL: "https://example.com"}}},
+		Rules:   []AlertRule{{Name: "payments-down", Condition: conditionFailedDeployments, Targets: []string{"oncall"}}},
+	}, "prod", nil, NamespaceScope{}, nil, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	server.Alerts = engine
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/silences", server.listSilencesHandler)
+	mux.HandleFunc("POST /api/v1/silences", server.createSilenceHandler)
+	mux.HandleFunc("DELETE /api/v1/silences/{id}", server.deleteSilenceHandler)
+	serve := func(method, target, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return rec
+	}
+
+	for _, body := range []string{
+		`{"comment": "no end"}`,
+		`{"duration": "1h"}`,
+		`{"rule": "unknown", "duration": "1h", "comment": "deploy"}`,
+		`{"namespace": "[", "duration": "1h", "comment": "deploy"}`,
+		`{"ends_at": "2020-01-01T00:00:00Z", "comment": "deploy"}`,
+	} {
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, "/api/v1/silences", body).Code, body)
+	}
+
+	rec = serve(http.MethodPost, "/api/v1/silences", `{"rule": "payments-down", "namespace": "payments-*", "duration": "2h", "comment": "database migration"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var silence Silence
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&silence))
+	assert.NotEmpty(t, silence.ID)
+	assert.Equal(t, 2*time.Hour, silence.EndsAt.Sub(silence.StartsAt))
+	assert.True(t, engine.Silences.silenced("payments-down", "payments-eu", time.Now()))
+	assert.False(t, engine.Silences.silenced("payments-down", "shop", time.Now()))
+
+	var list map[string][]Silence
+	assert.NoError(t, json.NewDecoder(serve(http.MethodGet, "/api/v1/silences", "").Body).Decode(&list))
+	assert.Equal(t, []Silence{silence}, list["silences"])
+
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodDelete, "/api/v1/silences/"+silence.ID, "").Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodDelete, "/api/v1/silences/"+silence.ID, "").Code)
+	assert.False(t, engine.Silences.silenced("payments-down", "payments-eu", time.Now()))
+}
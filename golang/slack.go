@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"path"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+const defaultSlackInterval = time.Minute
+
+// Kinds of Slack notifications a rule routes
+const (
+	slackDeployments = "deployments"
+	slackNodes       = "nodes"
+	slackPolicies    = "policies"
+	slackScans       = "scans"
+)
+
+var slackKinds = []string{slackDeployments, slackNodes, slackPolicies, slackScans}
+
+// policyEventReasons are the reasons of the Kubernetes events recorded when a managed policy is created or deleted.
+var policyEventReasons = []string{
+	"DenyPolicyCreated",
+	"DenyPolicyPromoted",
+	"DenyPolicyDeleted",
+	"DenyPolicyExpired",
+	"DenyPolicyUndone",
+	"DenyPolicyRestored",
+	"NamespaceIsolated",
+}
+
+// SlackConfig posts messages to Slack incoming webhooks when deployments fail or recover, nodes stop or start being
+// ready, managed policies are created or deleted, or the health scans fail.
+type SlackConfig struct {
+	Interval metav1.Duration `json:"interval,omitempty"`
+	Retries  *int            `json:"retries,omitempty"`
+	Rules    []SlackRule     `json:"rules"`
+}
+
+// SlackTarget is a Slack incoming webhook, posting to Channel rather than its own channel when it's set.
+type SlackTarget struct {
+	WebhookURL string `json:"webhook_url"`
+	Channel    string `json:"channel,omitempty"`
+}
+
+// SlackRule posts the notifications of Kinds, every kind when empty, about Namespaces to its target. Namespaces are
+// shell patterns such as payments-*, a rule without any also getting the notifications about nodes and scans.
+type SlackRule struct {
+	SlackTarget
+	Namespaces []string `json:"namespaces,omitempty"`
+	Kinds      []string `json:"kinds,omitempty"`
+}
+
+// slackMessage is the payload of a Slack incoming webhook, posting to its own channel without Channel.
+type slackMessage struct {
+	Channel string `json:"channel,omitempty"`
+	Text    string `json:"text"`
+}
+
+// slackNotification is a message about a namespace, empty for nodes and scans.
+type slackNotification struct {
+	kind      string
+	namespace string
+	text      string
+}
+
+// SlackNotifier scans the cluster every interval and posts its notifications to the Slack rules routing them.
+type SlackNotifier struct {
+	webhookSender
+	healthTransitions
+	interval  time.Duration
+	rules     []SlackRule
+	cluster   string
+	clientset kubernetes.Interface
+	scope     NamespaceScope
+	excluded  []string
+	// deployments reads the health of the deployments, see Server.reportedDeploymentsHealth
+	deployments func(context.Context) (*ClusterDeploymentsInfo, error)
+	// eventsSince is when the policy events were last read, and scanFailing set while the scans fail
+	eventsSince time.Time
+	scanFailing bool
+}
+
+func newSlackNotifier(config *SlackConfig, cluster string, clientset kubernetes.Interface, scope NamespaceScope, excluded []string, deployments func(context.Context) (*ClusterDeploymentsInfo, error)) (*SlackNotifier, error) {
+	sender, err := newWebhookSender(config.Retries, "slack.retries")
+	if err != nil {
+		return nil, err
+	}
+	notifier := &SlackNotifier{
+		webhookSender: sender,
+		interval:      config.Interval.Duration,
+		rules:         config.Rules,
+		cluster:       cluster,
+		clientset:     clientset,
+		scope:         scope,
+		excluded:      excluded,
+		deployments:   deployments,
+		eventsSince:   time.Now().Truncate(time.Second),
+	}
+	if notifier.interval == 0 {
+		notifier.interval = defaultSlackInterval
+	}
+
+	if len(config.Rules) == 0 {
+		return nil, errors.New("slack.rules needs at least a rule")
+	}
+	for i, rule := range config.Rules {
+		if err := rule.validate(fmt.Sprintf("slack.rules[%d]", i)); err != nil {
+			return nil, err
+		}
+		for _, pattern := range rule.Namespaces {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("slack.rules[%d].namespaces: invalid pattern %q", i, pattern)
+			}
+		}
+		for _, kind := range rule.Kinds {
+			if !slices.Contains(slackKinds, kind) {
+				return nil, fmt.Errorf("slack.rules[%d].kinds: unknown kind %q, expected one of %v", i, kind, slackKinds)
+			}
+		}
+	}
+	return notifier, nil
+}
+
+// validate checks the webhook URL of the target at field of the config.
+func (t SlackTarget) validate(field string) error {
+	parsed, err := url.Parse(t.WebhookURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return fmt.Errorf("%s.webhook_url %q is not an https URL", field, t.WebhookURL)
+	}
+	return nil
+}
+
+// run posts the notifications every interval until ctx is done.
+func (n *SlackNotifier) run(ctx context.Context) {
+	ticker := time.NewTicker(n.interval)
+	defer ticker.Stop()
+
+	for {
+		n.post(ctx, n.scan(ctx, time.Now()))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scan returns the notifications since the previous scan.
+func (n *SlackNotifier) scan(ctx context.Context, now time.Time) []slackNotification {
+	var notifications []slackNotification
+	var scanErrs []error
+	deployments, err := n.deployments(ctx)
+	if err != nil {
+		scanErrs = append(scanErrs, fmt.Errorf("deployments: %w", err))
+	}
+	nodes, err := getNodesHealth(ctx, n.clientset, labels.Everything())
+	if err != nil {
+		scanErrs = append(scanErrs, fmt.Errorf("nodes: %w", err))
+	}
+	for _, event := range n.track(deployments, nodes) {
+		notifications = append(notifications, n.transition(event))
+	}
+
+	events, err := n.policyEvents(ctx, now)
+	if err != nil {
+		scanErrs = append(scanErrs, fmt.Errorf("policy events: %w", err))
+	}
+	for _, event := range events {
+		notifications = append(notifications, slackNotification{
+			kind:      slackPolicies,
+			namespace: event.InvolvedObject.Namespace,
+			text:      fmt.Sprintf(":shield: *%s* `%s` %s in cluster %s: %s", event.Reason, policyName(event.InvolvedObject), event.InvolvedObject.Kind, n.cluster, event.Message),
+		})
+	}
+
+	// A scan failing is notified once, until it succeeds again
+	switch {
+	case len(scanErrs) > 0 && !n.scanFailing:
+		notifications = append(notifications, slackNotification{kind: slackScans, text: fmt.Sprintf(":warning: *Health scan failing* in cluster %s: %v", n.cluster, errors.Join(scanErrs...))})
+	case len(scanErrs) == 0 && n.scanFailing:
+		notifications = append(notifications, slackNotification{kind: slackScans, text: fmt.Sprintf(":white_check_mark: *Health scan recovered* in cluster %s", n.cluster)})
+	}
+	n.scanFailing = len(scanErrs) > 0
+	return notifications
+}
+
+// transition formats a transition of a deployment or a node.
+func (n *SlackNotifier) transition(event WebhookEvent) slackNotification {
+	switch event.Type {
+	case webhookDeploymentFailed:
+		return slackNotification{kind: slackDeployments, namespace: event.Deployment.Namespace, text: fmt.Sprintf(":red_circle: *Deployment failed* `%s/%s` in cluster %s: %d/%d pods ready",
+			event.Deployment.Namespace, event.Deployment.Name, n.cluster, event.Deployment.ReadyPods, event.Deployment.RequestedPods)}
+	case webhookDeploymentRecovered:
+		return slackNotification{kind: slackDeployments, namespace: event.Deployment.Namespace, text: fmt.Sprintf(":large_green_circle: *Deployment recovered* `%s/%s` in cluster %s: %d/%d pods ready",
+			event.Deployment.Namespace, event.Deployment.Name, n.cluster, event.Deployment.ReadyPods, event.Deployment.RequestedPods)}
+	case webhookNodeNotReady:
+		return slackNotification{kind: slackNodes, text: fmt.Sprintf(":red_circle: *Node not ready* `%s` in cluster %s", event.Node.Name, n.cluster)}
+	default:
+		return slackNotification{kind: slackNodes, text: fmt.Sprintf(":large_green_circle: *Node ready* `%s` in cluster %s", event.Node.Name, n.cluster)}
+	}
+}
+
+// policyEvents returns the events recorded for the managed policies since the previous scan.
+func (n *SlackNotifier) policyEvents(ctx context.Context, now time.Time) ([]corev1.Event, error) {
+	// Event timestamps have a precision of a second, an event of the current second is left to the next scan
+	now = now.Truncate(time.Second)
+	namespaces, err := n.scope.resolve(ctx, n.clientset)
+	if err != nil {
+		return nil, err
+	}
+	var events []corev1.Event
+	for _, namespace := range namespaces {
+		list, err := n.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: "source=" + eventSourceComponent})
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range list.Items {
+			if event.Source.Component == eventSourceComponent && slices.Contains(policyEventReasons, event.Reason) &&
+				!event.LastTimestamp.Time.Before(n.eventsSince) && event.LastTimestamp.Time.Before(now) && !slices.Contains(n.excluded, event.InvolvedObject.Namespace) {
+				events = append(events, event)
+			}
+		}
+	}
+	n.eventsSince = now
+	slices.SortFunc(events, func(a, b corev1.Event) int { return a.LastTimestamp.Time.Compare(b.LastTimestamp.Time) })
+	return events, nil
+}
+
+// policyName is the namespace/name of a namespaced policy, the name of a global one.
+func policyName(policy corev1.ObjectReference) string {
+	if policy.Namespace == "" {
+		return policy.Name
+	}
+	return policy.Namespace + "/" + policy.Name
+}
+
+// post sends every notification to the rules routing it.
+func (n *SlackNotifier) post(ctx context.Context, notifications []slackNotification) {
+	for _, notification := range notifications {
+		for _, rule := range n.rules {
+			if !rule.routes(notification) {
+				continue
+			}
+			if err := n.postSlack(ctx, rule.SlackTarget, notification.text); err != nil {
+				slog.ErrorContext(ctx, "Failed posting to Slack", "channel", rule.Channel, "kind", notification.kind, "error", err)
+			}
+		}
+	}
+}
+
+// postSlack posts text to a Slack target.
+func (s *webhookSender) postSlack(ctx context.Context, target SlackTarget, text string) error {
+	body, err := json.Marshal(slackMessage{Channel: target.Channel, Text: text})
+	if err != nil {
+		return err
+	}
+	return s.deliver(ctx, webhookEndpoint{url: target.WebhookURL}, body, time.Now())
+}
+
+// routes tells whether the rule gets a notification.
+func (r SlackRule) routes(notification slackNotification) bool {
+	if len(r.Kinds) > 0 && !slices.Contains(r.Kinds, notification.kind) {
+		return false
+	}
+	if len(r.Namespaces) == 0 {
+		return true
+	}
+	for _, pattern := range r.Namespaces {
+		if matched, _ := path.Match(pattern, notification.namespace); matched && notification.namespace != "" {
+			return true
+		}
+	}
+	return false
+}
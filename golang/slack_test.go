@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSlackNotifier(t *testing.T) {
+	var messages []slackMessage
+	slack := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message slackMessage
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&message))
+		messages = append(messages, message)
+	}))
+	defer slack.Close()
+
+	replicas := int32(2)
+	deployment := func(ready int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "ledger", Namespace: "payments-eu", Generation: int64(ready) + 1},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: ready, AvailableReplicas: ready},
+		}
+	}
+	clientset := fake.NewSimpleClientset(deployment(2))
+
+	notifier, err := newSlackNotifier(&SlackConfig{Rules: []SlackRule{
+		{SlackTarget: SlackTarget{WebhookURL: slack.URL, Channel: "#payments"}, Namespaces: []string{"payments-*"}, Kinds: []string{slackDeployments, slackPolicies}},
+		{SlackTarget: SlackTarget{WebhookURL: slack.URL, Channel: "#platform"}, Kinds: []string{slackPolicies, slackScans}},
+	}}, "prod", clientset, NamespaceScope{}, nil, Server{K8sClientSet: clientset}.reportedDeploymentsHealth)
+	if !assert.NoError(t, err) {
+		return
+	}
+	notifier.httpClient = slack.Client()
+	ctx := context.Background()
+	now := time.Now()
+	notifier.eventsSince = now.Add(-time.Minute).Truncate(time.Second)
+
+	// The first scan is the baseline
+	notifier.post(ctx, notifier.scan(ctx, now))
+	assert.Empty(t, messages)
+
+	_, err = clientset.AppsV1().Deployments("payments-eu").Update(ctx, deployment(0), metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	_, err = clientset.CoreV1().Events("payments-eu").Create(ctx, &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "deny.1", Namespace: "payments-eu"},
+		InvolvedObject: corev1.ObjectReference{Kind: "NetworkPolicy", Namespace: "payments-eu", Name: "deny-ledger"},
+		Reason:         "DenyPolicyCreated",
+		Message:        "Denied traffic between app == 'ledger' and shop",
+		Source:         corev1.EventSource{Component: eventSourceComponent},
+		LastTimestamp:  metav1.NewTime(now.Add(time.Second)),
+	}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	notifier.post(ctx, notifier.scan(ctx, now.Add(time.Minute)))
+
+	if assert.Len(t, messages, 3) {
+		assert.Equal(t, "#payments", messages[0].Channel)
+		assert.Contains(t, messages[0].Text, "*Deployment failed* `payments-eu/ledger` in cluster prod: 0/2 pods ready")
+		assert.Equal(t, "#payments", messages[1].Channel)
+		assert.Contains(t, messages[1].Text, "*DenyPolicyCreated* `payments-eu/deny-ledger`")
+		assert.Equal(t, "#platform", messages[2].Channel)
+		assert.Equal(t, messages[1].Text, messages[2].Text)
+	}
+
+	// The events already posted aren't posted again
+	messages = nil
+	notifier.post(ctx, notifier.scan(ctx, now.Add(2*time.Minute)))
+	assert.Empty(t, messages)
+
+	_, err = newSlackNotifier(&SlackConfig{Rules: []SlackRule{{SlackTarget: SlackTarget{WebhookURL: slack.URL}, Kinds: []string{"pods"}}}}, "prod", clientset, NamespaceScope{}, nil, nil)
+	assert.Error(t, err)
+	_, err = newSlackNotifier(&SlackConfig{Rules: []SlackRule{{SlackTarget: SlackTarget{WebhookURL: "http://hooks.slack.com/services/T0"}}}}, "prod", clientset, NamespaceScope{}, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestSlackRuleRoutes(t *testing.T) {
+	rule := SlackRule{Namespaces: []string{"payments-*", "shop"}}
+	assert.True(t, rule.routes(slackNotification{kind: slackDeployments, namespace: "payments-eu"}))
+	assert.True(t, rule.routes(slackNotification{kind: slackPolicies, namespace: "shop"}))
+	assert.False(t, rule.routes(slackNotification{kind: slackDeployments, namespace: "search"}))
+	// Nodes and scans aren't about a namespace
+	assert.False(t, rule.routes(slackNotification{kind: slackScans}))
+	assert.True(t, SlackRule{}.routes(slackNotification{kind: slackScans}))
+	assert.False(t, SlackRule{Kinds: []string{slackDeployments}}.routes(slackNotification{kind: slackNodes}))
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const maxSnapshots = 50
+
+// Kinds of change in a snapshot diff.
+const (
+	changeAdded   = "added"
+	changeRemoved = "removed"
+	changeChanged = "changed"
+)
+
+// ClusterSnapshot is the health of the cluster at a point in time, keyed by namespace/name (or node name).
+type ClusterSnapshot struct {
+	Name        string                        `json:"name"`
+	CapturedAt  time.Time                     `json:"captured_at"`
+	Deployments map[string]DeploymentSnapshot `json:"deployments"`
+	Nodes       map[string]NodeSnapshot       `json:"nodes"`
+	Policies    map[string]PolicySnapshot     `json:"policies"`
+	Warnings    []string                      `json:"warnings,omitempty"`
+}
+
+type DeploymentSnapshot struct {
+	RequestedPods int32  `json:"requested_pods"`
+	ReadyPods     int32  `json:"ready_pods"`
+	Healthy       bool   `json:"healthy"`
+	Generation    int64  `json:"generation"`
+	Images        string `json:"images"`
+}
+
+type NodeSnapshot struct {
+	Ready          bool   `json:"ready"`
+	Schedulable    bool   `json:"schedulable"`
+	KubeletVersion string `json:"kubelet_version"`
+}
+
+type PolicySnapshot struct {
+	Selector string `json:"selector"`
+	Managed  bool   `json:"managed"`
+	SpecHash string `json:"spec_hash"`
+}
+
+// SnapshotDiff is the change report between two snapshots.
+type SnapshotDiff struct {
+	From        string           `json:"from"`
+	To          string           `json:"to"`
+	Elapsed     string           `json:"elapsed"`
+	Deployments []SnapshotChange `json:"deployments"`
+	Nodes       []SnapshotChange `json:"nodes"`
+	Policies    []SnapshotChange `json:"policies"`
+}
+
+// SnapshotChange describes one resource that appeared, disappeared or changed between two snapshots.
+type SnapshotChange struct {
+	Key    string      `json:"key"`
+	Change string      `json:"change"`
+	Fields []string    `json:"fields,omitempty"`
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+// SnapshotStore keeps the captured snapshots in memory, dropping the oldest past maxSnapshots.
+type SnapshotStore struct {
+	mu        sync.RWMutex
+	snapshots map[string]*ClusterSnapshot
+	order     []string
+}
+
+func newSnapshotStore() *SnapshotStore {
+	return &SnapshotStore{snapshots: map[string]*ClusterSnapshot{}}
+}
+
+func (s *SnapshotStore) add(snapshot *ClusterSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.snapshots[snapshot.Name]; ok {
+		return fmt.Errorf("snapshot %q already exists", snapshot.Name)
+	}
+	s.snapshots[snapshot.Name] = snapshot
+	s.order = append(s.order, snapshot.Name)
+
+	if len(s.order) > maxSnapshots {
+		delete(s.snapshots, s.order[0])
+		s.order = s.order[1:]
+	}
+	return nil
+}
+
+func (s *SnapshotStore) get(name string) (*ClusterSnapshot, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshot, ok := s.snapshots[name]
+	return snapshot, ok
+}
+
+func (s *SnapshotStore) names() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]string{}, s.order...)
+}
+
+// Handler capturing a named snapshot of the cluster health
+func (s *Server) captureSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		writeErrorf(w, http.StatusBadRequest, "Invalid snapshot name %q: %s", name, strings.Join(errs, ", "))
+		return
+	}
+	if _, ok := s.Snapshots.get(name); ok {
+		writeErrorf(w, http.StatusConflict, "Snapshot %q already exists", name)
+		return
+	}
+
+	if !s.authorize(w, r, operationSnapshotCapture, "", name, nil) {
+		return
+	}
+
+	snapshot, err := s.captureSnapshot(r.Context(), name, time.Now())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if err := s.Snapshots.add(snapshot); err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+
+	slog.InfoContext(r.Context(), "Snapshot captured", "name", name, "deployments", len(snapshot.Deployments), "nodes", len(snapshot.Nodes), "policies", len(snapshot.Policies))
+	writeJSON(w, http.StatusCreated, snapshot)
+}
+
+// Handler listing the captured snapshots
+func (s *Server) listSnapshotsHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string][]string{"snapshots": s.Snapshots.names()})
+}
+
+// Handler returning a single snapshot
+func (s *Server) getSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	snapshot, ok := s.Snapshots.get(r.PathValue("name"))
+	if !ok {
+		writeErrorf(w, http.StatusNotFound, "Snapshot %q not found", r.PathValue("name"))
+		return
+	}
+	writeJSON(w, http.StatusOK, snapshot)
+}
+
+// Handler diffing two snapshots
+func (s *Server) diffSnapshotsHandler(w http.ResponseWriter, r *http.Request) {
+	var pair [2]*ClusterSnapshot
+	for i, name := range []string{r.PathValue("a"), r.PathValue("b")} {
+		snapshot, ok := s.Snapshots.get(name)
+		if !ok {
+			writeErrorf(w, http.StatusNotFound, "Snapshot %q not found", name)
+			return
+		}
+		pair[i] = snapshot
+	}
+
+	writeJSON(w, http.StatusOK, diffSnapshots(pair[0], pair[1]))
+}
+
+func (s *Server) captureSnapshot(ctx context.Context, name string, now time.Time) (*ClusterSnapshot, error) {
+	snapshot := &ClusterSnapshot{
+		Name:        name,
+		CapturedAt:  now,
+		Deployments: map[string]DeploymentSnapshot{},
+		Nodes:       map[string]NodeSnapshot{},
+		Policies:    map[string]PolicySnapshot{},
+	}
+
+	namespaces, err := s.Namespaces.resolve(ctx, s.K8sClientSet)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, namespace := range namespaces {
+		deployments, err := s.K8sClientSet.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, deployment := range deployments.Items {
+			var images []string
+			for _, container := range deployment.Spec.Template.Spec.Containers {
+				images = append(images, container.Image)
+			}
+
+			replicas := specReplicas(deployment.Spec.Replicas)
+			snapshot.Deployments[deployment.Namespace+"/"+deployment.Name] = DeploymentSnapshot{
+				RequestedPods: replicas,
+				ReadyPods:     deployment.Status.ReadyReplicas,
+				Healthy:       replicas <= deployment.Status.ReadyReplicas,
+				Generation:    deployment.Generation,
+				Images:        strings.Join(images, ","),
+			}
+		}
+
+		policies, err := s.CalicoClientSet.ProjectcalicoV3().NetworkPolicies(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, policy := range policies.Items {
+			snapshot.Policies[policy.Namespace+"/"+policy.Name] = PolicySnapshot{
+				Selector: policy.Spec.Selector,
+				Managed:  policy.Labels[managedByLabel] == managedByValue,
+				SpecHash: specHash(policy.Spec),
+			}
+		}
+	}
+
+	// Namespace-scoped deployments usually can't list nodes, the snapshot is still useful without them
+	nodes, err := s.K8sClientSet.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if apierrors.IsForbidden(err) {
+		snapshot.Warnings = append(snapshot.Warnings, "nodes not captured: "+err.Error())
+		return snapshot, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes.Items {
+		ready := false
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady {
+				ready = condition.Status == corev1.ConditionTrue
+			}
+		}
+		snapshot.Nodes[node.Name] = NodeSnapshot{
+			Ready:          ready,
+			Schedulable:    !node.Spec.Unschedulable,
+			KubeletVersion: node.Status.NodeInfo.KubeletVersion,
+		}
+	}
+
+	return snapshot, nil
+}
+
+// diffSnapshots reports what changed going from snapshot a to snapshot b.
+func diffSnapshots(a, b *ClusterSnapshot) *SnapshotDiff {
+	return &SnapshotDiff{
+		From:        a.Name,
+		To:          b.Name,
+		Elapsed:     b.CapturedAt.Sub(a.CapturedAt).String(),
+		Deployments: diffSnapshotItems(a.Deployments, b.Deployments),
+		Nodes:       diffSnapshotItems(a.Nodes, b.Nodes),
+		Policies:    diffSnapshotItems(a.Policies, b.Policies),
+	}
+}
+
+func diffSnapshotItems[T comparable](before, after map[string]T) []SnapshotChange {
+	changes := []SnapshotChange{}
+
+	for key, old := range before {
+		current, ok := after[key]
+		switch {
+		case !ok:
+			changes = append(changes, SnapshotChange{Key: key, Change: changeRemoved, Before: old})
+		case current != old:
+			changes = append(changes, SnapshotChange{Key: key, Change: changeChanged, Fields: changedFields(old, current), Before: old, After: current})
+		}
+	}
+	for key, current := range after {
+		if _, ok := before[key]; !ok {
+			changes = append(changes, SnapshotChange{Key: key, Change: changeAdded, After: current})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+// changedFields lists by JSON name the struct fields that differ between a and b.
+func changedFields(a, b interface{}) []string {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	var fields []string
+	for i := 0; i < va.NumField(); i++ {
+		if va.Field(i).Interface() != vb.Field(i).Interface() {
+			name, _, _ := strings.Cut(va.Type().Field(i).Tag.Get("json"), ",")
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	start := time.Date(2024, 7, 8, 12, 0, 0, 0, time.UTC)
+	before := &ClusterSnapshot{
+		Name:       "before",
+		CapturedAt: start,
+		Deployments: map[string]DeploymentSnapshot{
+			"payments/ledger": {RequestedPods: 3, ReadyPods: 3, Healthy: true, Images: "ledger:1.0"},
+			"shop/cart":       {RequestedPods: 1, ReadyPods: 1, Healthy: true, Images: "cart:2.1"},
+		},
+		Nodes:    map[string]NodeSnapshot{"node-a": {Ready: true, Schedulable: true, KubeletVersion: "v1.27.10"}},
+		Policies: map[string]PolicySnapshot{},
+	}
+	after := &ClusterSnapshot{
+		Name:       "after",
+		CapturedAt: start.Add(90 * time.Minute),
+		Deployments: map[string]DeploymentSnapshot{
+			"payments/ledger": {RequestedPods: 3, ReadyPods: 1, Healthy: false, Images: "ledger:1.1"},
+			"search/indexer":  {RequestedPods: 2, ReadyPods: 2, Healthy: true, Images: "indexer:5"},
+		},
+		Nodes:    map[string]NodeSnapshot{"node-a": {Ready: true, Schedulable: true, KubeletVersion: "v1.27.10"}},
+		Policies: map[string]PolicySnapshot{"payments/deny": {Selector: "app == 'ledger'", Managed: true}},
+	}
+
+	diff := diffSnapshots(before, after)
+	assert.Equal(t, "1h30m0s", diff.Elapsed)
+	assert.Empty(t, diff.Nodes)
+
+	if assert.Len(t, diff.Deployments, 3) {
+		assert.Equal(t, SnapshotChange{
+			Key:    "payments/ledger",
+			Change: changeChanged,
+			Fields: []string{"ready_pods", "healthy", "images"},
+			Before: before.Deployments["payments/ledger"],
+			After:  after.Deployments["payments/ledger"],
+		}, diff.Deployments[0])
+		assert.Equal(t, changeAdded, diff.Deployments[1].Change)
+		assert.Equal(t, "search/indexer", diff.Deployments[1].Key)
+		assert.Equal(t, changeRemoved, diff.Deployments[2].Change)
+	}
+	if assert.Len(t, diff.Policies, 1) {
+		assert.Equal(t, changeAdded, diff.Policies[0].Change)
+	}
+}
+
+func TestSnapshotStoreEvictsOldest(t *testing.T) {
+	store := newSnapshotStore()
+	for i := 0; i <= maxSnapshots; i++ {
+		assert.NoError(t, store.add(&ClusterSnapshot{Name: fmt.Sprintf("s%d", i)}))
+	}
+
+	assert.Error(t, store.add(&ClusterSnapshot{Name: "s1"}))
+	_, ok := store.get("s0")
+	assert.False(t, ok)
+	assert.Len(t, store.names(), maxSnapshots)
+}
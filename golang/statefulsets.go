@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// defaultStatefulSetRolloutDeadline is the default progress deadline of deployments, StatefulSets have none of their own.
+const defaultStatefulSetRolloutDeadline = 10 * time.Minute
+
+type StatefulSetInfo struct {
+	Name              string `json:"statefulset_name"`
+	Namespace         string `json:"namespace"`
+	RequestedPods     int32  `json:"requested_pods"`
+	ReadyPods         int32  `json:"ready_pods"`
+	AvailableReplicas int32  `json:"available_replicas"`
+	// CurrentReplicas run CurrentRevision and UpdatedReplicas UpdateRevision, the revisions diverging during a rollout
+	CurrentReplicas int32  `json:"current_replicas"`
+	UpdatedReplicas int32  `json:"updated_replicas"`
+	CurrentRevision string `json:"current_revision"`
+	UpdateRevision  string `json:"update_revision"`
+	// RolloutStartedAt is when the update revision was created, while pods remain to be rolled out to it
+	RolloutStartedAt *time.Time `json:"rollout_started_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+type ClusterStatefulSetsInfo struct {
+	ReadyStatefulSets  []StatefulSetInfo `json:"ready_statefulsets"`
+	FailedStatefulSets []StatefulSetInfo `json:"failed_statefulsets"`
+	// StuckRollouts are the unhealthy StatefulSets rolling out for longer than the rollout deadline. An OrderedReady
+	// rollout waits for the pod it updated to become ready, so a broken revision blocks it until the StatefulSet changes.
+	StuckRollouts []StatefulSetInfo `json:"stuck_rollouts"`
+}
+
+// Reports the health of the StatefulSets in scope, like clusterDeploymentsInfoHandler for deployments
+//
+// ?namespace= restricts it to a namespace and ?labelSelector= to the StatefulSets whose own labels match, both applied
+// by the API server when listing them. ?excludeNamespaces= leaves namespaces out on top of --exclude-namespaces, which
+// don't apply to the namespace of ?namespace=.
+func (s *Server) clusterStatefulSetsInfoHandler(w http.ResponseWriter, r *http.Request) {
+	filter, status, err := s.parseReportFilter(r)
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+
+	info, err := getStatefulSetsHealth(r.Context(), s.K8sClientSet, filter.Scope, filter.Selector, s.StatefulSetRolloutDeadline, time.Now())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	info.excludeNamespaces(filter.excludedNamespaces(s.ExcludedNamespaces))
+	writeJSON(w, http.StatusOK, info)
+}
+
+// getStatefulSetsHealth sorts the StatefulSets of every namespace in scope whose own labels match selector into ready,
+// failed and stuck ones, a rollout being stuck once it's been going on for deadline.
+func getStatefulSetsHealth(ctx context.Context, clientset kubernetes.Interface, scope NamespaceScope, selector labels.Selector, deadline time.Duration, now time.Time) (*ClusterStatefulSetsInfo, error) {
+	namespaces, err := scope.resolve(ctx, clientset)
+	if err != nil {
+		return nil, err
+	}
+
+	info := new(ClusterStatefulSetsInfo)
+	for _, namespace := range namespaces {
+		list, err := clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, err
+		}
+		for _, statefulSet := range list.Items {
+			current := StatefulSetInfo{
+				Name:              statefulSet.Name,
+				Namespace:         statefulSet.Namespace,
+				RequestedPods:     specReplicas(statefulSet.Spec.Replicas),
+				ReadyPods:         statefulSet.Status.ReadyReplicas,
+				AvailableReplicas: statefulSet.Status.AvailableReplicas,
+				CurrentReplicas:   statefulSet.Status.CurrentReplicas,
+				UpdatedReplicas:   statefulSet.Status.UpdatedReplicas,
+				CurrentRevision:   statefulSet.Status.CurrentRevision,
+				UpdateRevision:    statefulSet.Status.UpdateRevision,
+				CreatedAt:         statefulSet.CreationTimestamp.Time,
+			}
+			if rollingOut(statefulSet) {
+				current.RolloutStartedAt = rolloutStart(ctx, clientset, statefulSet)
+			}
+
+			switch {
+			case current.RequestedPods <= current.ReadyPods:
+				info.ReadyStatefulSets = append(info.ReadyStatefulSets, current)
+			case current.RolloutStartedAt != nil && now.Sub(*current.RolloutStartedAt) >= deadline:
+				info.StuckRollouts = append(info.StuckRollouts, current)
+			default:
+				info.FailedStatefulSets = append(info.FailedStatefulSets, current)
+			}
+		}
+	}
+	slog.DebugContext(ctx, "StatefulSets health", "ready", len(info.ReadyStatefulSets), "failed", len(info.FailedStatefulSets), "stuck", len(info.StuckRollouts))
+	return info, nil
+}
+
+// rollingOut reports whether pods of the StatefulSet remain to be updated to its update revision. Pods under the
+// partition of a rolling update and those of an OnDelete StatefulSet keep the current revision on purpose.
+func rollingOut(statefulSet appsv1.StatefulSet) bool {
+	status := statefulSet.Status
+	if status.UpdateRevision == "" || status.CurrentRevision == status.UpdateRevision {
+		return false
+	}
+	if statefulSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return false
+	}
+	var partition int32
+	if rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.Partition != nil {
+		partition = *rollingUpdate.Partition
+	}
+	return status.UpdatedReplicas < specReplicas(statefulSet.Spec.Replicas)-partition
+}
+
+// rolloutStart returns when the update revision of the StatefulSet was created, nil when its ControllerRevision can't
+// be read, the rollout then never being reported as stuck.
+func rolloutStart(ctx context.Context, clientset kubernetes.Interface, statefulSet appsv1.StatefulSet) *time.Time {
+	revision, err := clientset.AppsV1().ControllerRevisions(statefulSet.Namespace).Get(ctx, statefulSet.Status.UpdateRevision, metav1.GetOptions{})
+	if err != nil {
+		slog.WarnContext(ctx, "Failed reading the update revision of a StatefulSet", "namespace", statefulSet.Namespace, "statefulset", statefulSet.Name, "revision", statefulSet.Status.UpdateRevision, "error", err)
+		return nil
+	}
+	return &revision.CreationTimestamp.Time
+}
+
+// excludeNamespaces removes the StatefulSets of namespaces from every category of the report.
+func (info *ClusterStatefulSetsInfo) excludeNamespaces(namespaces []string) {
+	if len(namespaces) == 0 {
+		return
+	}
+	keep := func(statefulSets []StatefulSetInfo) []StatefulSetInfo {
+		kept := statefulSets[:0]
+		for _, statefulSet := range statefulSets {
+			if !slices.Contains(namespaces, statefulSet.Namespace) {
+				kept = append(kept, statefulSet)
+			}
+		}
+		return kept
+	}
+	info.ReadyStatefulSets = keep(info.ReadyStatefulSets)
+	info.FailedStatefulSets = keep(info.FailedStatefulSets)
+	info.StuckRollouts = keep(info.StuckRollouts)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetStatefulSetsHealth(t *testing.T) {
+	now := time.Now()
+	statefulSet := func(name string, replicas, ready, updated int32, current, update string, strategy appsv1.StatefulSetUpdateStrategy) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "data", Labels: map[string]string{"tier": "db"}},
+			Spec:       appsv1.StatefulSetSpec{Replicas: &replicas, UpdateStrategy: strategy},
+			Status:     appsv1.StatefulSetStatus{ReadyReplicas: ready, UpdatedReplicas: updated, CurrentRevision: current, UpdateRevision: update},
+		}
+	}
+	revision := func(name string, created time.Time) *appsv1.ControllerRevision {
+		return &appsv1.ControllerRevision{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "data", CreationTimestamp: metav1.NewTime(created)}}
+	}
+	partition := int32(2)
+	clientset := fake.NewSimpleClientset(
+		statefulSet("postgres", 3, 3, 3, "postgres-1", "postgres-1", appsv1.StatefulSetUpdateStrategy{}),
+		// Rolling out for an hour, blocked by the pod it updated
+		statefulSet("kafka", 3, 2, 1, "kafka-1", "kafka-2", appsv1.StatefulSetUpdateStrategy{}),
+		revision("kafka-2", now.Add(-time.Hour)),
+		// Rolling out for a minute
+		statefulSet("redis", 3, 2, 1, "redis-1", "redis-2", appsv1.StatefulSetUpdateStrategy{}),
+		revision("redis-2", now.Add(-time.Minute)),
+		// The partition keeps two pods on the current revision
+		statefulSet("etcd", 3, 2, 1, "etcd-1", "etcd-2", appsv1.StatefulSetUpdateStrategy{
+			Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition},
+		}),
+		revision("etcd-2", now.Add(-time.Hour)),
+		statefulSet("zookeeper", 3, 2, 0, "zookeeper-1", "zookeeper-2", appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType}),
+		revision("zookeeper-2", now.Add(-time.Hour)),
+	)
+
+	info, err := getStatefulSetsHealth(context.Background(), clientset, NamespaceScope{Names: []string{"data"}}, labels.Everything(), 10*time.Minute, now)
+	assert.NoError(t, err)
+	names := func(statefulSets []StatefulSetInfo) []string {
+		var result []string
+		for _, statefulSet := range statefulSets {
+			result = append(result, statefulSet.Name)
+		}
+		return result
+	}
+	assert.Equal(t, []string{"postgres"}, names(info.ReadyStatefulSets))
+	assert.Equal(t, []string{"etcd", "redis", "zookeeper"}, names(info.FailedStatefulSets))
+	assert.Equal(t, []string{"kafka"}, names(info.StuckRollouts))
+
+	kafka := info.StuckRollouts[0]
+	assert.Equal(t, "kafka-1", kafka.CurrentRevision)
+	assert.Equal(t, "kafka-2", kafka.UpdateRevision)
+	if assert.NotNil(t, kafka.RolloutStartedAt) {
+		assert.True(t, kafka.RolloutStartedAt.Equal(now.Add(-time.Hour)))
+	}
+	for _, failed := range info.FailedStatefulSets {
+		assert.Equal(t, failed.Name == "redis", failed.RolloutStartedAt != nil, failed.Name)
+	}
+
+	info, err = getStatefulSetsHealth(context.Background(), clientset, NamespaceScope{Names: []string{"data"}}, labels.Everything(), 10*time.Minute, now)
+	assert.NoError(t, err)
+	info.excludeNamespaces([]string{"data"})
+	assert.Empty(t, info.ReadyStatefulSets)
+	assert.Empty(t, info.FailedStatefulSets)
+	assert.Empty(t, info.StuckRollouts)
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultTicketingInterval  = time.Minute
+	defaultTicketingFailedFor = 10 * time.Minute
+)
+
+// TicketingConfig files a ticket when a deployment stays failed for FailedFor and closes it once it recovers.
+//
+// Requests are generic REST templates so any system of record (Jira, ServiceNow, ...) can be targeted.
+type TicketingConfig struct {
+	Interval  metav1.Duration   `json:"interval,omitempty"`
+	FailedFor metav1.Duration   `json:"failed_for,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Open      TicketRequest     `json:"open"`
+	Update    *TicketRequest    `json:"update,omitempty"`
+	Close     *TicketRequest    `json:"close,omitempty"`
+}
+
+// TicketRequest is an HTTP request whose URL and body are Go templates rendered with a ticketEvent.
+//
+// IDPath is the dot-separated path of the ticket ID in the JSON response, only used to open tickets.
+type TicketRequest struct {
+	Method string `json:"method,omitempty"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+	IDPath string `json:"id_path,omitempty"`
+}
+
+// ticketEvent is the data available to the ticket templates.
+type ticketEvent struct {
+	Namespace     string
+	Name          string
+	RequestedPods int32
+	ReadyPods     int32
+	FailedSince   time.Time
+	TicketID      string
+}
+
+type ticketTemplate struct {
+	method string
+	url    *template.Template
+	body   *template.Template
+	idPath string
+}
+
+type ticketState struct {
+	namespace   string
+	name        string
+	failedSince time.Time
+	readyPods   int32
+	ticketID    string
+}
+
+// TicketManager tracks how long deployments have been failing and drives the ticket lifecycle.
+//
+// State is kept in memory, so a restart forgets tickets that were open.
+type TicketManager struct {
+	interval   time.Duration
+	failedFor  time.Duration
+	headers    map[string]string
+	open       ticketTemplate
+	update     *ticketTemplate
+	close      *ticketTemplate
+	httpClient *http.Client
+	// deployments reads the health of the deployments, see Server.reportedDeploymentsHealth
+	deployments func(context.Context) (*ClusterDeploymentsInfo, error)
+	states      map[string]*ticketState
+}
+
+var ticketTemplateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+func newTicketManager(config *TicketingConfig, deployments func(context.Context) (*ClusterDeploymentsInfo, error)) (*TicketManager, error) {
+	manager := &TicketManager{
+		interval:    config.Interval.Duration,
+		failedFor:   config.FailedFor.Duration,
+		headers:     config.Headers,
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		deployments: deployments,
+		states:      map[string]*ticketState{},
+	}
+	if manager.interval == 0 {
+		manager.interval = defaultTicketingInterval
+	}
+	if manager.failedFor == 0 {
+		manager.failedFor = defaultTicketingFailedFor
+	}
+
+	open, err := parseTicketRequest("open", config.Open)
+	if err != nil {
+		return nil, err
+	}
+	if open.idPath == "" {
+		return nil, errors.New("ticketing.open.id_path is required")
+	}
+	manager.open = *open
+
+	if config.Update != nil {
+		if manager.update, err = parseTicketRequest("update", *config.Update); err != nil {
+			return nil, err
+		}
+	}
+	if config.Close != nil {
+		if manager.close, err = parseTicketRequest("close", *config.Close); err != nil {
+			return nil, err
+		}
+	}
+	return manager, nil
+}
+
+func parseTicketRequest(name string, request TicketRequest) (*ticketTemplate, error) {
+	if request.URL == "" {
+		return nil, fmt.Errorf("ticketing.%s.url is required", name)
+	}
+	if request.Method == "" {
+		request.Method = http.MethodPost
+	}
+
+	url, err := template.New(name + ".url").Funcs(ticketTemplateFuncs).Parse(request.URL)
+	if err != nil {
+		return nil, fmt.Errorf("ticketing.%s.url: %w", name, err)
+	}
+	body, err := template.New(name + ".body").Funcs(ticketTemplateFuncs).Parse(request.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ticketing.%s.body: %w", name, err)
+	}
+	return &ticketTemplate{method: strings.ToUpper(request.Method), url: url, body: body, idPath: request.IDPath}, nil
+}
+
+// run reconciles tickets every interval until ctx is done.
+func (m *TicketManager) run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		health, err := m.deployments(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed scanning deployments for ticketing", "error", err)
+		} else {
+			m.reconcile(ctx, health, time.Now())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reconcile opens tickets for deployments failed longer than failedFor, updates them when the ready pods change and closes them on recovery.
+func (m *TicketManager) reconcile(ctx context.Context, health *ClusterDeploymentsInfo, now time.Time) {
+	failed := map[string]bool{}
+
+	// A stuck rollout is failing too, for longer
+	for _, deployment := range append(append([]DeploymentInfo{}, health.FailedDeployments...), health.StuckRollouts...) {
+		// Deployments of different namespaces may share a name
+		key := deployment.Namespace + "/" + deployment.Name
+		failed[key] = true
+
+		state, ok := m.states[key]
+		if !ok {
+			m.states[key] = &ticketState{namespace: deployment.Namespace, name: deployment.Name, failedSince: now, readyPods: deployment.ReadyPods}
+			continue
+		}
+
+		event := ticketEvent{
+			Namespace:     deployment.Namespace,
+			Name:          deployment.Name,
+			RequestedPods: deployment.RequestedPods,
+			ReadyPods:     deployment.ReadyPods,
+			FailedSince:   state.failedSince,
+			TicketID:      state.ticketID,
+		}
+
+		switch {
+		case state.ticketID == "" && now.Sub(state.failedSince) >= m.failedFor:
+			id, err := m.send(ctx, m.open, event)
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed opening ticket", "namespace", deployment.Namespace, "deployment", deployment.Name, "error", err)
+				continue
+			}
+			state.ticketID = id
+			slog.InfoContext(ctx, "Opened ticket", "ticket", id, "namespace", deployment.Namespace, "deployment", deployment.Name)
+		case state.ticketID != "" && state.readyPods != deployment.ReadyPods && m.update != nil:
+			if _, err := m.send(ctx, *m.update, event); err != nil {
+				slog.ErrorContext(ctx, "Failed updating ticket", "ticket", state.ticketID, "error", err)
+				continue
+			}
+		}
+		state.readyPods = deployment.ReadyPods
+	}
+
+	for key, state := range m.states {
+		if failed[key] {
+			continue
+		}
+		if state.ticketID != "" && m.close != nil {
+			event := ticketEvent{Namespace: state.namespace, Name: state.name, FailedSince: state.failedSince, TicketID: state.ticketID}
+			if _, err := m.send(ctx, *m.close, event); err != nil {
+				slog.ErrorContext(ctx, "Failed closing ticket", "ticket", state.ticketID, "error", err)
+				continue
+			}
+			slog.InfoContext(ctx, "Closed ticket", "ticket", state.ticketID, "namespace", state.namespace, "deployment", state.name)
+		}
+		delete(m.states, key)
+	}
+}
+
+// send renders and performs the request, returning the ticket ID found at idPath if set.
+func (m *TicketManager) send(ctx context.Context, request ticketTemplate, event ticketEvent) (string, error) {
+	var url, body bytes.Buffer
+	if err := request.url.Execute(&url, event); err != nil {
+		return "", err
+	}
+	if err := request.body.Execute(&body, event); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, request.method, url.String(), &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range m.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode/100 != 2 {
+		return "", fmt.Errorf("%s %s returned %s: %s", request.method, url.String(), resp.Status, respBody)
+	}
+
+	if request.idPath == "" {
+		return "", nil
+	}
+	return lookupJSONPath(respBody, request.idPath)
+}
+
+// lookupJSONPath returns the value at a dot-separated path of a JSON document as a string.
+func lookupJSONPath(document []byte, path string) (string, error) {
+	var value interface{}
+	if err := json.Unmarshal(document, &value); err != nil {
+		return "", err
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("%s not found in response", path)
+		}
+		if value, ok = object[key]; !ok {
+			return "", fmt.Errorf("%s not found in response", path)
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return fmt.Sprintf("%.0f", v), nil
+	default:
+		return "", fmt.Errorf("%s is not a string or number", path)
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTicketManagerReconcile(t *testing.T) {
+	var calls []string
+	tracker := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		calls = append(calls, fmt.Sprintf("%s %s %v", r.Method, r.URL.Path, body["summary"]))
+		fmt.Fprint(w, `{"key": "OPS-1"}`)
+	}))
+	defer tracker.Close()
+
+	manager, err := newTicketManager(&TicketingConfig{
+		Open:   TicketRequest{URL: tracker.URL + "/issue", Body: `{"summary": {{json .Name}}}`, IDPath: "key"},
+		Update: &TicketRequest{Method: "put", URL: tracker.URL + "/issue/{{.TicketID}}", Body: `{"summary": "{{.ReadyPods}}/{{.RequestedPods}}"}`},
+		Close:  &TicketRequest{URL: tracker.URL + "/issue/{{.TicketID}}/close"},
+	}, nil)
+	assert.NoError(t, err)
+
+	start := time.Now()
+	failing := func(ready int32) *ClusterDeploymentsInfo {
+		return &ClusterDeploymentsInfo{FailedDeployments: []DeploymentInfo{{Name: "ledger", RequestedPods: 3, ReadyPods: ready}}}
+	}
+
+	manager.reconcile(context.TODO(), failing(0), start)
+	manager.reconcile(context.TODO(), failing(0), start.Add(5*time.Minute))
+	assert.Empty(t, calls)
+
+	manager.reconcile(context.TODO(), failing(0), start.Add(10*time.Minute))
+	manager.reconcile(context.TODO(), failing(0), start.Add(11*time.Minute))
+	manager.reconcile(context.TODO(), failing(1), start.Add(12*time.Minute))
+	manager.reconcile(context.TODO(), &ClusterDeploymentsInfo{}, start.Add(13*time.Minute))
+
+	assert.Equal(t, []string{
+		"POST /issue ledger",
+		"PUT /issue/OPS-1 1/3",
+		"POST /issue/OPS-1/close <nil>",
+	}, calls)
+	assert.Empty(t, manager.states)
+}
+
+func TestTicketManagerReconcileNamespaces(t *testing.T) {
+	var opened []string
+	tracker := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		opened = append(opened, fmt.Sprint(body["summary"]))
+		fmt.Fprintf(w, `{"key": "OPS-%d"}`, len(opened))
+	}))
+	defer tracker.Close()
+
+	manager, err := newTicketManager(&TicketingConfig{
+		Open: TicketRequest{URL: tracker.URL + "/issue", Body: `{"summary": "{{.Namespace}}/{{.Name}}"}`, IDPath: "key"},
+	}, nil)
+	assert.NoError(t, err)
+
+	// Deployments sharing a name in different namespaces get a ticket each
+	start := time.Now()
+	health := &ClusterDeploymentsInfo{FailedDeployments: []DeploymentInfo{
+		{Namespace: "payments", Name: "api", RequestedPods: 1},
+		{Namespace: "search", Name: "api", RequestedPods: 1},
+	}}
+	manager.reconcile(context.TODO(), health, start)
+	manager.reconcile(context.TODO(), health, start.Add(10*time.Minute))
+
+	assert.ElementsMatch(t, []string{"payments/api", "search/api"}, opened)
+	assert.Len(t, manager.states, 2)
+}
+
+func TestLookupJSONPath(t *testing.T) {
+	id, err := lookupJSONPath([]byte(`{"result": {"sys_id": "abc", "number": 42}}`), "result.sys_id")
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", id)
+
+	id, err = lookupJSONPath([]byte(`{"result": {"sys_id": "abc", "number": 42}}`), "result.number")
+	assert.NoError(t, err)
+	assert.Equal(t, "42", id)
+
+	_, err = lookupJSONPath([]byte(`{"result": {}}`), "result.sys_id")
+	assert.Error(t, err)
+}
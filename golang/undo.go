@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UndoResult lists the policies deleted by undoing an operation.
+type UndoResult struct {
+	OperationID string         `json:"operation_id"`
+	Deleted     []UndoneObject `json:"deleted"`
+	Failed      []UndoneObject `json:"failed,omitempty"`
+}
+
+// UndoneObject is a policy created by the undone operation, an empty namespace meaning a global one.
+type UndoneObject struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Handler deleting every policy created by a request, identified by the operation ID it returned
+//
+// The mirrors and AuthorizationPolicies of a deny were created by the same request and go with it, as do the policies
+// it staged. Policies which
+// already existed when the request was made carry the ID of the request which created them and are kept. Undoing
+// needs the permissions of deleting the policies.
+func (s *Server) undoOperationHandler(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if err := uuid.Validate(id); err != nil {
+		writeErrorf(w, http.StatusBadRequest, "Invalid operation ID %q", id)
+		return
+	}
+
+	policies, err := s.listBackendPolicies(r.Context(), s.Namespaces)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	staged, err := s.listStagedPolicies(r.Context(), s.Namespaces)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	policies = append(policies, staged...)
+	var created []PolicyObject
+	perNamespace := map[string]int{}
+	for _, policy := range policies {
+		if policy.GetLabels()[operationIDLabel] == id {
+			created = append(created, policy)
+			perNamespace[policy.GetNamespace()]++
+		}
+	}
+	if len(created) == 0 {
+		writeErrorf(w, http.StatusNotFound, "No policy created by operation %s", id)
+		return
+	}
+	sort.Slice(created, func(i, j int) bool {
+		if created[i].GetNamespace() != created[j].GetNamespace() {
+			return created[i].GetNamespace() < created[j].GetNamespace()
+		}
+		return created[i].GetName() < created[j].GetName()
+	})
+
+	operations := map[string]Operation{}
+	for namespace := range perNamespace {
+		operations[namespace] = operationDeletePolicy
+		if namespace == "" {
+			operations[namespace] = operationDeleteGlobal
+		}
+		if !s.authorize(w, r, operations[namespace], namespace, id, nil) {
+			return
+		}
+	}
+
+	// The whole undo is rejected up front rather than deleting only part of the operation
+	reservations := map[string]*BudgetReservation{}
+	for namespace, count := range perNamespace {
+		reservation, err := s.Guardrails.reserve(budgetPolicies, namespace, count, time.Now())
+		if err != nil {
+			for namespace, reservation := range reservations {
+				reservation.release(perNamespace[namespace])
+			}
+			writeGuardrailError(w, err)
+			return
+		}
+		reservations[namespace] = reservation
+	}
+
+	meta := requestMetadata(r.Context())
+	result := &UndoResult{OperationID: id, Deleted: []UndoneObject{}}
+	for _, policy := range created {
+		object := UndoneObject{Kind: policy.GetObjectKind().GroupVersionKind().Kind, Namespace: policy.GetNamespace(), Name: policy.GetName()}
+		audit := AuditEvent{
+			Action:          operations[object.Namespace].Action,
+			RequestMetadata: meta,
+			RemoteAddr:      r.RemoteAddr,
+			Namespace:       object.Namespace,
+			Target:          object.Name,
+			Request:         map[string]string{"undo": id},
+			Result:          "deleted",
+		}
+
+		// The backend keeps a policy recreated under the same name since it was listed
+		if err := s.Policies.Delete(r.Context(), policy); err != nil {
+			reservations[object.Namespace].release(1)
+			audit.Result, audit.Error = "failed", err.Error()
+			recordAudit(audit)
+			object.Error = err.Error()
+			result.Failed = append(result.Failed, object)
+			continue
+		}
+		recordAudit(audit)
+
+		slog.InfoContext(r.Context(), "Network policy undone", "namespace", object.Namespace, "name", object.Name, "operation_id", id)
+		recordEvent(r.Context(), s.K8sClientSet, policyReference(policy), "DenyPolicyUndone", fmt.Sprintf("Deleted by undoing operation %s", id), meta)
+		result.Deleted = append(result.Deleted, object)
+	}
+
+	status := http.StatusOK
+	if len(result.Failed) > 0 {
+		status = http.StatusInternalServerError
+	}
+	writeJSON(w, status, result)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	calicofake "github.com/projectcalico/api/pkg/client/clientset_generated/clientset/fake"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestUndoOperationHandler(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+	)
+	backend := newFakeCiliumBackend()
+	request := DenyNetworkRequest{
+		A: DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B: DenyNetworkRequestWorkload{Namespace: "shop", Labels: map[string]string{"app": "cart"}},
+	}
+	operationID := uuid.New().String()
+	name, _, err := createDenyNetworkPolicy(context.Background(), clientset, backend, NamespaceScope{}, request, RequestMetadata{OperationID: operationID})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// The policy and its mirror are labelled with the operation creating them
+	for _, namespace := range []string{"payments", "shop"} {
+		policies, err := backend.List(ctx, namespace)
+		if assert.NoError(t, err) && assert.Len(t, policies, 1) {
+			assert.Equal(t, operationID, policies[0].GetLabels()[operationIDLabel])
+		}
+	}
+	_, err = backend.Get(ctx, "payments", name)
+	assert.NoError(t, err)
+
+	server := &Server{Policies: backend}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /denyNetworkPolicy/undo/{id}", server.undoOperationHandler)
+	mux.HandleFunc("POST /denyNetworkPolicy/{name}/{action}", server.promoteDenyNetworkPolicyHandler)
+
+	for path, status := range map[string]int{
+		"/denyNetworkPolicy/undo/not-an-id":              http.StatusBadRequest,
+		"/denyNetworkPolicy/undo/" + uuid.New().String(): http.StatusNotFound,
+		"/denyNetworkPolicy/" + name + "/revert":         http.StatusNotFound,
+	} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+		assert.Equal(t, status, w.Code, path)
+	}
+}
+
+func TestUndoOperationHandlerStaged(t *testing.T) {
+	ctx := context.Background()
+	backend := &calicoBackend{
+		clientset: calicofake.NewSimpleClientset(),
+		client: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			calicoStagedPolicyResource:       "StagedNetworkPolicyList",
+			calicoStagedGlobalPolicyResource: "StagedGlobalNetworkPolicyList",
+		}),
+	}
+	request := DenyNetworkRequest{
+		A:      DenyNetworkRequestWorkload{Namespace: "payments", Labels: map[string]string{"app": "ledger"}},
+		B:      DenyNetworkRequestWorkload{CIDR: "10.12.0.0/16"},
+		Staged: true,
+	}
+	operationID := uuid.New().String()
+	staged, err := backend.Create(ctx, backend.Render(request, nil, RequestMetadata{OperationID: operationID}), false)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	server := &Server{K8sClientSet: fake.NewSimpleClientset(), Policies: backend}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /denyNetworkPolicy/undo/{id}", server.undoOperationHandler)
+
+	// A staged policy is undone like an enforced one
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/denyNetworkPolicy/undo/"+operationID, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var result UndoResult
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, []UndoneObject{{Kind: "StagedNetworkPolicy", Namespace: "payments", Name: staged.GetName()}}, result.Deleted)
+	_, err = backend.getStaged(ctx, "payments", staged.GetName())
+	assert.True(t, apierrors.IsNotFound(err))
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/denyNetworkPolicy/undo/"+operationID, nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
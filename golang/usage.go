@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// metricsAPI is the path of the resource metrics API served by metrics-server.
+const metricsAPI = "/apis/metrics.k8s.io/v1beta1"
+
+// errMetricsUnavailable is returned when the cluster doesn't serve the resource metrics API, without metrics-server.
+var errMetricsUnavailable = errors.New("the resource metrics API is not available, is metrics-server installed?")
+
+// MetricsClient reads the current CPU and memory usage of pods and nodes from metrics-server, and that of volumes from
+// the kubelets.
+type MetricsClient struct {
+	rest rest.Interface
+}
+
+// newMetricsClient reads the metrics through the REST client of a clientset, e.g. clientset.CoreV1().RESTClient().
+func newMetricsClient(client rest.Interface) *MetricsClient {
+	return &MetricsClient{rest: client}
+}
+
+// resourceMetrics is the part of a PodMetrics or NodeMetrics of metrics.k8s.io/v1beta1 read, Usage being that of a
+// node, Containers those of a pod.
+type resourceMetrics struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Usage             corev1.ResourceList `json:"usage"`
+	Containers        []struct {
+		Name  string              `json:"name"`
+		Usage corev1.ResourceList `json:"usage"`
+	} `json:"containers"`
+}
+
+// list gets the metrics of a resource, pods or nodes, of namespace, all of them for metav1.NamespaceAll or nodes.
+func (c *MetricsClient) list(ctx context.Context, resource, namespace string, selector labels.Selector) ([]resourceMetrics, error) {
+	path := metricsAPI + "/" + resource
+	if namespace != metav1.NamespaceAll {
+		path = metricsAPI + "/namespaces/" + namespace + "/" + resource
+	}
+	data, err := c.rest.Get().AbsPath(path).Param("labelSelector", selector.String()).DoRaw(ctx)
+	if apierrors.IsNotFound(err) || apierrors.IsServiceUnavailable(err) {
+		return nil, errMetricsUnavailable
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var list struct {
+		Items []resourceMetrics `json:"items"`
+	}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("invalid %s metrics: %w", resource, err)
+	}
+	return list.Items, nil
+}
+
+// volumeStats is the usage of a volume in the summary API of the kubelet, in bytes.
+type volumeStats struct {
+	CapacityBytes  uint64 `json:"capacityBytes"`
+	UsedBytes      uint64 `json:"usedBytes"`
+	AvailableBytes uint64 `json:"availableBytes"`
+}
+
+// volumeStats gets the usage of the volumes claimed by PVCs mounted on node, by the namespace and name of their claim,
+// from the summary API of its kubelet, proxied by the API server.
+func (c *MetricsClient) volumeStats(ctx context.Context, node string) (map[string]volumeStats, error) {
+	data, err := c.rest.Get().AbsPath("/api/v1/nodes", node, "proxy/stats/summary").DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var summary struct {
+		Pods []struct {
+			Volumes []struct {
+				volumeStats
+				PVCRef *struct {
+					Name      string `json:"name"`
+					Namespace string `json:"namespace"`
+				} `json:"pvcRef"`
+			} `json:"volume"`
+		} `json:"pods"`
+	}
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, fmt.Errorf("invalid stats summary of node %s: %w", node, err)
+	}
+	stats := map[string]volumeStats{}
+	for _, pod := range summary.Pods {
+		for _, volume := range pod.Volumes {
+			if volume.PVCRef != nil {
+				stats[volume.PVCRef.Namespace+"/"+volume.PVCRef.Name] = volume.volumeStats
+			}
+		}
+	}
+	return stats, nil
+}
+
+// ContainerUsage is the usage of a container against its limits, CPU in millicores and memory in bytes. A percentage
+// is only set when the container has that limit.
+type ContainerUsage struct {
+	Name               string   `json:"name"`
+	CPU                int64    `json:"cpu"`
+	Memory             int64    `json:"memory"`
+	CPULimit           int64    `json:"cpu_limit,omitempty"`
+	MemoryLimit        int64    `json:"memory_limit,omitempty"`
+	CPULimitPercent    *float64 `json:"cpu_limit_percent,omitempty"`
+	MemoryLimitPercent *float64 `json:"memory_limit_percent,omitempty"`
+}
+
+type PodUsage struct {
+	Name       string           `json:"pod_name"`
+	Namespace  string           `json:"namespace"`
+	Containers []ContainerUsage `json:"containers"`
+}
+
+type NodeUsage struct {
+	Name   string `json:"node_name"`
+	CPU    int64  `json:"cpu"`
+	Memory int64  `json:"memory"`
+	// CPUPercent and MemoryPercent are of the allocatable amounts of the node
+	CPUPercent    float64 `json:"cpu_percent"`
+	MemoryPercent float64 `json:"memory_percent"`
+}
+
+type UsageReport struct {
+	Nodes []NodeUsage `json:"nodes"`
+	Pods  []PodUsage  `json:"pods"`
+}
+
+// Reports the current CPU and memory usage of the nodes and of the pods in scope, against their limits, filtered like
+// the deployments of clusterDeploymentsInfoHandler. A 503 tells metrics-server isn't installed.
+func (s *Server) usageHandler(w http.ResponseWriter, r *http.Request) {
+	filter, status, err := s.parseReportFilter(r)
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+
+	report, err := getUsage(r.Context(), s.K8sClientSet, s.ResourceMetrics, filter.Scope, filter.Selector)
+	switch {
+	case errors.Is(err, errMetricsUnavailable):
+		writeError(w, http.StatusServiceUnavailable, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	excluded := filter.excludedNamespaces(s.ExcludedNamespaces)
+	report.Pods = slices.DeleteFunc(report.Pods, func(pod PodUsage) bool { return slices.Contains(excluded, pod.Namespace) })
+	writeJSON(w, http.StatusOK, report)
+}
+
+// getUsage returns the usage of every node and of the pods of the namespaces in scope whose labels match selector.
+func getUsage(ctx context.Context, clientset kubernetes.Interface, metrics *MetricsClient, scope NamespaceScope, selector labels.Selector) (*UsageReport, error) {
+	if metrics == nil {
+		return nil, errMetricsUnavailable
+	}
+	report := &UsageReport{Nodes: []NodeUsage{}, Pods: []PodUsage{}}
+
+	nodeMetrics, err := metrics.list(ctx, "nodes", metav1.NamespaceAll, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	allocatable := map[string]corev1.ResourceList{}
+	for _, node := range nodes.Items {
+		allocatable[node.Name] = node.Status.Allocatable
+	}
+	for _, node := range nodeMetrics {
+		usage := NodeUsage{Name: node.Name, CPU: node.Usage.Cpu().MilliValue(), Memory: node.Usage.Memory().Value()}
+		resources := allocatable[node.Name]
+		if cpu := resources.Cpu().MilliValue(); cpu > 0 {
+			usage.CPUPercent = float64(usage.CPU) * 100 / float64(cpu)
+		}
+		if memory := resources.Memory().Value(); memory > 0 {
+			usage.MemoryPercent = float64(usage.Memory) * 100 / float64(memory)
+		}
+		report.Nodes = append(report.Nodes, usage)
+	}
+	sort.Slice(report.Nodes, func(i, j int) bool { return report.Nodes[i].Name < report.Nodes[j].Name })
+
+	namespaces, err := scope.resolve(ctx, clientset)
+	if err != nil {
+		return nil, err
+	}
+	for _, namespace := range namespaces {
+		pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, err
+		}
+		usage, err := podsUsage(ctx, metrics, namespace, selector, pods.Items)
+		if err != nil {
+			return nil, err
+		}
+		report.Pods = append(report.Pods, usage...)
+	}
+	return report, nil
+}
+
+// podsUsage returns the usage of the pods of namespace whose labels match selector, against the limits of pods.
+func podsUsage(ctx context.Context, metrics *MetricsClient, namespace string, selector labels.Selector, pods []corev1.Pod) ([]PodUsage, error) {
+	podMetrics, err := metrics.list(ctx, "pods", namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+	limits := map[string]corev1.ResourceList{}
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			limits[pod.Namespace+"/"+pod.Name+"/"+container.Name] = container.Resources.Limits
+		}
+	}
+
+	var result []PodUsage
+	for _, pod := range podMetrics {
+		usage := PodUsage{Name: pod.Name, Namespace: pod.Namespace}
+		for _, container := range pod.Containers {
+			current := ContainerUsage{Name: container.Name, CPU: container.Usage.Cpu().MilliValue(), Memory: container.Usage.Memory().Value()}
+			limit := limits[pod.Namespace+"/"+pod.Name+"/"+container.Name]
+			if current.CPULimit = limit.Cpu().MilliValue(); current.CPULimit > 0 {
+				percent := float64(current.CPU) * 100 / float64(current.CPULimit)
+				current.CPULimitPercent = &percent
+			}
+			if current.MemoryLimit = limit.Memory().Value(); current.MemoryLimit > 0 {
+				percent := float64(current.Memory) * 100 / float64(current.MemoryLimit)
+				current.MemoryLimitPercent = &percent
+			}
+			usage.Containers = append(usage.Containers, current)
+		}
+		result = append(result, usage)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// diagnoseUsage attaches the usage of the pods of a failing deployment to its diagnosis, when metrics-server is
+// available. A pod sitting at its memory limit tells why it's OOM killed.
+func diagnoseUsage(ctx context.Context, metrics *MetricsClient, diagnosis *FailureDiagnosis, namespace string, selector labels.Selector, pods []corev1.Pod) {
+	if metrics == nil {
+		return
+	}
+	usage, err := podsUsage(ctx, metrics, namespace, selector, pods)
+	if err != nil {
+		slog.DebugContext(ctx, "No pod usage for the diagnosis", "namespace", namespace, "error", err)
+		return
+	}
+	diagnosis.Usage = usage
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	restfake "k8s.io/client-go/rest/fake"
+)
+
+// fakeMetricsClient serves responses by path, a 404 for any other.
+func fakeMetricsClient(responses map[string]string) *MetricsClient {
+	return newMetricsClient(&restfake.RESTClient{
+		NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+		Client: restfake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			header := http.Header{"Content-Type": []string{"application/json"}}
+			body, ok := responses[req.URL.Path]
+			if !ok {
+				return &http.Response{StatusCode: http.StatusNotFound, Header: header, Body: io.NopCloser(bytes.NewBufferString(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))}, nil
+			}
+			return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+		}),
+	})
+}
+
+func TestGetUsage(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+			Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "web", Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")}}},
+				{Name: "proxy"},
+			}},
+		},
+	)
+	metrics := fakeMetricsClient(map[string]string{
+		metricsAPI + "/nodes": `{"items":[{"metadata":{"name":"node-1"},"usage":{"cpu":"500m","memory":"1Gi"}}]}`,
+		metricsAPI + "/namespaces/default/pods": `{"items":[{"metadata":{"name":"web-1","namespace":"default"},"containers":[
+			{"name":"web","usage":{"cpu":"100m","memory":"192Mi"}},
+			{"name":"proxy","usage":{"cpu":"10m","memory":"16Mi"}}]}]}`,
+	})
+
+	report, err := getUsage(context.Background(), clientset, metrics, NamespaceScope{Names: []string{"default"}}, labels.Everything())
+	assert.NoError(t, err)
+	if assert.Len(t, report.Nodes, 1) {
+		assert.Equal(t, int64(500), report.Nodes[0].CPU)
+		assert.Equal(t, 25.0, report.Nodes[0].CPUPercent)
+		assert.Equal(t, 25.0, report.Nodes[0].MemoryPercent)
+	}
+	if assert.Len(t, report.Pods, 1) && assert.Len(t, report.Pods[0].Containers, 2) {
+		web, proxy := report.Pods[0].Containers[0], report.Pods[0].Containers[1]
+		assert.Equal(t, int64(256<<20), web.MemoryLimit)
+		if assert.NotNil(t, web.MemoryLimitPercent) {
+			assert.Equal(t, 75.0, *web.MemoryLimitPercent)
+		}
+		assert.Nil(t, web.CPULimitPercent)
+		assert.Equal(t, int64(10), proxy.CPU)
+		assert.Nil(t, proxy.MemoryLimitPercent)
+	}
+}
+
+func TestGetUsageWithoutMetricsServer(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	_, err := getUsage(context.Background(), clientset, fakeMetricsClient(nil), NamespaceScope{}, labels.Everything())
+	assert.ErrorIs(t, err, errMetricsUnavailable)
+	_, err = getUsage(context.Background(), clientset, nil, NamespaceScope{}, labels.Everything())
+	assert.ErrorIs(t, err, errMetricsUnavailable)
+
+	diagnosis := new(FailureDiagnosis)
+	diagnoseUsage(context.Background(), fakeMetricsClient(nil), diagnosis, "default", labels.Everything(), nil)
+	assert.Nil(t, diagnosis.Usage)
+}
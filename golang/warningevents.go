@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// defaultEventsWindow is how far back GET /events looks without ?since=, the default time to live of events.
+const defaultEventsWindow = time.Hour
+
+// NamespacedWarningEvent is a warning event of any object of a namespace.
+type NamespacedWarningEvent struct {
+	Namespace string `json:"namespace"`
+	WarningEvent
+}
+
+type EventsReport struct {
+	// Since is the start of the window, the events last seen before it are left out
+	Since  time.Time                `json:"since"`
+	Events []NamespacedWarningEvent `json:"events"`
+}
+
+// Reports the warning events of the namespaces in scope last seen within ?since=, 1h by default, the most recent first,
+// such as FailedScheduling, BackOff, Unhealthy or FailedMount
+//
+// ?namespace= and ?excludeNamespaces= filter them like the deployments of clusterDeploymentsInfoHandler, and
+// ?reason=FailedScheduling,FailedMount keeps the events with one of the reasons.
+func (s *Server) eventsHandler(w http.ResponseWriter, r *http.Request) {
+	filter, status, err := s.parseReportFilter(r)
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+	window := defaultEventsWindow
+	if value := r.URL.Query().Get("since"); value != "" {
+		window, err = time.ParseDuration(value)
+		if err != nil || window <= 0 {
+			writeErrorf(w, http.StatusBadRequest, "invalid since %q, expected a positive duration such as 30m", value)
+			return
+		}
+	}
+	var reasons []string
+	if value := r.URL.Query().Get("reason"); value != "" {
+		reasons = strings.Split(value, ",")
+	}
+
+	report, err := getWarningEvents(r.Context(), s.K8sClientSet, filter.Scope, reasons, time.Now().Add(-window))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	excluded := filter.excludedNamespaces(s.ExcludedNamespaces)
+	report.Events = slices.DeleteFunc(report.Events, func(event NamespacedWarningEvent) bool {
+		return slices.Contains(excluded, event.Namespace)
+	})
+	writeJSON(w, http.StatusOK, report)
+}
+
+// getWarningEvents returns the warning events of the namespaces in scope last seen since then, with one of reasons
+// unless it's empty.
+func getWarningEvents(ctx context.Context, clientset kubernetes.Interface, scope NamespaceScope, reasons []string, since time.Time) (*EventsReport, error) {
+	namespaces, err := scope.resolve(ctx, clientset)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &EventsReport{Since: since, Events: []NamespacedWarningEvent{}}
+	for _, namespace := range namespaces {
+		events, err := clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: "type=" + corev1.EventTypeWarning})
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range events.Items {
+			if event.Type != corev1.EventTypeWarning || len(reasons) > 0 && !slices.Contains(reasons, event.Reason) {
+				continue
+			}
+			warning := newWarningEvent(event)
+			if warning.LastSeen.Before(since) {
+				continue
+			}
+			report.Events = append(report.Events, NamespacedWarningEvent{Namespace: event.Namespace, WarningEvent: warning})
+		}
+	}
+	sort.SliceStable(report.Events, func(i, j int) bool { return report.Events[i].LastSeen.After(report.Events[j].LastSeen) })
+	slog.DebugContext(ctx, "Warning events", "events", len(report.Events), "since", since)
+	return report, nil
+}
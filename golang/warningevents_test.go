@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetWarningEvents(t *testing.T) {
+	now := time.Date(2024, time.January, 31, 12, 0, 0, 0, time.UTC)
+	event := func(namespace, name, eventType, reason string, lastSeen time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: namespace},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: name},
+			Type:           eventType,
+			Reason:         reason,
+			Count:          2,
+			LastTimestamp:  metav1.NewTime(lastSeen),
+		}
+	}
+	// Recorded through events.k8s.io
+	series := event("payments", "ledger-3", corev1.EventTypeWarning, "FailedMount", time.Time{})
+	series.EventTime = metav1.NewMicroTime(now.Add(-50 * time.Minute))
+	series.Series = &corev1.EventSeries{Count: 7, LastObservedTime: metav1.NewMicroTime(now.Add(-time.Minute))}
+	clientset := fake.NewSimpleClientset(
+		event("payments", "ledger-1", corev1.EventTypeWarning, "BackOff", now.Add(-10*time.Minute)),
+		event("payments", "ledger-2", corev1.EventTypeNormal, "Pulled", now.Add(-10*time.Minute)),
+		event("payments", "ledger-old", corev1.EventTypeWarning, "Unhealthy", now.Add(-2*time.Hour)),
+		event("web", "frontend-1", corev1.EventTypeWarning, "FailedScheduling", now.Add(-5*time.Minute)),
+		series,
+	)
+
+	report, err := getWarningEvents(context.Background(), clientset, NamespaceScope{}, nil, now.Add(-time.Hour))
+	assert.NoError(t, err)
+	var objects []string
+	for _, event := range report.Events {
+		objects = append(objects, event.Namespace+"/"+event.Object)
+	}
+	assert.Equal(t, []string{"payments/pod/ledger-3", "web/pod/frontend-1", "payments/pod/ledger-1"}, objects)
+	assert.Equal(t, int32(7), report.Events[0].Count)
+
+	report, err = getWarningEvents(context.Background(), clientset, NamespaceScope{Names: []string{"payments"}}, []string{"BackOff", "FailedScheduling"}, now.Add(-time.Hour))
+	assert.NoError(t, err)
+	if assert.Len(t, report.Events, 1) {
+		assert.Equal(t, "BackOff", report.Events[0].Reason)
+	}
+}
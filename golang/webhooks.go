@@ -0,0 +1,295 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	defaultWebhookInterval = time.Minute
+	defaultWebhookRetries  = 3
+	// webhookRetryDelay is the delay before the first retry of a delivery, doubled for each of the next ones.
+	webhookRetryDelay = time.Second
+)
+
+// Types of the webhook events
+const (
+	webhookDeploymentFailed    = "deployment.failed"
+	webhookDeploymentRecovered = "deployment.recovered"
+	webhookNodeNotReady        = "node.not_ready"
+	webhookNodeReady           = "node.ready"
+)
+
+// WebhooksConfig POSTs an event to every endpoint when a deployment enters or leaves the failed set, stuck rollouts
+// included, or when a node stops or starts being ready.
+type WebhooksConfig struct {
+	Interval metav1.Duration `json:"interval,omitempty"`
+	// Retries is how many times a failed delivery is retried, with an exponential backoff
+	Retries   *int              `json:"retries,omitempty"`
+	Endpoints []WebhookEndpoint `json:"endpoints"`
+}
+
+// WebhookEndpoint receives the events, signed with the secret read from SecretFile when it's set.
+type WebhookEndpoint struct {
+	URL        string            `json:"url"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	SecretFile string            `json:"secret_file,omitempty"`
+}
+
+// WebhookEvent is the JSON payload POSTed to the endpoints, with the deployment or the node which changed.
+type WebhookEvent struct {
+	Type       string          `json:"type"`
+	Cluster    string          `json:"cluster"`
+	At         time.Time       `json:"at"`
+	Deployment *DeploymentInfo `json:"deployment,omitempty"`
+	Node       *NodeInfo       `json:"node,omitempty"`
+}
+
+type webhookEndpoint struct {
+	url     string
+	headers map[string]string
+	secret  []byte
+}
+
+// webhookSender POSTs JSON payloads, retrying failed deliveries with an exponential backoff.
+type webhookSender struct {
+	httpClient *http.Client
+	retries    int
+	retryDelay time.Duration
+}
+
+func newWebhookSender(retries *int, field string) (webhookSender, error) {
+	sender := webhookSender{httpClient: &http.Client{Timeout: 10 * time.Second}, retries: defaultWebhookRetries, retryDelay: webhookRetryDelay}
+	if retries != nil {
+		if *retries < 0 {
+			return sender, fmt.Errorf("%s can't be negative", field)
+		}
+		sender.retries = *retries
+	}
+	return sender, nil
+}
+
+// healthTransitions tracks the failed deployments and the nodes which aren't ready across scans.
+//
+// The first scan only records them, so a restart doesn't notify them again, nor the transitions it missed.
+type healthTransitions struct {
+	// failed are the failed deployments by namespace/name and notReady the nodes which aren't ready, nil before the
+	// first scan of each
+	failed   map[string]DeploymentInfo
+	notReady map[string]bool
+}
+
+// WebhookNotifier scans the deployments and nodes every interval and notifies the endpoints of their transitions.
+type WebhookNotifier struct {
+	webhookSender
+	healthTransitions
+	interval  time.Duration
+	endpoints []webhookEndpoint
+	cluster   string
+	clientset kubernetes.Interface
+	// deployments reads the health of the deployments, see Server.reportedDeploymentsHealth
+	deployments func(context.Context) (*ClusterDeploymentsInfo, error)
+}
+
+func newWebhookNotifier(config *WebhooksConfig, cluster string, clientset kubernetes.Interface, deployments func(context.Context) (*ClusterDeploymentsInfo, error)) (*WebhookNotifier, error) {
+	sender, err := newWebhookSender(config.Retries, "webhooks.retries")
+	if err != nil {
+		return nil, err
+	}
+	notifier := &WebhookNotifier{
+		webhookSender: sender,
+		interval:      config.Interval.Duration,
+		cluster:       cluster,
+		clientset:     clientset,
+		deployments:   deployments,
+	}
+	if notifier.interval == 0 {
+		notifier.interval = defaultWebhookInterval
+	}
+
+	if len(config.Endpoints) == 0 {
+		return nil, errors.New("webhooks.endpoints needs at least an endpoint")
+	}
+	for i, endpoint := range config.Endpoints {
+		current, err := newWebhookEndpoint(fmt.Sprintf("webhooks.endpoints[%d]", i), endpoint)
+		if err != nil {
+			return nil, err
+		}
+		notifier.endpoints = append(notifier.endpoints, current)
+	}
+	return notifier, nil
+}
+
+// newWebhookEndpoint checks the URL of the endpoint at field of the config and reads its secret.
+func newWebhookEndpoint(field string, endpoint WebhookEndpoint) (webhookEndpoint, error) {
+	parsed, err := url.Parse(endpoint.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return webhookEndpoint{}, fmt.Errorf("%s.url %q is not an http or https URL", field, endpoint.URL)
+	}
+	current := webhookEndpoint{url: endpoint.URL, headers: endpoint.Headers}
+	if endpoint.SecretFile != "" {
+		secret, err := os.ReadFile(endpoint.SecretFile)
+		if err != nil {
+			return webhookEndpoint{}, fmt.Errorf("%s.secret_file: %w", field, err)
+		}
+		current.secret = bytes.TrimSpace(secret)
+	}
+	return current, nil
+}
+
+// run notifies the transitions every interval until ctx is done.
+func (n *WebhookNotifier) run(ctx context.Context) {
+	ticker := time.NewTicker(n.interval)
+	defer ticker.Stop()
+
+	for {
+		deployments, err := n.deployments(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed scanning deployments for the webhooks", "error", err)
+		}
+		nodes, err := getNodesHealth(ctx, n.clientset, labels.Everything())
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed scanning nodes for the webhooks", "error", err)
+		}
+		n.reconcile(ctx, deployments, nodes, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reconcile notifies the transitions since the previous scan.
+func (n *WebhookNotifier) reconcile(ctx context.Context, deployments *ClusterDeploymentsInfo, nodes *ClusterNodesInfo, now time.Time) {
+	for _, event := range n.track(deployments, nodes) {
+		event.Cluster, event.At = n.cluster, now.UTC()
+		body, err := json.Marshal(event)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed encoding webhook event", "error", err)
+			continue
+		}
+		for _, endpoint := range n.endpoints {
+			if err := n.deliver(ctx, endpoint, body, now); err != nil {
+				slog.ErrorContext(ctx, "Failed delivering webhook event", "url", endpoint.url, "event", event.Type, "error", err)
+			}
+		}
+	}
+}
+
+// track returns the transitions since the previous scan, a nil report leaving the state of its objects as it was.
+func (t *healthTransitions) track(deployments *ClusterDeploymentsInfo, nodes *ClusterNodesInfo) []WebhookEvent {
+	var events []WebhookEvent
+	if deployments != nil {
+		failed := map[string]DeploymentInfo{}
+		for _, deployment := range append(append([]DeploymentInfo{}, deployments.FailedDeployments...), deployments.StuckRollouts...) {
+			failed[deployment.Namespace+"/"+deployment.Name] = deployment
+			if _, ok := t.failed[deployment.Namespace+"/"+deployment.Name]; !ok && t.failed != nil {
+				events = append(events, WebhookEvent{Type: webhookDeploymentFailed, Deployment: &deployment})
+			}
+		}
+		// A deleted deployment leaves the failed set too, reported as it last was
+		current := map[string]DeploymentInfo{}
+		for _, deployment := range append(append([]DeploymentInfo{}, deployments.ReadyDeployments...), deployments.Progressing...) {
+			current[deployment.Namespace+"/"+deployment.Name] = deployment
+		}
+		for key, deployment := range t.failed {
+			if _, ok := failed[key]; ok {
+				continue
+			}
+			if recovered, ok := current[key]; ok {
+				deployment = recovered
+			}
+			events = append(events, WebhookEvent{Type: webhookDeploymentRecovered, Deployment: &deployment})
+		}
+		t.failed = failed
+	}
+
+	if nodes != nil {
+		notReady := map[string]bool{}
+		for _, node := range append(append([]NodeInfo{}, nodes.ReadyNodes...), nodes.UnhealthyNodes...) {
+			switch {
+			case !node.Ready:
+				notReady[node.Name] = true
+				if !t.notReady[node.Name] && t.notReady != nil {
+					events = append(events, WebhookEvent{Type: webhookNodeNotReady, Node: &node})
+				}
+			case t.notReady[node.Name]:
+				events = append(events, WebhookEvent{Type: webhookNodeReady, Node: &node})
+			}
+		}
+		t.notReady = notReady
+	}
+	return events
+}
+
+// deliver POSTs body to the endpoint, retrying network errors, 429 and 5xx responses.
+func (s *webhookSender) deliver(ctx context.Context, endpoint webhookEndpoint, body []byte, now time.Time) error {
+	delay := s.retryDelay
+	for attempt := 0; ; attempt++ {
+		retry, err := s.post(ctx, endpoint, body, now)
+		if err == nil || !retry || attempt == s.retries {
+			return err
+		}
+		slog.DebugContext(ctx, "Retrying webhook delivery", "url", endpoint.url, "attempt", attempt+1, "error", err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// post makes a delivery attempt, returning whether it's worth retrying when it fails.
+//
+// The signature of a signed delivery is the hex HMAC-SHA256 of "<unix timestamp>\n<body>" with the secret of the
+// endpoint, in the headers request signatures use.
+func (s *webhookSender) post(ctx context.Context, endpoint webhookEndpoint, body []byte, now time.Time) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range endpoint.headers {
+		req.Header.Set(key, value)
+	}
+	if endpoint.secret != nil {
+		timestamp := strconv.FormatInt(now.Unix(), 10)
+		mac := hmac.New(sha256.New, endpoint.secret)
+		mac.Write([]byte(timestamp + "\n"))
+		mac.Write(body)
+		req.Header.Set(signatureTimestampHeader, timestamp)
+		req.Header.Set(signatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		return false, nil
+	}
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+	err = fmt.Errorf("POST %s returned %s: %s", endpoint.url, resp.Status, respBody)
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, err
+}
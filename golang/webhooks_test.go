@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookNotifierReconcile(t *testing.T) {
+	var events []WebhookEvent
+	failures := 1
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mac := hmac.New(sha256.New, []byte("s3cret"))
+		mac.Write([]byte(r.Header.Get(signatureTimestampHeader) + "\n"))
+		mac.Write(body)
+		assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), r.Header.Get(signatureHeader))
+		assert.Equal(t, "sre", r.Header.Get("X-Team"))
+		// The first delivery fails and is retried
+		if failures > 0 {
+			failures--
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		var event WebhookEvent
+		assert.NoError(t, json.Unmarshal(body, &event))
+		events = append(events, event)
+	}))
+	defer receiver.Close()
+
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	assert.NoError(t, os.WriteFile(secretFile, []byte("s3cret\n"), 0o600))
+	notifier, err := newWebhookNotifier(&WebhooksConfig{
+		Endpoints: []WebhookEndpoint{{URL: receiver.URL, Headers: map[string]string{"X-Team": "sre"}, SecretFile: secretFile}},
+	}, "prod", nil, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	notifier.retryDelay = time.Millisecond
+
+	cart := func(ready int32) DeploymentInfo {
+		return DeploymentInfo{Namespace: "shop", Name: "cart", RequestedPods: 3, ReadyPods: ready}
+	}
+	node := func(ready bool) *ClusterNodesInfo {
+		if ready {
+			return &ClusterNodesInfo{ReadyNodes: []NodeInfo{{Name: "worker", Ready: true}}}
+		}
+		return &ClusterNodesInfo{UnhealthyNodes: []NodeInfo{{Name: "worker"}}}
+	}
+	now := time.Date(2024, time.January, 31, 12, 0, 0, 0, time.UTC)
+
+	// The first scan is the baseline
+	notifier.reconcile(context.TODO(), &ClusterDeploymentsInfo{FailedDeployments: []DeploymentInfo{{Namespace: "shop", Name: "web"}}}, node(true), now)
+	assert.Empty(t, events)
+
+	notifier.reconcile(context.TODO(), &ClusterDeploymentsInfo{FailedDeployments: []DeploymentInfo{cart(1)}}, node(false), now.Add(time.Minute))
+	// A failed scan keeps the previous state
+	notifier.reconcile(context.TODO(), nil, nil, now.Add(2*time.Minute))
+	notifier.reconcile(context.TODO(), &ClusterDeploymentsInfo{StuckRollouts: []DeploymentInfo{cart(1)}}, node(false), now.Add(3*time.Minute))
+	notifier.reconcile(context.TODO(), &ClusterDeploymentsInfo{ReadyDeployments: []DeploymentInfo{cart(3)}}, node(true), now.Add(4*time.Minute))
+
+	var types []string
+	for _, event := range events {
+		types = append(types, event.Type)
+		assert.Equal(t, "prod", event.Cluster)
+	}
+	assert.ElementsMatch(t, []string{
+		webhookDeploymentFailed, webhookDeploymentRecovered, webhookNodeNotReady,
+		webhookDeploymentRecovered, webhookNodeReady,
+	}, types)
+	if assert.Len(t, events, 5) {
+		assert.Equal(t, int32(3), events[len(events)-2].Deployment.ReadyPods)
+		assert.Equal(t, now.Add(4*time.Minute), events[len(events)-1].At)
+	}
+
+	_, err = newWebhookNotifier(&WebhooksConfig{Endpoints: []WebhookEndpoint{{URL: "ftp://example.com"}}}, "prod", nil, nil)
+	assert.Error(t, err)
+	_, err = newWebhookNotifier(&WebhooksConfig{}, "prod", nil, nil)
+	assert.Error(t, err)
+}
+
+func TestWebhookNotifierGivesUp(t *testing.T) {
+	var attempts int
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		http.Error(w, "bad request", http.StatusBadRequest)
+	}))
+	defer receiver.Close()
+	retries := 2
+	notifier, err := newWebhookNotifier(&WebhooksConfig{Retries: &retries, Endpoints: []WebhookEndpoint{{URL: receiver.URL}}}, "prod", nil, nil)
+	assert.NoError(t, err)
+	notifier.retryDelay = time.Millisecond
+
+	// Client errors aren't retried, server errors are until the retries run out
+	assert.Error(t, notifier.deliver(context.TODO(), notifier.endpoints[0], []byte(`{}`), time.Now()))
+	assert.Equal(t, 1, attempts)
+	receiver.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	assert.Error(t, notifier.deliver(context.TODO(), notifier.endpoints[0], []byte(`{}`), time.Now()))
+	assert.Equal(t, 4, attempts)
+}